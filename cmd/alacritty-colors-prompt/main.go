@@ -0,0 +1,44 @@
+// Command alacritty-colors-prompt prints the current theme for shell
+// prompts and status lines, like 'alacritty-colors prompt'. It only reads
+// the prompt cache, so it starts without loading the settings or the
+// packages of the interactive commands.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/vitruves/alacritty-colors/internal/config"
+	"github.com/vitruves/alacritty-colors/internal/prompt"
+)
+
+func main() {
+	var style, symbol string
+	flag.StringVar(&style, "style", prompt.Plain, "Escape style: plain, bash, zsh, tmux or none")
+	flag.StringVar(&style, "s", prompt.Plain, "Shorthand for --style")
+	flag.StringVar(&symbol, "symbol", "●", "Swatch character")
+	flag.Parse()
+
+	if err := prompt.CheckStyle(style); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// Until a theme is applied there is nothing to show
+	stateDir, err := config.StateDir()
+	if err != nil {
+		return
+	}
+	entry, err := prompt.ReadCache(stateDir)
+	if err != nil {
+		return
+	}
+
+	line, err := prompt.Render(entry, style, symbol)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	fmt.Println(line)
+}
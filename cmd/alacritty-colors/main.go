@@ -3,13 +3,18 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vitruves/alacritty-colors/internal/config"
+	"github.com/vitruves/alacritty-colors/internal/prompt"
+	"github.com/vitruves/alacritty-colors/internal/service"
 	"github.com/vitruves/alacritty-colors/internal/theme"
 	"github.com/vitruves/alacritty-colors/internal/tui"
 	"github.com/vitruves/alacritty-colors/internal/ui"
@@ -21,14 +26,24 @@ var (
 	configFile string
 	themesDir  string
 	backupDir  string
+	profile    string
 	verbose    bool
+	colorMode  string
+	// dotfilesMode edits the config in the dotfiles repository
+	dotfilesMode bool
+	// assumeYes and noInput answer prompts without asking
+	assumeYes bool
+	noInput   bool
 )
 
 func main() {
+	theme.Version = version
+
 	// Custom help template with enhanced colors and structure
 	cobra.AddTemplateFunc("colorize", func(s string) string {
 		return ui.ColorizeHeader(s)
 	})
+	cobra.AddTemplateFunc("command", ui.ColorizeCommand)
 
 	// Create custom help template with colors
 	helpTemplate := `{{colorize "Alacritty Colors v1.0.0"}}
@@ -38,7 +53,7 @@ Advanced Alacritty theme manager with 500+ themes, smart font pairing, and visua
   {{.UseLine}}
 
 {{if .HasAvailableSubCommands}}{{colorize "COMMANDS"}}
-{{range .Commands}}{{if (or .IsAvailableCommand (eq .Name "help"))}}  {{printf "\033[36m%-12s\033[0m" .Name}} {{.Short}}
+{{range .Commands}}{{if (or .IsAvailableCommand (eq .Name "help"))}}  {{command (printf "%-12s" .Name)}} {{.Short}}
 {{end}}{{end}}{{end}}
 {{if .HasAvailableLocalFlags}}{{colorize "OPTIONS"}}
 {{.LocalFlags.FlagUsages | trimTrailingWhitespaces}}
@@ -52,24 +67,86 @@ Advanced Alacritty theme manager with 500+ themes, smart font pairing, and visua
   alacritty-colors random --dark       # Random dark theme
   alacritty-colors generate -s neon    # Generate neon theme
 
-{{colorize "MORE INFO"}}
+{{if not .HasParent}}{{colorize "ENVIRONMENT"}}
+  ALACRITTY_COLORS_CONFIG       Alacritty config file path
+  ALACRITTY_COLORS_THEMES_DIR   Themes directory
+  ALACRITTY_COLORS_BACKUP_DIR   Backup directory
+  ALACRITTY_COLORS_PROFILE      Config profile
+  ALACRITTY_COLORS_DATA_DIR     Tool settings directory
+  ALACRITTY_COLORS_STATE_DIR    Tool state directory
+  ALACRITTY_COLORS_PROXY        Download proxy URL
+  ALACRITTY_COLORS_CA_CERTS     Extra CA certificates (path list)
+  GITHUB_TOKEN                  Token raising GitHub API rate limits
+  ALACRITTY_COLORS_NO_INPUT     Never prompt, like --no-input
+
+{{end}}{{colorize "MORE INFO"}}
   Use "alacritty-colors [command] --help" for detailed information.
 `
 
+	var showVersion, checkVersion bool
 	var rootCmd = &cobra.Command{
-		Use:     "alacritty-colors",
-		Version: version,
+		Use: "alacritty-colors",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			config.SetDotfilesMode(dotfilesMode)
+			ui.SetAssumeYes(assumeYes)
+			ui.SetNoInput(noInput || os.Getenv(config.EnvVar("NO_INPUT")) != "")
+			return setupOutput()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !showVersion {
+				if checkVersion {
+					return ui.WithHints(fmt.Errorf("--check only works with --version"), "Run 'alacritty-colors --version --check'.")
+				}
+				return cmd.Help()
+			}
+			fmt.Printf("alacritty-colors version %s\n", version)
+			if !checkVersion {
+				return nil
+			}
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.CheckVersion(version)
+		},
+		// The opt-in release notice comes after the command's own output.
+		// Commands that never load the settings, such as prompt, skip both.
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			tm := loadedManager
+			if showVersion || tm == nil {
+				return
+			}
+			// Commands other than apply edit the config too
+			if err := tm.DeployDotfiles(); err != nil {
+				ui.PrintWarning("%v", err)
+			}
+			tm.VersionNotice(version)
+		},
 	}
+	rootCmd.Flags().BoolVar(&showVersion, "version", false, "Print the version")
+	rootCmd.Flags().BoolVar(&checkVersion, "check", false, "With --version, compare with the latest release")
 
 	// Set custom help template
 	rootCmd.SetUsageTemplate(helpTemplate)
 
+	// Help bypasses the pre-run hooks, so set up output here as well
+	defaultHelp := rootCmd.HelpFunc()
+	rootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		setupOutput()
+		defaultHelp(cmd, args)
+	})
+
 	// Global flags with better organization
 	flags := rootCmd.PersistentFlags()
 	flags.StringVarP(&configFile, "config", "c", "", "Alacritty config file path")
 	flags.StringVar(&themesDir, "themes-dir", "", "Custom themes directory")
 	flags.StringVar(&backupDir, "backup-dir", "", "Custom backup directory")
+	flags.StringVarP(&profile, "profile", "p", "", "Config profile to use")
 	flags.BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	flags.StringVar(&colorMode, "color", "", "Colorize output: auto, always or never (default from settings, else auto)")
+	flags.BoolVarP(&assumeYes, "yes", "y", false, "Answer yes to every confirmation and take the default of every choice")
+	flags.BoolVar(&noInput, "no-input", false, "Never prompt; confirmations answer no and choices take their default")
+	flags.BoolVar(&dotfilesMode, "dotfiles-mode", false, "Edit the config in your dotfiles repository and deploy it with chezmoi or stow")
 
 	// Commands with improved structure
 	rootCmd.AddCommand(initCmd())
@@ -85,15 +162,54 @@ Advanced Alacritty theme manager with 500+ themes, smart font pairing, and visua
 	rootCmd.AddCommand(restoreCmd())
 	rootCmd.AddCommand(updateCmd())
 	rootCmd.AddCommand(configCmd())
+	rootCmd.AddCommand(localCmd())
+	rootCmd.AddCommand(hookCmd())
+	rootCmd.AddCommand(syncCmd())
+	rootCmd.AddCommand(exportCmd())
+	rootCmd.AddCommand(templateCmd())
+	rootCmd.AddCommand(oscCmd())
+	rootCmd.AddCommand(scheduleCmd())
+	rootCmd.AddCommand(daemonCmd())
+	rootCmd.AddCommand(serviceCmd())
+	rootCmd.AddCommand(watchWallpaperCmd())
+	rootCmd.AddCommand(watchWorkspacesCmd())
+	rootCmd.AddCommand(promptCmd())
+	rootCmd.AddCommand(excludeCmd())
+	rootCmd.AddCommand(protectCmd())
+	rootCmd.AddCommand(themeCmd())
+	rootCmd.AddCommand(benchmarkCmd())
+	rootCmd.AddCommand(toggleCmd())
+	rootCmd.AddCommand(effectsCmd())
+	rootCmd.AddCommand(statusCmd())
+	rootCmd.AddCommand(fontsCmd())
+	rootCmd.AddCommand(trashCmd())
+	rootCmd.AddCommand(showThemeCmd())
+	rootCmd.AddCommand(identifyCmd())
+	rootCmd.AddCommand(renderCmd())
+	rootCmd.AddCommand(statsCmd())
+	rootCmd.AddCommand(explainCmd())
+	rootCmd.AddCommand(validateCmd())
+	rootCmd.AddCommand(recordCmd())
+	rootCmd.AddCommand(replayCmd())
+	rootCmd.AddCommand(lockCmd())
+	rootCmd.AddCommand(unlockCmd())
+	rootCmd.AddCommand(harmonizeCmd())
+	rootCmd.AddCommand(tweakCmd())
 
 	if err := rootCmd.Execute(); err != nil {
-		ui.PrintError("Error: %v", err)
+		ui.PrintErrorWithHints(err)
 		os.Exit(1)
 	}
 }
 
 func initCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		offline            bool
+		insecureSkipVerify bool
+		skipDownload       bool
+	)
+
+	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "Initialize configuration and download themes",
 		Long: `Initialize alacritty-colors configuration:
@@ -103,6 +219,17 @@ func initCmd() *cobra.Command {
 • Set up configuration file with import statements
 • Verify Alacritty installation and config location
 
+A bundle of popular themes ships with the binary and is installed
+first, so --offline works with no network at all. The full collection
+is layered on top by a later 'update'. --skip-download only sets up the
+config and current.toml, installing no themes.
+
+Each step is numbered and timed, and a summary table ends the run.
+
+Downloaded archives are checked with SHA-256. Extra sources
+configured without a checksum_url are refused unless
+--insecure-skip-verify is given.
+
 This command is safe to run multiple times and will not
 overwrite existing configurations.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -110,25 +237,48 @@ overwrite existing configurations.`,
 				ui.PrintInfo("Initializing with verbose output enabled")
 			}
 
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
 
 			tm := theme.NewManager(cfg)
 			tm.SetVerbose(verbose)
-			return tm.Initialize()
+
+			if offline && skipDownload {
+				return fmt.Errorf("--offline and --skip-download cannot be used together")
+			}
+
+			opts := &theme.InitOptions{
+				Offline:            offline,
+				InsecureSkipVerify: insecureSkipVerify,
+				SkipDownload:       skipDownload,
+			}
+
+			return tm.InitializeWithOptions(opts)
 		},
 	}
+
+	cmd.Flags().BoolVar(&offline, "offline", false, "Only install the bundled themes, no network access")
+	cmd.Flags().BoolVar(&insecureSkipVerify, "insecure-skip-verify", false, "Allow sources without a published checksum")
+	cmd.Flags().BoolVar(&skipDownload, "skip-download", false, "Only set up the config, without installing themes")
+
+	return cmd
 }
 
 func applyCmd() *cobra.Command {
 	var (
-		withFont   bool
-		opacity    float64
-		blur       float64
-		fontSize   float64
-		fontFamily string
+		withFont    bool
+		opacity     float64
+		blur        float64
+		fontSize    float64
+		fontFamily  string
+		force       bool
+		to          string
+		reset       theme.ResetOptions
+		transform   theme.ColorTransform
+		revertAfter time.Duration
+		transition  time.Duration
 	)
 
 	cmd := &cobra.Command{
@@ -139,29 +289,89 @@ func applyCmd() *cobra.Command {
 The theme will be safely applied using the import system, preserving
 your existing configuration. Optionally modify font and visual effects.
 
+If current.toml was edited since the last apply, the command stops so the
+changes aren't lost. Keep them with 'theme adopt' or discard them with
+--force. --force also applies the theme while theme changes are locked
+with 'alacritty-colors lock'.
+
+--reset-opacity, --reset-blur and --reset-font put those settings back to
+what they were before alacritty-colors first changed them.
+
+--desaturate, --darken-bg and --hue-shift adjust the colors as they are
+written to current.toml, for quick experiments like "nord but warmer". The
+theme file is not modified and the next apply starts from it again. A
+negative --darken-bg lightens the background.
+
+Use --to to theme another Alacritty config file, such as one kept in your
+dotfiles for a different machine. The theme is copied to themes/current.toml
+next to that file and its import line is added when missing; the managed
+config and current theme are left alone.
+
+--revert-after applies the theme, then asks whether to keep it. Unless you
+answer yes within the given time, the previous theme and config come back,
+so trying themes over SSH or on a headless machine can't leave you stuck
+with an unreadable one.
+
+--transition fades from the current colors to the theme's over the given
+time, rewriting current.toml with blended colors a dozen times a second, so
+the switch is gentle instead of sudden. The scheduler does the same for
+its light and dark switches with scheduler.transition in the settings.
+
 Examples:
 
   alacritty-colors apply dracula
   alacritty-colors apply nord --font --font-size 16
-  alacritty-colors apply gruvbox --opacity 0.9 --blur 10`,
+  alacritty-colors apply gruvbox --opacity 0.9 --blur 10
+  alacritty-colors apply dracula --force
+  alacritty-colors apply nord --reset-opacity --reset-blur
+  alacritty-colors apply nord --hue-shift -30 --desaturate 20
+  alacritty-colors apply gruvbox_dark --darken-bg 10
+  alacritty-colors apply nord --to ~/dotfiles/laptop/alacritty.toml
+  alacritty-colors apply solarized_light --revert-after 30s
+  alacritty-colors apply gruvbox_light --transition 2s`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			switch {
+			case reset.Opacity && opacity > 0:
+				return fmt.Errorf("--opacity and --reset-opacity cannot be used together")
+			case reset.Blur && blur > 0:
+				return fmt.Errorf("--blur and --reset-blur cannot be used together")
+			case reset.Font && (withFont || fontSize > 0 || fontFamily != ""):
+				return fmt.Errorf("--reset-font cannot be combined with other font flags")
+			case revertAfter < 0:
+				return fmt.Errorf("--revert-after must not be negative")
+			case revertAfter > 0 && to != "":
+				return fmt.Errorf("--revert-after cannot be used with --to")
+			case transition < 0:
+				return fmt.Errorf("--transition must not be negative")
+			case transition > 0 && to != "":
+				return fmt.Errorf("--transition cannot be used with --to")
+			}
+
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
 
 			tm := theme.NewManager(cfg)
 			tm.SetVerbose(verbose)
+			tm.SetForce(force)
 
 			opts := &theme.ApplyOptions{
-				WithFont:   withFont,
-				Opacity:    opacity,
-				Blur:       blur,
-				FontSize:   fontSize,
-				FontFamily: fontFamily,
+				WithFont:    withFont,
+				Opacity:     opacity,
+				Blur:        blur,
+				FontSize:    fontSize,
+				FontFamily:  fontFamily,
+				Reset:       reset,
+				Transform:   transform,
+				RevertAfter: revertAfter,
+				Transition:  transition,
 			}
 
+			if to != "" {
+				return tm.ApplyThemeTo(args[0], to, opts)
+			}
 			return tm.ApplyThemeWithOptions(args[0], opts)
 		},
 	}
@@ -171,16 +381,32 @@ Examples:
 	cmd.Flags().Float64Var(&blur, "blur", 0, "Set background blur radius")
 	cmd.Flags().Float64Var(&fontSize, "font-size", 0, "Set font size")
 	cmd.Flags().StringVar(&fontFamily, "font-family", "", "Set font family")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite current.toml even if it was edited by hand or locked")
+	cmd.Flags().BoolVar(&reset.Opacity, "reset-opacity", false, "Restore the opacity set before alacritty-colors changed it")
+	cmd.Flags().BoolVar(&reset.Blur, "reset-blur", false, "Restore the blur set before alacritty-colors changed it")
+	cmd.Flags().BoolVar(&reset.Font, "reset-font", false, "Restore the font set before alacritty-colors changed it")
+	cmd.Flags().StringVar(&to, "to", "", "Apply to this Alacritty config file instead of the managed one")
+	cmd.Flags().DurationVar(&revertAfter, "revert-after", 0, "Restore the previous theme unless kept within this time, e.g. 30s")
+	cmd.Flags().DurationVar(&transition, "transition", 0, "Fade from the current colors to the theme's over this time, e.g. 2s")
+	cmd.Flags().Float64Var(&transform.Desaturate, "desaturate", 0, "Lower the saturation of every color by this percentage")
+	cmd.Flags().Float64Var(&transform.DarkenBg, "darken-bg", 0, "Darken the background by this percentage (negative lightens)")
+	cmd.Flags().Float64Var(&transform.HueShift, "hue-shift", 0, "Rotate every hue by this many degrees")
 
 	return cmd
 }
 
 func listCmd() *cobra.Command {
 	var (
-		format     string
-		showColors bool
-		darkOnly   bool
-		lightOnly  bool
+		format      string
+		showColors  bool
+		darkOnly    bool
+		lightOnly   bool
+		sortBy      string
+		collection  string
+		newOnly     bool
+		updated     bool
+		minContrast float64
+		minScore    int
 	)
 
 	cmd := &cobra.Command{
@@ -195,10 +421,30 @@ Formats:
   • colors  - Show color preview for each theme
 
 Filters:
-  • --dark   - Show only dark themes
-  • --light  - Show only light themes`,
+  • --dark          - Show only dark themes
+  • --light         - Show only light themes
+  • --collection    - Show only a collection from the settings file
+  • --new           - Show only themes added by the latest update
+  • --updated       - Show only themes changed by the latest update
+  • --min-contrast  - Hide themes whose text/background contrast is lower
+                      (WCAG: 4.5 is AA, 7 is AAA)
+  • --min-score     - Hide themes with a lower quality score
+
+Sorting:
+  • --sort name     - Alphabetical (default)
+  • --sort popular  - Most used themes first, with a popularity badge
+  • --sort contrast - Most readable themes first, with their contrast ratio
+  • --sort score    - Best built themes first, with their quality score
+
+The quality score, out of 100, rates text contrast, accents readable on the
+background, bright colors distinct from normal ones, a complete palette and
+no repeated colors. 'alacritty-colors explain <theme>' shows what costs a
+theme points.
+
+Popularity comes from an index bundled with the tool and, for community
+sources hosted on GitHub, the repository's star count.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
@@ -206,11 +452,22 @@ Filters:
 			tm := theme.NewManager(cfg)
 			tm.SetVerbose(verbose)
 
+			// The settings file provides the default layout
+			if !cmd.Flags().Changed("format") {
+				format = cfg.UI.ListFormat
+			}
+
 			opts := &theme.ListOptions{
-				Format:     format,
-				ShowColors: showColors,
-				DarkOnly:   darkOnly,
-				LightOnly:  lightOnly,
+				Format:      format,
+				ShowColors:  showColors,
+				DarkOnly:    darkOnly,
+				LightOnly:   lightOnly,
+				Sort:        sortBy,
+				Collection:  collection,
+				New:         newOnly,
+				Updated:     updated,
+				MinContrast: minContrast,
+				MinScore:    minScore,
 			}
 
 			return tm.ListThemesWithOptions(opts)
@@ -221,6 +478,12 @@ Filters:
 	cmd.Flags().BoolVar(&showColors, "colors", false, "Show color preview")
 	cmd.Flags().BoolVar(&darkOnly, "dark", false, "Show only dark themes")
 	cmd.Flags().BoolVar(&lightOnly, "light", false, "Show only light themes")
+	cmd.Flags().StringVar(&sortBy, "sort", "name", "Sort order (name|popular|contrast|score)")
+	cmd.Flags().StringVar(&collection, "collection", "", "Show only themes in this collection")
+	cmd.Flags().BoolVar(&newOnly, "new", false, "Show only themes added by the latest update")
+	cmd.Flags().BoolVar(&updated, "updated", false, "Show only themes changed by the latest update")
+	cmd.Flags().Float64Var(&minContrast, "min-contrast", 0, "Show only themes with at least this foreground/background contrast ratio")
+	cmd.Flags().IntVar(&minScore, "min-score", 0, "Show only themes with at least this quality score (0-100)")
 
 	return cmd
 }
@@ -233,6 +496,14 @@ func randomCmd() *cobra.Command {
 		opacity    float64
 		blur       float64
 		scheme     string
+		exclude    []string
+		repeat     bool
+		collection string
+		tags       []string
+		schedule   string
+		seed       string
+		tomorrow   bool
+		minScore   int
 	)
 
 	cmd := &cobra.Command{
@@ -246,6 +517,22 @@ Theme Selection:
   • --dark:  Only dark themes
   • --light: Only light themes  
   • --scheme: Generate new theme with specific scheme
+  • --exclude: Skip themes matching a name or glob pattern
+  • --collection: Pick from a collection defined in the settings file
+  • --tag:   Only themes with a palette tag (warm, pastel, high-contrast, ...)
+
+Themes on the exclusion list ('alacritty-colors exclude add') are never picked,
+and the last 7 themes you applied are skipped (set random.avoid_recent in the
+settings file, or pass --allow-repeat). Themes with a quality score under
+50, usually broken ones, are skipped too (set random.min_score, or pass
+--min-score 0 to allow them).
+
+Theme of the Day:
+
+  --schedule daily picks from the date instead of chance, so every machine
+  with the same themes and seed lands on the same theme each day. Set a seed
+  with --seed or random.seed in the settings file to get a different
+  sequence, and peek at the next pick with --preview-tomorrow.
 
 Visual Options:
 
@@ -257,9 +544,14 @@ Examples:
 
   alacritty-colors random --dark
   alacritty-colors random --light --font
+  alacritty-colors random --exclude 'solarized*' --exclude gruvbox_light
+  alacritty-colors random --collection cozy
+  alacritty-colors random --tag warm --tag muted
+  alacritty-colors random --schedule daily --dark
+  alacritty-colors random --schedule daily --preview-tomorrow
   alacritty-colors random --scheme cyberpunk --opacity 0.85`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
@@ -267,13 +559,25 @@ Examples:
 			tm := theme.NewManager(cfg)
 			tm.SetVerbose(verbose)
 
+			if !cmd.Flags().Changed("min-score") {
+				minScore = cfg.Random.MinScore
+			}
+
 			opts := &theme.RandomOptions{
-				DarkOnly:  darkTheme,
-				LightOnly: lightTheme,
-				WithFont:  withFont,
-				Opacity:   opacity,
-				Blur:      blur,
-				Scheme:    scheme,
+				DarkOnly:        darkTheme,
+				LightOnly:       lightTheme,
+				WithFont:        withFont,
+				Opacity:         opacity,
+				Blur:            blur,
+				Scheme:          scheme,
+				Exclude:         exclude,
+				AllowRepeat:     repeat,
+				Collection:      collection,
+				Tags:            tags,
+				Schedule:        schedule,
+				Seed:            seed,
+				PreviewTomorrow: tomorrow,
+				MinScore:        minScore,
 			}
 
 			return tm.RandomThemeWithOptions(opts)
@@ -285,7 +589,15 @@ Examples:
 	cmd.Flags().BoolVar(&withFont, "font", false, "Also change font to match theme")
 	cmd.Flags().Float64Var(&opacity, "opacity", 0, "Set window opacity (0.0-1.0)")
 	cmd.Flags().Float64Var(&blur, "blur", 0, "Set background blur radius")
-	cmd.Flags().StringVarP(&scheme, "scheme", "s", "", "Generate new theme with scheme (random|pastel|neon|mono|warm|cool|nature|cyberpunk|dracula|nord|solarized|gruvbox)")
+	cmd.Flags().StringVarP(&scheme, "scheme", "s", "", "Generate new theme with scheme (random|pastel|neon|mono|warm|cool|nature|cyberpunk|dracula|nord|solarized|gruvbox|seasonal)")
+	cmd.Flags().StringSliceVar(&exclude, "exclude", nil, "Skip themes matching a name or glob pattern (repeatable)")
+	cmd.Flags().BoolVar(&repeat, "allow-repeat", false, "Allow themes applied recently")
+	cmd.Flags().StringVar(&collection, "collection", "", "Only pick themes from this collection")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Only pick themes with this palette tag (repeatable)")
+	cmd.Flags().StringVar(&schedule, "schedule", "", "Pick deterministically from the date (daily)")
+	cmd.Flags().StringVar(&seed, "seed", "", "Namespace for the daily pick (default: random.seed)")
+	cmd.Flags().BoolVar(&tomorrow, "preview-tomorrow", false, "Show tomorrow's daily pick without applying it")
+	cmd.Flags().IntVar(&minScore, "min-score", 0, "Skip themes with a lower quality score, 0 for none (default: random.min_score)")
 
 	return cmd
 }
@@ -300,6 +612,9 @@ func generateCmd() *cobra.Command {
 		withFont   bool
 		opacity    float64
 		blur       float64
+		screenshot string
+		mode       string
+		date       string
 	)
 
 	cmd := &cobra.Command{
@@ -321,6 +636,12 @@ Color Schemes:
   • nord       - Nord-inspired cool tones and minimalism
   • solarized  - Solarized variations with scientific precision
   • gruvbox    - Warm retro computing feel
+  • seasonal   - Follows the date: winter blues, spring greens, summer
+                 sun, autumn ambers, and holiday palettes around New Year,
+                 Valentine's Day, St. Patrick's Day, Halloween and
+                 Christmas. The same day gives the same colors, saved as
+                 seasonal_<season or holiday>. Set seasonal.hemisphere =
+                 "south" or seasonal.holidays = false in the settings file.
 
 Theme Types:
 
@@ -328,17 +649,49 @@ Theme Types:
   • --light    - Generate light variant
   • Default: Auto-determine based on scheme
 
+From a Screenshot:
+
+  --from-screenshot reads the colors from an image instead of a scheme.
+  With --mode terminal (the default) the image is taken to be a screenshot
+  of a terminal: the dominant flat region becomes the background and the
+  remaining colors are clustered into the ANSI slots. --mode wallpaper
+  derives a palette the way watch-wallpaper does.
+
 Examples:
 
   alacritty-colors generate --scheme cyberpunk --dark
   alacritty-colors generate --scheme nature --light --name forest
-  alacritty-colors generate --scheme warm --font --opacity 0.9`,
+  alacritty-colors generate --scheme warm --font --opacity 0.9
+  alacritty-colors generate --scheme seasonal --date 2026-10-31
+  alacritty-colors generate --from-screenshot shot.png --name borrowed
+  alacritty-colors generate --from-screenshot art.png --mode wallpaper`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if darkTheme && lightTheme {
 				return fmt.Errorf("cannot specify both --dark and --light")
 			}
+			if screenshot == "" && cmd.Flags().Changed("mode") {
+				return fmt.Errorf("--mode only applies with --from-screenshot")
+			}
+			if screenshot != "" && cmd.Flags().Changed("scheme") {
+				return fmt.Errorf("cannot combine --scheme with --from-screenshot")
+			}
+			var day time.Time
+			if date != "" {
+				if scheme != theme.SeasonalScheme {
+					return fmt.Errorf("--date only applies with --scheme %s", theme.SeasonalScheme)
+				}
+				parsed, err := time.ParseInLocation("2006-01-02", date, time.Local)
+				if err != nil {
+					return fmt.Errorf("invalid --date %q (use YYYY-MM-DD)", date)
+				}
+				day = parsed
+			}
+			if !slices.Contains(theme.ScreenshotModes, mode) {
+				return ui.WithHints(fmt.Errorf("unknown mode '%s' (use %s)", mode, strings.Join(theme.ScreenshotModes, " or ")),
+					ui.DidYouMean(mode, theme.ScreenshotModes))
+			}
 
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
@@ -355,6 +708,10 @@ Examples:
 				WithFont:   withFont,
 				Opacity:    opacity,
 				Blur:       blur,
+
+				FromScreenshot: screenshot,
+				ScreenshotMode: mode,
+				Date:           day,
 			}
 
 			return tm.GenerateThemeWithOptions(opts)
@@ -369,6 +726,9 @@ Examples:
 	cmd.Flags().BoolVar(&withFont, "font", false, "Auto-select matching font")
 	cmd.Flags().Float64Var(&opacity, "opacity", 0, "Set window opacity (0.0-1.0)")
 	cmd.Flags().Float64Var(&blur, "blur", 0, "Set background blur radius")
+	cmd.Flags().StringVar(&screenshot, "from-screenshot", "", "Read the colors from an image")
+	cmd.Flags().StringVar(&mode, "mode", theme.ScreenshotTerminal, "How to read the screenshot (terminal or wallpaper)")
+	cmd.Flags().StringVar(&date, "date", "", "Date whose palette the seasonal scheme uses (YYYY-MM-DD, default today)")
 
 	return cmd
 }
@@ -377,23 +737,59 @@ func searchCmd() *cobra.Command {
 	var (
 		format     string
 		showColors bool
+		color      string
+		tolerance  float64
+		slot       string
 	)
 
 	cmd := &cobra.Command{
-		Use:   "search <query>",
-		Short: "Search themes by name or tags",
+		Use:   "search [query]",
+		Short: "Search themes by name, tags or color",
 		Long: `Search available themes by name, description, or tags:
 
 The search is case-insensitive and matches partial strings.
-Use quotes for exact phrases.
+Use quotes for exact phrases. Well known themes are marked with a
+popularity badge.
+
+Every theme is tagged from its palette when the collection is indexed:
+dark or light, monochrome, muted, pastel or vibrant, warm or cool,
+high-contrast or low-contrast, and a tint such as blue-tinted. Searching
+for a tag finds themes whose files carry no such metadata.
+
+With --color, find themes that use a color close to the given one, such
+as your wallpaper's accent. Closeness is the perceptual CIE76 distance:
+about 2 is barely noticeable and the default tolerance of 10 is close at
+a glance. --slot limits the search to one color, e.g. background or red
+(normal and bright). A query can narrow the results further. Here --color
+takes a color; output colors follow the [ui] color setting.
 
 Examples:
   alacritty-colors search dark
+  alacritty-colors search pastel
   alacritty-colors search "solarized"
-  alacritty-colors search nord --colors`,
-		Args: cobra.ExactArgs(1),
+  alacritty-colors search nord --colors
+  alacritty-colors search --color "#ff79c6"
+  alacritty-colors search --color "#1e1e2e" --slot background --tolerance 5`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			// --color is the search color here, but still accept the
+			// global output modes
+			if slices.Contains(ui.Modes, color) {
+				if err := ui.SetColorMode(color); err != nil {
+					return err
+				}
+				color = ""
+			}
+			if len(args) == 0 && color == "" {
+				return ui.WithHints(fmt.Errorf("nothing to search for"),
+					"Give a query, or a color with --color \"#rrggbb\".")
+			}
+			query := ""
+			if len(args) > 0 {
+				query = args[0]
+			}
+
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
@@ -404,14 +800,20 @@ Examples:
 			opts := &theme.SearchOptions{
 				Format:     format,
 				ShowColors: showColors,
+				Color:      color,
+				Tolerance:  tolerance,
+				Slot:       slot,
 			}
 
-			return tm.SearchThemesWithOptions(args[0], opts)
+			return tm.SearchThemesWithOptions(query, opts)
 		},
 	}
 
 	cmd.Flags().StringVarP(&format, "format", "f", "list", "Output format (list|grid|colors)")
 	cmd.Flags().BoolVar(&showColors, "colors", false, "Show color preview")
+	cmd.Flags().StringVar(&color, "color", "", "Find themes using a color close to this hex value")
+	cmd.Flags().Float64Var(&tolerance, "tolerance", theme.DefaultColorTolerance, "Largest color distance counted as a match")
+	cmd.Flags().StringVar(&slot, "slot", "", "Only compare this color slot (e.g. background, foreground, red)")
 
 	return cmd
 }
@@ -420,6 +822,8 @@ func previewCmd() *cobra.Command {
 	var (
 		apply     bool
 		showHex   bool
+		showcase  bool
+		useOSC    bool
 		slideshow bool
 		interval  int
 		darkOnly  bool
@@ -436,8 +840,17 @@ func previewCmd() *cobra.Command {
 SINGLE THEME MODE (with theme name):
 • Temporarily apply a specific theme
 • Show theme information and color palette
+• Draw a showcase in the theme: the 16 ANSI colors, 256-color and truecolor
+  ramps, text styles, a diff and a sample TUI, and warn about colors that
+  barely show on the background (--showcase=false to skip)
 • Offer to keep or restore previous theme
 
+The preview is written to current.toml, so it only shows up in Alacritty
+with live_config_reload on and the import in place. When this terminal
+isn't Alacritty, is an SSH session, or doesn't reload the file, preview says
+why and offers to recolor the terminal with escape sequences instead; pass
+--osc to do that from the start.
+
 SLIDESHOW MODE (no theme name or --slideshow flag):
 • Automatically cycle through all available themes
 • Live preview with configurable intervals
@@ -458,10 +871,11 @@ Examples:
   alacritty-colors preview --interval 5        # 5-second intervals
   alacritty-colors preview --dark --random     # Random dark themes only
   alacritty-colors preview dracula             # Preview specific theme
-  alacritty-colors preview nord --apply        # Preview and auto-apply`,
+  alacritty-colors preview nord --apply        # Preview and auto-apply
+  alacritty-colors preview nord --osc          # Preview over SSH or in another terminal`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
@@ -486,6 +900,8 @@ Examples:
 			opts := &theme.PreviewOptions{
 				AutoApply: apply,
 				ShowHex:   showHex,
+				Showcase:  showcase,
+				OSC:       useOSC,
 			}
 
 			return tm.PreviewThemeWithOptions(args[0], opts)
@@ -494,6 +910,8 @@ Examples:
 
 	cmd.Flags().BoolVarP(&apply, "apply", "a", false, "Apply theme after preview (single theme mode)")
 	cmd.Flags().BoolVar(&showHex, "hex", false, "Show hex color values (single theme mode)")
+	cmd.Flags().BoolVar(&useOSC, "osc", false, "Preview with escape sequences instead of writing current.toml (single theme mode)")
+	cmd.Flags().BoolVar(&showcase, "showcase", true, "Show color ramps, text styles and sample UIs (single theme mode)")
 	cmd.Flags().BoolVarP(&slideshow, "slideshow", "s", false, "Force slideshow mode")
 	cmd.Flags().IntVarP(&interval, "interval", "i", 3, "Seconds between theme changes (slideshow mode)")
 	cmd.Flags().BoolVar(&darkOnly, "dark", false, "Show only dark themes (slideshow mode)")
@@ -512,6 +930,9 @@ func slideshowCmd() *cobra.Command {
 		randomize  bool
 		loop       bool
 		categories []string
+		exclude    []string
+		collection string
+		tournament bool
 	)
 
 	cmd := &cobra.Command{
@@ -521,15 +942,18 @@ func slideshowCmd() *cobra.Command {
 
 This command will apply themes successively with configurable intervals,
 allowing you to see each theme in action in your actual Alacritty terminal.
-Alacritty will auto-reload each theme as it's applied.
+Alacritty will auto-reload each theme as it's applied. current.toml is
+replaced in one step, so the reload never catches a half-written theme; set
+apply.debounce_ms if very fast changes still flicker.
 
 Features:
 • Auto-cycle through themes with customizable intervals
 • Live preview in your actual terminal (not just color swatches)
 • Interactive controls for navigation and selection
-• Filter by dark/light themes or categories
+• Filter by dark/light themes, categories or a collection
 • Randomization option for discovery
 • Loop or single-pass modes
+• Skips themes on the exclusion list and those matching --exclude
 
 Controls during slideshow:
 • SPACE/ENTER: Select current theme and exit
@@ -539,13 +963,21 @@ Controls during slideshow:
 • q/ESC: Quit and restore original theme
 • +/-: Increase/decrease cycling speed
 
+With --tournament, themes meet in head-to-head matches instead: both are
+applied in turn (a/b or TAB to switch), SPACE/ENTER picks the one shown,
+and the winners advance through a bracket until one theme is crowned and
+applied. Combine it with filters to decide among many similar themes.
+
 Examples:
   alacritty-colors slideshow                    # Default 3-second intervals
   alacritty-colors slideshow --interval 5      # 5-second intervals
   alacritty-colors slideshow --dark --random   # Random dark themes only
-  alacritty-colors slideshow --interval 2 --loop  # Loop indefinitely`,
+  alacritty-colors slideshow --interval 2 --loop  # Loop indefinitely
+  alacritty-colors slideshow --exclude '*light*'  # Skip light variants by name
+  alacritty-colors slideshow --collection cozy    # Only themes in a collection
+  alacritty-colors slideshow --dark --tournament --random  # Bracket of dark themes`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
@@ -560,6 +992,9 @@ Examples:
 				Randomize:  randomize,
 				Loop:       loop,
 				Categories: categories,
+				Exclude:    exclude,
+				Collection: collection,
+				Tournament: tournament,
 			}
 
 			return tm.ThemeSlideshow(opts)
@@ -572,6 +1007,9 @@ Examples:
 	cmd.Flags().BoolVar(&randomize, "random", false, "Randomize theme order")
 	cmd.Flags().BoolVar(&loop, "loop", true, "Loop indefinitely (default true)")
 	cmd.Flags().StringSliceVar(&categories, "categories", nil, "Filter by theme categories")
+	cmd.Flags().StringSliceVar(&exclude, "exclude", nil, "Skip themes matching a name or glob pattern (repeatable)")
+	cmd.Flags().StringVar(&collection, "collection", "", "Only show themes in this collection")
+	cmd.Flags().BoolVar(&tournament, "tournament", false, "Pick a favorite through head-to-head matches")
 
 	return cmd
 }
@@ -597,7 +1035,7 @@ Controls:
 • 'r': Reset to original
 • 'q': Quit (with unsaved changes prompt)`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
@@ -614,6 +1052,7 @@ func backupCmd() *cobra.Command {
 	var (
 		name        string
 		description string
+		slot        string
 	)
 
 	cmd := &cobra.Command{
@@ -622,14 +1061,24 @@ func backupCmd() *cobra.Command {
 		Long: `Create a backup of your current Alacritty configuration:
 
 Backups are stored with timestamps and can include custom names
-and descriptions for easy identification.
+and descriptions for easy identification. The active theme's
+current.toml and name are saved with each backup, along with the
+opacity, blur, font and bold-bright settings in effect and the
+Alacritty and alacritty-colors versions.
+
+--slot saves to a named slot instead: a fixed-name backup that is
+overwritten each time, kept apart from the timestamped ones and never
+cleaned up. Use it as a known-good checkpoint to return to with
+'restore --slot'.
 
 Examples:
   alacritty-colors backup
   alacritty-colors backup --name "before-theme-experiment"
-  alacritty-colors backup --name "stable" --description "Working config"`,
+  alacritty-colors backup --name "stable" --description "Working config"
+  alacritty-colors backup --slot stable
+  alacritty-colors backup --slot stable --description "Before the font change"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
@@ -637,9 +1086,14 @@ Examples:
 			tm := theme.NewManager(cfg)
 			tm.SetVerbose(verbose)
 
+			if slot != "" && name != "" {
+				return fmt.Errorf("--slot and --name cannot be used together")
+			}
+
 			opts := &theme.BackupOptions{
 				Name:        name,
 				Description: description,
+				Slot:        slot,
 			}
 
 			return tm.CreateBackupWithOptions(opts)
@@ -648,6 +1102,7 @@ Examples:
 
 	cmd.Flags().StringVarP(&name, "name", "n", "", "Custom backup name")
 	cmd.Flags().StringVarP(&description, "description", "d", "", "Backup description")
+	cmd.Flags().StringVar(&slot, "slot", "", "Save to a named slot, replacing what it held")
 
 	return cmd
 }
@@ -656,6 +1111,10 @@ func restoreCmd() *cobra.Command {
 	var (
 		list        bool
 		interactive bool
+		configOnly  bool
+		latest      bool
+		nth         int
+		slot        string
 	)
 
 	cmd := &cobra.Command{
@@ -664,15 +1123,28 @@ func restoreCmd() *cobra.Command {
 		Long: `Restore your Alacritty configuration from a backup:
 
 Without arguments, shows available backups for interactive selection.
-With a backup file argument, restores directly from that backup.
+With a backup file argument, restores directly from that backup; any
+unique prefix of its name works too. --latest and -n pick backups by
+age instead, and --slot restores a slot saved with 'backup --slot'.
+
+The theme that was active when the backup was made (current.toml and its
+name) is restored along with alacritty.toml, unless --config-only is given.
+A backup made with another Alacritty release series than the installed
+one, such as a pre-0.13 backup from before TOML configs, gets a warning
+that some of its options may need 'alacritty migrate'.
 
 Examples:
   alacritty-colors restore                    # Interactive selection
   alacritty-colors restore --list             # List available backups  
-  alacritty-colors restore backup_2024.toml   # Restore specific backup`,
+  alacritty-colors restore backup_2024.toml   # Restore specific backup
+  alacritty-colors restore stable             # Backup whose name starts with "stable"
+  alacritty-colors restore --latest           # Most recent backup
+  alacritty-colors restore -n 2               # Second most recent backup
+  alacritty-colors restore --slot stable      # Slot saved with 'backup --slot stable'
+  alacritty-colors restore backup_2024.toml --config-only`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
@@ -689,8 +1161,27 @@ Examples:
 				backupFile = args[0]
 			}
 
+			if cmd.Flags().Changed("n") && nth < 1 {
+				return fmt.Errorf("-n must be 1 or more (1 is the latest backup)")
+			}
+			if latest {
+				if cmd.Flags().Changed("n") {
+					return fmt.Errorf("--latest and -n cannot be used together")
+				}
+				nth = 1
+			}
+			if nth > 0 && backupFile != "" {
+				return fmt.Errorf("give a backup name or --latest/-n, not both")
+			}
+			if slot != "" && (nth > 0 || backupFile != "" || interactive) {
+				return fmt.Errorf("--slot cannot be combined with a backup name, --latest, -n or --interactive")
+			}
+
 			opts := &theme.RestoreOptions{
 				Interactive: interactive || backupFile == "",
+				ConfigOnly:  configOnly,
+				Nth:         nth,
+				Slot:        slot,
 			}
 
 			return tm.RestoreBackupWithOptions(backupFile, opts)
@@ -699,14 +1190,22 @@ Examples:
 
 	cmd.Flags().BoolVarP(&list, "list", "l", false, "List available backups")
 	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Interactive backup selection")
+	cmd.Flags().BoolVar(&configOnly, "config-only", false, "Restore alacritty.toml only, keeping the current colors")
+	cmd.Flags().BoolVar(&latest, "latest", false, "Restore the most recent backup")
+	cmd.Flags().IntVarP(&nth, "n", "n", 0, "Restore the nth most recent backup (1 is the latest)")
+	cmd.Flags().StringVar(&slot, "slot", "", "Restore a named slot saved with 'backup --slot'")
 
 	return cmd
 }
 
 func updateCmd() *cobra.Command {
 	var (
-		force bool
-		check bool
+		force              bool
+		check              bool
+		incremental        bool
+		prune              string
+		insecureSkipVerify bool
+		source             string
 	)
 
 	cmd := &cobra.Command{
@@ -717,12 +1216,27 @@ func updateCmd() *cobra.Command {
 Downloads the latest themes from the Alacritty themes repository
 and updates the local theme collection.
 
+With --incremental, only themes whose content changed upstream are
+fetched through the GitHub API, and the new and updated themes are
+listed. --check reports the same changes without downloading. The API
+allows 60 requests an hour; set GITHUB_TOKEN to raise it to 5000.
+
+Extra [[sources]] in the settings file are downloaded along with the
+official collection, up to 4 at a time with a progress line each. --source
+refreshes a single source: "official" or the name of one of yours.
+
+Downloaded themes that no longer exist upstream can be archived to
+themes/.archive, deleted or kept (--prune). Generated and imported
+themes are never touched.
+
 Examples:
-  alacritty-colors update           # Update themes
-  alacritty-colors update --check   # Check for updates only
-  alacritty-colors update --force   # Force re-download all themes`,
+  alacritty-colors update                 # Update themes
+  alacritty-colors update --check         # Check for updates only
+  alacritty-colors update --incremental   # Fetch only changed themes
+  alacritty-colors update --force         # Force re-download all themes
+  alacritty-colors update --source catppuccin   # Refresh one source`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
@@ -730,9 +1244,17 @@ Examples:
 			tm := theme.NewManager(cfg)
 			tm.SetVerbose(verbose)
 
+			if source != "" && (force || check) {
+				return fmt.Errorf("--source cannot be combined with --force or --check")
+			}
+
 			opts := &theme.UpdateOptions{
-				Force: force,
-				Check: check,
+				Force:              force,
+				Check:              check,
+				Incremental:        incremental,
+				Prune:              prune,
+				InsecureSkipVerify: insecureSkipVerify,
+				Source:             source,
 			}
 
 			return tm.UpdateThemesWithOptions(opts)
@@ -741,6 +1263,10 @@ Examples:
 
 	cmd.Flags().BoolVar(&force, "force", false, "Force re-download all themes")
 	cmd.Flags().BoolVar(&check, "check", false, "Check for updates only")
+	cmd.Flags().BoolVar(&incremental, "incremental", false, "Fetch only themes that changed upstream")
+	cmd.Flags().StringVar(&prune, "prune", "ask", "Themes removed upstream (ask|archive|delete|keep)")
+	cmd.Flags().BoolVar(&insecureSkipVerify, "insecure-skip-verify", false, "Allow sources without a published checksum")
+	cmd.Flags().StringVar(&source, "source", "", "Refresh only this source (official or a [[sources]] name)")
 
 	return cmd
 }
@@ -759,6 +1285,9 @@ Use subcommands for specific configuration tasks.`,
 	cmd.AddCommand(configCleanThemesCmd())
 	cmd.AddCommand(configSetPathCmd())
 	cmd.AddCommand(configShowCmd())
+	cmd.AddCommand(configProfilesCmd())
+	cmd.AddCommand(configAddProfileCmd())
+	cmd.AddCommand(configUseCmd())
 
 	return cmd
 }
@@ -768,9 +1297,9 @@ func configCleanBackupsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "clean-backups",
 		Short: "Clean up old backup files",
-		Long:  "Remove old backup files, keeping only the most recent ones",
+		Long:  "Remove old backup files, keeping only the most recent ones. Removed files go to the trash (see 'alacritty-colors trash').",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
@@ -805,11 +1334,13 @@ func configCleanBackupsCmd() *cobra.Command {
 				return nil
 			}
 
-			// Remove older backups
+			// Move older backups to the trash
+			tm := theme.NewManager(cfg)
+			tm.SetVerbose(verbose)
 			deleted := 0
 			for i := keepCount; i < len(backups); i++ {
 				path := filepath.Join(cfg.BackupDir, backups[i].Name())
-				if err := os.Remove(path); err != nil {
+				if err := tm.MoveToTrash(path, theme.TrashBackup); err != nil {
 					ui.PrintWarning("Failed to remove %s: %v", backups[i].Name(), err)
 					continue
 				}
@@ -817,6 +1348,7 @@ func configCleanBackupsCmd() *cobra.Command {
 			}
 
 			ui.PrintSuccess("Cleaned up %d backup files, kept %d most recent", deleted, keepCount)
+			ui.PrintInfo("Removed files stay in the trash for %d days: alacritty-colors trash list", int(theme.TrashExpiry.Hours()/24))
 			return nil
 		},
 	}
@@ -831,15 +1363,18 @@ func configCleanThemesCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "clean-themes",
 		Short: "Clean up theme files",
-		Long:  "Remove generated or unused theme files",
+		Long:  "Remove generated or unused theme files. Removed files go to the trash (see 'alacritty-colors trash').",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
 
 			ui.PrintHeader("Cleaning Theme Files")
 
+			tm := theme.NewManager(cfg)
+			tm.SetVerbose(verbose)
+
 			// Get list of theme files
 			files, err := os.ReadDir(cfg.ThemesDir)
 			if err != nil {
@@ -847,11 +1382,12 @@ func configCleanThemesCmd() *cobra.Command {
 			}
 
 			deleted := 0
+			protected := 0
 
 			// Process each theme file
 			for _, file := range files {
-				// Skip directories and current.toml
-				if file.IsDir() || file.Name() == "current.toml" {
+				// Skip directories, non-theme files and current.toml
+				if file.IsDir() || !strings.HasSuffix(file.Name(), ".toml") || file.Name() == "current.toml" {
 					continue
 				}
 
@@ -874,7 +1410,11 @@ func configCleanThemesCmd() *cobra.Command {
 				// Delete if criteria met
 				if shouldDelete {
 					path := filepath.Join(cfg.ThemesDir, file.Name())
-					if err := os.Remove(path); err != nil {
+					if tm.IsProtected(path) {
+						protected++
+						continue
+					}
+					if err := tm.MoveToTrash(path, theme.TrashTheme); err != nil {
 						ui.PrintWarning("Failed to remove %s: %v", file.Name(), err)
 						continue
 					}
@@ -883,6 +1423,12 @@ func configCleanThemesCmd() *cobra.Command {
 			}
 
 			ui.PrintSuccess("Cleaned up %d theme files", deleted)
+			if protected > 0 {
+				ui.PrintInfo("Kept %d protected themes", protected)
+			}
+			if deleted > 0 {
+				ui.PrintInfo("Removed files stay in the trash for %d days: alacritty-colors trash list", int(theme.TrashExpiry.Hours()/24))
+			}
 			return nil
 		},
 	}
@@ -896,14 +1442,25 @@ func configSetPathCmd() *cobra.Command {
 	var newConfigPath string
 	var newThemesDir string
 	var newBackupDir string
+	var extraThemesDirs []string
+	var newSaveDir string
 
 	cmd := &cobra.Command{
 		Use:   "set-path",
 		Short: "Set custom paths for configuration",
-		Long:  "Set custom paths for Alacritty config file, themes directory, and backup directory",
+		Long: `Set custom paths for Alacritty config file, themes directory, and backup directory.
+
+Themes are looked up in the themes directory first and then in any added
+with --add-themes-dir, such as personal themes kept in a dotfiles repo. A
+theme found earlier hides one of the same name found later. Generated themes
+are written to --save-dir, which defaults to the themes directory.
+
+Examples:
+  alacritty-colors config set-path --add-themes-dir ~/dotfiles/alacritty-themes
+  alacritty-colors config set-path --save-dir ~/dotfiles/alacritty-themes`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Load current config
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
@@ -929,6 +1486,24 @@ func configSetPathCmd() *cobra.Command {
 				}
 			}
 
+			for _, dir := range extraThemesDirs {
+				if slices.Contains(cfg.ThemeDirs(), dir) {
+					ui.PrintInfo("Already searched: %s", dir)
+					continue
+				}
+				cfg.ExtraThemesDirs = append(cfg.ExtraThemesDirs, dir)
+				ui.PrintInfo("Added themes directory: %s", dir)
+			}
+
+			if newSaveDir != "" {
+				ui.PrintInfo("Updated save directory: %s -> %s", cfg.ThemeSaveDir(), newSaveDir)
+				cfg.SaveDir = newSaveDir
+
+				if err := os.MkdirAll(newSaveDir, 0755); err != nil {
+					return fmt.Errorf("failed to create save directory: %w", err)
+				}
+			}
+
 			// Update backup directory if specified
 			if newBackupDir != "" {
 				oldPath := cfg.BackupDir
@@ -954,24 +1529,2186 @@ func configSetPathCmd() *cobra.Command {
 	cmd.Flags().StringVar(&newConfigPath, "config", "", "new path for Alacritty config file")
 	cmd.Flags().StringVar(&newThemesDir, "themes-dir", "", "new path for themes directory")
 	cmd.Flags().StringVar(&newBackupDir, "backup-dir", "", "new path for backup directory")
+	cmd.Flags().StringSliceVar(&extraThemesDirs, "add-themes-dir", nil, "additional themes directory to search (repeatable)")
+	cmd.Flags().StringVar(&newSaveDir, "save-dir", "", "directory generated themes are written to")
 	return cmd
 }
 
 func configShowCmd() *cobra.Command {
-	return &cobra.Command{
+	var opts theme.ShowConfigOptions
+
+	cmd := &cobra.Command{
 		Use:   "show",
 		Short: "Show current configuration",
 		Long: `Display current configuration paths and settings:
 
-Shows all configured paths, current theme, and tool status.`,
+Shows all configured paths, current theme, and tool status, then every
+effective setting with where its value comes from: a command-line flag, an
+ALACRITTY_COLORS_* environment variable, the selected profile, the settings
+file, or the default. The theme sources 'init' and 'update' download follow.
+
+--paths lists the files and directories in use instead: every place
+Alacritty looks for its config, in order, with the one in use marked, the
+tool's directories and its caches and state files.
+
+--json prints all of it as JSON, handy to attach to a bug report.
+
+Examples:
+
+  alacritty-colors config show
+  alacritty-colors config show --paths
+  alacritty-colors config show --json
+  alacritty-colors config show --paths --json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile, themesDir, backupDir)
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
 			if err != nil {
 				return err
 			}
 
 			tm := theme.NewManager(cfg)
-			return tm.ShowConfig()
+			return tm.ShowConfigWithOptions(opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Paths, "paths", false, "Show the files and directories in use instead of the settings")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Print as JSON")
+	return cmd
+}
+
+func configProfilesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "profiles",
+		Short: "List config profiles",
+		Long: `List the named profiles in the settings file.
+
+Each profile points at its own alacritty.toml, themes and backups, so one
+dotfile repository can manage several machines or setups. The active
+profile is marked.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+			if err != nil {
+				return err
+			}
+
+			active := cfg.Profile
+			if active == "" {
+				active = config.DefaultProfile
+			}
+
+			ui.PrintHeader("Config Profiles")
+			rows := [][]string{}
+			for _, name := range append([]string{config.DefaultProfile}, cfg.ProfileNames()...) {
+				marker := ""
+				if name == active {
+					marker = "*"
+				}
+				rows = append(rows, []string{marker, name, cfg.ProfileConfigFile(name)})
+			}
+			ui.PrintTable([]string{"", "Profile", "Config File"}, rows)
+			return nil
+		},
+	}
+}
+
+func configAddProfileCmd() *cobra.Command {
+	var (
+		newConfigPath string
+		newThemesDir  string
+		newBackupDir  string
+		use           bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add-profile <name>",
+		Short: "Add a config profile",
+		Long: `Add a named profile pointing at another Alacritty config.
+
+The themes and backup directories default to themes/ and backups/ next to
+the profile's config file.
+
+Examples:
+  alacritty-colors config add-profile laptop --config ~/dotfiles/laptop/alacritty.toml
+  alacritty-colors config add-profile work --config ~/work/alacritty.toml --use`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+			if err != nil {
+				return err
+			}
+
+			p := config.Profile{
+				ConfigFile: newConfigPath,
+				ThemesDir:  newThemesDir,
+				BackupDir:  newBackupDir,
+			}
+			if err := cfg.AddProfile(args[0], p); err != nil {
+				return err
+			}
+
+			if use {
+				if err := cfg.UseProfile(args[0]); err != nil {
+					return err
+				}
+			} else if err := cfg.Save(); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+
+			ui.PrintSuccess("Added profile %s", args[0])
+			if !use {
+				ui.PrintInfo("Use it with --profile %s or 'alacritty-colors config use %s'", args[0], args[0])
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&newConfigPath, "config", "", "Alacritty config file of the profile")
+	cmd.Flags().StringVar(&newThemesDir, "themes-dir", "", "themes directory of the profile")
+	cmd.Flags().StringVar(&newBackupDir, "backup-dir", "", "backup directory of the profile")
+	cmd.Flags().BoolVar(&use, "use", false, "make the new profile the default")
+	cmd.MarkFlagRequired("config")
+	return cmd
+}
+
+func configUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <profile>",
+		Short: "Select the default config profile",
+		Long: `Select the profile used when --profile is not given.
+
+Use "default" to go back to the top-level paths.
+
+Examples:
+  alacritty-colors config use laptop
+  alacritty-colors config use default`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+			if err != nil {
+				return err
+			}
+
+			if err := cfg.UseProfile(args[0]); err != nil {
+				return err
+			}
+
+			ui.PrintSuccess("Using profile %s", args[0])
+			return nil
+		},
+	}
+}
+
+func localCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "local",
+		Short: "Per-directory themes",
+		Long: `Record a theme for a directory and its subdirectories.
+
+The theme is stored in a .alacritty-theme file, which can be committed with
+the project. Together with the shell hook (see 'alacritty-colors hook'), the
+theme is applied on cd and the previous theme is restored when leaving, e.g.
+to tell production and development checkouts apart at a glance.
+
+Examples:
+  alacritty-colors local set gruvbox_dark
+  alacritty-colors local show
+  alacritty-colors local unset`,
+	}
+
+	cmd.AddCommand(localSetCmd())
+	cmd.AddCommand(localUnsetCmd())
+	cmd.AddCommand(localShowCmd())
+	cmd.AddCommand(localApplyCmd())
+
+	return cmd
+}
+
+// localManager loads the configuration and returns a manager with the
+// current working directory
+func localManager() (*theme.Manager, string, error) {
+	cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	tm := theme.NewManager(cfg)
+	tm.SetVerbose(verbose)
+	return tm, dir, nil
+}
+
+func localSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <theme-name>",
+		Short: "Set the theme of the current directory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, dir, err := localManager()
+			if err != nil {
+				return err
+			}
+			if err := tm.SetLocalTheme(dir, args[0]); err != nil {
+				return err
+			}
+			return tm.ApplyLocalTheme(dir)
+		},
+	}
+}
+
+func localUnsetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset",
+		Short: "Remove the theme of the current directory",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, dir, err := localManager()
+			if err != nil {
+				return err
+			}
+			if err := tm.UnsetLocalTheme(dir); err != nil {
+				return err
+			}
+			return tm.ApplyLocalTheme(dir)
+		},
+	}
+}
+
+func localShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Show the theme that applies to the current directory",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, dir, err := localManager()
+			if err != nil {
+				return err
+			}
+			return tm.ShowLocalTheme(dir)
+		},
+	}
+}
+
+func localApplyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "apply",
+		Short: "Apply the theme of the current directory",
+		Long: `Apply the theme recorded for the current directory, or restore the
+previous theme when outside of any project. This is what the shell hook runs.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, dir, err := localManager()
+			if err != nil {
+				return err
+			}
+			return tm.ApplyLocalTheme(dir)
 		},
 	}
 }
+
+func hookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "hook <bash|zsh|fish>",
+		Short:     "Print shell integration for per-directory themes",
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		Long: `Print shell code that applies per-directory themes on cd.
+
+Add it to your shell startup file:
+
+  bash  (~/.bashrc):                 eval "$(alacritty-colors hook bash)"
+  zsh   (~/.zshrc):                  eval "$(alacritty-colors hook zsh)"
+  fish  (~/.config/fish/config.fish): alacritty-colors hook fish | source
+
+See 'alacritty-colors hook ssh --help' for themes during SSH sessions.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			executable, err := os.Executable()
+			if err != nil {
+				executable = "alacritty-colors"
+			}
+
+			script, err := theme.ShellHook(args[0], executable)
+			if err != nil {
+				return err
+			}
+
+			fmt.Print(script)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(hookSSHCmd())
+
+	return cmd
+}
+
+func hookSSHCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:       "ssh <bash|zsh|fish>",
+		Short:     "Print shell integration that recolors the terminal during SSH sessions",
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		Long: `Print a shell function wrapping ssh. Sessions to hosts with a theme recolor
+only the terminal they run in, with OSC escape sequences, and its own
+colors come back when ssh exits. The classic use is a red background on
+production servers:
+
+  [ssh]
+  theme = ""                         # hosts matched below only
+
+  [ssh.hosts]
+  "*.prod.example.com" = "red_alert" # glob patterns; the longest match wins
+  bastion = "gruvbox_dark"           # host names as typed, aliases included
+
+ssh.theme, when set, applies to every other host. ssh with its output
+redirected is left alone.
+
+Add it to your shell startup file:
+
+  bash  (~/.bashrc):                 eval "$(alacritty-colors hook ssh bash)"
+  zsh   (~/.zshrc):                  eval "$(alacritty-colors hook ssh zsh)"
+  fish  (~/.config/fish/config.fish): alacritty-colors hook ssh fish | source`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			executable, err := os.Executable()
+			if err != nil {
+				executable = "alacritty-colors"
+			}
+
+			script, err := theme.SSHHook(args[0], executable)
+			if err != nil {
+				return err
+			}
+
+			fmt.Print(script)
+			return nil
+		},
+	}
+}
+
+func syncCmd() *cobra.Command {
+	var (
+		themeName   string
+		list        bool
+		targetNames []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync [target...]",
+		Short: "Write the theme's colors to other programs",
+		Long: `Write the current theme's colors to other programs so they match Alacritty.
+
+Targets listed in the settings file are synced automatically after every
+apply. Without arguments, this command syncs those targets again; name
+targets to sync them once.
+
+Settings:
+  [sync]
+  targets = ["tmux"]
+
+  [sync.tmux]
+  path = "~/.config/tmux/alacritty-colors.conf"
+  reload = true   # default; run 'tmux source-file' after writing
+
+Available targets:
+  bat       tmTheme for bat and delta (select with BAT_THEME=alacritty-colors)
+  fzf       shell file extending FZF_DEFAULT_OPTS with --color
+  i3        client.* border colors to include from the i3 config
+  kitty     color config to include from kitty.conf (live reload)
+  polybar   [colors] section to include-file from the polybar config
+  starship  palette table in starship.toml, patched in place
+  sway      client.* border colors to include from the sway config
+  tmux      status line, window list, pane borders and messages
+  waybar    @define-color names to @import from style.css
+  wezterm   Lua color table to require from wezterm.lua
+  zellij    KDL theme named alacritty-colors
+
+Examples:
+  alacritty-colors sync                      # Sync the configured targets
+  alacritty-colors sync tmux                 # Sync tmux once
+  alacritty-colors sync --targets starship   # Same, as a flag
+  alacritty-colors sync --list               # Show targets and their paths`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+			if err != nil {
+				return err
+			}
+
+			tm := theme.NewManager(cfg)
+			tm.SetVerbose(verbose)
+
+			if list {
+				tm.ListSyncTargets()
+				return nil
+			}
+
+			names := append(args, targetNames...)
+			if len(names) == 0 {
+				names = cfg.Sync.Targets
+			}
+			if len(names) == 0 {
+				ui.PrintWarning("No sync targets configured")
+				ui.PrintInfo("Add targets to [sync] in %s or name one, e.g. 'alacritty-colors sync tmux'", cfg.Path())
+				return nil
+			}
+
+			if err := tm.SyncTargets(themeName, names); err != nil {
+				return err
+			}
+			ui.PrintSuccess("Synced %s", strings.Join(names, ", "))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&themeName, "theme", "t", "", "Theme to sync instead of the current one")
+	cmd.Flags().BoolVarP(&list, "list", "l", false, "List available targets")
+	cmd.Flags().StringSliceVar(&targetNames, "targets", nil, "Targets to sync, comma-separated")
+
+	return cmd
+}
+
+func exportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export themes for other programs",
+		Long: `Export themes in formats other programs can import.
+
+Use subcommands for each kind of export.`,
+	}
+
+	cmd.AddCommand(exportTerminalCmd())
+	cmd.AddCommand(exportSnippetCmd())
+	cmd.AddCommand(exportSchemaCmd())
+
+	return cmd
+}
+
+func exportTerminalCmd() *cobra.Command {
+	var (
+		target string
+		output string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "terminal [theme|pattern...]",
+		Short: "Export a theme for GNOME Terminal or Konsole",
+		Long: `Export a theme for another terminal emulator, defaulting to the current theme.
+
+Targets:
+  gnome     shell script that adds the theme as a GNOME Terminal profile via dconf
+  konsole   .colorscheme file for ~/.local/share/konsole
+
+Without --output the result is printed to stdout. When --output is a
+directory, a file name is chosen for you.
+
+Several themes, glob patterns ('nord*') or regular expressions between
+slashes ('/^gruvbox_(dark|light)$/') export every match into the --output
+directory; --dry-run lists the matches first.
+
+Examples:
+  alacritty-colors export terminal --target gnome | sh
+  alacritty-colors export terminal nord --target konsole -o ~/.local/share/konsole/
+  alacritty-colors export terminal 'nord*' --target konsole --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+			if err != nil {
+				return err
+			}
+
+			tm := theme.NewManager(cfg)
+			tm.SetVerbose(verbose)
+
+			return tm.ExportTerminal(args, target, output, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVar(&target, "target", "", "Terminal to export for (gnome, konsole)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File or directory to write instead of stdout")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the themes that would be exported")
+	cmd.MarkFlagRequired("target")
+
+	return cmd
+}
+
+func exportSnippetCmd() *cobra.Command {
+	var (
+		stripComments bool
+		output        string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "alacritty-snippet [theme-name]",
+		Short: "Print a theme's [colors] block to paste into alacritty.toml",
+		Long: `Print the [colors] tables of a theme, defaulting to the current one, as
+TOML ready to paste into an Alacritty config. Use this if you keep colors
+in your config instead of importing themes/current.toml.
+
+Without --output the block is printed to stdout. When --output is a
+directory, a file name is chosen for you.
+
+Examples:
+  alacritty-colors export alacritty-snippet dracula
+  alacritty-colors export alacritty-snippet nord --strip-comments | wl-copy
+  alacritty-colors export alacritty-snippet gruvbox_dark >> ~/.config/alacritty/alacritty.toml`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+
+			themeName := ""
+			if len(args) > 0 {
+				themeName = args[0]
+			}
+			return tm.ExportSnippet(themeName, stripComments, output)
+		},
+	}
+
+	cmd.Flags().BoolVar(&stripComments, "strip-comments", false, "Leave out comments")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File or directory to write instead of stdout")
+
+	return cmd
+}
+
+func exportSchemaCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "json-schema <theme|settings>",
+		Short: "Print the JSON Schema of theme files or the settings file",
+		Long: `Print a JSON Schema describing theme files or alacritty-colors' own
+settings file, for editor completion and validation while editing them
+by hand. The schemas are generated from the structures the tool reads, so
+they match the running version.
+
+Documents:
+  theme      the [colors] tables of a theme file
+  settings   alacritty-colors.toml, with defaults and allowed values
+
+TOML editors built on Taplo, such as Even Better TOML for VS Code, pick a
+schema up from a '#:schema <path>' comment on the first line of the file.
+
+Without --output the schema is printed to stdout. When --output is a
+directory, a file name is chosen for you.
+
+Examples:
+  alacritty-colors export json-schema theme -o ~/.config/alacritty/themes/
+  alacritty-colors export json-schema settings > alacritty-colors.schema.json`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: theme.SchemaKinds,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.ExportSchema(args[0], output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File or directory to write instead of stdout")
+
+	return cmd
+}
+
+func templateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Render config files for other programs from a theme",
+		Long: `Render config files for other programs from the current theme.
+
+Built-in templates:
+  dunst   notification colors as a dunstrc.d drop-in (reloads dunst)
+  rofi    .rasi theme for rofi
+  wofi    CSS stylesheet for wofi
+
+Templates use Go text/template syntax with these fields:
+  {{.Theme}} {{.Background}} {{.Foreground}} {{.Cursor}}
+  {{.Normal.Red}} ... {{.Bright.White}}   (Black, Red, Green, Yellow,
+                                          Blue, Magenta, Cyan, White)
+  {{.Colors.normal_red}}                  (any color the theme defines)
+and functions: strip (drop '#'), alpha 0.8 (append opacity), rgb ("r, g, b").
+
+Put your own templates in <data dir>/templates/<name>.tmpl; a file named
+after a built-in replaces it. Start from a built-in with 'template show'.
+The first lines may set where output goes and what to run afterwards:
+  {{/* output: ~/.config/rofi/alacritty-colors.rasi */}}
+  {{/* reload: dunstctl reload */}}`,
+	}
+
+	cmd.AddCommand(templateListCmd())
+	cmd.AddCommand(templateShowCmd())
+	cmd.AddCommand(templateApplyCmd())
+
+	return cmd
+}
+
+// loadedManager is the last manager loadManager returned, for the work
+// done after the command
+var loadedManager *theme.Manager
+
+func loadManager() (*theme.Manager, error) {
+	cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+	if err != nil {
+		return nil, err
+	}
+
+	tm := theme.NewManager(cfg)
+	tm.SetVerbose(verbose)
+	loadedManager = tm
+	return tm, nil
+}
+
+func templateListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List built-in and custom templates",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.ListTemplates()
+		},
+	}
+}
+
+func templateShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <name>",
+		Short: "Print a template's source",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.ShowTemplate(args[0])
+		},
+	}
+}
+
+func templateApplyCmd() *cobra.Command {
+	var (
+		themeName string
+		output    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply <name>",
+		Short: "Render a template with the current theme",
+		Long: `Render a template with the current theme and write it to the template's
+output path. Use --output to write elsewhere, or '-o -' for stdout.
+
+Examples:
+  alacritty-colors template apply rofi
+  alacritty-colors template apply dunst --theme nord
+  alacritty-colors template apply wofi -o -`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.ApplyTemplate(args[0], themeName, output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&themeName, "theme", "t", "", "Theme to render instead of the current one")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File to write, or - for stdout")
+
+	return cmd
+}
+
+func oscCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "osc",
+		Short: "Recolor the current terminal with escape sequences",
+		Long: `Recolor the current terminal with OSC escape sequences.
+
+This works in any xterm-compatible terminal (xterm, foot, kitty, WezTerm,
+Alacritty...), inside tmux and over SSH, and touches no config files. The
+colors last until the terminal is reset or closed.`,
+	}
+
+	cmd.AddCommand(oscApplyCmd())
+	cmd.AddCommand(oscSSHCmd())
+
+	return cmd
+}
+
+func oscApplyCmd() *cobra.Command {
+	var reset bool
+
+	cmd := &cobra.Command{
+		Use:   "apply [theme-name]",
+		Short: "Try a theme's palette in the current terminal",
+		Long: `Send a theme's palette (OSC 4) and foreground, background and cursor
+colors (OSC 10/11/12) to the controlling terminal. Defaults to the current
+theme; --reset restores the terminal's own colors.
+
+Examples:
+  alacritty-colors osc apply gruvbox-dark   # Try a theme in this terminal
+  alacritty-colors osc apply --reset        # Back to the terminal's colors`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+			if err != nil {
+				return err
+			}
+
+			tm := theme.NewManager(cfg)
+			tm.SetVerbose(verbose)
+
+			if reset {
+				if len(args) > 0 {
+					return fmt.Errorf("--reset does not take a theme name")
+				}
+				return tm.ResetOSC()
+			}
+
+			themeName := ""
+			if len(args) > 0 {
+				themeName = args[0]
+			}
+			return tm.ApplyOSC(themeName)
+		},
+	}
+
+	cmd.Flags().BoolVar(&reset, "reset", false, "Restore the terminal's default colors")
+
+	return cmd
+}
+
+func oscSSHCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ssh -- <ssh arguments>",
+		Short: "Recolor the terminal for the host of an ssh command line",
+		Long: `Find the host in an ssh command line and send the theme configured for it
+in the [ssh] settings to the terminal. The theme's name is printed, or
+nothing for hosts without one. The ssh hook runs this before each session;
+see 'alacritty-colors hook ssh --help'.
+
+Examples:
+  alacritty-colors osc ssh -- -p 2222 admin@db1.prod.example.com
+  alacritty-colors osc apply --reset   # Back to the terminal's colors`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+			if err != nil {
+				return err
+			}
+
+			tm := theme.NewManager(cfg)
+			tm.SetVerbose(verbose)
+
+			name, err := tm.ApplySSHTheme(args)
+			if err != nil {
+				return err
+			}
+			if name != "" {
+				fmt.Println(name)
+			}
+			return nil
+		},
+	}
+}
+
+func scheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Switch between light and dark themes by time of day",
+		Long: `Switch between a light and a dark theme by time of day.
+
+Configure the scheduler in the settings file:
+  [scheduler]
+  enabled = true
+  light_theme = "solarized-light"
+  dark_theme = "solarized-dark"
+  light_at = "07:00"
+  dark_at = "19:00"
+  transition = "2m"   # optional: fade between the two themes
+
+Then let 'service install --scheduler' run it automatically.`,
+	}
+
+	cmd.AddCommand(scheduleRunCmd())
+	cmd.AddCommand(scheduleStatusCmd())
+
+	return cmd
+}
+
+func scheduleRunCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "run",
+		Short: "Apply the theme due now",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+			if err != nil {
+				return err
+			}
+
+			tm := theme.NewManager(cfg)
+			tm.SetVerbose(verbose)
+			return tm.RunSchedule()
+		},
+	}
+}
+
+func scheduleStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the schedule and the installed service",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+			if err != nil {
+				return err
+			}
+
+			tm := theme.NewManager(cfg)
+			s := cfg.Scheduler
+
+			ui.PrintHeader("Theme Schedule")
+			fmt.Printf("Enabled:        %t\n", s.Enabled)
+			fmt.Printf("Light Theme:    %s (from %s)\n", valueOrNone(s.LightTheme), s.LightAt)
+			fmt.Printf("Dark Theme:     %s (from %s)\n", valueOrNone(s.DarkTheme), s.DarkAt)
+			if period, err := tm.SchedulePeriod(time.Now()); err == nil {
+				fmt.Printf("Current Period: %s\n", period)
+			}
+
+			installed := service.Installed()
+			if len(installed) == 0 {
+				fmt.Println("Service:        not installed")
+			}
+			for i, path := range installed {
+				label := ""
+				if i == 0 {
+					label = "Service:"
+				}
+				fmt.Printf("%-15s %s\n", label, path)
+			}
+			return nil
+		},
+	}
+}
+
+func valueOrNone(s string) string {
+	if s == "" {
+		return "(not set)"
+	}
+	return s
+}
+
+func daemonCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "daemon",
+		Short: "Run the theme scheduler in the foreground",
+		Long: `Run the theme scheduler in the foreground, switching themes at the
+configured times until interrupted. Use 'service install --daemon' to run it
+in the background at login.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+			if err != nil {
+				return err
+			}
+
+			tm := theme.NewManager(cfg)
+			tm.SetVerbose(verbose)
+
+			stop := make(chan os.Signal, 1)
+			signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+			return tm.RunDaemon(stop)
+		},
+	}
+}
+
+func serviceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "service",
+		Short: "Run the scheduler automatically in the background",
+		Long: `Install user-level services that run the theme scheduler: systemd user
+units on Linux and launchd agents on macOS.`,
+	}
+
+	cmd.AddCommand(serviceInstallCmd())
+	cmd.AddCommand(serviceUninstallCmd())
+
+	return cmd
+}
+
+func serviceInstallCmd() *cobra.Command {
+	var scheduler, daemon bool
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install and enable the scheduler service",
+		Long: `Install and enable a user-level service for the theme scheduler.
+
+Modes:
+  --scheduler   a timer runs 'schedule run' at light_at and dark_at
+                (and after login), with nothing left running in between
+  --daemon      'daemon' keeps running and checks the clock every minute
+
+Re-run after changing the schedule times so the timer follows them.
+
+Examples:
+  alacritty-colors service install --scheduler
+  alacritty-colors --profile work service install --daemon`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if scheduler == daemon {
+				return fmt.Errorf("choose one of --scheduler or --daemon")
+			}
+			mode := service.ModeScheduler
+			if daemon {
+				mode = service.ModeDaemon
+			}
+
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+			if err != nil {
+				return err
+			}
+
+			executable, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("failed to locate executable: %w", err)
+			}
+
+			var globalArgs []string
+			if profile != "" {
+				globalArgs = append(globalArgs, "--profile", profile)
+			}
+
+			paths, err := service.Install(mode, executable, globalArgs, service.Schedule{
+				LightAt: cfg.Scheduler.LightAt,
+				DarkAt:  cfg.Scheduler.DarkAt,
+			})
+			for _, path := range paths {
+				ui.PrintInfo("Wrote %s", path)
+			}
+			if err != nil {
+				return err
+			}
+
+			ui.PrintSuccess("Installed the %s service", mode)
+			if !cfg.Scheduler.Enabled || cfg.Scheduler.LightTheme == "" || cfg.Scheduler.DarkTheme == "" {
+				ui.PrintWarning("The scheduler is not fully configured yet; see 'alacritty-colors schedule --help'")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&scheduler, "scheduler", false, "Run 'schedule run' from a timer")
+	cmd.Flags().BoolVar(&daemon, "daemon", false, "Keep 'daemon' running in the background")
+
+	return cmd
+}
+
+func serviceUninstallCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "uninstall",
+		Short: "Disable and remove the scheduler service",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			removed, err := service.Uninstall()
+			for _, path := range removed {
+				ui.PrintInfo("Removed %s", path)
+			}
+			if err != nil {
+				return err
+			}
+			if len(removed) == 0 {
+				ui.PrintInfo("No service installed")
+				return nil
+			}
+			ui.PrintSuccess("Service uninstalled")
+			return nil
+		},
+	}
+}
+
+func watchWallpaperCmd() *cobra.Command {
+	var opts theme.WallpaperOptions
+
+	cmd := &cobra.Command{
+		Use:   "watch-wallpaper",
+		Short: "Generate and apply a theme from the desktop wallpaper",
+		Long: `Generate a theme from the desktop wallpaper and apply it, again whenever
+the wallpaper changes.
+
+The wallpaper is detected from swaybg, GNOME (gsettings), feh (~/.fehbg) or
+macOS; use --file to watch a specific image instead. The generated theme is
+saved as 'wallpaper' and overwritten on each change. PNG, JPEG and GIF
+images are supported.
+
+Examples:
+  alacritty-colors watch-wallpaper                     # Keep following the wallpaper
+  alacritty-colors watch-wallpaper --once              # Apply the current wallpaper once
+  alacritty-colors watch-wallpaper --file ~/bg.png     # Follow one file`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+			if err != nil {
+				return err
+			}
+
+			tm := theme.NewManager(cfg)
+			tm.SetVerbose(verbose)
+
+			if opts.Interval < time.Second {
+				return fmt.Errorf("--interval must be at least 1s")
+			}
+
+			stop := make(chan os.Signal, 1)
+			signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+			return tm.WatchWallpaper(opts, stop)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.File, "file", "f", "", "Image to watch instead of the detected wallpaper")
+	cmd.Flags().DurationVarP(&opts.Interval, "interval", "i", 5*time.Second, "How often to check for changes")
+	cmd.Flags().BoolVar(&opts.Once, "once", false, "Apply the current wallpaper's theme and exit")
+
+	return cmd
+}
+
+func watchWorkspacesCmd() *cobra.Command {
+	var opts theme.WorkspaceOptions
+
+	cmd := &cobra.Command{
+		Use:   "watch-workspaces",
+		Short: "Apply themes mapped to window manager workspaces and apps",
+		Long: `Follow the focused workspace and application in sway, i3 or Hyprland and
+apply the theme mapped to them in the settings file:
+
+  [workspaces]
+  "9" = "red_alert"        # by name, or by the number in names like "9: prod"
+  mail = "nord"
+
+  [workspace_apps]
+  firefox = "github_light" # Wayland app_id or X11 window class
+
+A mapped application wins over its workspace. Unmapped workspaces get the
+theme that was applied when the watch started, or the last one applied by
+hand, which is restored on exit. Theme locks are respected.
+
+The window manager is detected from HYPRLAND_INSTANCE_SIGNATURE, SWAYSOCK
+or I3SOCK; use --wm to name it.
+
+Examples:
+  alacritty-colors watch-workspaces                 # Follow the focus
+  alacritty-colors watch-workspaces --once          # Apply the focused workspace's theme
+  alacritty-colors watch-workspaces --wm hyprland   # Skip detection`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+			if err != nil {
+				return err
+			}
+
+			tm := theme.NewManager(cfg)
+			tm.SetVerbose(verbose)
+
+			stop := make(chan os.Signal, 1)
+			signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+			return tm.WatchWorkspaces(opts, stop)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.WM, "wm", "", "Window manager: "+strings.Join(theme.WindowManagers, ", ")+" (default detected)")
+	cmd.Flags().BoolVar(&opts.Once, "once", false, "Apply the focused workspace's theme and exit")
+
+	return cmd
+}
+
+func promptCmd() *cobra.Command {
+	var (
+		style  string
+		symbol string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prompt",
+		Short: "Print the current theme for shell prompts and status lines",
+		Long: `Print the current theme name followed by color swatches, on one line.
+
+The output comes from a cache written on every apply. Use --style so
+escape sequences don't confuse line editing. Nothing is printed until a
+theme has been applied.
+
+For every shell prompt, prefer the alacritty-colors-prompt helper built
+alongside: it takes the same flags and starts in a few milliseconds,
+without loading the rest of the tool.
+
+Examples:
+  PS1='$(alacritty-colors-prompt --style bash) \$ '         # bash
+  PROMPT='$(alacritty-colors-prompt --style zsh) %# '       # zsh (setopt prompt_subst)
+  set -g status-right '#(alacritty-colors-prompt --style tmux)'   # tmux
+  alacritty-colors prompt --style none                      # name only`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// A bad style is the caller's mistake; anything else leaves the
+			// prompt empty rather than spilling errors into it
+			if err := prompt.CheckStyle(style); err != nil {
+				return err
+			}
+			entry := promptEntry()
+			if entry.Theme == "" {
+				return nil
+			}
+			line, err := prompt.Render(entry, style, symbol)
+			if err != nil {
+				return err
+			}
+			fmt.Println(line)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&style, "style", "s", prompt.Plain, "Escape style: plain, bash, zsh, tmux or none")
+	cmd.Flags().StringVar(&symbol, "symbol", "●", "Swatch character")
+
+	return cmd
+}
+
+// promptEntry reads the prompt cache, rebuilding it from the settings only
+// when it is missing. It is empty when neither works.
+func promptEntry() prompt.Entry {
+	if stateDir, err := config.StateDir(); err == nil {
+		if entry, err := prompt.ReadCache(stateDir); err == nil {
+			return entry
+		}
+	}
+
+	cfg, err := config.LoadProfile(profile, configFile, themesDir, backupDir)
+	if err != nil {
+		return prompt.Entry{}
+	}
+	entry, err := theme.NewManager(cfg).RefreshPromptCache()
+	if err != nil {
+		return prompt.Entry{}
+	}
+	return entry
+}
+
+func excludeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exclude",
+		Short: "Keep themes out of random and slideshow",
+		Long: `Manage the list of themes that 'random' and 'slideshow' never pick.
+
+Entries are theme names, glob patterns or regular expressions between
+slashes, matched case-insensitively, so '*light*' or '/light/' skips every
+light variant. The list is stored under [random] in
+the settings file. Use --exclude on either command to skip themes for a
+single run.
+
+Examples:
+  alacritty-colors exclude add solarized_light
+  alacritty-colors exclude add 'gruvbox*' '*high_contrast*'
+  alacritty-colors exclude remove solarized_light
+  alacritty-colors exclude list`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "add <theme|pattern>...",
+		Short: "Add themes or patterns to the exclusion list",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.AddExclusions(args)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "remove <theme|pattern>...",
+		Short: "Remove entries from the exclusion list",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.RemoveExclusions(args)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "Show the exclusion list",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.ListExclusions()
+		},
+	})
+
+	return cmd
+}
+
+func protectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "protect",
+		Short: "Keep themes safe from updates and clean-up",
+		Long: `Manage the list of themes that 'update', 'update --force',
+'config clean-themes' and the pruning of themes removed upstream never
+overwrite or delete. Protect official themes you have edited by hand.
+
+Entries are theme names or glob patterns matched case-insensitively and are
+stored as 'protected' in the settings file. A theme file can also protect
+itself with a comment near the top:
+
+  # alacritty-colors: keep
+
+Examples:
+  alacritty-colors protect add dracula
+  alacritty-colors protect add 'gruvbox*'
+  alacritty-colors protect remove dracula
+  alacritty-colors protect list`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "add <theme|pattern>...",
+		Short: "Add themes or patterns to the protected list",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.Protect(args)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "remove <theme|pattern>...",
+		Short: "Remove entries from the protected list",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.Unprotect(args)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "Show the protected themes",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.ListProtected()
+		},
+	})
+
+	return cmd
+}
+
+func themeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "theme",
+		Short: "Work with individual themes",
+		Long: `Commands that act on a single theme.
+
+Examples:
+  alacritty-colors theme adopt
+  alacritty-colors theme adopt my_dracula
+  alacritty-colors theme show dracula
+  alacritty-colors theme freeze my_tweaks
+  alacritty-colors theme rm 'generated-*' --dry-run
+  alacritty-colors theme overrides`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "adopt [name]",
+		Short: "Save the edited current.toml as a new theme",
+		Long: `Save the content of current.toml as a new theme and make it the
+applied one. Use this after editing current.toml by hand so the changes
+survive the next 'apply'. The name defaults to '<theme>_modified' and the
+file goes to the save directory.
+
+Examples:
+  alacritty-colors theme adopt
+  alacritty-colors theme adopt my_dracula`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return tm.AdoptCurrent(name)
+		},
+	})
+	cmd.AddCommand(showThemeCmd())
+	cmd.AddCommand(freezeThemeCmd())
+	cmd.AddCommand(removeThemeCmd())
+	cmd.AddCommand(&cobra.Command{
+		Use:   "overrides",
+		Short: "List your per-theme overrides",
+		Long: `List the override files and the themes they apply to.
+
+An override is a partial theme in the overrides directory, merged on top of
+the theme when it is applied. Name it after a theme (dracula.toml) or use a
+glob (gruvbox*.toml) to correct every variant at once. Keys it sets replace
+the theme's, so personal fixes survive 'update --force' re-downloads.
+
+Example overrides/gruvbox*.toml:
+  [colors.normal]
+  red = "#e0524a"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.ListOverrides()
+		},
+	})
+
+	return cmd
+}
+
+func removeThemeCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:     "rm <theme|pattern>...",
+		Aliases: []string{"remove"},
+		Short:   "Move themes to the trash",
+		Long: `Remove themes by name, glob pattern or regular expression between
+slashes. Removed files go to the trash (see 'alacritty-colors trash'). The
+applied theme and protected themes are kept.
+
+Examples:
+  alacritty-colors theme rm my_old_theme
+  alacritty-colors theme rm 'generated-*' --dry-run
+  alacritty-colors theme rm '/_(light|day)$/'`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.RemoveThemes(args, dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the themes that would be removed")
+
+	return cmd
+}
+
+func validateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [theme|pattern...]",
+		Short: "Check that themes parse and define the required colors",
+		Long: `Check installed themes the way downloads are checked: the file must parse
+and set the primary and normal colors as hex values. Themes are named,
+matched by glob pattern or by a regular expression between slashes; without
+arguments every theme is checked. Exits non-zero when any theme is invalid.
+
+Examples:
+  alacritty-colors validate
+  alacritty-colors validate 'gruvbox*'
+  alacritty-colors validate '/^(nord|dracula)$/'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.ValidateThemes(args)
+		},
+	}
+}
+
+func recordCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "Record a session of theme changes",
+		Long: `Capture the themes you apply, e.g. during a live demo or a stream, to
+replay them later with 'alacritty-colors replay'.
+
+'record start' marks the beginning of the session and 'record stop' saves
+every theme applied since, with its time, from the history. Sessions are
+saved in the recordings directory unless 'record stop' is given a path.
+
+Examples:
+  alacritty-colors record start
+  alacritty-colors record stop
+  alacritty-colors record stop demo
+  alacritty-colors record stop ~/talks/themes.jsonl`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "start",
+		Short: "Start recording theme changes",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.StartRecording()
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "stop [file]",
+		Short: "Stop recording and save the session",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			output := ""
+			if len(args) > 0 {
+				output = args[0]
+			}
+			return tm.StopRecording(output)
+		},
+	})
+
+	return cmd
+}
+
+func replayCmd() *cobra.Command {
+	var interval int
+
+	cmd := &cobra.Command{
+		Use:   "replay <file>",
+		Short: "Replay a recorded session of theme changes",
+		Long: `Apply the themes of a session saved by 'record stop' in order. The time
+between changes is the recorded one unless --interval sets a fixed number
+of seconds. A bare name is looked up in the recordings directory.
+
+Examples:
+  alacritty-colors replay demo
+  alacritty-colors replay demo --interval 2
+  alacritty-colors replay ~/talks/themes.jsonl`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval < 0 {
+				return fmt.Errorf("--interval must not be negative")
+			}
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.Replay(args[0], time.Duration(interval)*time.Second)
+		},
+	}
+
+	cmd.Flags().IntVarP(&interval, "interval", "i", 0, "Seconds between changes (default: as recorded)")
+
+	return cmd
+}
+
+func lockCmd() *cobra.Command {
+	var until string
+
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Keep the current theme from changing",
+		Long: `Keep the applied theme in place, e.g. while recording your screen or pair
+programming. The scheduler, the daemon, slideshows, random and other
+commands that change the theme are refused until 'alacritty-colors unlock'
+or the time given with --until. 'apply --force' still applies a theme.
+
+--until takes a time of day, the next time it comes around, or a duration.
+
+Examples:
+  alacritty-colors lock
+  alacritty-colors lock --until 18:00
+  alacritty-colors lock --until 90m`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.Lock(until)
+		},
+	}
+
+	cmd.Flags().StringVar(&until, "until", "", "Unlock at this time (HH:MM) or after this duration")
+
+	return cmd
+}
+
+func unlockCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unlock",
+		Short: "Let the theme change again after 'lock'",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.Unlock()
+		},
+	}
+}
+
+func harmonizeCmd() *cobra.Command {
+	var opts theme.HarmonizeOptions
+
+	cmd := &cobra.Command{
+		Use:   "harmonize <theme>",
+		Short: "Even out the lightness and chroma of a theme's ANSI colors",
+		Long: `Snap the six chromatic ANSI colors (red, green, yellow, blue, magenta and
+cyan) of a theme to the same perceived lightness and chroma in the OKLCH
+color space, keeping each hue. This fixes themes whose reds glare while
+their blues are muddy. The result is saved as <theme>_harmonized; the
+original theme is left alone.
+
+By default the normal colors move to the median lightness and chroma of
+the theme's own normal colors. Set other targets with --lightness, in
+percent, and --chroma, from 0 (gray) to about 0.37. Bright colors keep the
+theme's step up from the normal ones. Grays among the accents only have
+their lightness changed.
+
+Examples:
+  alacritty-colors harmonize gruvbox_dark
+  alacritty-colors harmonize dracula --lightness 72 --chroma 0.14
+  alacritty-colors harmonize nord --dry-run
+  alacritty-colors harmonize nord --name nord_even --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.Harmonize(args[0], opts)
+		},
+	}
+
+	cmd.Flags().Float64Var(&opts.Lightness, "lightness", 0, "Target OKLCH lightness in percent (default: the theme's median)")
+	cmd.Flags().Float64Var(&opts.Chroma, "chroma", 0, "Target OKLCH chroma (default: the theme's median)")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Name of the new theme (default <theme>_harmonized)")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Overwrite an existing theme with that name")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the new colors without saving the theme")
+
+	return cmd
+}
+
+func tweakCmd() *cobra.Command {
+	var opts theme.TweakOptions
+
+	cmd := &cobra.Command{
+		Use:   "tweak",
+		Short: "Vary the current theme's accent colors",
+		Long: `Re-apply the current theme with its six chromatic ANSI colors (red,
+yellow, green, cyan, blue and magenta) trading hues, for a little variety
+without changing how readable it is. Each color keeps its own lightness and
+chroma, and the background and foreground stay as they are. Normal and
+bright colors move together. The theme file is not modified; the next
+apply starts from it again.
+
+--rotate-accents gives each color the hue of the one that many steps
+further around the color wheel (1 when no number is given).
+--shuffle-accents reorders the hues at random; with --seed the order only
+depends on the seed, so passing the date gives a new order every day.
+
+Examples:
+  alacritty-colors tweak --rotate-accents
+  alacritty-colors tweak --rotate-accents=2
+  alacritty-colors tweak --shuffle-accents
+  alacritty-colors tweak --shuffle-accents --seed "$(date +%F)"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.Tweak(opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.RotateAccents, "rotate-accents", 0, "Move accent hues this many steps around the color wheel")
+	cmd.Flags().Lookup("rotate-accents").NoOptDefVal = "1"
+	cmd.Flags().BoolVar(&opts.ShuffleAccents, "shuffle-accents", false, "Reorder the accent hues at random")
+	cmd.Flags().StringVar(&opts.Seed, "seed", "", "Make --shuffle-accents pick the same order for the same seed")
+
+	return cmd
+}
+
+func freezeThemeCmd() *cobra.Command {
+	var opts theme.FreezeOptions
+
+	cmd := &cobra.Command{
+		Use:   "freeze <name>",
+		Short: "Save the colors on screen as a new theme",
+		Long: `Capture exactly what Alacritty shows as a named theme, after experimenting
+with edits, overrides or the interactive editor.
+
+A running Alacritty instance is asked for its effective config over IPC,
+which includes overrides set with 'alacritty msg config'. Without one, or
+with --config-only, the config file is read together with everything it
+imports, later files winning as in Alacritty. The theme goes to the save
+directory.
+
+Examples:
+  alacritty-colors theme freeze my_tweaks
+  alacritty-colors theme freeze late_night --socket $ALACRITTY_SOCKET
+  alacritty-colors theme freeze from_files --config-only`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			opts.Name = args[0]
+			return tm.FreezeTheme(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Socket, "socket", "", "IPC socket of the Alacritty instance to read")
+	cmd.Flags().BoolVar(&opts.ConfigOnly, "config-only", false, "Read the config files instead of a running instance")
+	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Overwrite an existing theme of the same name")
+
+	return cmd
+}
+
+func identifyCmd() *cobra.Command {
+	var opts theme.IdentifyOptions
+
+	cmd := &cobra.Command{
+		Use:   "identify [screenshot]",
+		Short: "Find the installed theme that matches a screenshot or palette",
+		Long: `Answer "which theme is this?" by comparing a terminal screenshot or a
+list of colors against every installed theme, best matches first.
+
+A screenshot is read the way 'generate --from-screenshot' reads it: the
+dominant flat region is the background and the remaining colors are sorted
+into ANSI slots, which are compared slot by slot. With --colors each color
+is matched against the closest color of a theme, whatever its slot.
+
+Confidence is 100% for an exact match and drops as the colors drift apart.
+
+Examples:
+  alacritty-colors identify shot.png
+  alacritty-colors identify shot.png --limit 10
+  alacritty-colors identify --colors "#282a36,#f8f8f2,#ff79c6"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				opts.Image = args[0]
+			}
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.Identify(opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Colors, "colors", nil, "Colors to match instead of a screenshot (comma-separated)")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 5, "Number of matches to show")
+	cmd.Flags().StringVarP(&opts.Format, "format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func renderCmd() *cobra.Command {
+	var opts theme.RenderOptions
+
+	cmd := &cobra.Command{
+		Use:   "render [theme...]",
+		Short: "Draw themes over a mock terminal as a PNG or animated GIF",
+		Long: `Draw a theme over a mock terminal session (a listing, git status, some
+code and the full palette) and save it as an image, for dotfile READMEs and
+for sharing shortlists.
+
+A single theme is written as a PNG. With --animate, the themes given or
+those of a collection become the frames of a looping GIF, each shown for
+--delay.
+
+Examples:
+  alacritty-colors render dracula
+  alacritty-colors render nord -o nord.png --scale 3
+  alacritty-colors render --animate dracula nord gruvbox_dark -o hero.gif
+  alacritty-colors render --animate --collection cozy --delay 3s`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Themes = args
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.Render(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Image file, .png or .gif (default <theme>.png or themes.gif)")
+	cmd.Flags().BoolVar(&opts.Animate, "animate", false, "Write an animated GIF cycling through the themes")
+	cmd.Flags().StringVar(&opts.Collection, "collection", "", "Render the themes of this collection")
+	cmd.Flags().DurationVar(&opts.Delay, "delay", 2*time.Second, "How long each theme is shown in an animation")
+	cmd.Flags().IntVar(&opts.Scale, "scale", 2, "Pixel size multiplier (1-8)")
+
+	return cmd
+}
+
+func statsCmd() *cobra.Command {
+	var opts theme.StatsOptions
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Summarize the installed themes by variant, family and color",
+		Long: `Summarize the installed themes: how many are dark or light, the largest
+scheme families (names up to the first '_' or '-', so gruvbox_dark and
+gruvbox_light are both gruvbox) and the themes you apply most.
+
+--heatmap adds a map of background hue against lightness, showing which
+parts of color space the collection covers. Cells holding a theme you have
+applied are marked with '*', so you can see where your favorites cluster.
+
+Examples:
+  alacritty-colors stats
+  alacritty-colors stats --heatmap
+  alacritty-colors stats --heatmap --collection cozy
+  alacritty-colors stats --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.Stats(opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Heatmap, "heatmap", false, "Show background hue against lightness")
+	cmd.Flags().StringVar(&opts.Collection, "collection", "", "Only analyze themes in this collection")
+	cmd.Flags().StringVarP(&opts.Format, "format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func explainCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "explain <theme>",
+		Short: "Describe a theme's character from its palette",
+		Long: `Describe a theme in words computed from its colors: dark or light, how
+saturated it is (monochrome, muted, balanced, vibrant or pastel), whether
+it feels warm or cool, the hue it leans towards, how its foreground
+contrast grades against WCAG, and the well-known scheme family it is
+closest to among the installed themes.
+
+Examples:
+  alacritty-colors explain nord
+  alacritty-colors explain current
+  alacritty-colors explain gruvbox_dark --format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.Explain(args[0], format)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+// showThemeCmd is registered both as 'theme show' and as the 'show' shortcut
+func showThemeCmd() *cobra.Command {
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "show [theme|current]",
+		Short: "Print every color a theme sets, with swatches",
+		Long: `Print all color settings of a theme as Alacritty will see them, with a
+swatch next to each value. Settings the theme leaves out are filled in with
+Alacritty's defaults and marked as such. Colors are normalized to #rrggbb.
+
+Without an argument, or with 'current', current.toml is shown as it is on
+disk. --raw prints the file unchanged.
+
+Examples:
+  alacritty-colors show dracula
+  alacritty-colors show current
+  alacritty-colors theme show nord --raw`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return tm.ShowTheme(name, raw)
+		},
+	}
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the theme file unchanged")
+	return cmd
+}
+
+func statusCmd() *cobra.Command {
+	var live bool
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the current theme and where it comes from",
+		Long: `Show the applied theme with its author, variant, source and upstream
+URL, and whether current.toml still matches it.
+
+--live also checks that the theme reaches open windows: whether Alacritty's
+live_config_reload is on, and, through 'alacritty msg get-config'
+(Alacritty 0.14 or later), which background each running instance shows.
+Instances are found through $ALACRITTY_SOCKET and the sockets Alacritty
+creates, or its named pipes on Windows.
+
+Examples:
+  alacritty-colors status
+  alacritty-colors status --live`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			if err := tm.ShowCurrentTheme(); err != nil {
+				return err
+			}
+			if live {
+				return tm.CheckLiveReload()
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&live, "live", false, "Check that running Alacritty windows show the theme")
+
+	return cmd
+}
+
+func fontsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fonts",
+		Short: "List and preview installed monospace fonts",
+		Long: `List the monospace fonts installed on this system and try them in
+Alacritty before committing to one.
+
+Examples:
+  alacritty-colors fonts list
+  alacritty-colors fonts list --nerd --ligatures
+  alacritty-colors fonts preview "JetBrains Mono"
+  alacritty-colors fonts pairings`,
+	}
+
+	var opts theme.FontListOptions
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List installed monospace fonts",
+		Long: `List installed monospace font families. Fonts with programming
+ligatures and Nerd Font patched fonts are marked.
+
+Spacing comes from fontconfig where available; elsewhere fonts are
+recognised by name, so a few monospace fonts may be missing.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.ListFonts(opts)
+		},
+	}
+	listCmd.Flags().StringVarP(&opts.Format, "format", "f", "list", "Output format (list, json)")
+	listCmd.Flags().BoolVar(&opts.Ligatures, "ligatures", false, "Show only fonts with programming ligatures")
+	listCmd.Flags().BoolVar(&opts.Nerd, "nerd", false, "Show only Nerd Fonts")
+
+	var keep bool
+	previewCmd := &cobra.Command{
+		Use:   "preview <family>",
+		Short: "Try a font in Alacritty",
+		Long: `Temporarily set the font family in your Alacritty config, show a
+sample with ligatures, icons and box drawing, then offer to keep the font
+or restore your previous config.
+
+A kept font can be undone later with 'alacritty-colors effects clear --font'.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.PreviewFont(args[0], keep)
+		},
+	}
+	previewCmd.Flags().BoolVarP(&keep, "apply", "a", false, "Keep the font without asking")
+
+	pairingsCmd := &cobra.Command{
+		Use:   "pairings",
+		Short: "Show which fonts each color scheme is paired with",
+		Long: `Show the font pairings used by 'apply --font', built-in and from the
+[font_pairings] table of the settings file. Keys are color schemes found in
+theme names, or full theme names, and the first installed font wins.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.ListFontPairings()
+		},
+	}
+
+	cmd.AddCommand(listCmd, previewCmd, pairingsCmd)
+	return cmd
+}
+
+func trashCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "Recover themes and backups removed by clean commands",
+		Long: `Themes and backups removed by 'config clean-themes', 'config clean-backups'
+and 'update --prune delete' are moved to a trash in the data directory
+instead of being deleted. They are deleted for good after 30 days, or
+when the trash is emptied.
+
+Examples:
+  alacritty-colors trash list
+  alacritty-colors trash restore my-theme
+  alacritty-colors trash empty`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List files in the trash",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.ListTrash()
+		},
+	}
+
+	restoreCmd := &cobra.Command{
+		Use:   "restore <id|name>...",
+		Short: "Put files from the trash back where they were",
+		Long: `Move files from the trash back to their original location. Give the ID
+shown by 'trash list' or the file name; theme names work without .toml.
+A name trashed several times restores the most recent copy.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.RestoreTrash(args)
+		},
+	}
+
+	emptyCmd := &cobra.Command{
+		Use:   "empty",
+		Short: "Delete everything in the trash",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			if !ui.PromptConfirm("Permanently delete everything in the trash?") {
+				ui.PrintInfo("Cancelled")
+				return nil
+			}
+			return tm.EmptyTrash()
+		},
+	}
+
+	cmd.AddCommand(listCmd, restoreCmd, emptyCmd)
+	return cmd
+}
+
+func effectsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "effects",
+		Short: "Manage window, font and color settings changed by alacritty-colors",
+		Long: `Manage the opacity, blur and font settings that --opacity, --blur,
+--font, --font-size and --font-family write to your Alacritty config, and
+draw_bold_text_with_bright_colors, set with 'effects bold-bright'.
+
+The first time one of them changes a setting, the value you had is
+remembered so 'effects clear' can put it back.
+
+Examples:
+  alacritty-colors effects clear
+  alacritty-colors effects clear --opacity --blur
+  alacritty-colors effects bold-bright on`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "bold-bright [on|off]",
+		Short: "Show or set draw_bold_text_with_bright_colors",
+		Long: `Show or set Alacritty's draw_bold_text_with_bright_colors, which draws
+bold text in the bright variant of its color.
+
+Many themes ship bright colors identical to the normal ones, and bold text
+in those colors then looks regular. 'explain' lists them, and
+apply.synthesize_brights = true in the settings file gives them a brighter
+shade as themes are applied.`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: []string{"on", "off"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			value := ""
+			if len(args) > 0 {
+				value = args[0]
+			}
+			return tm.BoldBright(value)
+		},
+	})
+
+	var reset theme.ResetOptions
+	clearCmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Restore the settings alacritty-colors changed",
+		Long: `Put opacity, blur, font and bold-bright settings back to the values they
+had before alacritty-colors changed them. Settings the config didn't have are removed.
+Without flags, everything is restored.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			if !reset.Any() {
+				reset = theme.ResetOptions{Opacity: true, Blur: true, Font: true, BoldBright: true}
+			}
+			return tm.ResetEffects(reset)
+		},
+	}
+	clearCmd.Flags().BoolVar(&reset.Opacity, "opacity", false, "Restore only the window opacity")
+	clearCmd.Flags().BoolVar(&reset.Blur, "blur", false, "Restore only the window blur")
+	clearCmd.Flags().BoolVar(&reset.Font, "font", false, "Restore only the font family and size")
+	clearCmd.Flags().BoolVar(&reset.BoldBright, "bold-bright", false, "Restore only draw_bold_text_with_bright_colors")
+	cmd.AddCommand(clearCmd)
+
+	return cmd
+}
+
+func toggleCmd() *cobra.Command {
+	var list bool
+
+	cmd := &cobra.Command{
+		Use:   "toggle",
+		Short: "Switch between the light and dark variant of the current theme",
+		Long: `Apply the light or dark counterpart of the current theme.
+
+Counterparts come from, in order:
+  • [pairs] in the settings file, e.g. "tokyo_night" = "alabaster"
+  • the scheduler's light_theme and dark_theme
+  • installed themes whose names swap dark/light, night/day, moon/dawn
+    or black/white, e.g. gruvbox_dark and gruvbox_light
+
+Examples:
+  alacritty-colors toggle
+  alacritty-colors toggle --list    # Show every known pair`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			if list {
+				return tm.ListPairs()
+			}
+			return tm.Toggle()
+		},
+	}
+
+	cmd.Flags().BoolVarP(&list, "list", "l", false, "List light/dark pairs instead of toggling")
+
+	return cmd
+}
+
+func benchmarkCmd() *cobra.Command {
+	var (
+		iterations int
+		slowest    int
+		format     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "benchmark",
+		Short: "Time theme operations over the installed collection",
+		Long: `Time the main theme operations over every installed theme and print a
+report:
+
+  • index build - reading and parsing all themes directories
+  • parse       - parsing each theme file
+  • apply       - installing each theme, in a scratch directory
+  • generate    - generating a theme with each scheme
+
+Your configuration and current theme are left untouched. Use --format json
+to compare runs across versions.
+
+Examples:
+  alacritty-colors benchmark
+  alacritty-colors benchmark --iterations 10 --slowest 10
+  alacritty-colors benchmark --format json > bench.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "table" && format != "json" {
+				return ui.WithHints(fmt.Errorf("unknown format '%s'", format), "Use --format table or --format json.")
+			}
+
+			tm, err := loadManager()
+			if err != nil {
+				return err
+			}
+			return tm.Benchmark(&theme.BenchmarkOptions{
+				Iterations: iterations,
+				Slowest:    slowest,
+				Format:     format,
+			})
+		},
+	}
+
+	cmd.Flags().IntVarP(&iterations, "iterations", "n", 3, "Times to run each operation")
+	cmd.Flags().IntVar(&slowest, "slowest", 5, "Number of slowest theme files to list")
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+// setupOutput applies the --color flag, falling back to the [ui] settings
+func setupOutput() error {
+	prefs := config.ReadUIPreferences()
+
+	mode := prefs.Color
+	if colorMode != "" {
+		mode = colorMode
+	}
+	if err := ui.SetColorMode(mode); err != nil {
+		return err
+	}
+	ui.SetAccessible(prefs.Accessible)
+	return ui.SetUnicodeMode(prefs.Unicode)
+}
@@ -5,8 +5,11 @@ go 1.21
 require (
 	github.com/fatih/color v1.18.0
 	github.com/gdamore/tcell/v2 v2.7.0
+	github.com/mattn/go-runewidth v0.0.15
 	github.com/rivo/tview v0.0.0-20240101144852-b3bd1aa5e9f2
 	github.com/spf13/cobra v1.8.0
+	golang.org/x/sys v0.25.0
+	golang.org/x/term v0.15.0
 )
 
 require (
@@ -15,10 +18,7 @@ require (
 	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
-	github.com/mattn/go-runewidth v0.0.15 // indirect
 	github.com/rivo/uniseg v0.4.3 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
-	golang.org/x/sys v0.25.0 // indirect
-	golang.org/x/term v0.15.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
 )
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ValidateCollectionName rejects names that cannot be used as a TOML key
+func ValidateCollectionName(name string) error {
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid collection name %q (use letters, digits, '-' and '_')", name)
+	}
+	return nil
+}
+
+// CollectionNames returns the configured collections in alphabetical order
+func (c *Config) CollectionNames() []string {
+	names := make([]string, 0, len(c.Collections))
+	for name := range c.Collections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,24 +10,137 @@ import (
 )
 
 type Config struct {
-	ConfigFile   string `json:"config_file"`
-	ThemesDir    string `json:"themes_dir"`
-	BackupDir    string `json:"backup_dir"`
-	CurrentTheme string `json:"current_theme"`
-	Version      string `json:"version"`
+	ConfigFile      string        `json:"config_file"`
+	ThemesDir       string        `json:"themes_dir"`
+	BackupDir       string        `json:"backup_dir"`
+	CurrentTheme    string        `json:"current_theme"`
+	Sources         []ThemeSource `json:"sources,omitempty"`
+	OfficialMirrors []string      `json:"official_mirrors,omitempty"`
+	ProxyURL        string        `json:"proxy_url,omitempty"`
+	CACerts         []string      `json:"ca_certs,omitempty"`
+	GitHubToken     string        `json:"github_token,omitempty"`
+	// VersionCheck opts in to a daily check for new releases
+	VersionCheck bool `json:"version_check"`
+
+	// Protected holds theme names or glob patterns that updates and clean
+	// commands never overwrite or delete
+	Protected []string `json:"protected,omitempty"`
+
+	// ExtraThemesDirs are searched after ThemesDir, which keeps downloads
+	// and current.toml. SaveDir receives generated themes.
+	ExtraThemesDirs []string `json:"extra_themes_dirs,omitempty"`
+	SaveDir         string   `json:"save_dir,omitempty"`
+
+	Hooks     Hooks              `json:"hooks"`
+	Scheduler Scheduler          `json:"scheduler"`
+	Sync      SyncTargets        `json:"sync"`
+	Random    RandomPreferences  `json:"random"`
+	Apply     ApplyPreferences   `json:"apply"`
+	Backup    BackupPolicy       `json:"backup"`
+	UI        UIPreferences      `json:"ui"`
+	Display   DisplayCalibration `json:"display"`
+	Seasonal  SeasonalScheme     `json:"seasonal"`
+	SSH       SSHSettings        `json:"ssh"`
+
+	// Collections are named lists of theme names or glob patterns
+	Collections map[string][]string `json:"collections,omitempty"`
+	// Pairs maps a theme to its light or dark counterpart, both ways
+	Pairs map[string]string `json:"pairs,omitempty"`
+	// FontPairings maps a color scheme (matched within theme names) or a
+	// full theme name to preferred font families, tried in order
+	FontPairings map[string][]string `json:"font_pairings,omitempty"`
+	// Workspaces maps window manager workspaces, by name or number, and
+	// WorkspaceApps focused applications to the theme applied while they
+	// have focus
+	Workspaces    map[string]string `json:"workspaces,omitempty"`
+	WorkspaceApps map[string]string `json:"workspace_apps,omitempty"`
+
+	// ActiveProfile is the profile used when none is given on the command
+	// line; Profile is the one selected for this run
+	ActiveProfile string             `json:"profile,omitempty"`
+	Profiles      map[string]Profile `json:"profiles,omitempty"`
+	Profile       string             `json:"-"`
+
+	// Dotfiles mode edits ConfigFile in a dotfiles repository; the
+	// Alacritty config it is deployed to is DeployedConfigFile
+	Dotfiles           DotfilesSettings `json:"dotfiles"`
+	DeployedConfigFile string           `json:"-"`
+
+	// DataDir holds the tool's own settings and StateDir its caches and
+	// history, keeping the Alacritty config directory clean
+	DataDir  string `json:"-"`
+	StateDir string `json:"-"`
+
+	// fromFile holds the settings before environment overrides were applied
+	fromFile *Config
+	envUsed  map[string]bool
+	// profileSource tells where the selected profile was chosen
+	profileSource string
+	// base holds the top-level paths while a profile is selected
+	base *Profile
+	// saved is the settings file as this process last read or wrote it,
+	// and loadArgs what it was loaded with, so changes made meanwhile by
+	// other processes can be merged or reloaded
+	saved    []byte
+	loadArgs [4]string
+
+	// Migrations lists the schema migrations applied while loading
+	Migrations []string `json:"-"`
+}
+
+// ThemeSource describes an additional theme archive downloaded on init/update
+type ThemeSource struct {
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`
+	Mirrors     []string `json:"mirrors,omitempty"`
+	ChecksumURL string   `json:"checksum_url,omitempty"`
 }
 
 const (
-	configFileName = "alacritty-colors.json"
-	currentVersion = "1.0.0"
+	configFileName = "alacritty-colors.toml"
+	// legacyConfigFileName is the JSON settings file used by older versions
+	legacyConfigFileName = "alacritty-colors.json"
+	appName              = "alacritty-colors"
 )
 
+// Load resolves the configuration with flags taking precedence over
+// ALACRITTY_COLORS_* environment variables, then the settings file, then
+// the defaults
 func Load(configFile, themesDir, backupDir string) (*Config, error) {
-	cfg := &Config{
-		Version: currentVersion,
+	return LoadProfile("", configFile, themesDir, backupDir)
+}
+
+// LoadProfile is like Load but selects a named profile. An empty name falls
+// back to ALACRITTY_COLORS_PROFILE and then to the profile set in the file.
+func LoadProfile(profile, configFile, themesDir, backupDir string) (*Config, error) {
+	cfg, err := loadProfile(profile, configFile, themesDir, backupDir)
+	if err != nil {
+		return nil, err
+	}
+	// The file is only written when missing, migrated or missing settings
+	if cfg.saved != nil && bytes.Equal(cfg.saved, cfg.persistable().encodeSettings()) {
+		return cfg, nil
 	}
+	return cfg, cfg.save()
+}
 
-	if err := cfg.initPaths(configFile, themesDir, backupDir); err != nil {
+func loadProfile(profile, configFile, themesDir, backupDir string) (*Config, error) {
+	cfg := &Config{loadArgs: [4]string{profile, configFile, themesDir, backupDir}}
+	cfg.setDefaults()
+
+	if err := cfg.initPaths(); err != nil {
+		return nil, err
+	}
+
+	// The data directory decides where the settings file is read from
+	if dir, ok := lookupEnv("DATA_DIR"); ok {
+		cfg.DataDir = dir
+	}
+	if value, ok := lookupEnv("CONFIG"); ok && configFile == "" {
+		cfg.ConfigFile = value
+	}
+
+	if err := cfg.migrateLegacyConfig(); err != nil {
 		return nil, err
 	}
 
@@ -34,69 +148,210 @@ func Load(configFile, themesDir, backupDir string) (*Config, error) {
 		return nil, err
 	}
 
+	cfg.profileSource = SourceFlag
+	if profile == "" {
+		profile, _ = lookupEnv("PROFILE")
+		cfg.profileSource = SourceEnv + " " + EnvVar("PROFILE")
+	}
+	if profile == "" {
+		profile = cfg.ActiveProfile
+		cfg.profileSource = SourceFile
+	}
+	if profile == "" {
+		cfg.profileSource = SourceDefault
+	}
+	if err := cfg.selectProfile(profile); err != nil {
+		return nil, err
+	}
+	// Reloads stay on this profile even if the default one changes
+	cfg.loadArgs[0] = profile
+	if profile == "" {
+		cfg.loadArgs[0] = DefaultProfile
+	}
+
+	fromFile := *cfg
+	cfg.fromFile = &fromFile
+	cfg.envUsed = make(map[string]bool)
+	for _, name := range cfg.applyEnv() {
+		cfg.envUsed[name] = true
+	}
+
+	// Paths given on the command line win over everything else, for this
+	// run only
+	if configFile != "" {
+		cfg.ConfigFile = configFile
+	}
+	if themesDir != "" {
+		cfg.ThemesDir = themesDir
+	}
+	if backupDir != "" {
+		cfg.BackupDir = backupDir
+	}
+
+	if err := cfg.resolveDotfiles(); err != nil {
+		return nil, err
+	}
+
 	if err := cfg.createDirectories(); err != nil {
 		return nil, err
 	}
 
-	return cfg, cfg.save()
+	return cfg, nil
 }
 
-func (c *Config) initPaths(configFile, themesDir, backupDir string) error {
+// Reload reads the settings file again, keeping the profile and paths the
+// configuration was loaded with. Long-running processes call it when
+// another process has changed the file.
+func (c *Config) Reload() error {
+	fresh, err := loadProfile(c.loadArgs[0], c.loadArgs[1], c.loadArgs[2], c.loadArgs[3])
+	if err != nil {
+		return err
+	}
+	// The profile is passed on explicitly, but was chosen as before
+	fresh.profileSource = c.profileSource
+	*c = *fresh
+	return nil
+}
+
+// initPaths sets the default paths, next to the Alacritty config found
+func (c *Config) initPaths() error {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	var baseConfigDir string
-	switch runtime.GOOS {
-	case "darwin":
-		baseConfigDir = filepath.Join(homeDir, ".config", "alacritty")
-	case "linux":
-		baseConfigDir = filepath.Join(homeDir, ".config", "alacritty")
-	case "windows":
-		baseConfigDir = filepath.Join(homeDir, "AppData", "Roaming", "alacritty")
-	default:
-		baseConfigDir = filepath.Join(homeDir, ".config", "alacritty")
+	detectedConfig, baseConfigDir := detectConfigFile(homeDir)
+
+	c.ConfigFile = detectedConfig
+	c.ThemesDir = filepath.Join(baseConfigDir, "themes")
+	c.BackupDir = filepath.Join(baseConfigDir, "backups")
+
+	c.DataDir, c.StateDir = dataDirs(homeDir)
+
+	return nil
+}
+
+// dataDirs returns the data and state directories following the XDG base
+// directory specification, or %LOCALAPPDATA% on Windows
+func dataDirs(homeDir string) (string, string) {
+	if runtime.GOOS == "windows" {
+		base := os.Getenv("LOCALAPPDATA")
+		if base == "" {
+			base = filepath.Join(homeDir, "AppData", "Local")
+		}
+		return filepath.Join(base, appName), filepath.Join(base, appName, "state")
 	}
 
-	// Set defaults or use provided values
-	if configFile != "" {
-		c.ConfigFile = configFile
-	} else {
-		c.ConfigFile = filepath.Join(baseConfigDir, "alacritty.toml")
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" || !filepath.IsAbs(dataHome) {
+		dataHome = filepath.Join(homeDir, ".local", "share")
 	}
 
-	if themesDir != "" {
-		c.ThemesDir = themesDir
-	} else {
-		// Create themes directory next to config
-		c.ThemesDir = filepath.Join(baseConfigDir, "themes")
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" || !filepath.IsAbs(stateHome) {
+		stateHome = filepath.Join(homeDir, ".local", "state")
 	}
 
-	if backupDir != "" {
-		c.BackupDir = backupDir
-	} else {
-		c.BackupDir = filepath.Join(baseConfigDir, "backups")
+	return filepath.Join(dataHome, appName), filepath.Join(stateHome, appName)
+}
+
+// StateDir returns the state directory without loading the settings file,
+// for commands that must start fast
+func StateDir() (string, error) {
+	if dir, ok := lookupEnv("STATE_DIR"); ok {
+		return dir, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	_, stateDir := dataDirs(homeDir)
+	return stateDir, nil
+}
+
+// Path returns the location of the tool's settings file
+func (c *Config) Path() string {
+	return filepath.Join(c.DataDir, configFileName)
+}
+
+// migrateLegacyConfig converts the JSON settings file of older versions,
+// found next to alacritty.toml or in the data directory, to TOML. The JSON
+// file is kept with a .bak suffix.
+func (c *Config) migrateLegacyConfig() error {
+	if _, err := os.Stat(c.Path()); err == nil {
+		return nil
+	}
+
+	candidates := []string{
+		filepath.Join(c.DataDir, legacyConfigFileName),
+		filepath.Join(filepath.Dir(c.ConfigFile), legacyConfigFileName),
+	}
+
+	for _, legacy := range candidates {
+		data, err := os.ReadFile(legacy)
+		if err != nil {
+			continue
+		}
+
+		legacyConfig := &Config{}
+		legacyConfig.setDefaults()
+		if err := json.Unmarshal(data, legacyConfig); err != nil {
+			return fmt.Errorf("failed to parse legacy config %s: %w", legacy, err)
+		}
+		legacyConfig.DataDir = c.DataDir
+
+		if err := os.MkdirAll(c.DataDir, 0755); err != nil {
+			return fmt.Errorf("failed to create data directory: %w", err)
+		}
+		if err := os.WriteFile(c.Path(), legacyConfig.encodeSettings(), 0644); err != nil {
+			return fmt.Errorf("failed to migrate config: %w", err)
+		}
+
+		return os.Rename(legacy, legacy+".bak")
 	}
 
 	return nil
 }
 
 func (c *Config) loadFromFile() error {
-	configDir := filepath.Dir(c.ConfigFile)
-	configPath := filepath.Join(configDir, configFileName)
-
-	data, err := os.ReadFile(configPath)
+	data, err := os.ReadFile(c.Path())
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil // File doesn't exist, use defaults
 		}
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
+	c.saved = data
 
-	var fileConfig Config
-	if err := json.Unmarshal(data, &fileConfig); err != nil {
-		return fmt.Errorf("failed to parse config file: %w", err)
+	entries, err := parseTOML(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", c.Path(), err)
+	}
+
+	version, err := schemaVersionOf(entries)
+	if err != nil {
+		return fmt.Errorf("invalid setting in %s: %w", c.Path(), err)
+	}
+	entries, applied, err := migrateEntries(entries, version)
+	if err != nil {
+		return err
+	}
+	if len(applied) > 0 {
+		// The migrated settings are written back when loading completes
+		if err := backupBeforeMigration(c.Path(), version); err != nil {
+			return fmt.Errorf("failed to back up settings before migration: %w", err)
+		}
+		c.Migrations = applied
+	}
+
+	fileConfig := &Config{}
+	fileConfig.setDefaults()
+	if err := fileConfig.decodeSettings(entries); err != nil {
+		return fmt.Errorf("invalid setting in %s: %w", c.Path(), err)
+	}
+	if fileConfig.GitHubToken != "" {
+		// A token added by hand leaves the file as readable as it was
+		makePrivate(c.Path())
 	}
 
 	// Merge file config with current config
@@ -109,9 +364,34 @@ func (c *Config) loadFromFile() error {
 	if fileConfig.BackupDir != "" {
 		c.BackupDir = fileConfig.BackupDir
 	}
-	if fileConfig.CurrentTheme != "" {
-		c.CurrentTheme = fileConfig.CurrentTheme
-	}
+	c.ExtraThemesDirs = fileConfig.ExtraThemesDirs
+	c.SaveDir = fileConfig.SaveDir
+	c.CurrentTheme = fileConfig.CurrentTheme
+	c.Sources = fileConfig.Sources
+	c.OfficialMirrors = fileConfig.OfficialMirrors
+	c.ProxyURL = fileConfig.ProxyURL
+	c.CACerts = fileConfig.CACerts
+	c.GitHubToken = fileConfig.GitHubToken
+	c.VersionCheck = fileConfig.VersionCheck
+	c.Hooks = fileConfig.Hooks
+	c.Scheduler = fileConfig.Scheduler
+	c.Sync = fileConfig.Sync
+	c.Random = fileConfig.Random
+	c.Protected = fileConfig.Protected
+	c.Apply = fileConfig.Apply
+	c.Backup = fileConfig.Backup
+	c.UI = fileConfig.UI
+	c.Display = fileConfig.Display
+	c.Seasonal = fileConfig.Seasonal
+	c.SSH = fileConfig.SSH
+	c.Dotfiles = fileConfig.Dotfiles
+	c.Collections = fileConfig.Collections
+	c.Pairs = fileConfig.Pairs
+	c.FontPairings = fileConfig.FontPairings
+	c.Workspaces = fileConfig.Workspaces
+	c.WorkspaceApps = fileConfig.WorkspaceApps
+	c.ActiveProfile = fileConfig.ActiveProfile
+	c.Profiles = fileConfig.Profiles
 
 	return nil
 }
@@ -121,6 +401,12 @@ func (c *Config) createDirectories() error {
 		filepath.Dir(c.ConfigFile),
 		c.ThemesDir,
 		c.BackupDir,
+		c.DataDir,
+		c.StateDir,
+	}
+
+	if c.SaveDir != "" {
+		dirs = append(dirs, c.SaveDir)
 	}
 
 	for _, dir := range dirs {
@@ -132,21 +418,62 @@ func (c *Config) createDirectories() error {
 	return nil
 }
 
+// save writes the settings file under a lock. If another process changed
+// the file since this one read it, its changes are kept and only the
+// settings changed here are written over them.
 func (c *Config) save() error {
-	configDir := filepath.Dir(c.ConfigFile)
-	configPath := filepath.Join(configDir, configFileName)
+	unlock, err := LockFile(c.Path())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	out := c.persistable()
+	if disk, err := os.ReadFile(c.Path()); err == nil && c.saved != nil && !bytes.Equal(disk, c.saved) {
+		if merged, err := mergeSettings(c.saved, disk, out); err == nil {
+			out = merged
+		}
+	}
+
+	if err := writeFileAtomic(c.Path(), out.encodeSettings(), out.GitHubToken != ""); err != nil {
+		return err
+	}
+	// Later merges apply what changes here after this point
+	c.saved = c.persistable().encodeSettings()
+	return nil
+}
 
-	data, err := json.MarshalIndent(c, "", "  ")
+// update re-reads the settings under a lock, so changes made by other
+// processes are seen, lets change modify them and writes them back if it
+// reports a change
+func (c *Config) update(change func(c *Config) bool) error {
+	unlock, err := LockFile(c.Path())
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return err
 	}
+	defer unlock()
 
-	return os.WriteFile(configPath, data, 0644)
+	if err := c.Reload(); err != nil {
+		return err
+	}
+	if !change(c) {
+		return nil
+	}
+
+	out := c.persistable()
+	data := out.encodeSettings()
+	if err := writeFileAtomic(c.Path(), data, out.GitHubToken != ""); err != nil {
+		return err
+	}
+	c.saved = data
+	return nil
 }
 
 func (c *Config) SetCurrentTheme(theme string) error {
-	c.CurrentTheme = theme
-	return c.save()
+	return c.update(func(c *Config) bool {
+		c.CurrentTheme = theme
+		return true
+	})
 }
 
 // Save persists the current configuration to disk
@@ -154,7 +481,78 @@ func (c *Config) Save() error {
 	return c.save()
 }
 
-// GetThemePath returns the full path to a theme file
+// ThemeDirs returns the themes directories in search order: the configured
+// ones, then the save directory if it is not among them
+func (c *Config) ThemeDirs() []string {
+	dirs := append([]string{c.ThemesDir}, c.ExtraThemesDirs...)
+	if c.SaveDir == "" {
+		return dirs
+	}
+	for _, dir := range dirs {
+		if filepath.Clean(dir) == filepath.Clean(c.SaveDir) {
+			return dirs
+		}
+	}
+	return append(dirs, c.SaveDir)
+}
+
+// ThemeSaveDir returns where generated themes are written
+func (c *Config) ThemeSaveDir() string {
+	if c.SaveDir != "" {
+		return c.SaveDir
+	}
+	return c.ThemesDir
+}
+
+// GetThemePath returns the full path to a theme file, taken from the first
+// themes directory holding it, or from the main one if none does
 func (c *Config) GetThemePath(themeName string) string {
+	for _, dir := range c.ThemeDirs() {
+		path := filepath.Join(dir, themeName+".toml")
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
 	return filepath.Join(c.ThemesDir, themeName+".toml")
 }
+
+// ReadUIPreferences returns the [ui] settings without loading the rest of
+// the configuration, so output can be set up before a command runs. Any
+// problem with the settings file yields the defaults; loading it later
+// reports the error.
+func ReadUIPreferences() UIPreferences {
+	c := &Config{}
+	c.setDefaults()
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return c.UI
+	}
+	c.DataDir, _ = dataDirs(homeDir)
+	if dir, ok := lookupEnv("DATA_DIR"); ok {
+		c.DataDir = dir
+	}
+
+	data, err := os.ReadFile(c.Path())
+	if err != nil {
+		return c.UI
+	}
+	entries, err := parseTOML(data)
+	if err != nil {
+		return c.UI
+	}
+	version, err := schemaVersionOf(entries)
+	if err != nil {
+		return c.UI
+	}
+	if entries, _, err = migrateEntries(entries, version); err != nil {
+		return c.UI
+	}
+
+	fileConfig := &Config{}
+	fileConfig.setDefaults()
+	if err := fileConfig.decodeSettings(entries); err != nil {
+		return c.UI
+	}
+	return fileConfig.UI
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Dotfile managers the tool hands edited files back to
+const (
+	DotfilesChezmoi = "chezmoi"
+	DotfilesStow    = "stow"
+)
+
+var dotfilesManagers = []string{DotfilesChezmoi, DotfilesStow}
+
+// DotfilesSettings make the tool edit the Alacritty config in a dotfiles
+// repository instead of the deployed copy, so re-applying the dotfiles
+// doesn't revert theme changes
+type DotfilesSettings struct {
+	Mode bool `json:"mode"`
+	// Manager is DotfilesChezmoi, DotfilesStow or empty to only edit the
+	// repository
+	Manager string `json:"manager,omitempty"`
+	// Source is the directory in the repository that is deployed as the
+	// Alacritty config directory. Empty asks chezmoi, or follows stow's
+	// symlinks.
+	Source string `json:"source,omitempty"`
+	// Deploy runs chezmoi apply or stow after each change
+	Deploy bool `json:"deploy"`
+}
+
+// dotfilesFlag is --dotfiles-mode, which turns the mode on for one run
+var dotfilesFlag bool
+
+// SetDotfilesMode turns dotfiles mode on for this run, whatever the
+// settings say
+func SetDotfilesMode(on bool) {
+	dotfilesFlag = on
+}
+
+// resolveDotfiles points ConfigFile at its source in the dotfiles
+// repository. current.toml stays where it is: previews rewrite it all the
+// time, and it belongs out of the repository.
+func (c *Config) resolveDotfiles() error {
+	if dotfilesFlag {
+		c.Dotfiles.Mode = true
+	}
+	if !c.Dotfiles.Mode {
+		return nil
+	}
+
+	target := c.ConfigFile
+	source, err := c.dotfilesSource(target)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(source); err != nil {
+		return fmt.Errorf("dotfiles mode: %s is not in the dotfiles repository (looked for %s)", target, source)
+	}
+	c.DeployedConfigFile = target
+	c.ConfigFile = source
+	return nil
+}
+
+// dotfilesSource returns the file in the dotfiles repository deployed as
+// the Alacritty config target
+func (c *Config) dotfilesSource(target string) (string, error) {
+	if c.Dotfiles.Source != "" {
+		return filepath.Join(expandHome(c.Dotfiles.Source), filepath.Base(target)), nil
+	}
+
+	switch c.Dotfiles.Manager {
+	case DotfilesChezmoi:
+		out, err := exec.Command("chezmoi", "source-path", target).Output()
+		if err != nil {
+			return "", fmt.Errorf("dotfiles mode: chezmoi doesn't manage %s", target)
+		}
+		source := strings.TrimSpace(string(out))
+		if strings.HasSuffix(source, ".tmpl") {
+			return "", fmt.Errorf("dotfiles mode: %s is a chezmoi template, which the tool cannot edit", source)
+		}
+		return source, nil
+	case DotfilesStow:
+		// Stow deploys a symlink to the file or to a folder above it
+		source, err := filepath.EvalSymlinks(target)
+		if err != nil || source == target {
+			return "", fmt.Errorf("dotfiles mode: %s is not a stow symlink", target)
+		}
+		return source, nil
+	}
+	return "", fmt.Errorf("dotfiles mode needs dotfiles.source or dotfiles.manager in %s", c.Path())
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
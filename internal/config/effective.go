@@ -0,0 +1,176 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Where an effective setting comes from, from strongest to weakest
+const (
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceProfile = "profile"
+	SourceFile    = "file"
+	SourceDefault = "default"
+)
+
+// Setting is one effective setting, as the tool uses it for this run
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// flagSettings maps the settings the global path flags override to their
+// position in loadArgs
+var flagSettings = map[string]int{
+	"paths.config_file": 1,
+	"paths.themes_dir":  2,
+	"paths.backup_dir":  3,
+}
+
+// profileSettings are the settings a selected profile replaces
+var profileSettings = map[string]bool{
+	"current_theme":     true,
+	"paths.config_file": true,
+	"paths.themes_dir":  true,
+	"paths.backup_dir":  true,
+}
+
+// secretSettings are the settings whose value is never shown
+var secretSettings = map[string]bool{
+	"network.github_token": true,
+}
+
+// secretValue replaces the value of a secret setting
+const secretValue = "(set, hidden)"
+
+// EffectiveSettings lists every setting in settings-file order with the
+// value used for this run and where it comes from. A setting equal to its
+// default counts as a default even when the file spells it out, since
+// every run writes the full file.
+func (c *Config) EffectiveSettings() []Setting {
+	entries, err := parseTOML(c.encodeSettings())
+	if err != nil {
+		return nil
+	}
+
+	defaults := make(map[string]string)
+	d := &Config{}
+	d.setDefaults()
+	if err := d.initPaths(); err == nil {
+		if defaultEntries, err := parseTOML(d.encodeSettings()); err == nil {
+			for _, e := range defaultEntries {
+				defaults[e.Path()] = e.Value.String()
+			}
+		}
+	}
+
+	envKeys := make(map[string]string)
+	for _, setting := range envSettings {
+		if setting.key != "" {
+			envKeys[setting.key] = setting.name
+		}
+	}
+
+	settings := []Setting{{Key: "profile", Value: c.Profile, Source: c.profileSource}}
+	if c.Profile == "" {
+		settings[0].Value = DefaultProfile
+	}
+	for _, e := range entries {
+		key := e.Path()
+		if e.Index >= 0 || key == "profile" || key == "schema_version" || strings.HasPrefix(key, "profiles.") {
+			continue
+		}
+		s := Setting{Key: key, Value: e.Value.String(), Source: SourceFile}
+		switch {
+		case flagSettings[key] > 0 && c.loadArgs[flagSettings[key]] != "":
+			s.Source = SourceFlag
+		case key == "dotfiles.mode" && dotfilesFlag:
+			s.Source = SourceFlag
+		case c.envUsed[envKeys[key]]:
+			s.Source = SourceEnv + " " + EnvVar(envKeys[key])
+		case c.Profile != "" && profileSettings[key]:
+			s.Source = SourceProfile + " " + c.Profile
+		case defaults[key] == s.Value:
+			s.Source = SourceDefault
+		}
+		if secretSettings[key] {
+			s.Value = secretValue
+		}
+		settings = append(settings, s)
+	}
+
+	// A token only in GITHUB_TOKEN is used too; list it with the network
+	// settings
+	if c.GitHubToken == "" && c.GitHubAPIToken() != "" {
+		token := Setting{Key: "network.github_token", Value: secretValue, Source: SourceEnv + " GITHUB_TOKEN"}
+		at := len(settings)
+		for i, s := range settings {
+			if strings.HasPrefix(s.Key, "network.") {
+				at = i + 1
+			}
+		}
+		settings = append(settings[:at], append([]Setting{token}, settings[at:]...)...)
+	}
+	return settings
+}
+
+// String renders a value as settings show it: strings unquoted and lists
+// separated by commas
+func (v tomlValue) String() string {
+	switch v.kind {
+	case tomlInt:
+		return strconv.FormatInt(v.num, 10)
+	case tomlFloat:
+		return strconv.FormatFloat(v.f, 'g', -1, 64)
+	case tomlBool:
+		return strconv.FormatBool(v.b)
+	case tomlStrings:
+		return strings.Join(v.strs, ", ")
+	default:
+		return v.str
+	}
+}
+
+// PathInfo is a file or directory the tool reads or writes
+type PathInfo struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Exists bool   `json:"exists"`
+	// Used marks the Alacritty config file picked among the candidates
+	Used bool `json:"used,omitempty"`
+}
+
+// NewPathInfo describes path, checking whether it exists
+func NewPathInfo(name, path string) PathInfo {
+	_, err := os.Stat(path)
+	return PathInfo{Name: name, Path: path, Exists: err == nil}
+}
+
+// ConfigCandidates lists the places Alacritty reads its config from on
+// this platform, in the order it looks, marking the one in use
+func (c *Config) ConfigCandidates() []PathInfo {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	used := c.ConfigFile
+	if c.DeployedConfigFile != "" {
+		used = c.DeployedConfigFile
+	}
+	var paths []PathInfo
+	seen := make(map[string]bool)
+	for _, candidate := range configCandidates(homeDir) {
+		// Without XDG_CONFIG_HOME the first and third are the same file
+		if seen[candidate.path] {
+			continue
+		}
+		seen[candidate.path] = true
+		p := NewPathInfo("Alacritty config", candidate.path)
+		p.Used = candidate.path == used
+		paths = append(paths, p)
+	}
+	return paths
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// envPrefix is shared by all environment variables read by the tool
+const envPrefix = "ALACRITTY_COLORS_"
+
+// envSetting binds an environment variable to the setting it overrides
+type envSetting struct {
+	name string
+	// key is the setting overridden, for those in the settings file
+	key   string
+	apply func(c *Config, value string)
+	// restore copies the setting from src to dst so that values coming from
+	// the environment are never written back to the settings file
+	restore func(dst, src *Config)
+}
+
+var envSettings = []envSetting{
+	{
+		name:    "CONFIG",
+		key:     "paths.config_file",
+		apply:   func(c *Config, v string) { c.ConfigFile = v },
+		restore: func(dst, src *Config) { dst.ConfigFile = src.ConfigFile },
+	},
+	{
+		name:    "THEMES_DIR",
+		key:     "paths.themes_dir",
+		apply:   func(c *Config, v string) { c.ThemesDir = v },
+		restore: func(dst, src *Config) { dst.ThemesDir = src.ThemesDir },
+	},
+	{
+		name:    "BACKUP_DIR",
+		key:     "paths.backup_dir",
+		apply:   func(c *Config, v string) { c.BackupDir = v },
+		restore: func(dst, src *Config) { dst.BackupDir = src.BackupDir },
+	},
+	{
+		name:  "DATA_DIR",
+		apply: func(c *Config, v string) { c.DataDir = v },
+	},
+	{
+		name:  "STATE_DIR",
+		apply: func(c *Config, v string) { c.StateDir = v },
+	},
+	{
+		name:    "PROXY",
+		key:     "network.proxy_url",
+		apply:   func(c *Config, v string) { c.ProxyURL = v },
+		restore: func(dst, src *Config) { dst.ProxyURL = src.ProxyURL },
+	},
+	{
+		name:    "CA_CERTS",
+		key:     "network.ca_certs",
+		apply:   func(c *Config, v string) { c.CACerts = filepath.SplitList(v) },
+		restore: func(dst, src *Config) { dst.CACerts = src.CACerts },
+	},
+	{
+		name:    "OFFICIAL_MIRRORS",
+		key:     "network.mirrors",
+		apply:   func(c *Config, v string) { c.OfficialMirrors = strings.Fields(strings.ReplaceAll(v, ",", " ")) },
+		restore: func(dst, src *Config) { dst.OfficialMirrors = src.OfficialMirrors },
+	},
+	{
+		name:    "GITHUB_TOKEN",
+		key:     "network.github_token",
+		apply:   func(c *Config, v string) { c.GitHubToken = v },
+		restore: func(dst, src *Config) { dst.GitHubToken = src.GitHubToken },
+	},
+}
+
+// EnvVar returns the full name of a tool environment variable
+func EnvVar(name string) string {
+	return envPrefix + name
+}
+
+// GitHubAPIToken returns the token sent with GitHub API requests: the
+// github_token setting, or else GITHUB_TOKEN, which other GitHub tools
+// read too
+func (c *Config) GitHubAPIToken() string {
+	if c.GitHubToken != "" {
+		return c.GitHubToken
+	}
+	return strings.TrimSpace(os.Getenv("GITHUB_TOKEN"))
+}
+
+// lookupEnv returns a non-empty tool environment variable
+func lookupEnv(name string) (string, bool) {
+	value := strings.TrimSpace(os.Getenv(EnvVar(name)))
+	return value, value != ""
+}
+
+// applyEnv overrides settings from the environment and returns the names of
+// the variables that were used
+func (c *Config) applyEnv() []string {
+	var used []string
+	for _, setting := range envSettings {
+		if value, ok := lookupEnv(setting.name); ok {
+			setting.apply(c, value)
+			used = append(used, setting.name)
+		}
+	}
+	return used
+}
+
+// persistable returns the configuration as it should be written to disk,
+// with environment and flag overrides replaced by the values loaded from the
+// file and the selected profile's paths stored in its own table
+func (c *Config) persistable() *Config {
+	if c.fromFile == nil {
+		return c
+	}
+
+	out := *c
+	for _, setting := range envSettings {
+		if !c.envUsed[setting.name] || setting.restore == nil {
+			continue
+		}
+		setting.restore(&out, c.fromFile)
+	}
+	// The repository copy is edited, but the deployed config stays the setting
+	if c.DeployedConfigFile != "" {
+		out.ConfigFile = c.DeployedConfigFile
+	}
+	if dotfilesFlag {
+		out.Dotfiles.Mode = c.fromFile.Dotfiles.Mode
+	}
+	// Path flags apply to one run, like the environment
+	if c.loadArgs[1] != "" {
+		out.ConfigFile = c.fromFile.ConfigFile
+	}
+	if c.loadArgs[2] != "" {
+		out.ThemesDir = c.fromFile.ThemesDir
+	}
+	if c.loadArgs[3] != "" {
+		out.BackupDir = c.fromFile.BackupDir
+	}
+	c.persistProfile(&out)
+	return &out
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ValidateExcludePattern rejects malformed glob and /regex/ patterns
+func ValidateExcludePattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return fmt.Errorf("empty exclude pattern")
+	}
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		if _, err := regexp.Compile(pattern[1 : len(pattern)-1]); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		return nil
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// AddExclusions adds themes or patterns to the random exclusion list and
+// returns those that were not already listed
+func (c *Config) AddExclusions(patterns []string) ([]string, error) {
+	return c.addPatterns(func(c *Config) *[]string { return &c.Random.Exclude }, patterns)
+}
+
+// RemoveExclusions removes entries from the random exclusion list and
+// returns those that were listed
+func (c *Config) RemoveExclusions(patterns []string) ([]string, error) {
+	return c.removePatterns(func(c *Config) *[]string { return &c.Random.Exclude }, patterns)
+}
+
+// AddProtected adds themes or patterns to the protected list and returns
+// those that were not already listed
+func (c *Config) AddProtected(patterns []string) ([]string, error) {
+	return c.addPatterns(func(c *Config) *[]string { return &c.Protected }, patterns)
+}
+
+// RemoveProtected removes entries from the protected list and returns those
+// that were listed
+func (c *Config) RemoveProtected(patterns []string) ([]string, error) {
+	return c.removePatterns(func(c *Config) *[]string { return &c.Protected }, patterns)
+}
+
+// addPatterns appends new patterns to the list picked by field
+func (c *Config) addPatterns(field func(*Config) *[]string, patterns []string) ([]string, error) {
+	for _, pattern := range patterns {
+		if err := ValidateExcludePattern(pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	var added []string
+	err := c.update(func(c *Config) bool {
+		list := field(c)
+		for _, pattern := range patterns {
+			if containsFold(*list, pattern) || containsFold(added, pattern) {
+				continue
+			}
+			added = append(added, pattern)
+		}
+		*list = append(*list, added...)
+		return len(added) > 0
+	})
+	return added, err
+}
+
+// removePatterns drops the given entries from the list picked by field
+func (c *Config) removePatterns(field func(*Config) *[]string, patterns []string) ([]string, error) {
+	var removed []string
+	err := c.update(func(c *Config) bool {
+		list := field(c)
+		var kept []string
+		for _, existing := range *list {
+			if containsFold(patterns, existing) {
+				removed = append(removed, existing)
+			} else {
+				kept = append(kept, existing)
+			}
+		}
+		*list = kept
+		return len(removed) > 0
+	})
+	return removed, err
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	lockTimeout = 5 * time.Second
+	lockRetry   = 25 * time.Millisecond
+)
+
+// LockFile takes an exclusive lock on path, shared with other
+// alacritty-colors processes such as the scheduler daemon, by locking
+// path.lock. The lock is held by the operating system, so it goes away
+// with a process that crashes. The returned function releases it.
+func LockFile(path string) (func(), error) {
+	lock := path + ".lock"
+	f, err := os.OpenFile(lock, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		locked, err := tryLock(f)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			owner, _ := os.ReadFile(lock)
+			f.Close()
+			return nil, fmt.Errorf("timed out waiting for %s (held by process %s)", lock, strings.TrimSpace(string(owner)))
+		}
+		time.Sleep(lockRetry)
+	}
+
+	// The process ID only names the holder in the timeout error above
+	if err := f.Truncate(0); err == nil {
+		f.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+	return func() {
+		unlock(f)
+		f.Close()
+	}, nil
+}
+
+// writeFileAtomic replaces path in one step so readers never see a
+// partly written file. The file keeps its permissions; a private file,
+// such as settings holding a GitHub token, is made readable by its owner
+// only.
+func writeFileAtomic(path string, data []byte, private bool) error {
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if private {
+		perm &= 0600
+	}
+	tmp := path + ".tmp." + strconv.Itoa(os.Getpid())
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	// WriteFile applies the umask to the mode
+	if err := os.Chmod(tmp, perm); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// makePrivate takes away group and other access to path, best effort
+func makePrivate(path string) {
+	if info, err := os.Stat(path); err == nil && info.Mode().Perm()&0077 != 0 {
+		os.Chmod(path, info.Mode().Perm()&0600)
+	}
+}
+
+// decodeSettingsData decodes a settings file as loadFromFile does, without
+// side effects
+func decodeSettingsData(data []byte) (*Config, error) {
+	entries, err := parseTOML(data)
+	if err != nil {
+		return nil, err
+	}
+	version, err := schemaVersionOf(entries)
+	if err != nil {
+		return nil, err
+	}
+	if entries, _, err = migrateEntries(entries, version); err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	c.setDefaults()
+	if err := c.decodeSettings(entries); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// mergeSettings applies the settings this process changed since it read
+// base to the file another process has written since. Maps are merged key
+// by key; other settings are replaced as a whole.
+func mergeSettings(base, disk []byte, ours *Config) (*Config, error) {
+	old, err := decodeSettingsData(base)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := decodeSettingsData(disk)
+	if err != nil {
+		return nil, err
+	}
+
+	ov, nv, mv := reflect.ValueOf(old).Elem(), reflect.ValueOf(ours).Elem(), reflect.ValueOf(merged).Elem()
+	t := ov.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("json") == "-" {
+			continue
+		}
+		before, after, target := ov.Field(i), nv.Field(i), mv.Field(i)
+		if reflect.DeepEqual(before.Interface(), after.Interface()) {
+			continue
+		}
+		if field.Type.Kind() != reflect.Map {
+			target.Set(after)
+			continue
+		}
+
+		if target.IsNil() {
+			target.Set(reflect.MakeMap(field.Type))
+		}
+		for _, key := range after.MapKeys() {
+			value := after.MapIndex(key)
+			if prev := before.MapIndex(key); !prev.IsValid() || !reflect.DeepEqual(prev.Interface(), value.Interface()) {
+				target.SetMapIndex(key, value)
+			}
+		}
+		for _, key := range before.MapKeys() {
+			if !after.MapIndex(key).IsValid() {
+				target.SetMapIndex(key, reflect.Value{})
+			}
+		}
+	}
+	return merged, nil
+}
+
+// Watch polls the settings file and sends on the returned channel each
+// time it changes, until done is closed
+func (c *Config) Watch(interval time.Duration, done <-chan struct{}) <-chan struct{} {
+	changed := make(chan struct{}, 1)
+	path := c.Path()
+
+	stamp := func() string {
+		info, err := os.Stat(path)
+		if err != nil {
+			return ""
+		}
+		return fmt.Sprintf("%d/%d", info.ModTime().UnixNano(), info.Size())
+	}
+
+	go func() {
+		last := stamp()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if now := stamp(); now != last {
+					last = now
+					select {
+					case changed <- struct{}{}:
+					default:
+					}
+				}
+			}
+		}
+	}()
+	return changed
+}
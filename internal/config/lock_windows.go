@@ -0,0 +1,28 @@
+package config
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockRange is where the lock sits: Windows locks keep other processes
+// from reading the locked bytes, so it lies past the process ID
+var lockRange = windows.Overlapped{OffsetHigh: 1}
+
+// tryLock takes an exclusive LockFileEx lock on f without waiting
+func tryLock(f *os.File) (bool, error) {
+	ol := lockRange
+	err := windows.LockFileEx(windows.Handle(f.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, &ol)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func unlock(f *os.File) {
+	ol := lockRange
+	windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, &ol)
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// SchemaVersion is the settings layout written by this release. Bump it
+// together with a new entry in migrations whenever stored keys change.
+const SchemaVersion = 3
+
+// migration upgrades a settings file from version To-1 to version To. It
+// works on the parsed entries, before unknown keys are rejected, so renamed
+// or removed keys never reach validation.
+type migration struct {
+	To          int
+	Description string
+	Apply       func(entries []tomlEntry) []tomlEntry
+}
+
+// migrations must stay ordered by To and may never be edited once released
+var migrations = []migration{
+	{
+		To:          2,
+		Description: "replace the tool version with schema_version",
+		Apply: func(entries []tomlEntry) []tomlEntry {
+			return dropKey(entries, "version")
+		},
+	},
+	// Every file stored the unused default of false while apply always made
+	// a backup; dropping the key keeps that behavior
+	{
+		To:          3,
+		Description: "back up on apply unless backup.on_apply is turned off",
+		Apply: func(entries []tomlEntry) []tomlEntry {
+			return dropKey(entries, "backup.on_apply")
+		},
+	},
+}
+
+// migrateEntries upgrades entries read from a file with the given schema
+// version and returns the descriptions of the migrations that ran
+func migrateEntries(entries []tomlEntry, version int) ([]tomlEntry, []string, error) {
+	if version > SchemaVersion {
+		return nil, nil, fmt.Errorf("settings use schema version %d but this release only understands up to %d; please upgrade alacritty-colors", version, SchemaVersion)
+	}
+
+	var applied []string
+	for _, m := range migrations {
+		if m.To <= version {
+			continue
+		}
+		entries = m.Apply(entries)
+		applied = append(applied, fmt.Sprintf("v%d: %s", m.To, m.Description))
+	}
+
+	return dropKey(entries, "schema_version"), applied, nil
+}
+
+// schemaVersionOf returns the schema_version stored in entries. Files written
+// before versioning was introduced are version 1.
+func schemaVersionOf(entries []tomlEntry) (int, error) {
+	for _, e := range entries {
+		if e.Table == "" && e.Key == "schema_version" {
+			if e.Value.kind != tomlInt || e.Value.num < 1 {
+				return 0, e.typeError("a positive integer")
+			}
+			return int(e.Value.num), nil
+		}
+	}
+	return 1, nil
+}
+
+// backupBeforeMigration keeps the file as it was before migrating it. The
+// copy is readable by its owner only, since it may hold a GitHub token even
+// when the original was left readable by others.
+func backupBeforeMigration(path string, version int) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	perm := info.Mode().Perm() &^ 0077
+	backup := fmt.Sprintf("%s.v%d.bak", path, version)
+	if err := os.WriteFile(backup, data, perm); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of a backup that already exists
+	return os.Chmod(backup, perm)
+}
+
+func dropKey(entries []tomlEntry, path string) []tomlEntry {
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.Path() != path {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
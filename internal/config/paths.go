@@ -0,0 +1,90 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+const (
+	flatpakAppDir = ".var/app/org.alacritty.Alacritty"
+	snapAppDir    = "snap/alacritty"
+)
+
+// configCandidate is a location Alacritty reads its config from
+type configCandidate struct {
+	path string
+	// dir holds themes/ and backups/ for this config
+	dir string
+	// appDir exists once a Flatpak or Snap package has been run
+	appDir string
+}
+
+// configCandidates lists the config files Alacritty looks for, in the order
+// it looks for them, followed by the sandboxed Flatpak and Snap locations
+func configCandidates(homeDir string) []configCandidate {
+	return configCandidatesFor(runtime.GOOS, homeDir, os.Getenv)
+}
+
+// configCandidatesFor is configCandidates for the given OS and environment
+func configCandidatesFor(goos, homeDir string, getenv func(string) string) []configCandidate {
+	if goos == "windows" {
+		appData := getenv("APPDATA")
+		if appData == "" {
+			appData = filepath.Join(homeDir, "AppData", "Roaming")
+		}
+		dir := filepath.Join(appData, "alacritty")
+		return []configCandidate{{path: filepath.Join(dir, "alacritty.toml"), dir: dir}}
+	}
+
+	configHome := getenv("XDG_CONFIG_HOME")
+	if configHome == "" || !filepath.IsAbs(configHome) {
+		configHome = filepath.Join(homeDir, ".config")
+	}
+	dir := filepath.Join(configHome, "alacritty")
+	homeDirDefault := filepath.Join(homeDir, ".config", "alacritty")
+
+	candidates := []configCandidate{
+		{path: filepath.Join(dir, "alacritty.toml"), dir: dir},
+		{path: filepath.Join(configHome, "alacritty.toml"), dir: dir},
+		{path: filepath.Join(homeDirDefault, "alacritty.toml"), dir: homeDirDefault},
+		{path: filepath.Join(homeDir, ".alacritty.toml"), dir: dir},
+	}
+
+	if goos == "linux" {
+		flatpak := filepath.Join(homeDir, flatpakAppDir)
+		snap := filepath.Join(homeDir, snapAppDir)
+		flatpakDir := filepath.Join(flatpak, "config", "alacritty")
+		snapDir := filepath.Join(snap, "current", ".config", "alacritty")
+		candidates = append(candidates,
+			configCandidate{path: filepath.Join(flatpakDir, "alacritty.toml"), dir: flatpakDir, appDir: flatpak},
+			configCandidate{path: filepath.Join(snapDir, "alacritty.toml"), dir: snapDir, appDir: snap},
+		)
+	}
+
+	return candidates
+}
+
+// detectConfigFile picks the config file Alacritty will actually read and the
+// directory themes and backups go in. Without an existing config, a sandboxed
+// Flatpak or Snap install is preferred over the regular location.
+func detectConfigFile(homeDir string) (string, string) {
+	candidates := configCandidates(homeDir)
+
+	for _, c := range candidates {
+		if _, err := os.Stat(c.path); err == nil {
+			return c.path, c.dir
+		}
+	}
+
+	for _, c := range candidates {
+		if c.appDir == "" {
+			continue
+		}
+		if _, err := os.Stat(c.appDir); err == nil {
+			return c.path, c.dir
+		}
+	}
+
+	return candidates[0].path, candidates[0].dir
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestConfigCandidatesFor(t *testing.T) {
+	home := filepath.Join("home", "user")
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	tests := []struct {
+		name  string
+		goos  string
+		env   map[string]string
+		paths []string
+		dirs  []string
+	}{
+		{
+			name:  "windows uses APPDATA",
+			goos:  "windows",
+			env:   map[string]string{"APPDATA": filepath.Join("C:", "Users", "user", "AppData", "Roaming")},
+			paths: []string{filepath.Join("C:", "Users", "user", "AppData", "Roaming", "alacritty", "alacritty.toml")},
+			dirs:  []string{filepath.Join("C:", "Users", "user", "AppData", "Roaming", "alacritty")},
+		},
+		{
+			name:  "windows without APPDATA falls back to the home directory",
+			goos:  "windows",
+			paths: []string{filepath.Join(home, "AppData", "Roaming", "alacritty", "alacritty.toml")},
+			dirs:  []string{filepath.Join(home, "AppData", "Roaming", "alacritty")},
+		},
+		{
+			name:  "windows ignores XDG_CONFIG_HOME",
+			goos:  "windows",
+			env:   map[string]string{"XDG_CONFIG_HOME": filepath.Join(home, "xdg")},
+			paths: []string{filepath.Join(home, "AppData", "Roaming", "alacritty", "alacritty.toml")},
+			dirs:  []string{filepath.Join(home, "AppData", "Roaming", "alacritty")},
+		},
+		{
+			name: "darwin defaults to ~/.config",
+			goos: "darwin",
+			paths: []string{
+				filepath.Join(home, ".config", "alacritty", "alacritty.toml"),
+				filepath.Join(home, ".config", "alacritty.toml"),
+				filepath.Join(home, ".config", "alacritty", "alacritty.toml"),
+				filepath.Join(home, ".alacritty.toml"),
+			},
+			dirs: []string{
+				filepath.Join(home, ".config", "alacritty"),
+				filepath.Join(home, ".config", "alacritty"),
+				filepath.Join(home, ".config", "alacritty"),
+				filepath.Join(home, ".config", "alacritty"),
+			},
+		},
+		{
+			name: "linux honors an absolute XDG_CONFIG_HOME and adds Flatpak and Snap",
+			goos: "linux",
+			env:  map[string]string{"XDG_CONFIG_HOME": "/xdg"},
+			paths: []string{
+				filepath.Join("/xdg", "alacritty", "alacritty.toml"),
+				filepath.Join("/xdg", "alacritty.toml"),
+				filepath.Join(home, ".config", "alacritty", "alacritty.toml"),
+				filepath.Join(home, ".alacritty.toml"),
+				filepath.Join(home, flatpakAppDir, "config", "alacritty", "alacritty.toml"),
+				filepath.Join(home, snapAppDir, "current", ".config", "alacritty", "alacritty.toml"),
+			},
+			dirs: []string{
+				filepath.Join("/xdg", "alacritty"),
+				filepath.Join("/xdg", "alacritty"),
+				filepath.Join(home, ".config", "alacritty"),
+				filepath.Join("/xdg", "alacritty"),
+				filepath.Join(home, flatpakAppDir, "config", "alacritty"),
+				filepath.Join(home, snapAppDir, "current", ".config", "alacritty"),
+			},
+		},
+		{
+			name: "linux ignores a relative XDG_CONFIG_HOME",
+			goos: "linux",
+			env:  map[string]string{"XDG_CONFIG_HOME": "xdg"},
+			paths: []string{
+				filepath.Join(home, ".config", "alacritty", "alacritty.toml"),
+				filepath.Join(home, ".config", "alacritty.toml"),
+				filepath.Join(home, ".config", "alacritty", "alacritty.toml"),
+				filepath.Join(home, ".alacritty.toml"),
+				filepath.Join(home, flatpakAppDir, "config", "alacritty", "alacritty.toml"),
+				filepath.Join(home, snapAppDir, "current", ".config", "alacritty", "alacritty.toml"),
+			},
+			dirs: []string{
+				filepath.Join(home, ".config", "alacritty"),
+				filepath.Join(home, ".config", "alacritty"),
+				filepath.Join(home, ".config", "alacritty"),
+				filepath.Join(home, ".config", "alacritty"),
+				filepath.Join(home, flatpakAppDir, "config", "alacritty"),
+				filepath.Join(home, snapAppDir, "current", ".config", "alacritty"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths, dirs []string
+			for _, c := range configCandidatesFor(tt.goos, home, env(tt.env)) {
+				paths = append(paths, c.path)
+				dirs = append(dirs, c.dir)
+			}
+			if !reflect.DeepEqual(paths, tt.paths) {
+				t.Errorf("paths = %q, want %q", paths, tt.paths)
+			}
+			if !reflect.DeepEqual(dirs, tt.dirs) {
+				t.Errorf("dirs = %q, want %q", dirs, tt.dirs)
+			}
+		})
+	}
+}
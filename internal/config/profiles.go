@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultProfile names the paths set at the top level of the settings file
+const DefaultProfile = "default"
+
+// Profile points at one managed Alacritty configuration. Unset directories
+// default to themes/ and backups/ next to the profile's config file.
+type Profile struct {
+	ConfigFile   string `json:"config_file,omitempty"`
+	ThemesDir    string `json:"themes_dir,omitempty"`
+	BackupDir    string `json:"backup_dir,omitempty"`
+	CurrentTheme string `json:"current_theme,omitempty"`
+}
+
+var profileNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateProfileName rejects names that cannot be used as a TOML table key
+func ValidateProfileName(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("'%s' is reserved for the top-level paths", DefaultProfile)
+	}
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q (use letters, digits, '-' and '_')", name)
+	}
+	return nil
+}
+
+// ProfileNames returns the configured profiles in alphabetical order
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProfileConfigFile returns the Alacritty config file managed by a profile
+func (c *Config) ProfileConfigFile(name string) string {
+	if p, ok := c.Profiles[name]; ok {
+		return p.ConfigFile
+	}
+	if c.base != nil {
+		return c.base.ConfigFile
+	}
+	return c.ConfigFile
+}
+
+// selectProfile switches the paths and current theme to the named profile
+func (c *Config) selectProfile(name string) error {
+	if name == "" || name == DefaultProfile {
+		return nil
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok {
+		available := append([]string{DefaultProfile}, c.ProfileNames()...)
+		return fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(available, ", "))
+	}
+
+	c.base = &Profile{
+		ConfigFile:   c.ConfigFile,
+		ThemesDir:    c.ThemesDir,
+		BackupDir:    c.BackupDir,
+		CurrentTheme: c.CurrentTheme,
+	}
+	c.Profile = name
+
+	if p.ConfigFile != "" {
+		c.ConfigFile = p.ConfigFile
+	}
+	configDir := filepath.Dir(c.ConfigFile)
+
+	c.ThemesDir = p.ThemesDir
+	if c.ThemesDir == "" {
+		c.ThemesDir = filepath.Join(configDir, "themes")
+	}
+	c.BackupDir = p.BackupDir
+	if c.BackupDir == "" {
+		c.BackupDir = filepath.Join(configDir, "backups")
+	}
+	c.CurrentTheme = p.CurrentTheme
+
+	return nil
+}
+
+// AddProfile registers a profile, replacing any profile of the same name
+func (c *Config) AddProfile(name string, p Profile) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if p.ConfigFile == "" {
+		return fmt.Errorf("profile %q needs a config file", name)
+	}
+
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]Profile)
+	}
+	c.Profiles[name] = p
+	return nil
+}
+
+// UseProfile makes a profile the default for later invocations
+func (c *Config) UseProfile(name string) error {
+	if name == DefaultProfile {
+		c.ActiveProfile = ""
+		return c.save()
+	}
+
+	if _, ok := c.Profiles[name]; !ok {
+		return fmt.Errorf("unknown profile %q", name)
+	}
+	c.ActiveProfile = name
+	return c.save()
+}
+
+// persistProfile writes the active profile's paths back into its entry and
+// restores the top-level values
+func (c *Config) persistProfile(out *Config) {
+	if c.Profile == "" || c.base == nil {
+		return
+	}
+
+	profiles := make(map[string]Profile, len(c.Profiles))
+	for name, p := range c.Profiles {
+		profiles[name] = p
+	}
+
+	p := profiles[c.Profile]
+	p.ConfigFile = out.ConfigFile
+	p.CurrentTheme = out.CurrentTheme
+	configDir := filepath.Dir(p.ConfigFile)
+	if out.ThemesDir != filepath.Join(configDir, "themes") {
+		p.ThemesDir = out.ThemesDir
+	}
+	if out.BackupDir != filepath.Join(configDir, "backups") {
+		p.BackupDir = out.BackupDir
+	}
+	profiles[c.Profile] = p
+
+	out.Profiles = profiles
+	out.ConfigFile = c.base.ConfigFile
+	out.ThemesDir = c.base.ThemesDir
+	out.BackupDir = c.base.BackupDir
+	out.CurrentTheme = c.base.CurrentTheme
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/schema"
+)
+
+// settingFields names the Config fields of settings whose key differs from
+// the field's; the other [paths] and [network] settings match top-level
+// fields by key
+var settingFields = map[string]string{
+	"network.mirrors": "official_mirrors",
+}
+
+// settingConstraints narrow settings whose decoder accepts less, or more,
+// than their Go type
+var settingConstraints = map[string]schema.Schema{
+	"paths.themes_dir":          {"type": []string{"string", "array"}, "items": schema.Schema{"type": "string"}, "minItems": 1},
+	"scheduler.light_at":        {"pattern": `^([01]?[0-9]|2[0-3]):[0-5][0-9]$`},
+	"scheduler.dark_at":         {"pattern": `^([01]?[0-9]|2[0-3]):[0-5][0-9]$`},
+	"scheduler.transition":      {"pattern": `^(0|([0-9]+(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+)$`},
+	"seasonal.hemisphere":       {"enum": hemispheres},
+	"apply.current_file":        {"enum": currentModes},
+	"ui.color":                  {"enum": autoModes},
+	"ui.unicode":                {"enum": autoModes},
+	"ui.list_format":            {"enum": listFormats},
+	"display.gamma":             {"minimum": 0.2, "maximum": 5},
+	"display.brightness_offset": {"minimum": -0.5, "maximum": 0.5},
+	"dotfiles.manager":          {"enum": dotfilesManagers},
+}
+
+// themeMapSettings are the tables mapping names or patterns to themes or
+// theme lists, with keys chosen by the user
+var themeMapSettings = []string{"collections", "pairs", "font_pairings", "workspaces", "workspace_apps", "ssh.hosts"}
+
+// SettingsSchema describes the settings file as a JSON Schema, built from
+// the keys the decoder accepts and the types of the fields they set
+func SettingsSchema() schema.Schema {
+	defaults := settingDefaults()
+	root := settingsTable()
+
+	add := func(key string, s schema.Schema) {
+		name, sub, nested := strings.Cut(key, ".")
+		properties := root["properties"].(schema.Schema)
+		if !nested {
+			properties[key] = s
+			return
+		}
+		table, ok := properties[name].(schema.Schema)
+		if !ok {
+			table = settingsTable()
+			properties[name] = table
+		}
+		table["properties"].(schema.Schema)[sub] = s
+	}
+
+	add("schema_version", schema.Schema{"type": "integer", "minimum": 1, "maximum": SchemaVersion})
+	for _, key := range sortedKeys(settingsKeys) {
+		t, ok := settingType(key)
+		if !ok {
+			add(key, schema.Schema{})
+			continue
+		}
+		s := schema.Of(t, "json")
+		if s["type"] == "integer" {
+			s["minimum"] = 0
+		}
+		for k, v := range settingConstraints[key] {
+			s[k] = v
+		}
+		if value, ok := defaults[key]; ok {
+			s["default"] = value
+		}
+		add(key, s)
+	}
+	for _, key := range themeMapSettings {
+		if t, ok := settingType(key); ok {
+			add(key, schema.Of(t, "json"))
+		}
+	}
+
+	properties := root["properties"].(schema.Schema)
+	properties["sync"].(schema.Schema)["additionalProperties"] = keysSchema(syncTargetKeys)
+
+	profile := keysSchema(profileKeys)
+	profile["required"] = []string{"config_file"}
+	properties["profiles"] = schema.Schema{
+		"type":                 "object",
+		"propertyNames":        schema.Schema{"pattern": profileNameRegex.String()},
+		"additionalProperties": profile,
+	}
+	properties["collections"].(schema.Schema)["propertyNames"] = schema.Schema{"pattern": profileNameRegex.String()}
+
+	source := keysSchema(sourceKeys)
+	source["required"] = []string{"name", "url"}
+	properties["sources"] = schema.Schema{"type": "array", "items": source}
+
+	return schema.Document("alacritty-colors settings", root)
+}
+
+func settingsTable() schema.Schema {
+	return schema.Schema{"type": "object", "properties": schema.Schema{}, "additionalProperties": false}
+}
+
+// settingType returns the type of the Config field a setting decodes into
+func settingType(key string) (reflect.Type, bool) {
+	config := reflect.TypeOf(Config{})
+	table, name, nested := strings.Cut(key, ".")
+	if !nested {
+		f, ok := schema.Field(config, key, "json")
+		return f.Type, ok
+	}
+	if f, ok := schema.Field(config, table, "json"); ok && f.Type.Kind() == reflect.Struct {
+		f, ok := schema.Field(f.Type, name, "json")
+		return f.Type, ok
+	}
+	if field, ok := settingFields[key]; ok {
+		name = field
+	}
+	f, ok := schema.Field(config, name, "json")
+	return f.Type, ok
+}
+
+// keysSchema describes a table decoded by keys, whose names match the json
+// names of the fields they set
+func keysSchema[T any](keys map[string]func(*T, tomlEntry) error) schema.Schema {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	properties := schema.Schema{}
+	for _, key := range sortedKeys(keys) {
+		if f, ok := schema.Field(t, key, "json"); ok {
+			properties[key] = schema.Of(f.Type, "json")
+		} else {
+			properties[key] = schema.Schema{}
+		}
+	}
+	return schema.Schema{"type": "object", "properties": properties, "additionalProperties": false}
+}
+
+// settingDefaults returns the default value of each setting, leaving out
+// the paths, which depend on the machine
+func settingDefaults() map[string]any {
+	d := &Config{}
+	d.setDefaults()
+	entries, err := parseTOML(d.encodeSettings())
+	if err != nil {
+		return nil
+	}
+	defaults := make(map[string]any)
+	for _, e := range entries {
+		if e.Index < 0 && e.Table != "paths" {
+			defaults[e.Path()] = e.Value.any()
+		}
+	}
+	return defaults
+}
+
+// any returns the value as the Go value JSON encodes it from
+func (v tomlValue) any() any {
+	switch v.kind {
+	case tomlInt:
+		return v.num
+	case tomlFloat:
+		return v.f
+	case tomlBool:
+		return v.b
+	case tomlStrings:
+		if v.strs == nil {
+			return []string{}
+		}
+		return v.strs
+	default:
+		return v.str
+	}
+}
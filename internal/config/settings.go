@@ -0,0 +1,743 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Hooks are shell commands run around theme changes, and the webhooks and
+// MQTT topics notified after each one
+type Hooks struct {
+	PreApply   []string `json:"pre_apply,omitempty"`
+	PostApply  []string `json:"post_apply,omitempty"`
+	Webhooks   []string `json:"webhooks,omitempty"`
+	MQTTBroker string   `json:"mqtt_broker,omitempty"`
+	MQTTTopics []string `json:"mqtt_topics,omitempty"`
+}
+
+// Scheduler switches between a light and a dark theme during the day
+type Scheduler struct {
+	Enabled    bool   `json:"enabled"`
+	LightTheme string `json:"light_theme,omitempty"`
+	DarkTheme  string `json:"dark_theme,omitempty"`
+	LightAt    string `json:"light_at"`
+	DarkAt     string `json:"dark_at"`
+	// Transition is how long the switch between the light and dark themes
+	// fades, as a duration such as "2m"; empty switches at once
+	Transition string `json:"transition,omitempty"`
+}
+
+// TransitionDuration returns Transition as a duration, zero when unset
+func (s Scheduler) TransitionDuration() time.Duration {
+	d, _ := time.ParseDuration(s.Transition)
+	return d
+}
+
+// SyncTargets lists other programs that follow the applied theme
+type SyncTargets struct {
+	Targets []string `json:"targets,omitempty"`
+	// Options holds the [sync.<target>] tables
+	Options map[string]SyncTarget `json:"options,omitempty"`
+}
+
+// SyncTarget overrides where a target's snippet is written and whether the
+// program is told to reload it
+type SyncTarget struct {
+	Path   string `json:"path,omitempty"`
+	Reload bool   `json:"reload"`
+}
+
+// RandomPreferences controls which themes random picks may choose
+type RandomPreferences struct {
+	// Exclude holds theme names or glob patterns never picked at random
+	Exclude []string `json:"exclude,omitempty"`
+	// AvoidRecent is how many recently applied themes random skips
+	AvoidRecent int `json:"avoid_recent"`
+	// Seed namespaces the daily pick, so groups of machines sharing a seed
+	// get their own theme of the day
+	Seed string `json:"seed,omitempty"`
+	// MinScore skips themes with a lower quality score; 0 allows all
+	MinScore int `json:"min_score"`
+}
+
+// BackupPolicy controls automatic backups and their retention
+type BackupPolicy struct {
+	OnApply    bool `json:"on_apply"`
+	Keep       int  `json:"keep"`
+	MaxAgeDays int  `json:"max_age_days"`
+}
+
+// How current.toml shows the applied theme
+const (
+	CurrentCopy    = "copy"
+	CurrentSymlink = "symlink"
+)
+
+// ApplyPreferences controls how themes are applied
+type ApplyPreferences struct {
+	// CurrentFile is CurrentCopy or CurrentSymlink
+	CurrentFile string `json:"current_file"`
+	// DeriveColors fills in selection, cursor and search colors a theme
+	// leaves out, derived from its background and foreground
+	DeriveColors bool `json:"derive_colors"`
+	// SynthesizeBrights makes bright colors that repeat the normal ones a
+	// step lighter, or darker on light themes
+	SynthesizeBrights bool `json:"synthesize_brights"`
+	// DebounceMS is the least time, in milliseconds, between two changes
+	// of current.toml; quicker ones wait. Zero turns it off.
+	DebounceMS int `json:"debounce_ms"`
+}
+
+// DisplayCalibration adjusts every theme for a monitor that renders them
+// too dark or washed out. Gamma above 1 lifts the midtones and below 1
+// deepens them; BrightnessOffset is added to every channel, from -0.5 to
+// 0.5 of full brightness.
+type DisplayCalibration struct {
+	Gamma            float64 `json:"gamma"`
+	BrightnessOffset float64 `json:"brightness_offset"`
+}
+
+// Calibrated reports whether the calibration changes any color
+func (d DisplayCalibration) Calibrated() bool {
+	return d.Gamma != 1 || d.BrightnessOffset != 0
+}
+
+// SSHSettings pick the colors a terminal shows while an SSH session runs in
+// it, as a reminder of where commands are going
+type SSHSettings struct {
+	// Theme is used for hosts that no pattern in Hosts matches; empty
+	// leaves their sessions alone
+	Theme string `json:"theme,omitempty"`
+	// Hosts maps host names or glob patterns to themes
+	Hosts map[string]string `json:"hosts,omitempty"`
+}
+
+// Hemispheres for the seasonal generator scheme
+const (
+	HemisphereNorth = "north"
+	HemisphereSouth = "south"
+)
+
+// SeasonalScheme localizes the palettes of the seasonal generator scheme
+type SeasonalScheme struct {
+	// Hemisphere is HemisphereNorth or HemisphereSouth, where the seasons
+	// are six months apart
+	Hemisphere string `json:"hemisphere"`
+	// Holidays lets holiday palettes take over from the season's around
+	// their dates
+	Holidays bool `json:"holidays"`
+}
+
+// UIPreferences holds output defaults
+type UIPreferences struct {
+	Color      string `json:"color"`
+	Unicode    string `json:"unicode"`
+	ListFormat string `json:"list_format"`
+	// Accessible replaces swatches, bars and spinners with text for
+	// screen readers
+	Accessible bool `json:"accessible"`
+}
+
+var (
+	autoModes    = []string{"auto", "always", "never"}
+	listFormats  = []string{"grid", "list", "json", "colors"}
+	currentModes = []string{CurrentCopy, CurrentSymlink}
+	hemispheres  = []string{HemisphereNorth, HemisphereSouth}
+)
+
+func (c *Config) setDefaults() {
+	c.Scheduler.LightAt = "07:00"
+	c.Scheduler.DarkAt = "19:00"
+	c.Random.AvoidRecent = 7
+	c.Random.MinScore = 50
+	c.Backup.OnApply = true
+	c.Apply.CurrentFile = CurrentCopy
+	c.UI.Color = "auto"
+	c.UI.Unicode = "auto"
+	c.UI.ListFormat = "grid"
+	c.Display.Gamma = 1
+	c.Dotfiles.Deploy = true
+	c.Seasonal.Hemisphere = HemisphereNorth
+	c.Seasonal.Holidays = true
+}
+
+// settingsKeys decodes every key allowed outside of [[sources]]
+var settingsKeys = map[string]func(c *Config, e tomlEntry) error{
+	"profile":                   func(c *Config, e tomlEntry) error { return e.setString(&c.ActiveProfile) },
+	"current_theme":             func(c *Config, e tomlEntry) error { return e.setString(&c.CurrentTheme) },
+	"protected":                 func(c *Config, e tomlEntry) error { return e.setPatterns(&c.Protected) },
+	"paths.config_file":         func(c *Config, e tomlEntry) error { return e.setString(&c.ConfigFile) },
+	"paths.themes_dir":          func(c *Config, e tomlEntry) error { return e.setDirs(&c.ThemesDir, &c.ExtraThemesDirs) },
+	"paths.save_dir":            func(c *Config, e tomlEntry) error { return e.setString(&c.SaveDir) },
+	"paths.backup_dir":          func(c *Config, e tomlEntry) error { return e.setString(&c.BackupDir) },
+	"network.proxy_url":         func(c *Config, e tomlEntry) error { return e.setString(&c.ProxyURL) },
+	"network.ca_certs":          func(c *Config, e tomlEntry) error { return e.setStrings(&c.CACerts) },
+	"network.github_token":      func(c *Config, e tomlEntry) error { return e.setString(&c.GitHubToken) },
+	"network.mirrors":           func(c *Config, e tomlEntry) error { return e.setStrings(&c.OfficialMirrors) },
+	"network.version_check":     func(c *Config, e tomlEntry) error { return e.setBool(&c.VersionCheck) },
+	"hooks.pre_apply":           func(c *Config, e tomlEntry) error { return e.setStrings(&c.Hooks.PreApply) },
+	"hooks.post_apply":          func(c *Config, e tomlEntry) error { return e.setStrings(&c.Hooks.PostApply) },
+	"hooks.webhooks":            func(c *Config, e tomlEntry) error { return e.setStrings(&c.Hooks.Webhooks) },
+	"hooks.mqtt_broker":         func(c *Config, e tomlEntry) error { return e.setString(&c.Hooks.MQTTBroker) },
+	"hooks.mqtt_topics":         func(c *Config, e tomlEntry) error { return e.setStrings(&c.Hooks.MQTTTopics) },
+	"scheduler.enabled":         func(c *Config, e tomlEntry) error { return e.setBool(&c.Scheduler.Enabled) },
+	"scheduler.light_theme":     func(c *Config, e tomlEntry) error { return e.setString(&c.Scheduler.LightTheme) },
+	"scheduler.dark_theme":      func(c *Config, e tomlEntry) error { return e.setString(&c.Scheduler.DarkTheme) },
+	"scheduler.light_at":        func(c *Config, e tomlEntry) error { return e.setClock(&c.Scheduler.LightAt) },
+	"scheduler.dark_at":         func(c *Config, e tomlEntry) error { return e.setClock(&c.Scheduler.DarkAt) },
+	"scheduler.transition":      func(c *Config, e tomlEntry) error { return e.setDuration(&c.Scheduler.Transition) },
+	"sync.targets":              func(c *Config, e tomlEntry) error { return e.setStrings(&c.Sync.Targets) },
+	"random.exclude":            func(c *Config, e tomlEntry) error { return e.setStrings(&c.Random.Exclude) },
+	"random.avoid_recent":       func(c *Config, e tomlEntry) error { return e.setCount(&c.Random.AvoidRecent) },
+	"random.seed":               func(c *Config, e tomlEntry) error { return e.setString(&c.Random.Seed) },
+	"random.min_score":          func(c *Config, e tomlEntry) error { return e.setCount(&c.Random.MinScore) },
+	"apply.current_file":        func(c *Config, e tomlEntry) error { return e.setChoice(&c.Apply.CurrentFile, currentModes) },
+	"apply.derive_colors":       func(c *Config, e tomlEntry) error { return e.setBool(&c.Apply.DeriveColors) },
+	"apply.synthesize_brights":  func(c *Config, e tomlEntry) error { return e.setBool(&c.Apply.SynthesizeBrights) },
+	"apply.debounce_ms":         func(c *Config, e tomlEntry) error { return e.setCount(&c.Apply.DebounceMS) },
+	"backup.on_apply":           func(c *Config, e tomlEntry) error { return e.setBool(&c.Backup.OnApply) },
+	"backup.keep":               func(c *Config, e tomlEntry) error { return e.setCount(&c.Backup.Keep) },
+	"backup.max_age_days":       func(c *Config, e tomlEntry) error { return e.setCount(&c.Backup.MaxAgeDays) },
+	"ui.color":                  func(c *Config, e tomlEntry) error { return e.setChoice(&c.UI.Color, autoModes) },
+	"ui.unicode":                func(c *Config, e tomlEntry) error { return e.setChoice(&c.UI.Unicode, autoModes) },
+	"ui.list_format":            func(c *Config, e tomlEntry) error { return e.setChoice(&c.UI.ListFormat, listFormats) },
+	"ui.accessible":             func(c *Config, e tomlEntry) error { return e.setBool(&c.UI.Accessible) },
+	"display.gamma":             func(c *Config, e tomlEntry) error { return e.setFloat(&c.Display.Gamma, 0.2, 5) },
+	"display.brightness_offset": func(c *Config, e tomlEntry) error { return e.setFloat(&c.Display.BrightnessOffset, -0.5, 0.5) },
+	"seasonal.hemisphere":       func(c *Config, e tomlEntry) error { return e.setChoice(&c.Seasonal.Hemisphere, hemispheres) },
+	"seasonal.holidays":         func(c *Config, e tomlEntry) error { return e.setBool(&c.Seasonal.Holidays) },
+	"ssh.theme":                 func(c *Config, e tomlEntry) error { return e.setString(&c.SSH.Theme) },
+	"dotfiles.mode":             func(c *Config, e tomlEntry) error { return e.setBool(&c.Dotfiles.Mode) },
+	"dotfiles.manager":          func(c *Config, e tomlEntry) error { return e.setChoice(&c.Dotfiles.Manager, dotfilesManagers) },
+	"dotfiles.source":           func(c *Config, e tomlEntry) error { return e.setString(&c.Dotfiles.Source) },
+	"dotfiles.deploy":           func(c *Config, e tomlEntry) error { return e.setBool(&c.Dotfiles.Deploy) },
+}
+
+// sourceKeys decodes the keys of a [[sources]] entry
+var sourceKeys = map[string]func(s *ThemeSource, e tomlEntry) error{
+	"name":         func(s *ThemeSource, e tomlEntry) error { return e.setString(&s.Name) },
+	"url":          func(s *ThemeSource, e tomlEntry) error { return e.setString(&s.URL) },
+	"mirrors":      func(s *ThemeSource, e tomlEntry) error { return e.setStrings(&s.Mirrors) },
+	"checksum_url": func(s *ThemeSource, e tomlEntry) error { return e.setString(&s.ChecksumURL) },
+}
+
+// profileKeys decodes the keys of a [profiles.<name>] table
+var profileKeys = map[string]func(p *Profile, e tomlEntry) error{
+	"config_file":   func(p *Profile, e tomlEntry) error { return e.setString(&p.ConfigFile) },
+	"themes_dir":    func(p *Profile, e tomlEntry) error { return e.setString(&p.ThemesDir) },
+	"backup_dir":    func(p *Profile, e tomlEntry) error { return e.setString(&p.BackupDir) },
+	"current_theme": func(p *Profile, e tomlEntry) error { return e.setString(&p.CurrentTheme) },
+}
+
+// SyncTarget returns the settings of a sync target. Targets reload by
+// default so the change shows up without restarting the program.
+func (c *Config) SyncTarget(name string) SyncTarget {
+	if t, ok := c.Sync.Options[name]; ok {
+		return t
+	}
+	return SyncTarget{Reload: true}
+}
+
+// syncTargetKeys decodes the keys of a [sync.<target>] table
+var syncTargetKeys = map[string]func(t *SyncTarget, e tomlEntry) error{
+	"path":   func(t *SyncTarget, e tomlEntry) error { return e.setString(&t.Path) },
+	"reload": func(t *SyncTarget, e tomlEntry) error { return e.setBool(&t.Reload) },
+}
+
+// decodeSettings applies a parsed settings file, rejecting unknown keys and
+// values of the wrong type with an error naming the key
+func (c *Config) decodeSettings(entries []tomlEntry) error {
+	var sources []ThemeSource
+
+	for _, e := range entries {
+		if e.Table == "sources" && e.Index >= 0 {
+			for len(sources) <= e.Index {
+				sources = append(sources, ThemeSource{})
+			}
+			decode, ok := sourceKeys[e.Key]
+			if !ok {
+				return e.unknown()
+			}
+			if err := decode(&sources[e.Index], e); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(e.Table, "profiles."); ok && e.Index < 0 {
+			if err := ValidateProfileName(name); err != nil {
+				return fmt.Errorf("line %d: %w", e.Line, err)
+			}
+			decode, ok := profileKeys[e.Key]
+			if !ok {
+				return e.unknown()
+			}
+			if c.Profiles == nil {
+				c.Profiles = make(map[string]Profile)
+			}
+			p := c.Profiles[name]
+			if err := decode(&p, e); err != nil {
+				return err
+			}
+			c.Profiles[name] = p
+			continue
+		}
+
+		// Accepts both [collections] tables and dotted collections.<name> keys
+		if name, ok := strings.CutPrefix(e.Path(), "collections."); ok && e.Index < 0 {
+			if err := ValidateCollectionName(name); err != nil {
+				return fmt.Errorf("line %d: %w", e.Line, err)
+			}
+			var patterns []string
+			if err := e.setStrings(&patterns); err != nil {
+				return err
+			}
+			for _, pattern := range patterns {
+				if err := ValidateExcludePattern(pattern); err != nil {
+					return fmt.Errorf("line %d: %s: %w", e.Line, e.Path(), err)
+				}
+			}
+			if c.Collections == nil {
+				c.Collections = make(map[string][]string)
+			}
+			c.Collections[name] = patterns
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(e.Path(), "pairs."); ok && e.Index < 0 {
+			var counterpart string
+			if err := e.setString(&counterpart); err != nil {
+				return err
+			}
+			if name == "" || counterpart == "" {
+				return fmt.Errorf("line %d: %s: both themes of a pair are required", e.Line, e.Path())
+			}
+			if c.Pairs == nil {
+				c.Pairs = make(map[string]string)
+			}
+			c.Pairs[name] = counterpart
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(e.Path(), "font_pairings."); ok && e.Index < 0 {
+			var families []string
+			if err := e.setStrings(&families); err != nil {
+				return err
+			}
+			if name == "" || len(families) == 0 {
+				return fmt.Errorf("line %d: %s: at least one font family is required", e.Line, e.Path())
+			}
+			if c.FontPairings == nil {
+				c.FontPairings = make(map[string][]string)
+			}
+			c.FontPairings[strings.ToLower(name)] = families
+			continue
+		}
+
+		if pattern, ok := strings.CutPrefix(e.Path(), "ssh.hosts."); ok && e.Index < 0 {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("line %d: invalid host pattern %q: %w", e.Line, pattern, err)
+			}
+			if err := decodeThemeMap(&c.SSH.Hosts, strings.ToLower(pattern), e); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(e.Path(), "workspaces."); ok && e.Index < 0 {
+			if err := decodeThemeMap(&c.Workspaces, name, e); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Applications are matched by app_id or window class, in any case
+		if name, ok := strings.CutPrefix(e.Path(), "workspace_apps."); ok && e.Index < 0 {
+			if err := decodeThemeMap(&c.WorkspaceApps, strings.ToLower(name), e); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(e.Table, "sync."); ok && e.Index < 0 {
+			decode, ok := syncTargetKeys[e.Key]
+			if !ok {
+				return fmt.Errorf("line %d: unknown key %s (valid keys in [%s]: path, reload)", e.Line, e.Path(), e.Table)
+			}
+			if c.Sync.Options == nil {
+				c.Sync.Options = make(map[string]SyncTarget)
+			}
+			t := c.SyncTarget(name)
+			if err := decode(&t, e); err != nil {
+				return err
+			}
+			c.Sync.Options[name] = t
+			continue
+		}
+
+		decode, ok := settingsKeys[e.Path()]
+		if !ok {
+			return e.unknown()
+		}
+		if err := decode(c, e); err != nil {
+			return err
+		}
+	}
+
+	for name, p := range c.Profiles {
+		if p.ConfigFile == "" {
+			return fmt.Errorf("profiles.%s: config_file is required", name)
+		}
+	}
+	if c.ActiveProfile != "" && c.ActiveProfile != DefaultProfile {
+		if _, ok := c.Profiles[c.ActiveProfile]; !ok {
+			return fmt.Errorf("profile: no [profiles.%s] table", c.ActiveProfile)
+		}
+	}
+
+	for i, src := range sources {
+		if src.Name == "" || src.URL == "" {
+			return fmt.Errorf("sources[%d]: name and url are required", i)
+		}
+	}
+	if len(sources) > 0 {
+		c.Sources = sources
+	}
+
+	return nil
+}
+
+// encodeSettings renders the configuration as a settings file
+func (c *Config) encodeSettings() []byte {
+	w := &tomlWriter{}
+	w.comment("alacritty-colors settings")
+	w.integer("schema_version", SchemaVersion)
+	w.str("current_theme", c.CurrentTheme)
+	if c.ActiveProfile != "" {
+		w.str("profile", c.ActiveProfile)
+	}
+	if len(c.Protected) > 0 {
+		w.strs("protected", c.Protected)
+	}
+
+	w.table("paths")
+	w.str("config_file", c.ConfigFile)
+	if len(c.ExtraThemesDirs) > 0 {
+		w.strs("themes_dir", append([]string{c.ThemesDir}, c.ExtraThemesDirs...))
+	} else {
+		w.str("themes_dir", c.ThemesDir)
+	}
+	if c.SaveDir != "" {
+		w.str("save_dir", c.SaveDir)
+	}
+	w.str("backup_dir", c.BackupDir)
+
+	w.table("network")
+	w.str("proxy_url", c.ProxyURL)
+	w.strs("ca_certs", c.CACerts)
+	w.strs("mirrors", c.OfficialMirrors)
+	if c.GitHubToken != "" {
+		w.str("github_token", c.GitHubToken)
+	}
+	w.boolean("version_check", c.VersionCheck)
+
+	w.table("hooks")
+	w.strs("pre_apply", c.Hooks.PreApply)
+	w.strs("post_apply", c.Hooks.PostApply)
+	w.strs("webhooks", c.Hooks.Webhooks)
+	w.str("mqtt_broker", c.Hooks.MQTTBroker)
+	w.strs("mqtt_topics", c.Hooks.MQTTTopics)
+
+	w.table("scheduler")
+	w.boolean("enabled", c.Scheduler.Enabled)
+	w.str("light_theme", c.Scheduler.LightTheme)
+	w.str("dark_theme", c.Scheduler.DarkTheme)
+	w.str("light_at", c.Scheduler.LightAt)
+	w.str("dark_at", c.Scheduler.DarkAt)
+	if c.Scheduler.Transition != "" {
+		w.str("transition", c.Scheduler.Transition)
+	}
+
+	w.table("sync")
+	w.strs("targets", c.Sync.Targets)
+	for _, name := range sortedKeys(c.Sync.Options) {
+		t := c.Sync.Options[name]
+		w.table("sync." + name)
+		if t.Path != "" {
+			w.str("path", t.Path)
+		}
+		w.boolean("reload", t.Reload)
+	}
+
+	w.table("random")
+	w.strs("exclude", c.Random.Exclude)
+	w.integer("avoid_recent", c.Random.AvoidRecent)
+	if c.Random.Seed != "" {
+		w.str("seed", c.Random.Seed)
+	}
+	w.integer("min_score", c.Random.MinScore)
+
+	w.table("apply")
+	w.str("current_file", c.Apply.CurrentFile)
+	w.boolean("derive_colors", c.Apply.DeriveColors)
+	w.boolean("synthesize_brights", c.Apply.SynthesizeBrights)
+	w.integer("debounce_ms", c.Apply.DebounceMS)
+
+	if len(c.Collections) > 0 {
+		w.table("collections")
+		for _, name := range c.CollectionNames() {
+			w.strs(name, c.Collections[name])
+		}
+	}
+
+	if len(c.Pairs) > 0 {
+		w.table("pairs")
+		for _, name := range sortedKeys(c.Pairs) {
+			w.str(tomlQuote(name), c.Pairs[name])
+		}
+	}
+
+	if len(c.Workspaces) > 0 {
+		w.table("workspaces")
+		for _, name := range sortedKeys(c.Workspaces) {
+			w.str(tomlQuote(name), c.Workspaces[name])
+		}
+	}
+
+	if len(c.WorkspaceApps) > 0 {
+		w.table("workspace_apps")
+		for _, name := range sortedKeys(c.WorkspaceApps) {
+			w.str(tomlQuote(name), c.WorkspaceApps[name])
+		}
+	}
+
+	if len(c.FontPairings) > 0 {
+		w.table("font_pairings")
+		for _, name := range sortedKeys(c.FontPairings) {
+			w.strs(tomlQuote(name), c.FontPairings[name])
+		}
+	}
+
+	w.table("backup")
+	w.boolean("on_apply", c.Backup.OnApply)
+	w.integer("keep", c.Backup.Keep)
+	w.integer("max_age_days", c.Backup.MaxAgeDays)
+
+	w.table("ui")
+	w.str("color", c.UI.Color)
+	w.str("unicode", c.UI.Unicode)
+	w.str("list_format", c.UI.ListFormat)
+	w.boolean("accessible", c.UI.Accessible)
+
+	w.table("display")
+	w.float("gamma", c.Display.Gamma)
+	w.float("brightness_offset", c.Display.BrightnessOffset)
+
+	w.table("seasonal")
+	w.str("hemisphere", c.Seasonal.Hemisphere)
+	w.boolean("holidays", c.Seasonal.Holidays)
+
+	w.table("ssh")
+	w.str("theme", c.SSH.Theme)
+	if len(c.SSH.Hosts) > 0 {
+		w.table("ssh.hosts")
+		for _, pattern := range sortedKeys(c.SSH.Hosts) {
+			w.str(tomlQuote(pattern), c.SSH.Hosts[pattern])
+		}
+	}
+
+	w.table("dotfiles")
+	w.boolean("mode", c.Dotfiles.Mode)
+	if c.Dotfiles.Manager != "" {
+		w.str("manager", c.Dotfiles.Manager)
+	}
+	if c.Dotfiles.Source != "" {
+		w.str("source", c.Dotfiles.Source)
+	}
+	w.boolean("deploy", c.Dotfiles.Deploy)
+
+	for _, name := range c.ProfileNames() {
+		p := c.Profiles[name]
+		w.table("profiles." + name)
+		w.str("config_file", p.ConfigFile)
+		if p.ThemesDir != "" {
+			w.str("themes_dir", p.ThemesDir)
+		}
+		if p.BackupDir != "" {
+			w.str("backup_dir", p.BackupDir)
+		}
+		w.str("current_theme", p.CurrentTheme)
+	}
+
+	for _, src := range c.Sources {
+		w.arrayTable("sources")
+		w.str("name", src.Name)
+		w.str("url", src.URL)
+		if len(src.Mirrors) > 0 {
+			w.strs("mirrors", src.Mirrors)
+		}
+		if src.ChecksumURL != "" {
+			w.str("checksum_url", src.ChecksumURL)
+		}
+	}
+
+	return w.Bytes()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (e tomlEntry) unknown() error {
+	known := make([]string, 0, len(settingsKeys))
+	prefix := e.Table + "."
+	for key := range settingsKeys {
+		if e.Table != "" && strings.HasPrefix(key, prefix) {
+			known = append(known, strings.TrimPrefix(key, prefix))
+		}
+	}
+	sort.Strings(known)
+
+	if len(known) > 0 {
+		return fmt.Errorf("line %d: unknown key %s (valid keys in [%s]: %s)", e.Line, e.Path(), e.Table, strings.Join(known, ", "))
+	}
+	return fmt.Errorf("line %d: unknown key %s", e.Line, e.Path())
+}
+
+func (e tomlEntry) typeError(want string) error {
+	return fmt.Errorf("line %d: %s must be %s", e.Line, e.Path(), want)
+}
+
+func (e tomlEntry) setString(dst *string) error {
+	if e.Value.kind != tomlString {
+		return e.typeError("a string")
+	}
+	*dst = e.Value.str
+	return nil
+}
+
+func (e tomlEntry) setStrings(dst *[]string) error {
+	if e.Value.kind != tomlStrings {
+		return e.typeError("an array of strings")
+	}
+	*dst = e.Value.strs
+	return nil
+}
+
+// setPatterns reads a list of theme names or glob patterns
+func (e tomlEntry) setPatterns(dst *[]string) error {
+	var patterns []string
+	if err := e.setStrings(&patterns); err != nil {
+		return err
+	}
+	for _, pattern := range patterns {
+		if err := ValidateExcludePattern(pattern); err != nil {
+			return fmt.Errorf("line %d: %s: %w", e.Line, e.Path(), err)
+		}
+	}
+	*dst = patterns
+	return nil
+}
+
+// setDirs accepts a single directory or a non-empty list whose first entry
+// is the main one
+func (e tomlEntry) setDirs(main *string, extra *[]string) error {
+	switch {
+	case e.Value.kind == tomlString:
+		*main, *extra = e.Value.str, nil
+	case e.Value.kind == tomlStrings && len(e.Value.strs) > 0:
+		*main, *extra = e.Value.strs[0], e.Value.strs[1:]
+	default:
+		return e.typeError("a string or a non-empty array of strings")
+	}
+	return nil
+}
+
+func (e tomlEntry) setBool(dst *bool) error {
+	if e.Value.kind != tomlBool {
+		return e.typeError("true or false")
+	}
+	*dst = e.Value.b
+	return nil
+}
+
+func (e tomlEntry) setCount(dst *int) error {
+	if e.Value.kind != tomlInt || e.Value.num < 0 {
+		return e.typeError("a non-negative integer")
+	}
+	*dst = int(e.Value.num)
+	return nil
+}
+
+func (e tomlEntry) setFloat(dst *float64, lo, hi float64) error {
+	value := e.Value.f
+	switch e.Value.kind {
+	case tomlInt:
+		value = float64(e.Value.num)
+	case tomlFloat:
+	default:
+		return e.typeError(fmt.Sprintf("a number from %g to %g", lo, hi))
+	}
+	if value < lo || value > hi {
+		return e.typeError(fmt.Sprintf("a number from %g to %g", lo, hi))
+	}
+	*dst = value
+	return nil
+}
+
+// decodeThemeMap adds a name = "theme" entry to one of the tables mapping
+// workspaces or applications to themes
+func decodeThemeMap(dst *map[string]string, name string, e tomlEntry) error {
+	var theme string
+	if err := e.setString(&theme); err != nil {
+		return err
+	}
+	if name == "" || theme == "" {
+		return fmt.Errorf("line %d: %s: a name and a theme are required", e.Line, e.Path())
+	}
+	if *dst == nil {
+		*dst = make(map[string]string)
+	}
+	(*dst)[name] = theme
+	return nil
+}
+
+func (e tomlEntry) setChoice(dst *string, choices []string) error {
+	if e.Value.kind == tomlString {
+		for _, choice := range choices {
+			if e.Value.str == choice {
+				*dst = choice
+				return nil
+			}
+		}
+	}
+	return e.typeError("one of " + strings.Join(choices, ", "))
+}
+
+func (e tomlEntry) setClock(dst *string) error {
+	if e.Value.kind == tomlString {
+		if _, err := time.Parse("15:04", e.Value.str); err == nil {
+			*dst = e.Value.str
+			return nil
+		}
+	}
+	return e.typeError(`a time of day such as "07:30"`)
+}
+
+func (e tomlEntry) setDuration(dst *string) error {
+	if e.Value.kind == tomlString {
+		if d, err := time.ParseDuration(e.Value.str); err == nil && d >= 0 {
+			*dst = e.Value.str
+			return nil
+		}
+	}
+	return e.typeError(`a duration such as "90s"`)
+}
@@ -0,0 +1,336 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The settings file uses a small subset of TOML: tables, arrays of tables,
+// strings, integers, floats, booleans and arrays of strings.
+
+type tomlKind int
+
+const (
+	tomlString tomlKind = iota
+	tomlInt
+	tomlFloat
+	tomlBool
+	tomlStrings
+)
+
+type tomlValue struct {
+	kind tomlKind
+	str  string
+	num  int64
+	f    float64
+	b    bool
+	strs []string
+}
+
+// tomlEntry is a key/value pair with the table it belongs to. Index is the
+// position within an array of tables, or -1 for a plain table.
+type tomlEntry struct {
+	Table string
+	Index int
+	Key   string
+	Value tomlValue
+	Line  int
+}
+
+// Path returns the dotted key used in error messages, e.g. "sources[1].url"
+func (e tomlEntry) Path() string {
+	switch {
+	case e.Table == "":
+		return e.Key
+	case e.Index >= 0:
+		return fmt.Sprintf("%s[%d].%s", e.Table, e.Index, e.Key)
+	default:
+		return e.Table + "." + e.Key
+	}
+}
+
+func parseTOML(data []byte) ([]tomlEntry, error) {
+	var entries []tomlEntry
+	tables := make(map[string]bool)
+	arrays := make(map[string]int)
+	seen := make(map[string]bool)
+
+	table, index := "", -1
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(stripTOMLComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[[") {
+			if !strings.HasSuffix(line, "]]") {
+				return nil, fmt.Errorf("line %d: malformed table header", lineNo)
+			}
+			table = strings.TrimSpace(line[2 : len(line)-2])
+			index = arrays[table]
+			arrays[table]++
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: malformed table header", lineNo)
+			}
+			table, index = strings.TrimSpace(line[1:len(line)-1]), -1
+			if tables[table] {
+				return nil, fmt.Errorf("line %d: table [%s] defined twice", lineNo, table)
+			}
+			tables[table] = true
+			continue
+		}
+
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		key := strings.Trim(strings.TrimSpace(line[:eq]), `"`)
+		raw := strings.TrimSpace(line[eq+1:])
+		start := lineNo
+
+		// Arrays may span several lines
+		for strings.HasPrefix(raw, "[") && !arrayClosed(raw) && scanner.Scan() {
+			lineNo++
+			raw += " " + strings.TrimSpace(stripTOMLComment(scanner.Text()))
+		}
+
+		entry := tomlEntry{Table: table, Index: index, Key: key, Line: start}
+		value, err := parseTOMLValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", start, entry.Path(), err)
+		}
+		entry.Value = value
+
+		if seen[entry.Path()] {
+			return nil, fmt.Errorf("line %d: %s is set twice", start, entry.Path())
+		}
+		seen[entry.Path()] = true
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+func parseTOMLValue(raw string) (tomlValue, error) {
+	switch {
+	case raw == "":
+		return tomlValue{}, fmt.Errorf("missing value")
+	case raw == "true" || raw == "false":
+		return tomlValue{kind: tomlBool, b: raw == "true"}, nil
+	case strings.HasPrefix(raw, `"`) || strings.HasPrefix(raw, "'"):
+		s, err := parseTOMLString(raw)
+		return tomlValue{kind: tomlString, str: s}, err
+	case strings.HasPrefix(raw, "["):
+		return parseTOMLArray(raw)
+	case strings.HasPrefix(raw, "{"):
+		return tomlValue{}, fmt.Errorf("inline tables are not supported")
+	}
+
+	number := strings.ReplaceAll(raw, "_", "")
+	if n, err := strconv.ParseInt(number, 10, 64); err == nil {
+		return tomlValue{kind: tomlInt, num: n}, nil
+	}
+	if strings.ContainsAny(number, ".eE") {
+		if f, err := strconv.ParseFloat(number, 64); err == nil {
+			return tomlValue{kind: tomlFloat, f: f}, nil
+		}
+	}
+	return tomlValue{}, fmt.Errorf("unsupported value %s", raw)
+}
+
+func parseTOMLString(raw string) (string, error) {
+	if len(raw) < 2 || raw[len(raw)-1] != raw[0] {
+		return "", fmt.Errorf("unterminated string")
+	}
+	if raw[0] == '\'' {
+		return raw[1 : len(raw)-1], nil
+	}
+	s, err := strconv.Unquote(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid string %s", raw)
+	}
+	return s, nil
+}
+
+func parseTOMLArray(raw string) (tomlValue, error) {
+	if !strings.HasSuffix(raw, "]") {
+		return tomlValue{}, fmt.Errorf("unterminated array")
+	}
+
+	value := tomlValue{kind: tomlStrings, strs: []string{}}
+	for _, item := range splitTOMLArray(raw[1 : len(raw)-1]) {
+		s, err := parseTOMLString(item)
+		if err != nil {
+			return tomlValue{}, fmt.Errorf("arrays may only contain strings")
+		}
+		value.strs = append(value.strs, s)
+	}
+	return value, nil
+}
+
+// splitTOMLArray splits array items on commas outside of strings
+func splitTOMLArray(body string) []string {
+	var items []string
+	var current strings.Builder
+	var quote byte
+
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case quote != 0:
+			current.WriteByte(c)
+			if c == '\\' && quote == '"' && i+1 < len(body) {
+				i++
+				current.WriteByte(body[i])
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+			current.WriteByte(c)
+		case c == ',':
+			items = append(items, strings.TrimSpace(current.String()))
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	if last := strings.TrimSpace(current.String()); last != "" {
+		items = append(items, last)
+	}
+	return items
+}
+
+func arrayClosed(raw string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		}
+	}
+	return depth == 0
+}
+
+// stripTOMLComment removes a trailing comment outside of strings
+func stripTOMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// tomlWriter builds a settings file section by section
+type tomlWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *tomlWriter) comment(text string) {
+	fmt.Fprintf(&w.buf, "# %s\n", text)
+}
+
+func (w *tomlWriter) table(name string) {
+	fmt.Fprintf(&w.buf, "\n[%s]\n", name)
+}
+
+func (w *tomlWriter) arrayTable(name string) {
+	fmt.Fprintf(&w.buf, "\n[[%s]]\n", name)
+}
+
+func (w *tomlWriter) str(key, value string) {
+	fmt.Fprintf(&w.buf, "%s = %s\n", key, tomlQuote(value))
+}
+
+func (w *tomlWriter) integer(key string, value int) {
+	fmt.Fprintf(&w.buf, "%s = %d\n", key, value)
+}
+
+// float always writes a decimal point, which TOML requires of floats
+func (w *tomlWriter) float(key string, value float64) {
+	text := strconv.FormatFloat(value, 'f', -1, 64)
+	if !strings.ContainsAny(text, ".eE") {
+		text += ".0"
+	}
+	fmt.Fprintf(&w.buf, "%s = %s\n", key, text)
+}
+
+func (w *tomlWriter) boolean(key string, value bool) {
+	fmt.Fprintf(&w.buf, "%s = %t\n", key, value)
+}
+
+func (w *tomlWriter) strs(key string, values []string) {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = tomlQuote(v)
+	}
+	fmt.Fprintf(&w.buf, "%s = [%s]\n", key, strings.Join(quoted, ", "))
+}
+
+func (w *tomlWriter) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// tomlQuote returns a TOML basic string
+func tomlQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
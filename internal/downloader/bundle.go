@@ -0,0 +1,71 @@
+package downloader
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// BundledSource is the manifest name for themes installed from the binary
+const BundledSource = "bundled"
+
+//go:embed bundle/*.toml
+var bundleFS embed.FS
+
+// bundleModTime is old enough that any downloaded copy replaces a bundled theme
+var bundleModTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// InstallBundledThemes writes the embedded themes that are not already present
+// so the tool is usable without network access
+func (d *Downloader) InstallBundledThemes() (int, error) {
+	if err := os.MkdirAll(d.themesDir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create themes directory: %w", err)
+	}
+
+	entries, err := fs.ReadDir(bundleFS, "bundle")
+	if err != nil {
+		return 0, err
+	}
+
+	files := make(map[string]string)
+	var written []string
+	installed := 0
+	for _, entry := range entries {
+		content, err := bundleFS.ReadFile("bundle/" + entry.Name())
+		if err != nil {
+			return installed, err
+		}
+		files[entry.Name()] = gitBlobSHA(content)
+
+		outputPath := filepath.Join(d.themesDir, entry.Name())
+		if _, err := os.Stat(outputPath); err == nil {
+			continue
+		}
+
+		if err := os.WriteFile(outputPath, content, 0644); err != nil {
+			return installed, fmt.Errorf("failed to write %s: %w", entry.Name(), err)
+		}
+		os.Chtimes(outputPath, bundleModTime, bundleModTime)
+		written = append(written, entry.Name())
+		installed++
+	}
+
+	if err := d.indexMetadata(BundledSource, written); err != nil {
+		return installed, fmt.Errorf("failed to index theme metadata: %w", err)
+	}
+
+	record := SourceRecord{
+		URL:          "embedded",
+		Verified:     true,
+		DownloadedAt: time.Now(),
+		Files:        files,
+	}
+	if err := d.saveRecord(BundledSource, record); err != nil {
+		return installed, fmt.Errorf("failed to update manifest: %w", err)
+	}
+
+	return installed, nil
+}
@@ -2,13 +2,20 @@ package downloader
 
 import (
 	"archive/zip"
-	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/vitruves/alacritty-colors/internal/ui"
@@ -18,51 +25,374 @@ const (
 	OfficialRepoURL = "https://github.com/alacritty/alacritty-theme/archive/refs/heads/master.zip"
 	UserAgent       = "alacritty-colors/1.0.0"
 	Timeout         = 30 * time.Second
+
+	// ManifestFile records what was downloaded from each source
+	ManifestFile = "sources.json"
+	// legacyManifestFile is where the manifest lived inside the themes directory
+	legacyManifestFile = ".sources.json"
 )
 
+// Source is a theme archive that can be downloaded and extracted
+type Source struct {
+	Name        string
+	URL         string
+	Mirrors     []string
+	ChecksumURL string
+	Official    bool
+}
+
+// OfficialSource is the upstream alacritty-theme repository
+var OfficialSource = Source{
+	Name:     "official",
+	URL:      OfficialRepoURL,
+	Official: true,
+}
+
+// SourceRecord is the manifest entry for the last successful download of a source
+type SourceRecord struct {
+	URL          string            `json:"url"`
+	SHA256       string            `json:"sha256,omitempty"`
+	TreeSHA      string            `json:"tree_sha,omitempty"`
+	Verified     bool              `json:"verified"`
+	DownloadedAt time.Time         `json:"downloaded_at"`
+	Files        map[string]string `json:"files,omitempty"`
+	Stars        int               `json:"stars,omitempty"`
+}
+
 type Downloader struct {
-	themesDir string
-	client    *http.Client
+	themesDir  string
+	stateDir   string
+	client     *http.Client
+	transport  *http.Transport
+	skipVerify bool
+	protected  func(filename string) bool
+	analyzer   func(path string, meta *ThemeMetadata)
+
+	githubToken     string
+	rateLimitMu     sync.Mutex
+	rateLimitWarned bool
 }
 
-func New(themesDir string) *Downloader {
+// New creates a downloader writing themes to themesDir and its manifest to stateDir
+func New(themesDir, stateDir string) *Downloader {
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY are honored by default
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
 	return &Downloader{
 		themesDir: themesDir,
+		stateDir:  stateDir,
+		transport: transport,
 		client: &http.Client{
-			Timeout: Timeout,
+			Timeout:   Timeout,
+			Transport: transport,
 		},
 	}
 }
 
+// ConfigureTransport sets an explicit proxy, overriding the environment, and
+// trusts the PEM certificates in caFiles in addition to the system roots
+func (d *Downloader) ConfigureTransport(proxyURL string, caFiles []string) error {
+	if proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("invalid proxy URL: %s", proxyURL)
+		}
+		d.transport.Proxy = http.ProxyURL(u)
+	}
+
+	if len(caFiles) == 0 {
+		return nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	for _, file := range caFiles {
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in %s", file)
+		}
+	}
+
+	d.transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return nil
+}
+
+// Transport is the HTTP transport downloads go through, for other requests
+// that should honor the configured proxy and CA certificates
+func (d *Downloader) Transport() *http.Transport {
+	return d.transport
+}
+
+// SetSkipVerify allows sources without a checksum URL to be extracted
+func (d *Downloader) SetSkipVerify(skip bool) {
+	d.skipVerify = skip
+}
+
+// SetProtected names theme files that downloads must never overwrite
+func (d *Downloader) SetProtected(protected func(filename string) bool) {
+	d.protected = protected
+}
+
+// SetAnalyzer sets how the metadata index derives tags and a quality
+// score from a theme file's palette
+func (d *Downloader) SetAnalyzer(analyzer func(path string, meta *ThemeMetadata)) {
+	d.analyzer = analyzer
+}
+
+// isProtected reports whether an existing theme file must be left alone
+func (d *Downloader) isProtected(filename string) bool {
+	if d.protected == nil || !d.protected(filename) {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(d.themesDir, filename))
+	return err == nil
+}
+
 func (d *Downloader) DownloadOfficialThemes() (int, error) {
 	ui.PrintInfo("Downloading from official repository...")
+	return d.DownloadSource(OfficialSource)
+}
+
+// DownloadSource downloads a theme archive, verifies it and extracts its themes
+func (d *Downloader) DownloadSource(src Source) (int, error) {
+	if !src.Official {
+		ui.PrintInfo("Downloading from source '%s'...", src.Name)
+	}
+	return d.downloadSource(src, sourceRun{})
+}
 
-	// Download the zip file
-	resp, err := d.downloadFile(OfficialRepoURL)
+func (d *Downloader) downloadSource(src Source, run sourceRun) (int, error) {
+
+	// Custom sources must publish a checksum unless verification is skipped
+	if src.ChecksumURL == "" && !src.Official && !d.skipVerify {
+		return 0, fmt.Errorf("source '%s' has no checksum_url (use --insecure-skip-verify to download anyway)", src.Name)
+	}
+
+	expected := ""
+	if src.ChecksumURL != "" {
+		sum, err := d.fetchChecksum(src.ChecksumURL)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch checksum: %w", err)
+		}
+		expected = sum
+	}
+
+	// Try the primary URL first and fall back to each mirror in turn
+	var lastErr error
+	for i, archiveURL := range src.URLs() {
+		if i > 0 {
+			run.info("Falling back to mirror %s", archiveURL)
+		}
+
+		count, err := d.downloadArchive(src, run, archiveURL, expected)
+		if err != nil {
+			run.warn("Download from %s failed: %v", archiveURL, err)
+			lastErr = err
+			continue
+		}
+
+		return count, nil
+	}
+
+	return 0, fmt.Errorf("failed to download themes: %w", lastErr)
+}
+
+// URLs returns the primary URL followed by the configured mirrors
+func (s Source) URLs() []string {
+	return append([]string{s.URL}, s.Mirrors...)
+}
+
+func (d *Downloader) downloadArchive(src Source, run sourceRun, archiveURL, expected string) (int, error) {
+	resp, err := d.downloadFile(archiveURL)
 	if err != nil {
-		return 0, fmt.Errorf("failed to download themes: %w", err)
+		return 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("failed to download themes: HTTP %d", resp.StatusCode)
+		return 0, fmt.Errorf("HTTP %d", resp.StatusCode)
 	}
 
-	// Read the zip content
-	body, err := io.ReadAll(resp.Body)
+	// Stream the archive to a temporary file instead of holding it in memory
+	archive, err := os.CreateTemp("", "alacritty-colors-*.zip")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	hash := sha256.New()
+	progress := run.progress(resp.ContentLength)
+	size, err := io.Copy(io.MultiWriter(archive, hash, progress), resp.Body)
+	progress.Finish()
 	if err != nil {
 		return 0, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	ui.PrintInfo("Extracting themes...")
+	if resp.ContentLength > 0 && size != resp.ContentLength {
+		return 0, fmt.Errorf("archive is truncated: got %d of %d bytes", size, resp.ContentLength)
+	}
+
+	digest := hex.EncodeToString(hash.Sum(nil))
+
+	if expected != "" {
+		if !strings.EqualFold(expected, digest) {
+			return 0, fmt.Errorf("checksum mismatch: expected %s, got %s", expected, digest)
+		}
+		run.success("Checksum verified")
+	} else if !src.Official {
+		run.warn("Skipping checksum verification for '%s' (sha256 %s)", src.Name, digest)
+	}
+
+	run.waitTurn()
+	run.info("Extracting themes...")
 
 	// Extract theme files
-	count, err := d.extractThemes(body)
+	files, err := d.extractThemes(run, archive, size)
 	if err != nil {
 		return 0, fmt.Errorf("failed to extract themes: %w", err)
 	}
 
-	return count, nil
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	run.pause()
+	invalid := d.quarantine(names)
+	run.resume()
+	if err := d.indexMetadata(src.Name, names); err != nil {
+		run.warn("Failed to index theme metadata: %v", err)
+	}
+
+	record := SourceRecord{
+		URL:          archiveURL,
+		SHA256:       digest,
+		Verified:     expected != "",
+		DownloadedAt: time.Now(),
+		Files:        files,
+	}
+	if !src.Official {
+		stars, err := d.fetchStars(archiveURL)
+		var limitErr *RateLimitError
+		switch {
+		case err == nil:
+			record.Stars = stars
+		case errors.As(err, &limitErr):
+			run.warn("Skipped the star count of %s: %v", src.Name, err)
+		}
+	}
+	if err := d.saveRecord(src.Name, record); err != nil {
+		run.warn("Failed to record checksum: %v", err)
+	}
+
+	return len(files) - len(invalid), nil
+}
+
+// fetchChecksum reads a sha256sum-style file and returns the first digest in it
+func (d *Downloader) fetchChecksum(url string) (string, error) {
+	resp, err := d.downloadFile(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 || len(fields[0]) != sha256.Size*2 {
+		return "", fmt.Errorf("no sha256 digest found in %s", url)
+	}
+	if _, err := hex.DecodeString(fields[0]); err != nil {
+		return "", fmt.Errorf("invalid sha256 digest in %s", url)
+	}
+
+	return strings.ToLower(fields[0]), nil
+}
+
+// LoadManifest returns the recorded downloads keyed by source name
+func (d *Downloader) LoadManifest() (map[string]SourceRecord, error) {
+	records := make(map[string]SourceRecord)
+
+	if err := d.migrateManifest(); err != nil {
+		return nil, fmt.Errorf("failed to migrate manifest: %w", err)
+	}
+
+	data, err := os.ReadFile(d.manifestPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return records, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	return records, nil
+}
+
+func (d *Downloader) saveRecord(name string, record SourceRecord) error {
+	records, err := d.LoadManifest()
+	if err != nil {
+		return err
+	}
+	records[name] = record
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(d.stateDir, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(d.manifestPath(), data, 0644)
+}
+
+func (d *Downloader) manifestPath() string {
+	return filepath.Join(d.stateDir, ManifestFile)
+}
+
+// migrateManifest moves a manifest left in the themes directory by older
+// versions into the state directory
+func (d *Downloader) migrateManifest() error {
+	legacy := filepath.Join(d.themesDir, legacyManifestFile)
+	if _, err := os.Stat(legacy); err != nil {
+		return nil
+	}
+	if _, err := os.Stat(d.manifestPath()); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(d.stateDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(legacy)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(d.manifestPath(), data, 0644); err != nil {
+		return err
+	}
+
+	return os.Remove(legacy)
 }
 
 func (d *Downloader) downloadFile(url string) (*http.Response, error) {
@@ -75,40 +405,56 @@ func (d *Downloader) downloadFile(url string) (*http.Response, error) {
 	return d.client.Do(req)
 }
 
-func (d *Downloader) extractThemes(zipData []byte) (int, error) {
+// extractThemes writes the theme files in the archive and returns their git
+// blob SHAs keyed by filename
+func (d *Downloader) extractThemes(run sourceRun, r io.ReaderAt, size int64) (map[string]string, error) {
 	// Create a zip reader
-	zipReader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	zipReader, err := zip.NewReader(r, size)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create zip reader: %w", err)
+		return nil, fmt.Errorf("failed to create zip reader: %w", err)
 	}
 
 	// Ensure themes directory exists
 	if err := os.MkdirAll(d.themesDir, 0755); err != nil {
-		return 0, fmt.Errorf("failed to create themes directory: %w", err)
+		return nil, fmt.Errorf("failed to create themes directory: %w", err)
 	}
 
-	themeCount := 0
+	// TOML themes take precedence over a legacy YAML file of the same name
+	tomlThemes := make(map[string]bool)
+	for _, file := range zipReader.File {
+		if d.isThemeFile(file.Name) && strings.HasSuffix(file.Name, ".toml") {
+			tomlThemes[filepath.Base(file.Name)] = true
+		}
+	}
+
+	files := make(map[string]string)
 	totalFiles := len(zipReader.File)
 	processed := 0
 
 	for _, file := range zipReader.File {
 		processed++
-		ui.PrintProgress(processed, totalFiles, "Processing")
+		run.processed(processed, totalFiles)
 
 		// Skip if not a theme file
 		if !d.isThemeFile(file.Name) {
 			continue
 		}
 
-		if err := d.extractThemeFile(file); err != nil {
-			ui.PrintWarning("Failed to extract %s: %v", filepath.Base(file.Name), err)
+		filename := themeFileName(file.Name)
+		if strings.HasSuffix(file.Name, ".yaml") && tomlThemes[filename] {
 			continue
 		}
 
-		themeCount++
+		blobSHA, err := d.extractThemeFile(file)
+		if err != nil {
+			run.warn("Failed to extract %s: %v", filepath.Base(file.Name), err)
+			continue
+		}
+
+		files[filename] = blobSHA
 	}
 
-	return themeCount, nil
+	return files, nil
 }
 
 func (d *Downloader) isThemeFile(filename string) bool {
@@ -117,30 +463,40 @@ func (d *Downloader) isThemeFile(filename string) bool {
 		(strings.HasSuffix(filename, ".toml") || strings.HasSuffix(filename, ".yaml"))
 }
 
-func (d *Downloader) extractThemeFile(file *zip.File) error {
+func (d *Downloader) extractThemeFile(file *zip.File) (string, error) {
 	rc, err := file.Open()
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer rc.Close()
 
 	content, err := io.ReadAll(rc)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Extract filename
-	filename := filepath.Base(file.Name)
-	outputPath := filepath.Join(d.themesDir, filename)
+	outputPath := filepath.Join(d.themesDir, themeFileName(file.Name))
+	blobSHA := gitBlobSHA(content)
+
+	if d.isProtected(themeFileName(file.Name)) {
+		return blobSHA, nil
+	}
 
 	// Check if file already exists and is newer
 	if info, err := os.Stat(outputPath); err == nil {
 		if info.ModTime().After(file.Modified) {
-			return nil // Skip if local file is newer
+			return blobSHA, nil // Skip if local file is newer
 		}
 	}
 
-	return os.WriteFile(outputPath, content, 0644)
+	if strings.HasSuffix(file.Name, ".yaml") {
+		if content, err = convertYAMLTheme(content); err != nil {
+			return "", fmt.Errorf("failed to convert YAML theme: %w", err)
+		}
+	}
+
+	return blobSHA, os.WriteFile(outputPath, content, 0644)
 }
 
 func (d *Downloader) DownloadFromURL(url, filename string) error {
@@ -166,6 +522,13 @@ func (d *Downloader) DownloadFromURL(url, filename string) error {
 		return fmt.Errorf("invalid theme file format")
 	}
 
+	if strings.HasSuffix(filename, ".yaml") {
+		if content, err = convertYAMLTheme(content); err != nil {
+			return fmt.Errorf("failed to convert YAML theme: %w", err)
+		}
+		filename = themeFileName(filename)
+	}
+
 	outputPath := filepath.Join(d.themesDir, filename)
 	if err := os.WriteFile(outputPath, content, 0644); err != nil {
 		return fmt.Errorf("failed to save theme: %w", err)
@@ -0,0 +1,225 @@
+package downloader
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+const (
+	GitHubTreeURL = "https://api.github.com/repos/alacritty/alacritty-theme/git/trees/master?recursive=1"
+	GitHubRawURL  = "https://raw.githubusercontent.com/alacritty/alacritty-theme/master/"
+)
+
+// SyncResult describes what an incremental sync changed, or would change
+type SyncResult struct {
+	Added     []string
+	Updated   []string
+	Skipped   []string
+	Removed   []string
+	Invalid   []InvalidTheme
+	Unchanged int
+}
+
+type treeEntry struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	SHA  string `json:"sha"`
+}
+
+type treeResponse struct {
+	SHA       string      `json:"sha"`
+	Tree      []treeEntry `json:"tree"`
+	Truncated bool        `json:"truncated"`
+}
+
+// SyncOfficialThemes uses the GitHub trees API to fetch only the official
+// themes whose blob SHA changed since the last download. With dryRun set
+// nothing is written and the result only reports pending changes.
+func (d *Downloader) SyncOfficialThemes(dryRun bool) (*SyncResult, error) {
+	tree, err := d.fetchTree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch theme index: %w", err)
+	}
+
+	records, err := d.LoadManifest()
+	if err != nil {
+		return nil, err
+	}
+	record := records[OfficialSource.Name]
+	if record.Files == nil {
+		record.Files = make(map[string]string)
+	}
+
+	// TOML themes take precedence over a legacy YAML file of the same name
+	tomlThemes := make(map[string]bool)
+	for _, entry := range tree.Tree {
+		if strings.HasSuffix(entry.Path, ".toml") && d.isThemeFile(entry.Path) {
+			tomlThemes[filepath.Base(entry.Path)] = true
+		}
+	}
+
+	result := &SyncResult{}
+	var pending []treeEntry
+	upstream := make(map[string]bool)
+
+	for _, entry := range tree.Tree {
+		if entry.Type != "blob" || !d.isThemeFile(entry.Path) {
+			continue
+		}
+		if strings.HasSuffix(entry.Path, ".yaml") && tomlThemes[themeFileName(entry.Path)] {
+			continue
+		}
+
+		filename := themeFileName(entry.Path)
+		upstream[filename] = true
+		known, exists := record.Files[filename]
+		switch {
+		case known != entry.SHA && d.isProtected(filename):
+			result.Skipped = append(result.Skipped, filename)
+			continue
+		case !exists:
+			result.Added = append(result.Added, filename)
+		case known != entry.SHA:
+			// Leave files that were edited locally alone; converted YAML
+			// themes never match their upstream blob so they are not checked
+			if local, err := os.ReadFile(filepath.Join(d.themesDir, filename)); err == nil && strings.HasSuffix(entry.Path, ".toml") && gitBlobSHA(local) != known {
+				result.Skipped = append(result.Skipped, filename)
+				continue
+			}
+			result.Updated = append(result.Updated, filename)
+		default:
+			result.Unchanged++
+			continue
+		}
+		pending = append(pending, entry)
+	}
+
+	for filename := range record.Files {
+		if !upstream[filename] {
+			result.Removed = append(result.Removed, filename)
+		}
+	}
+
+	sort.Strings(result.Added)
+	sort.Strings(result.Updated)
+	sort.Strings(result.Skipped)
+	sort.Strings(result.Removed)
+
+	if dryRun || (len(pending) == 0 && len(result.Removed) == 0) {
+		return result, nil
+	}
+
+	// Stop tracking files that are gone upstream; pruning them is up to the caller
+	for _, filename := range result.Removed {
+		delete(record.Files, filename)
+	}
+
+	if err := os.MkdirAll(d.themesDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create themes directory: %w", err)
+	}
+
+	var synced []string
+	for i, entry := range pending {
+		ui.PrintProgress(i+1, len(pending), "Syncing")
+
+		filename := themeFileName(entry.Path)
+		if err := d.fetchBlob(entry); err != nil {
+			// The rest stays pending for the next sync
+			var limitErr *RateLimitError
+			if errors.As(err, &limitErr) {
+				ui.PrintWarning("Stopped syncing after %d of %d themes: %v", len(synced), len(pending), err)
+				break
+			}
+			ui.PrintWarning("Failed to sync %s: %v", filename, err)
+			continue
+		}
+		record.Files[filename] = entry.SHA
+		synced = append(synced, filename)
+	}
+	result.Invalid = d.quarantine(synced)
+	if err := d.indexMetadata(OfficialSource.Name, synced); err != nil {
+		ui.PrintWarning("Failed to index theme metadata: %v", err)
+	}
+
+	record.URL = GitHubTreeURL
+	record.TreeSHA = tree.SHA
+	record.DownloadedAt = time.Now()
+	if err := d.saveRecord(OfficialSource.Name, record); err != nil {
+		ui.PrintWarning("Failed to update manifest: %v", err)
+	}
+
+	return result, nil
+}
+
+func (d *Downloader) fetchTree() (*treeResponse, error) {
+	resp, err := d.githubGet(context.Background(), GitHubTreeURL, RateLimitWait)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	var tree treeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tree); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if tree.Truncated {
+		return nil, fmt.Errorf("theme index is truncated, use a full update instead")
+	}
+
+	return &tree, nil
+}
+
+// fetchBlob downloads a single file and checks it against its blob SHA
+func (d *Downloader) fetchBlob(entry treeEntry) error {
+	resp, err := d.githubGet(context.Background(), GitHubRawURL+entry.Path, RateLimitWait)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if sha := gitBlobSHA(content); sha != entry.SHA {
+		return fmt.Errorf("content does not match blob %s", entry.SHA)
+	}
+
+	if strings.HasSuffix(entry.Path, ".yaml") {
+		if content, err = convertYAMLTheme(content); err != nil {
+			return fmt.Errorf("failed to convert YAML theme: %w", err)
+		}
+	}
+
+	return os.WriteFile(filepath.Join(d.themesDir, themeFileName(entry.Path)), content, 0644)
+}
+
+// gitBlobSHA computes the SHA-1 git uses to identify a file's content
+func gitBlobSHA(content []byte) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
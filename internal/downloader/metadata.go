@@ -0,0 +1,150 @@
+package downloader
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MetadataFile is the index of theme metadata gathered during downloads
+	MetadataFile = "metadata.json"
+	// OfficialBrowseURL is where official theme files are viewed on GitHub
+	OfficialBrowseURL = "https://github.com/alacritty/alacritty-theme/blob/master/themes/"
+)
+
+// ThemeMetadata describes a theme beyond its colors
+type ThemeMetadata struct {
+	Title    string `json:"title,omitempty"`
+	Author   string `json:"author,omitempty"`
+	Variant  string `json:"variant,omitempty"`
+	Upstream string `json:"upstream,omitempty"`
+	Source   string `json:"source,omitempty"`
+	// Tags describe the palette, e.g. "warm" or "pastel"
+	Tags []string `json:"tags,omitempty"`
+	// Score rates how well the palette is built, out of 100
+	Score int `json:"score,omitempty"`
+}
+
+var (
+	titleComment = regexp.MustCompile(`^(?i)colors\s*\((.+)\)$`)
+	urlPattern   = regexp.MustCompile(`https?://\S+`)
+	// variantWords are name parts that tell variants of a theme apart
+	variantWords = []string{"dark", "light", "dim", "night", "day", "moon", "dawn", "storm"}
+)
+
+// ParseHeaderComment reads metadata from one comment line of a theme file,
+// such as "# Colors (Dracula)", "# Author: Jane" or an upstream URL. Fields
+// already set are kept.
+func ParseHeaderComment(meta *ThemeMetadata, line string) {
+	text := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+	if text == "" {
+		return
+	}
+
+	set := func(field *string, value string) {
+		if *field == "" {
+			*field = strings.TrimSpace(value)
+		}
+	}
+
+	if m := titleComment.FindStringSubmatch(text); m != nil {
+		set(&meta.Title, m[1])
+		return
+	}
+
+	key, value, ok := strings.Cut(text, ":")
+	if ok && !strings.HasPrefix(value, "//") {
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "author", "authors", "maintainer", "created by":
+			set(&meta.Author, value)
+			return
+		case "variant":
+			set(&meta.Variant, strings.ToLower(value))
+			return
+		case "name", "theme":
+			set(&meta.Title, value)
+			return
+		}
+	}
+
+	if url := urlPattern.FindString(text); url != "" {
+		set(&meta.Upstream, strings.TrimRight(url, ").,"))
+	}
+}
+
+// ParseThemeHeader reads the metadata in a theme's comments
+func ParseThemeHeader(content []byte) ThemeMetadata {
+	var meta ThemeMetadata
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); strings.HasPrefix(line, "#") {
+			ParseHeaderComment(&meta, line)
+		}
+	}
+	return meta
+}
+
+// VariantFromName guesses a variant such as "dark" or "moon" from a theme name
+func VariantFromName(name string) string {
+	parts := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	})
+	for i := len(parts) - 1; i >= 0; i-- {
+		for _, word := range variantWords {
+			if parts[i] == word {
+				return word
+			}
+		}
+	}
+	return ""
+}
+
+// LoadMetadata returns the metadata index keyed by theme name
+func (d *Downloader) LoadMetadata() map[string]ThemeMetadata {
+	index := make(map[string]ThemeMetadata)
+	data, err := os.ReadFile(filepath.Join(d.stateDir, MetadataFile))
+	if err == nil {
+		json.Unmarshal(data, &index)
+	}
+	return index
+}
+
+// indexMetadata records the metadata of freshly downloaded theme files
+func (d *Downloader) indexMetadata(source string, filenames []string) error {
+	index := d.LoadMetadata()
+	for _, filename := range filenames {
+		content, err := os.ReadFile(filepath.Join(d.themesDir, filename))
+		if err != nil {
+			continue
+		}
+
+		name := strings.TrimSuffix(filename, filepath.Ext(filename))
+		meta := ParseThemeHeader(content)
+		meta.Source = source
+		if meta.Variant == "" {
+			meta.Variant = VariantFromName(name)
+		}
+		// Bundled themes are copies of official ones
+		if meta.Upstream == "" && (source == OfficialSource.Name || source == BundledSource) {
+			meta.Upstream = OfficialBrowseURL + filename
+		}
+		if d.analyzer != nil {
+			d.analyzer(filepath.Join(d.themesDir, filename), &meta)
+		}
+		index[name] = meta
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(d.stateDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(d.stateDir, MetadataFile), data, 0644)
+}
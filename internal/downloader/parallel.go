@@ -0,0 +1,174 @@
+package downloader
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// MaxParallelDownloads bounds how many sources DownloadSources fetches at
+// once
+const MaxParallelDownloads = 4
+
+// SourceResult is how one source of DownloadSources went
+type SourceResult struct {
+	Source string
+	Count  int
+	Err    error
+}
+
+// DownloadSources downloads several sources at once, at most
+// MaxParallelDownloads at a time, showing one progress line per source.
+// Themes are still extracted one source at a time in the given order, so
+// sources shipping the same theme end up as when they download in turn.
+func (d *Downloader) DownloadSources(srcs []Source) []SourceResult {
+	names := make([]string, len(srcs))
+	for i, src := range srcs {
+		names[i] = src.Name
+	}
+	group := ui.NewTransferGroup(names)
+
+	results := make([]SourceResult, len(srcs))
+	// Each source closes its turn when done; the next one waits for it
+	// before extracting
+	turns := make([]chan struct{}, len(srcs))
+	for i := range turns {
+		turns[i] = make(chan struct{})
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(MaxParallelDownloads, len(srcs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				run := sourceRun{group: group, index: i}
+				if i > 0 {
+					run.turn = turns[i-1]
+				}
+				count, err := d.downloadSource(srcs[i], run)
+				results[i] = SourceResult{Source: srcs[i].Name, Count: count, Err: err}
+				if err != nil {
+					group.Failed(i, "failed")
+				} else {
+					group.Done(i, "%d themes", count)
+				}
+				close(turns[i])
+			}
+		}()
+	}
+	for i := range srcs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	group.Finish()
+	return results
+}
+
+// sourceRun is where one source's download reports to: straight to the
+// terminal, or its line of a TransferGroup while several sources download
+// at once. turn, when set, is closed once the previous source is done.
+type sourceRun struct {
+	group *ui.TransferGroup
+	index int
+	turn  <-chan struct{}
+}
+
+func (r sourceRun) info(format string, args ...interface{}) {
+	if r.group == nil {
+		ui.PrintInfo(format, args...)
+		return
+	}
+	r.group.Status(r.index, "%s", strings.TrimSuffix(fmt.Sprintf(format, args...), "..."))
+}
+
+func (r sourceRun) success(format string, args ...interface{}) {
+	if r.group == nil {
+		ui.PrintSuccess(format, args...)
+		return
+	}
+	r.group.Status(r.index, format, args...)
+}
+
+// processed reports how many files of the archive were extracted
+func (r sourceRun) processed(current, total int) {
+	if r.group == nil {
+		ui.PrintProgress(current, total, "Processing")
+		return
+	}
+	r.group.Progress(r.index, "Extracting %d/%d", current, total)
+}
+
+// warn prints above the progress lines, so warnings stay on screen
+func (r sourceRun) warn(format string, args ...interface{}) {
+	r.pause()
+	ui.PrintWarning(format, args...)
+	r.resume()
+}
+
+// pause and resume let output be printed above the progress lines
+func (r sourceRun) pause() {
+	if r.group != nil {
+		r.group.Pause()
+	}
+}
+
+func (r sourceRun) resume() {
+	if r.group != nil {
+		r.group.Resume()
+	}
+}
+
+// waitTurn waits until the previous source is done
+func (r sourceRun) waitTurn() {
+	if r.turn == nil {
+		return
+	}
+	select {
+	case <-r.turn:
+	default:
+		r.info("Waiting for the previous sources...")
+		<-r.turn
+	}
+}
+
+// transferProgress reports the bytes of a download written through it
+type transferProgress interface {
+	Write(b []byte) (int, error)
+	Finish()
+}
+
+func (r sourceRun) progress(total int64) transferProgress {
+	if r.group == nil {
+		return newProgressWriter(total, "Downloading")
+	}
+	return &groupProgress{group: r.group, index: r.index, total: total}
+}
+
+// groupProgress is a progressWriter for a line of a TransferGroup
+type groupProgress struct {
+	group   *ui.TransferGroup
+	index   int
+	total   int64
+	written int64
+	last    time.Time
+}
+
+func (p *groupProgress) Write(b []byte) (int, error) {
+	p.written += int64(len(b))
+	if now := time.Now(); now.Sub(p.last) >= progressInterval {
+		p.last = now
+		p.group.Update(p.index, p.written, p.total)
+	}
+	return len(b), nil
+}
+
+func (p *groupProgress) Finish() {
+	p.group.Update(p.index, p.written, p.total)
+}
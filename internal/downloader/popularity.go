@@ -0,0 +1,106 @@
+package downloader
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GitHubRepoAPI is used to look up star counts of community sources
+const GitHubRepoAPI = "https://api.github.com/repos/"
+
+// popularityIndex scores well known themes from 0 to 100 by usage
+//
+//go:embed popularity.json
+var popularityIndex []byte
+
+// Popularity describes how widely used a theme is
+type Popularity struct {
+	Score int // Usage score from the bundled index, 0-100
+	Stars int // GitHub stars of the community source providing the theme
+}
+
+// Popularity returns popularity data keyed by theme name, combining the
+// bundled index with the star counts recorded for community sources
+func (d *Downloader) Popularity() map[string]Popularity {
+	result := make(map[string]Popularity)
+
+	var scores map[string]int
+	if err := json.Unmarshal(popularityIndex, &scores); err == nil {
+		for name, score := range scores {
+			result[name] = Popularity{Score: score}
+		}
+	}
+
+	records, err := d.LoadManifest()
+	if err != nil {
+		return result
+	}
+	for _, record := range records {
+		if record.Stars == 0 {
+			continue
+		}
+		for file := range record.Files {
+			name := strings.TrimSuffix(file, ".toml")
+			p := result[name]
+			if record.Stars > p.Stars {
+				p.Stars = record.Stars
+			}
+			result[name] = p
+		}
+	}
+
+	return result
+}
+
+// fetchStars returns the star count of the GitHub repository an archive URL
+// points to
+func (d *Downloader) fetchStars(archiveURL string) (int, error) {
+	repo, ok := githubRepo(archiveURL)
+	if !ok {
+		return 0, fmt.Errorf("not a GitHub URL: %s", archiveURL)
+	}
+
+	// Stars are a nicety, not worth waiting on a rate limit for
+	resp, err := d.githubGet(context.Background(), GitHubRepoAPI+repo, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	var info struct {
+		Stars int `json:"stargazers_count"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return info.Stars, nil
+}
+
+// githubRepo extracts "owner/repo" from github.com and codeload.github.com URLs
+func githubRepo(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+
+	if u.Host != "github.com" && u.Host != "codeload.github.com" {
+		return "", false
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 {
+		return "", false
+	}
+
+	return parts[0] + "/" + strings.TrimSuffix(parts[1], ".git"), true
+}
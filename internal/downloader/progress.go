@@ -0,0 +1,49 @@
+package downloader
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// progressInterval limits how often the transfer line is redrawn
+const progressInterval = 100 * time.Millisecond
+
+// progressWriter counts bytes written through it and reports the transfer
+type progressWriter struct {
+	total     int64
+	written   int64
+	start     time.Time
+	lastDraw  time.Time
+	operation string
+}
+
+func newProgressWriter(total int64, operation string) *progressWriter {
+	return &progressWriter{
+		total:     total,
+		start:     time.Now(),
+		operation: operation,
+	}
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	p.written += int64(len(b))
+
+	if now := time.Now(); now.Sub(p.lastDraw) >= progressInterval {
+		p.lastDraw = now
+		ui.PrintTransfer(p.written, p.total, now.Sub(p.start), p.operation)
+	}
+
+	return len(b), nil
+}
+
+// Finish draws the final state of the transfer and ends the line
+func (p *progressWriter) Finish() {
+	total := p.total
+	if total <= 0 {
+		total = p.written
+	}
+	ui.PrintTransfer(p.written, total, time.Since(p.start), p.operation)
+	fmt.Println()
+}
@@ -0,0 +1,174 @@
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// GitHubAPIHost is the host requests send the GitHub token to
+const GitHubAPIHost = "api.github.com"
+
+// RateLimitWait is the longest a request waits for a GitHub rate limit to
+// lift before giving up
+const RateLimitWait = time.Minute
+
+// rateLimitRetries bounds how often a request is retried after waiting
+// for a rate limit
+const rateLimitRetries = 3
+
+// lowRateLimit is the number of API requests left below which a warning
+// is shown
+const lowRateLimit = 10
+
+// Hourly API request limits GitHub grants without and with a token
+const (
+	anonymousRateLimit     = 60
+	authenticatedRateLimit = 5000
+)
+
+// RateLimitError reports that GitHub turned a request down until Reset
+type RateLimitError struct {
+	Limit int
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	msg := "GitHub rate limit exceeded"
+	if e.Limit > 0 {
+		msg += fmt.Sprintf(" (%d requests per hour)", e.Limit)
+	}
+	if !e.Reset.IsZero() {
+		msg += fmt.Sprintf(", it lifts at %s", e.Reset.Local().Format("15:04"))
+	}
+	return msg
+}
+
+// SetGitHubToken sets the token sent with GitHub API requests, raising
+// the rate limit from 60 to 5000 requests per hour
+func (d *Downloader) SetGitHubToken(token string) {
+	d.githubToken = token
+}
+
+// githubGet requests a GitHub URL, with the token when it goes to the API.
+// A request turned down by a rate limit is retried once the limit lifts
+// if that is at most maxWait away, and ctx allows it; otherwise it fails
+// with a RateLimitError.
+func (d *Downloader) githubGet(ctx context.Context, rawURL string, maxWait time.Duration) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", UserAgent)
+		api := req.URL.Host == GitHubAPIHost
+		if api {
+			req.Header.Set("Accept", "application/vnd.github+json")
+			if d.githubToken != "" {
+				req.Header.Set("Authorization", "Bearer "+d.githubToken)
+			}
+		}
+
+		resp, err := d.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if api {
+			d.checkRateLimit(resp)
+		}
+		if resp.StatusCode == http.StatusUnauthorized && api && d.githubToken != "" {
+			resp.Body.Close()
+			return nil, ui.WithHints(fmt.Errorf("GitHub rejected the token (HTTP 401)"),
+				"Check the token in GITHUB_TOKEN or network.github_token, it may have expired")
+		}
+
+		wait, limited := rateLimitWait(resp)
+		if !limited {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		limitErr := &RateLimitError{Reset: time.Now().Add(wait)}
+		if limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil {
+			limitErr.Limit = limit
+		}
+		deadline, hasDeadline := ctx.Deadline()
+		if wait > maxWait || attempt == rateLimitRetries || (hasDeadline && time.Now().Add(wait).After(deadline)) {
+			return nil, d.rateLimitHints(limitErr)
+		}
+
+		ui.PrintWarning("GitHub rate limit reached, retrying in %s...", wait.Round(time.Second))
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, d.rateLimitHints(limitErr)
+		}
+	}
+}
+
+// rateLimitWait tells whether a response was turned down by a rate limit,
+// and how long to wait before trying again. GitHub answers 403 or 429 with
+// Retry-After for its secondary limits and X-RateLimit-Reset once the
+// hourly requests are used up.
+func rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		// A plain 429 still asks to slow down; a plain 403 is a refusal
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return time.Minute, true
+		}
+		return 0, false
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return time.Hour, true
+	}
+	// A second of slack for clocks slightly behind GitHub's
+	return max(time.Until(time.Unix(reset, 0))+time.Second, 0), true
+}
+
+// checkRateLimit warns once when few API requests are left
+func (d *Downloader) checkRateLimit(resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil || remaining == 0 || remaining >= lowRateLimit {
+		return
+	}
+	d.rateLimitMu.Lock()
+	defer d.rateLimitMu.Unlock()
+	if d.rateLimitWarned {
+		return
+	}
+	d.rateLimitWarned = true
+
+	msg := fmt.Sprintf("Only %d GitHub API requests left", remaining)
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		msg += " until " + time.Unix(reset, 0).Local().Format("15:04")
+	}
+	if d.githubToken == "" {
+		msg += fmt.Sprintf("; set GITHUB_TOKEN to raise the limit to %d an hour", authenticatedRateLimit)
+	}
+	ui.PrintWarning("%s", msg)
+}
+
+// rateLimitHints adds what to do about a rate limit to err
+func (d *Downloader) rateLimitHints(err *RateLimitError) error {
+	if d.githubToken != "" {
+		return ui.WithHints(err, "Try again once the limit lifts")
+	}
+	limit := err.Limit
+	if limit == 0 {
+		limit = anonymousRateLimit
+	}
+	return ui.WithHints(err,
+		fmt.Sprintf("Without a token GitHub allows %d API requests per hour; set GITHUB_TOKEN or network.github_token to raise it to %d", limit, authenticatedRateLimit),
+		"Or try again once the limit lifts")
+}
@@ -0,0 +1,135 @@
+package downloader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReleaseURL is the GitHub API endpoint for the latest alacritty-colors
+// release
+const ReleaseURL = GitHubRepoAPI + "vitruves/alacritty-colors/releases/latest"
+
+// ReleaseCacheFile remembers the last version check so it runs at most
+// once a day
+const ReleaseCacheFile = "release.json"
+
+// Release is the latest published version and its release notes
+type Release struct {
+	Version   string    `json:"version"`
+	URL       string    `json:"url"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// LatestRelease asks GitHub for the latest release and records the answer
+// in the release cache
+func (d *Downloader) LatestRelease(timeout time.Duration) (Release, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := d.githubGet(ctx, ReleaseURL, timeout)
+	if err != nil {
+		return Release{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Release{}, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	var info struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return Release{}, fmt.Errorf("failed to parse release: %w", err)
+	}
+
+	release := Release{
+		Version:   strings.TrimPrefix(info.TagName, "v"),
+		URL:       info.HTMLURL,
+		CheckedAt: time.Now(),
+	}
+	d.saveRelease(release)
+	return release, nil
+}
+
+// CachedRelease returns the cached release while it is younger than maxAge,
+// and asks GitHub otherwise. A failed check is cached too, so an offline
+// machine isn't slowed down on every run.
+func (d *Downloader) CachedRelease(maxAge, timeout time.Duration) (Release, error) {
+	cached, err := d.loadRelease()
+	if err == nil && time.Since(cached.CheckedAt) < maxAge {
+		return cached, nil
+	}
+
+	release, err := d.LatestRelease(timeout)
+	if err != nil {
+		cached.CheckedAt = time.Now()
+		d.saveRelease(cached)
+		return cached, err
+	}
+	return release, nil
+}
+
+func (d *Downloader) loadRelease() (Release, error) {
+	var release Release
+	data, err := os.ReadFile(filepath.Join(d.stateDir, ReleaseCacheFile))
+	if err != nil {
+		return release, err
+	}
+	err = json.Unmarshal(data, &release)
+	return release, err
+}
+
+func (d *Downloader) saveRelease(release Release) {
+	data, err := json.MarshalIndent(release, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(d.stateDir, 0755); err != nil {
+		return
+	}
+	os.WriteFile(filepath.Join(d.stateDir, ReleaseCacheFile), data, 0644)
+}
+
+// NewerVersion reports whether latest is a higher dotted version than
+// current, e.g. 1.10.0 over 1.9.2. Pre-release suffixes are ignored.
+func NewerVersion(current, latest string) bool {
+	a, b := versionParts(current), versionParts(latest)
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			return y > x
+		}
+	}
+	return false
+}
+
+func versionParts(version string) []int {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	var parts []int
+	for _, field := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
@@ -0,0 +1,115 @@
+package downloader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+	"github.com/vitruves/alacritty-colors/pkg/alacritty"
+)
+
+// InvalidDir holds downloaded themes that failed validation
+const InvalidDir = ".invalid"
+
+var validHexColor = regexp.MustCompile(`^(#|0x)[0-9a-fA-F]{6}$`)
+
+// requiredColors must be present, as hex values, in every theme
+var requiredColors = []string{
+	"background", "foreground",
+	"normal_black", "normal_red", "normal_green", "normal_yellow",
+	"normal_blue", "normal_magenta", "normal_cyan", "normal_white",
+}
+
+// InvalidTheme is a theme moved to quarantine and the reason why
+type InvalidTheme struct {
+	Name   string
+	Reason string
+}
+
+// ValidateThemeFile checks that a theme parses and defines the required colors
+func ValidateThemeFile(path string) error {
+	parser := alacritty.NewParser()
+	cfg, err := parser.ParseFile(path)
+	if err != nil {
+		return err
+	}
+
+	colors := parser.ExtractColors(cfg)
+	var missing []string
+	for _, key := range requiredColors {
+		value, ok := colors[key]
+		if !ok {
+			missing = append(missing, key)
+			continue
+		}
+		if !validHexColor.MatchString(value) {
+			return fmt.Errorf("invalid color for %s: %s", key, value)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing colors: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// quarantine validates the named themes and moves the broken ones into
+// InvalidDir, returning what was moved
+func (d *Downloader) quarantine(names []string) []InvalidTheme {
+	var invalid []InvalidTheme
+
+	for _, name := range names {
+		path := filepath.Join(d.themesDir, name)
+		err := ValidateThemeFile(path)
+		if err == nil {
+			continue
+		}
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		invalidDir := filepath.Join(d.themesDir, InvalidDir)
+		if mkErr := os.MkdirAll(invalidDir, 0755); mkErr != nil {
+			ui.PrintWarning("Failed to create %s: %v", invalidDir, mkErr)
+			return invalid
+		}
+		if mvErr := os.Rename(path, filepath.Join(invalidDir, name)); mvErr != nil {
+			ui.PrintWarning("Failed to quarantine %s: %v", name, mvErr)
+			continue
+		}
+
+		invalid = append(invalid, InvalidTheme{Name: name, Reason: err.Error()})
+	}
+
+	sort.Slice(invalid, func(i, j int) bool {
+		return invalid[i].Name < invalid[j].Name
+	})
+
+	if len(invalid) > 0 {
+		d.reportInvalid(invalid)
+	}
+
+	return invalid
+}
+
+func (d *Downloader) reportInvalid(invalid []InvalidTheme) {
+	invalidDir := filepath.Join(d.themesDir, InvalidDir)
+
+	ui.PrintWarning("Quarantined %d invalid themes in %s", len(invalid), invalidDir)
+	var report strings.Builder
+	fmt.Fprintf(&report, "# Themes quarantined on %s\n", time.Now().Format("2006-01-02 15:04:05"))
+	for _, theme := range invalid {
+		ui.PrintInfo("  %s: %s", theme.Name, theme.Reason)
+		fmt.Fprintf(&report, "%s: %s\n", theme.Name, theme.Reason)
+	}
+
+	if err := os.WriteFile(filepath.Join(invalidDir, "report.txt"), []byte(report.String()), 0644); err != nil {
+		ui.PrintWarning("Failed to write quarantine report: %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package downloader
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var yamlHexColor = regexp.MustCompile(`^(?:0x|#)([0-9a-fA-F]{6})$`)
+
+// themeFileName returns the name a theme is stored under; legacy YAML
+// themes are converted and saved as TOML
+func themeFileName(path string) string {
+	name := filepath.Base(path)
+	if strings.HasSuffix(name, ".yaml") {
+		return strings.TrimSuffix(name, ".yaml") + ".toml"
+	}
+	return name
+}
+
+type yamlSection struct {
+	name  string
+	lines []string
+}
+
+// convertYAMLTheme converts the colors of a legacy YAML Alacritty theme into
+// the TOML format. Only the colors mapping is kept; lists such as
+// indexed_colors are dropped.
+func convertYAMLTheme(content []byte) ([]byte, error) {
+	type level struct {
+		indent int
+		key    string
+	}
+
+	var header []string
+	var sections []*yamlSection
+	byName := make(map[string]*yamlSection)
+	var stack []level
+	inBody := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		raw := scanner.Text()
+		trimmed := strings.TrimSpace(raw)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			// Keep the leading comment block as the TOML header
+			if !inBody && strings.HasPrefix(trimmed, "#") {
+				header = append(header, trimmed)
+			}
+			continue
+		}
+		inBody = true
+
+		if strings.HasPrefix(trimmed, "-") || trimmed == "---" {
+			continue
+		}
+
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = stripYAMLComment(strings.TrimSpace(value))
+
+		indent := len(raw) - len(strings.TrimLeft(raw, " \t"))
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		if value == "" {
+			stack = append(stack, level{indent: indent, key: key})
+			continue
+		}
+
+		if len(stack) == 0 || stack[0].key != "colors" {
+			continue
+		}
+
+		path := make([]string, len(stack))
+		for i, l := range stack {
+			path[i] = l.key
+		}
+		name := strings.Join(path, ".")
+
+		section, ok := byName[name]
+		if !ok {
+			section = &yamlSection{name: name}
+			byName[name] = section
+			if name == "colors" {
+				// Keys directly under colors must precede its sub-tables
+				sections = append([]*yamlSection{section}, sections...)
+			} else {
+				sections = append(sections, section)
+			}
+		}
+		section.lines = append(section.lines, fmt.Sprintf("%s = %s", key, tomlValue(value)))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("no colors found")
+	}
+
+	var out strings.Builder
+	for _, line := range header {
+		out.WriteString(line + "\n")
+	}
+	for _, section := range sections {
+		if out.Len() > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString("[" + section.name + "]\n")
+		for _, line := range section.lines {
+			out.WriteString(line + "\n")
+		}
+	}
+
+	return []byte(out.String()), nil
+}
+
+func stripYAMLComment(value string) string {
+	if strings.HasPrefix(value, "'") || strings.HasPrefix(value, `"`) {
+		quote := value[:1]
+		if end := strings.Index(value[1:], quote); end >= 0 {
+			return value[:end+2]
+		}
+		return value
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		return strings.TrimSpace(value[:i])
+	}
+	return value
+}
+
+func tomlValue(value string) string {
+	value = strings.Trim(value, `"'`)
+
+	if m := yamlHexColor.FindStringSubmatch(value); m != nil {
+		return "'#" + strings.ToLower(m[1]) + "'"
+	}
+
+	switch value {
+	case "true", "false":
+		return value
+	}
+
+	return "'" + value + "'"
+}
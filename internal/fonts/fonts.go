@@ -0,0 +1,237 @@
+// Package fonts finds out which font families are installed: through
+// fontconfig on Linux and the BSDs, the registry on Windows and the font
+// folders on macOS, falling back to scanning font folders anywhere.
+package fonts
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Generic families are resolved by the system and always count as installed
+var Generic = []string{"monospace", "sans-serif", "serif"}
+
+// styleWords end a font name without being part of its family
+var styleWords = map[string]bool{
+	"regular": true, "bold": true, "italic": true, "oblique": true,
+	"light": true, "medium": true, "thin": true, "black": true, "heavy": true,
+	"semibold": true, "extrabold": true, "extralight": true, "ultralight": true,
+	"demibold": true, "book": true, "retina": true, "vf": true, "variable": true,
+}
+
+// Set is a collection of installed font families
+type Set struct {
+	names      []string
+	normalized map[string]bool
+}
+
+var (
+	once      sync.Once
+	installed *Set
+	detectErr error
+)
+
+// Installed returns the font families on this system. The lookup runs once
+// per process.
+func Installed() (*Set, error) {
+	once.Do(func() {
+		var names []string
+		names, detectErr = detect()
+		if detectErr == nil && len(names) == 0 {
+			detectErr = fmt.Errorf("no fonts found")
+		}
+		installed = newSet(names)
+	})
+	return installed, detectErr
+}
+
+func newSet(names []string) *Set {
+	s := &Set{normalized: make(map[string]bool)}
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		s.names = append(s.names, name)
+		s.normalized[normalize(name)] = true
+	}
+	sort.Slice(s.names, func(i, j int) bool {
+		return strings.ToLower(s.names[i]) < strings.ToLower(s.names[j])
+	})
+	return s
+}
+
+// Families returns the installed family names in alphabetical order
+func (s *Set) Families() []string {
+	return s.names
+}
+
+// Has reports whether a family is installed. Names are compared without
+// case, spaces or dashes, so "JetBrains Mono" matches "JetBrainsMono".
+func (s *Set) Has(family string) bool {
+	for _, g := range Generic {
+		if strings.EqualFold(family, g) {
+			return true
+		}
+	}
+	return s.normalized[normalize(family)]
+}
+
+// FirstInstalled returns the first candidate that is installed
+func (s *Set) FirstInstalled(candidates []string) (string, bool) {
+	for _, c := range candidates {
+		if s.Has(c) {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+func normalize(name string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(name))
+}
+
+func detect() ([]string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		if names, err := fromRegistry(); err == nil && len(names) > 0 {
+			return names, nil
+		}
+	case "darwin":
+		// CoreText needs cgo; the font folders hold the same families
+	default:
+		if names, err := fromFontconfig(); err == nil && len(names) > 0 {
+			return names, nil
+		}
+	}
+	return fromDirectories(fontDirs())
+}
+
+// fromFontconfig lists families with fc-list, one font per line with its
+// family names separated by commas
+func fromFontconfig() ([]string, error) {
+	out, err := exec.Command("fc-list", ":", "family").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		for _, name := range strings.Split(scanner.Text(), ",") {
+			names = append(names, strings.ReplaceAll(name, `\-`, "-"))
+		}
+	}
+	return names, scanner.Err()
+}
+
+// fromRegistry reads the system and per-user font lists, whose value names
+// look like "JetBrains Mono Bold (TrueType)"
+func fromRegistry() ([]string, error) {
+	var names []string
+	var lastErr error
+	for _, key := range []string{
+		`HKLM\SOFTWARE\Microsoft\Windows NT\CurrentVersion\Fonts`,
+		`HKCU\SOFTWARE\Microsoft\Windows NT\CurrentVersion\Fonts`,
+	} {
+		out, err := exec.Command("reg", "query", key).Output()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(out))
+		for scanner.Scan() {
+			value, _, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "    REG_")
+			if !ok {
+				continue
+			}
+			if i := strings.Index(value, " ("); i > 0 {
+				value = value[:i]
+			}
+			for _, face := range strings.Split(value, " & ") {
+				names = append(names, familyFromName(face, " "))
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, lastErr
+	}
+	return names, nil
+}
+
+// fontDirs lists the usual font folders for this system
+func fontDirs() []string {
+	home, _ := os.UserHomeDir()
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"/System/Library/Fonts", "/Library/Fonts", filepath.Join(home, "Library", "Fonts")}
+	case "windows":
+		return []string{
+			filepath.Join(os.Getenv("WINDIR"), "Fonts"),
+			filepath.Join(os.Getenv("LOCALAPPDATA"), "Microsoft", "Windows", "Fonts"),
+		}
+	default:
+		dirs := []string{"/usr/share/fonts", "/usr/local/share/fonts", filepath.Join(home, ".fonts")}
+		if data := os.Getenv("XDG_DATA_HOME"); data != "" {
+			return append(dirs, filepath.Join(data, "fonts"))
+		}
+		return append(dirs, filepath.Join(home, ".local", "share", "fonts"))
+	}
+}
+
+// fromDirectories guesses families from font file names such as
+// "JetBrainsMono-Regular.ttf"
+func fromDirectories(dirs []string) ([]string, error) {
+	var names []string
+	for _, dir := range dirs {
+		filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".ttf", ".otf", ".ttc", ".otc", ".dfont":
+				base := strings.TrimSuffix(d.Name(), filepath.Ext(d.Name()))
+				names = append(names, familyFromName(base, "-"))
+			}
+			return nil
+		})
+	}
+	return names, nil
+}
+
+// familyFromName drops style words from the end of a font name
+func familyFromName(name, separator string) string {
+	if separator == "-" {
+		family, _, _ := strings.Cut(name, "-")
+		return splitWords(family)
+	}
+	words := strings.Fields(name)
+	for len(words) > 1 && styleWords[strings.ToLower(words[len(words)-1])] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+// splitWords spaces out the words file names run together, so
+// "JetBrainsMono" reads "JetBrains Mono"
+func splitWords(name string) string {
+	for _, word := range []string{"Mono", "Code", "Sans", "Serif", "Nerd", "Font"} {
+		for i := 1; i+len(word) <= len(name); i++ {
+			if name[i:i+len(word)] == word && name[i-1] >= 'a' && name[i-1] <= 'z' {
+				name = name[:i] + " " + name[i:]
+				i++
+			}
+		}
+	}
+	return name
+}
@@ -0,0 +1,103 @@
+package fonts
+
+import (
+	"bufio"
+	"bytes"
+	"os/exec"
+	"strings"
+)
+
+// Family describes an installed monospace family
+type Family struct {
+	Name      string `json:"name"`
+	Ligatures bool   `json:"ligatures"`
+	Nerd      bool   `json:"nerd_font"`
+}
+
+// ligatureFamilies are coding fonts known to ship programming ligatures,
+// in normalized form
+var ligatureFamilies = []string{
+	"firacode", "jetbrainsmono", "cascadiacode", "iosevka", "victormono",
+	"monoid", "hasklig", "monaspace", "lilex", "recursivemono", "comicmono",
+	"maplemono", "commitmono", "intonemono", "0xproto",
+}
+
+// monoWords appear in the names of most monospace families
+var monoWords = []string{
+	"mono", "code", "consol", "courier", "terminal", "term", "hack",
+	"inconsolata", "iosevka", "menlo", "monaco", "fixed", "typewriter",
+}
+
+// Monospace returns the installed monospace families. fontconfig reports
+// spacing directly; elsewhere families are recognised by name.
+func Monospace() ([]Family, error) {
+	var names []string
+	if out, err := exec.Command("fc-list", ":spacing=mono", "family").Output(); err == nil {
+		scanner := bufio.NewScanner(bytes.NewReader(out))
+		for scanner.Scan() {
+			// The first name is the family, the others are localized aliases
+			name, _, _ := strings.Cut(scanner.Text(), ",")
+			names = append(names, strings.ReplaceAll(name, `\-`, "-"))
+		}
+	} else {
+		installed, err := Installed()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range installed.Families() {
+			if looksMonospace(name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	var families []Family
+	for _, name := range newSet(names).Families() {
+		families = append(families, Family{
+			Name:      name,
+			Ligatures: hasLigatures(name),
+			Nerd:      isNerdFont(name),
+		})
+	}
+	return families, nil
+}
+
+func looksMonospace(name string) bool {
+	n := normalize(name)
+	for _, word := range monoWords {
+		if strings.Contains(n, word) {
+			return true
+		}
+	}
+	return isNerdFont(name)
+}
+
+func hasLigatures(name string) bool {
+	n := normalize(name)
+	if strings.Contains(n, "liga") {
+		return true
+	}
+	for _, family := range ligatureFamilies {
+		if strings.HasPrefix(n, family) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNerdFont recognises the patched families, named "<Font> Nerd Font" or
+// abbreviated to "<Font> NF", "NFM" and "NFP"
+func isNerdFont(name string) bool {
+	if strings.Contains(strings.ToLower(name), "nerd font") {
+		return true
+	}
+	fields := strings.Fields(name)
+	for i := 1; i < len(fields); i++ {
+		f := fields[i]
+		switch f {
+		case "NF", "NFM", "NFP":
+			return true
+		}
+	}
+	return false
+}
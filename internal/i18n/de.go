@@ -0,0 +1,476 @@
+package i18n
+
+var german = map[string]string{
+	// Prompts
+	"[y/N]: ":                  "[j/N]: ",
+	"Select option (number): ": "Option wählen (Nummer): ",
+	"Invalid choice. Please enter a number between 1 and %d.": "Ungültige Auswahl. Bitte eine Zahl zwischen 1 und %d eingeben.",
+	"Do you want to keep this theme?":                         "Dieses Theme behalten?",
+	"Do you want to keep this font?":                          "Diese Schriftart behalten?",
+	"Restore from '%s'?":                                      "Aus „%s“ wiederherstellen?",
+	"Permanently delete everything in the trash?":             "Den gesamten Papierkorb endgültig leeren?",
+	"What should happen to these themes?":                     "Was soll mit diesen Themes geschehen?",
+	"Yes":                                                     "Ja",
+	"No":                                                      "Nein",
+	"Select backup to restore":                                "Wiederherzustellende Sicherung wählen",
+	"(arrows move, type to filter, Esc cancels)":              "(Pfeiltasten wählen, Tippen filtert, Esc bricht ab)",
+	"No matches":                                              "Keine Treffer",
+	"cancelled":                                               "abgebrochen",
+
+	// Errors and hints
+	"Error: %v":            "Fehler: %v",
+	"Did you mean: %s?":    "Meinten Sie: %s?",
+	"theme '%s' not found": "Theme „%s“ nicht gefunden",
+	"No themes are installed. Run 'alacritty-colors init' to download them.":                  "Es sind keine Themes installiert. Mit „alacritty-colors init“ herunterladen.",
+	"Search installed themes with: %s":                                                        "Installierte Themes durchsuchen mit: %s",
+	"failed to apply theme: %w":                                                               "Theme konnte nicht angewendet werden: %w",
+	"backup file not found: %s":                                                               "Sicherung nicht gefunden: %s",
+	"themes directory not found: %s":                                                          "Theme-Verzeichnis nicht gefunden: %s",
+	"Run 'alacritty-colors init' to create it and download themes,":                           "Mit „alacritty-colors init“ anlegen und Themes herunterladen,",
+	"or point to an existing one with 'alacritty-colors config set-path --themes-dir <dir>'.": "oder ein vorhandenes mit „alacritty-colors config set-path --themes-dir <verz>“ angeben.",
+
+	// Command output
+	"Applying theme: %s":                    "Theme wird angewendet: %s",
+	"Applied theme '%s'":                    "Theme „%s“ angewendet",
+	"Backup created: %s":                    "Sicherung erstellt: %s",
+	"Failed to create backup: %v":           "Sicherung fehlgeschlagen: %v",
+	"Failed to update theme tracking: %v":   "Aktuelles Theme konnte nicht gespeichert werden: %v",
+	"No backups found":                      "Keine Sicherungen gefunden",
+	"No themes found matching '%s'":         "Keine Themes passend zu „%s“ gefunden",
+	"Generated theme saved: %s":             "Erzeugtes Theme gespeichert: %s",
+	"Launching interactive color editor...": "Interaktiver Farbeditor wird gestartet...",
+	"Use 'q' to quit, 's' to save changes":  "„q“ zum Beenden, „s“ zum Speichern",
+	"Alacritty Colors Configuration":        "Alacritty Colors Konfiguration",
+	"Profile":                               "Profil",
+	"Config File":                           "Konfiguration",
+	"Themes Dir":                            "Themes",
+	"Backup Dir":                            "Sicherungen",
+	"Current Theme":                         "Aktives Theme",
+	"Available Themes":                      "Verfügbar",
+	"Backups":                               "Sicherungen",
+	"None":                                  "Keins",
+	"Settings":                              "Einstellungen",
+	"State Dir":                             "Zustand",
+
+	// Interactive editor
+	"Tab: switch panels | ↑↓: navigate | ←→: adjust RGB values | Enter: edit | q: quit | s: save | r: reset": "Tab: Bereich wechseln | ↑↓: bewegen | ←→: RGB anpassen | Enter: bearbeiten | q: beenden | s: speichern | r: zurücksetzen",
+	"Select a theme to start editing": "Theme zum Bearbeiten auswählen",
+	"Primary":                         "Primär",
+	"Cursor":                          "Cursor",
+	"Vi Mode Cursor":                  "Cursor im Vi-Modus",
+	"(default)":                       "(Standard)",
+	"Selection":                       "Auswahl",
+	"Normal":                          "Normal",
+	"Bright":                          "Hell",
+	"Dim":                             "Gedämpft",
+	"Focus: Color Panel | Use arrow keys to navigate, Enter to edit":  "Farbbereich | Pfeiltasten zum Bewegen, Enter zum Bearbeiten",
+	"Focus: Theme List | Use arrow keys to navigate, Enter to select": "Theme-Liste | Pfeiltasten zum Bewegen, Enter zum Auswählen",
+	"You have unsaved changes. Are you sure you want to quit?":        "Es gibt ungespeicherte Änderungen. Wirklich beenden?",
+	"Save & Quit":                    "Speichern & beenden",
+	"Quit":                           "Beenden",
+	"Cancel":                         "Abbrechen",
+	"No theme selected to save":      "Kein Theme zum Speichern ausgewählt",
+	"Error loading themes: %v":       "Fehler beim Laden der Themes: %v",
+	"Error loading theme %s: %v":     "Fehler beim Laden von Theme %s: %v",
+	"Error saving theme: %v":         "Fehler beim Speichern des Themes: %v",
+	"Theme '%s' saved successfully":  "Theme „%s“ gespeichert",
+	"Theme reset to original values": "Theme auf Originalwerte zurückgesetzt",
+
+	// Status messages, labels and hints
+	"\nBright Colors:": "\nHelle Farben:",
+	"\nNormal Colors:": "\nNormale Farben:",
+	"\nYou can now see how the theme looks in your terminal.":                     "\nSie sehen jetzt, wie das Theme in Ihrem Terminal aussieht.",
+	"\n⌨️  Controls: SPACE=select | n/p=nav | q=quit | +/-=speed":                 "\n⌨️  Steuerung: LEERTASTE=wählen | n/p=blättern | q=beenden | +/-=Tempo",
+	"\n⌨️  Controls: a/b or TAB=switch | SPACE/ENTER=pick the one shown | q=quit": "\n⌨️  Steuerung: a/b oder TAB=wechseln | LEERTASTE/ENTER=angezeigtes wählen | q=beenden",
+	"    Created: %s":                               "    Erstellt: %s",
+	"    Description: %s":                           "    Beschreibung: %s",
+	"    Effects: %s":                               "    Effekte: %s",
+	"    Made with: %s":                             "    Erstellt mit: %s",
+	"    Theme: %s":                                 "    Theme: %s",
+	"  +/-         - Increase/decrease speed":       "  +/-         - Schneller/langsamer",
+	"  SPACE/ENTER - Select current theme and exit": "  LEERTASTE/ENTER - Aktuelles Theme wählen und beenden",
+	"  Save it with 'alacritty-colors theme adopt <name>' or reset it with 'alacritty-colors apply %s --force'": "  Mit „alacritty-colors theme adopt <name>“ speichern oder mit „alacritty-colors apply %s --force“ zurücksetzen",
+	"  n/RIGHT     - Next theme immediately":                                                               "  n/RECHTS    - Sofort nächstes Theme",
+	"  p/LEFT      - Previous theme":                                                                       "  p/LINKS     - Vorheriges Theme",
+	"  q/ESC       - Quit without applying":                                                                "  q/ESC       - Beenden, ohne anzuwenden",
+	"  r           - Restart slideshow":                                                                    "  r           - Diashow neu starten",
+	"%d Alacritty instances are running; using %s (pick another with --socket)":                            "%d Alacritty-Instanzen laufen; %s wird verwendet (eine andere mit --socket wählen)",
+	"%d instance(s) did not pick up the theme; they may use another config file (alacritty --config-file)": "%d Instanz(en) haben das Theme nicht übernommen; sie verwenden vielleicht eine andere Konfigurationsdatei (alacritty --config-file)",
+	"%d instance(s) still show the previous theme because live reload is off":                              "%d Instanz(en) zeigen noch das vorherige Theme, weil Live-Reload aus ist",
+	"%d of %d color settings come from the theme; the rest are Alacritty defaults":                         "%d von %d Farbeinstellungen stammen aus dem Theme; der Rest sind Alacritty-Standardwerte",
+	"%d smaller families not shown":                                                                        "%d kleinere Familien nicht angezeigt",
+	"%s has the same normal and bright %s, so bold text in them looks regular":                             "%s hat dasselbe normale und helle %s, daher wirkt fetter Text darin normal",
+	"A newer release is available":                                                                         "Eine neuere Version ist verfügbar",
+	"Add --animate to cycle through them in a GIF.":                                                        "Mit --animate werden sie in einem GIF durchlaufen.",
+	"Add --schedule daily.":                                                                                "--schedule daily hinzufügen.",
+	"Add <theme>.toml, or a glob such as gruvbox*.toml, to %s":                                             "<theme>.toml oder ein Muster wie gruvbox*.toml zu %s hinzufügen",
+	"Add one with: alacritty-colors exclude add <theme|pattern>":                                           "Eines hinzufügen mit: alacritty-colors exclude add <theme|muster>",
+	"Add one with: alacritty-colors protect add <theme|pattern>":                                           "Eines hinzufügen mit: alacritty-colors protect add <theme|muster>",
+	"Add targets to [sync] in %s or name one, e.g. 'alacritty-colors sync tmux'":                           "Ziele unter [sync] in %s eintragen oder eines nennen, z. B. „alacritty-colors sync tmux“",
+	"Add your own as <name>%s in %s":                                                                       "Eigene als <name>%s in %s anlegen",
+	"Added import line to %s":                                                                              "Import-Zeile zu %s hinzugefügt",
+	"Added profile %s":                                                                                     "Profil %s hinzugefügt",
+	"Added themes directory: %s":                                                                           "Theme-Verzeichnis hinzugefügt: %s",
+	"Alacritty Colors Paths":                                                                               "Pfade von Alacritty Colors",
+	"All %d theme(s) are valid":                                                                            "Alle %d Theme(s) sind gültig",
+	"Already excluded":                                                                                     "Bereits ausgeschlossen",
+	"Already protected":                                                                                    "Bereits geschützt",
+	"Already searched: %s":                                                                                 "Bereits durchsucht: %s",
+	"Also Searched":                                                                                        "Ebenfalls durchsucht",
+	"Applied '%s' to this terminal":                                                                        "„%s“ auf dieses Terminal angewendet",
+	"Applied theme '%s' to %s":                                                                             "Theme „%s“ auf %s angewendet",
+	"Applied theme: %s":                                                                                    "Theme angewendet: %s",
+	"Apply a theme anyway with: alacritty-colors apply <theme> --force":                                    "Trotzdem ein Theme anwenden mit: alacritty-colors apply <theme> --force",
+	"Apply a theme first: alacritty-colors apply <theme>":                                                  "Zuerst ein Theme anwenden: alacritty-colors apply <theme>",
+	"Apply it with: alacritty-colors apply %s":                                                             "Anwenden mit: alacritty-colors apply %s",
+	"Apply one first: alacritty-colors apply <theme>":                                                      "Zuerst eines anwenden: alacritty-colors apply <theme>",
+	"Apply the best match: alacritty-colors apply %s":                                                      "Besten Treffer anwenden: alacritty-colors apply %s",
+	"Apply themes as usual, then save the session with: alacritty-colors record stop":                      "Themes wie gewohnt anwenden, dann die Sitzung speichern mit: alacritty-colors record stop",
+	"Applying theme %s to %s":                                                                              "Theme %s wird auf %s angewendet",
+	"Archived %d themes to %s":                                                                             "%d Themes in %s archiviert",
+	"Author":                                                                                               "Autor",
+	"Author: %s":                                                                                           "Autor: %s",
+	"Auto-applying theme: %s":                                                                              "Theme wird automatisch angewendet: %s",
+	"Available Backups":                                                                                    "Verfügbare Sicherungen",
+	"Available themes":                                                                                     "Verfügbare Themes",
+	"Background Hue and Lightness":                                                                         "Farbton und Helligkeit des Hintergrunds",
+	"Backups directory: %s":                                                                                "Sicherungsverzeichnis: %s",
+	"Benchmark":                                                                                            "Leistungsmessung",
+	"Bright colors":                                                                                        "Helle Farben",
+	"Built":                                                                                                "Erstellt",
+	"CA Certs":                                                                                             "CA-Zertifikate",
+	"Calibration":                                                                                          "Kalibrierung",
+	"Cancelled":                                                                                            "Abgebrochen",
+	"Champion":                                                                                             "Sieger",
+	"Changelog":                                                                                            "Änderungen",
+	"Check the token in GITHUB_TOKEN or network.github_token, it may have expired": "Token in GITHUB_TOKEN oder network.github_token prüfen, er ist vielleicht abgelaufen",
+	"Checking for theme updates...":                                     "Suche nach Theme-Aktualisierungen...",
+	"Choose another name with --name, or pass --force to overwrite it.": "Mit --name einen anderen Namen wählen oder mit --force überschreiben.",
+	"Choose another name, or pass --force to overwrite it.":             "Einen anderen Namen wählen oder mit --force überschreiben.",
+	"Choose another name: alacritty-colors theme adopt <name>":          "Einen anderen Namen wählen: alacritty-colors theme adopt <name>",
+	"Chroma": "Buntheit",
+	"Cleaned up %d backup files, kept %d most recent":   "%d Sicherungen bereinigt, die %d neuesten behalten",
+	"Cleaned up %d theme files":                         "%d Theme-Dateien bereinigt",
+	"Cleaning Backup Files":                             "Sicherungen werden bereinigt",
+	"Cleaning Theme Files":                              "Theme-Dateien werden bereinigt",
+	"Cleaning up themes older than %d days":             "Themes älter als %d Tage werden bereinigt",
+	"Color Palette":                                     "Farbpalette",
+	"Colors adjusted: %s (the theme file is unchanged)": "Farben angepasst: %s (die Theme-Datei bleibt unverändert)",
+	"Commit":          "Commit",
+	"Config Profiles": "Konfigurationsprofile",
+	"Config file: %s": "Konfigurationsdatei: %s",
+	"Configuration and colors restored from backup":                "Konfiguration und Farben aus der Sicherung wiederhergestellt",
+	"Configuration and theme '%s' restored from backup":            "Konfiguration und Theme „%s“ aus der Sicherung wiederhergestellt",
+	"Configuration complete":                                       "Konfiguration abgeschlossen",
+	"Configuration paths updated successfully":                     "Konfigurationspfade aktualisiert",
+	"Configuration restored from backup":                           "Konfiguration aus der Sicherung wiederhergestellt",
+	"Configuration restored from backup (colors left as they are)": "Konfiguration aus der Sicherung wiederhergestellt (Farben unverändert)",
+	"Contrast":  "Kontrast",
+	"Controls:": "Steuerung:",
+	"Converted %s from UTF-16 to UTF-8, which Alacritty requires (original kept as %s.utf16.bak)": "%s von UTF-16 nach UTF-8 umgewandelt, wie Alacritty es verlangt (Original als %s.utf16.bak behalten)",
+	"Create it first, or check the path given to --to.":                                           "Zuerst anlegen oder den Pfad bei --to prüfen.",
+	"Create one with 'alacritty-colors backup'":                                                   "Eine mit „alacritty-colors backup“ anlegen",
+	"Current":           "Aktuell",
+	"Current theme":     "Aktuelles Theme",
+	"Current theme: %s": "Aktuelles Theme: %s",
+	"Cycling through %d themes with %v intervals":                                       "%d Themes werden im Abstand von %v durchlaufen",
+	"Define it in the settings file, e.g. collections.cozy = [\"gruvbox*\", \"nord\"]":  "In der Einstellungsdatei festlegen, z. B. collections.cozy = [\"gruvbox*\", \"nord\"]",
+	"Deleted %d files from the trash":                                                   "%d Dateien aus dem Papierkorb gelöscht",
+	"Deleted %d themes (recoverable for %d days with 'alacritty-colors trash restore')": "%d Themes gelöscht (%d Tage lang mit „alacritty-colors trash restore“ wiederherstellbar)",
+	"Deployed %s with %s": "%s mit %s bereitgestellt",
+	"Deployed To":         "Bereitgestellt nach",
+	"Description: %s":     "Beschreibung: %s",
+	"Dominant hue":        "Vorherrschender Farbton",
+	"Download themes with 'alacritty-colors update'.":             "Themes mit „alacritty-colors update“ herunterladen.",
+	"Downloaded theme: %s":                                        "Theme heruntergeladen: %s",
+	"Downloading from official repository and %d more sources...": "Download aus dem offiziellen Repository und %d weiteren Quellen...",
+	"Downloading from official repository...":                     "Download aus dem offiziellen Repository...",
+	"Downloading from source '%s'...":                             "Download aus der Quelle „%s“...",
+	"Downloading theme from %s":                                   "Theme wird von %s heruntergeladen",
+	"Drop --socket to read the config files instead.":             "--socket weglassen, um stattdessen die Konfigurationsdateien zu lesen.",
+	"Dry run: '%s' was not saved":                                 "Probelauf: „%s“ wurde nicht gespeichert",
+	"Effective Settings":                                          "Wirksame Einstellungen",
+	"Excluded Themes":                                             "Ausgeschlossene Themes",
+	"Excluded from random and slideshow: %s":                      "Von Zufall und Diashow ausgeschlossen: %s",
+	"Exported %d theme(s) for %s to %s":                           "%d Theme(s) für %s nach %s exportiert",
+	"Exported '%s' for %s to %s":                                  "„%s“ für %s nach %s exportiert",
+	"Exported the %s schema to %s":                                "%s-Schema nach %s exportiert",
+	"Exported the colors of '%s' to %s":                           "Farben von „%s“ nach %s exportiert",
+	"Failed to apply scheduled theme: %v":                         "Geplantes Theme konnte nicht angewendet werden: %v",
+	"Failed to apply theme: %v":                                   "Theme konnte nicht angewendet werden: %v",
+	"Failed to apply visual effects: %v":                          "Visuelle Effekte konnten nicht angewendet werden: %v",
+	"Failed to apply workspace theme: %v":                         "Arbeitsbereich-Theme konnte nicht angewendet werden: %v",
+	"Failed to back up current.toml: %v":                          "current.toml konnte nicht gesichert werden: %v",
+	"Failed to create %s: %v":                                     "%s konnte nicht angelegt werden: %v",
+	"Failed to download source '%s': %v":                          "Quelle „%s“ konnte nicht heruntergeladen werden: %v",
+	"Failed to download themes: %v":                               "Themes konnten nicht heruntergeladen werden: %v",
+	"Failed to export %s: %v":                                     "%s konnte nicht exportiert werden: %v",
+	"Failed to index theme metadata: %v":                          "Theme-Metadaten konnten nicht indiziert werden: %v",
+	"Failed to parse theme %s: %v":                                "Theme %s konnte nicht gelesen werden: %v",
+	"Failed to prune %s: %v":                                      "%s konnte nicht bereinigt werden: %v",
+	"Failed to quarantine %s: %v":                                 "%s konnte nicht in Quarantäne verschoben werden: %v",
+	"Failed to read %s: %v":                                       "%s konnte nicht gelesen werden: %v",
+	"Failed to read theme colors for notifications: %v":           "Theme-Farben für Benachrichtigungen konnten nicht gelesen werden: %v",
+	"Failed to reload settings: %v":                               "Einstellungen konnten nicht neu geladen werden: %v",
+	"Failed to remove %s: %v":                                     "%s konnte nicht entfernt werden: %v",
+	"Failed to remove old backup %s: %v":                          "Alte Sicherung %s konnte nicht entfernt werden: %v",
+	"Failed to reset settings: %v":                                "Einstellungen konnten nicht zurückgesetzt werden: %v",
+	"Failed to restore %s: %v":                                    "%s konnte nicht wiederhergestellt werden: %v",
+	"Failed to restore '%s': %v":                                  "„%s“ konnte nicht wiederhergestellt werden: %v",
+	"Failed to restore original theme: %v":                        "Ursprüngliches Theme konnte nicht wiederhergestellt werden: %v",
+	"Failed to restore previous theme: %v":                        "Vorheriges Theme konnte nicht wiederhergestellt werden: %v",
+	"Failed to set font: %v":                                      "Schriftart konnte nicht gesetzt werden: %v",
+	"Failed to sync %s: %v":                                       "%s konnte nicht synchronisiert werden: %v",
+	"Failed to update manifest: %v":                               "Manifest konnte nicht aktualisiert werden: %v",
+	"Failed to write quarantine report: %v":                       "Quarantänebericht konnte nicht geschrieben werden: %v",
+	"Fetch more themes with 'alacritty-colors update', or recreate it with 'alacritty-colors generate --from-screenshot'": "Mehr Themes mit „alacritty-colors update“ holen oder es mit „alacritty-colors generate --from-screenshot“ neu erzeugen",
+	"File": "Datei",
+	"Font '%s' doesn't seem to be installed; Alacritty will fall back to its default": "Schriftart „%s“ scheint nicht installiert zu sein; Alacritty nimmt seine Standardschrift",
+	"Font Pairings":                                      "Schriftart-Paarungen",
+	"Font set to %s":                                     "Schriftart auf %s gesetzt",
+	"Force update: removing existing themes":             "Erzwungene Aktualisierung: vorhandene Themes werden entfernt",
+	"Froze %d colors from %s as theme '%s' (%s)":         "%d Farben aus %s als Theme „%s“ festgehalten (%s)",
+	"Generating %s theme":                                "%s-Theme wird erzeugt",
+	"GitHub Token":                                       "GitHub-Token",
+	"GitHub rate limit reached, retrying in %s...":       "GitHub-Anfragelimit erreicht, neuer Versuch in %s...",
+	"Give a query, or a color with --color \"#rrggbb\".": "Eine Suche angeben oder eine Farbe mit --color \"#rrggbb\".",
+	"Globs use * and ?, e.g. 'gruvbox*'; regular expressions go between slashes, e.g. '/^nord|_night$/'.": "Muster verwenden * und ?, z. B. „gruvbox*“; reguläre Ausdrücke stehen zwischen Schrägstrichen, z. B. „/^nord|_night$/“.",
+	"If Alacritty reports renamed options, 'alacritty migrate' updates the restored config":               "Meldet Alacritty umbenannte Optionen, aktualisiert „alacritty migrate“ die wiederhergestellte Konfiguration",
+	"If Alacritty reports unknown or renamed options, run 'alacritty migrate' on the restored config":     "Meldet Alacritty unbekannte oder umbenannte Optionen, „alacritty migrate“ auf die wiederhergestellte Konfiguration anwenden",
+	"Initializing with verbose output enabled":                                                            "Initialisierung mit ausführlicher Ausgabe",
+	"Installed the %s service": "Dienst %s installiert",
+	"Iterations":               "Durchläufe",
+	"Keep the changes as a new theme with 'alacritty-colors theme adopt <name>',": "Änderungen als neues Theme behalten mit „alacritty-colors theme adopt <name>“,",
+	"Keeping %d most recent backups":                                              "Die %d neuesten Sicherungen werden behalten",
+	"Keeping %d protected themes: %s":                                             "%d geschützte Themes werden behalten: %s",
+	"Keeping %s: it is protected":                                                 "%s bleibt: es ist geschützt",
+	"Keeping %s: it is the applied theme":                                         "%s bleibt: es ist das angewendete Theme",
+	"Keeping current theme %s although it was removed upstream":                   "Aktuelles Theme %s bleibt, obwohl es upstream entfernt wurde",
+	"Keeping protected theme %s although it was removed upstream":                 "Geschütztes Theme %s bleibt, obwohl es upstream entfernt wurde",
+	"Keeping theme '%s'":                                                          "Theme „%s“ wird behalten",
+	"Kept %d locally modified or protected themes: %s":                            "%d lokal geänderte oder geschützte Themes behalten: %s",
+	"Kept %d protected themes":                                                    "%d geschützte Themes behalten",
+	"Kept %d themes":                                                              "%d Themes behalten",
+	"Latest":                                                                      "Neueste",
+	"Lightness":                                                                   "Helligkeit",
+	"List backups with 'alacritty-colors restore --list'":                         "Sicherungen auflisten mit „alacritty-colors restore --list“",
+	"List backups with 'alacritty-colors restore --list', or run 'alacritty-colors restore' to pick one.": "Sicherungen auflisten mit „alacritty-colors restore --list“ oder mit „alacritty-colors restore“ eine auswählen.",
+	"List fonts with: alacritty-colors fonts list":                                                        "Schriftarten auflisten mit: alacritty-colors fonts list",
+	"List the trash with: alacritty-colors trash list":                                                    "Papierkorb auflisten mit: alacritty-colors trash list",
+	"Live Reload": "Live-Reload",
+	"Live reload": "Live-Reload",
+	"Local Theme": "Lokales Theme",
+	"Locked":      "Gesperrt",
+	"Loosen the filters, or apply the theme directly.": "Filter lockern oder das Theme direkt anwenden.",
+	"Matches":      "Treffer",
+	"Most Applied": "Am häufigsten angewendet",
+	"Move or rename it first, then restore again":                                    "Zuerst verschieben oder umbenennen, dann erneut wiederherstellen",
+	"Moved %d old backups to the trash (backup.keep = %d, backup.max_age_days = %d)": "%d alte Sicherungen in den Papierkorb verschoben (backup.keep = %d, backup.max_age_days = %d)",
+	"Name a theme: alacritty-colors export alacritty-snippet <theme>":                "Ein Theme angeben: alacritty-colors export alacritty-snippet <theme>",
+	"Name it with: alacritty-colors watch-workspaces --wm <name>":                    "Angeben mit: alacritty-colors watch-workspaces --wm <name>",
+	"Name one or more themes, or pick a collection with --collection.":               "Ein oder mehrere Themes angeben oder mit --collection eine Sammlung wählen.",
+	"Nearest family": "Nächste Familie",
+	"No Alacritty named pipes found; Alacritty on Windows may not offer IPC, so only live reload applies themes to open windows": "Keine Named Pipes von Alacritty gefunden; unter Windows bietet Alacritty eventuell kein IPC, daher wendet nur Live-Reload Themes auf offene Fenster an",
+	"No answer within %s":                                             "Keine Antwort innerhalb von %s",
+	"No backup files found":                                           "Keine Sicherungsdateien gefunden",
+	"No backups to clean up (found %d, keeping %d)":                   "Keine Sicherungen zu bereinigen (%d gefunden, %d werden behalten)",
+	"No close match; the theme may not be installed":                  "Kein ähnlicher Treffer; das Theme ist vielleicht nicht installiert",
+	"No installed theme named '%s'":                                   "Kein installiertes Theme namens „%s“",
+	"No local theme for %s":                                           "Kein lokales Theme für %s",
+	"No longer excluded: %s":                                          "Nicht mehr ausgeschlossen: %s",
+	"No longer protected: %s":                                         "Nicht mehr geschützt: %s",
+	"No matching monospace fonts found":                               "Keine passenden Festbreitenschriften gefunden",
+	"No old themes to remove":                                         "Keine alten Themes zu entfernen",
+	"No overrides yet":                                                "Noch keine Überschreibungen",
+	"No pairs found. Define some under [pairs] in the settings file.": "Keine Paare gefunden. Unter [pairs] in der Einstellungsdatei festlegen.",
+	"No running Alacritty instances found (set ALACRITTY_SOCKET if yours uses another path)": "Keine laufenden Alacritty-Instanzen gefunden (ALACRITTY_SOCKET setzen, falls Ihre einen anderen Pfad nutzt)",
+	"No service installed":                                                     "Kein Dienst installiert",
+	"No sync targets configured":                                               "Keine Synchronisationsziele eingerichtet",
+	"No theme currently applied":                                               "Derzeit ist kein Theme angewendet",
+	"No themes are excluded":                                                   "Keine Themes ausgeschlossen",
+	"No themes are protected":                                                  "Keine Themes geschützt",
+	"No themes found":                                                          "Keine Themes gefunden",
+	"No themes have a color within %.1f of %s":                                 "Kein Theme hat eine Farbe innerhalb von %.1f um %s",
+	"No themes installed to compare against":                                   "Keine installierten Themes zum Vergleich",
+	"No themes to analyze":                                                     "Keine Themes zu analysieren",
+	"No themes to remove":                                                      "Keine Themes zu entfernen",
+	"No window, font or color settings to reset":                               "Keine Fenster-, Schrift- oder Farbeinstellungen zurückzusetzen",
+	"None of %s is installed; using the generic monospace font":                "Keine von %s ist installiert; die allgemeine Festbreitenschrift wird verwendet",
+	"Not found in the screenshot, derived instead: %s":                         "Nicht im Screenshot gefunden, stattdessen abgeleitet: %s",
+	"Notification failed: %s":                                                  "Benachrichtigung fehlgeschlagen: %s",
+	"Notifications not sent: %v":                                               "Benachrichtigungen nicht gesendet: %v",
+	"On Linux, install fontconfig so 'fc-list' is available":                   "Unter Linux fontconfig installieren, damit „fc-list“ verfügbar ist",
+	"Or try again once the limit lifts":                                        "Oder nach Ablauf des Limits erneut versuchen",
+	"Original theme restored":                                                  "Ursprüngliches Theme wiederhergestellt",
+	"Override them in the [font_pairings] table of %s":                         "In der Tabelle [font_pairings] von %s überschreiben",
+	"Overrides are merged into current.toml when a theme is applied":           "Überschreibungen werden beim Anwenden eines Themes in current.toml übernommen",
+	"Pass --rotate-accents or --shuffle-accents.":                              "--rotate-accents oder --shuffle-accents angeben.",
+	"Pass a screenshot: alacritty-colors identify shot.png":                    "Einen Screenshot angeben: alacritty-colors identify shot.png",
+	"Pass one with --output, e.g. -o ~/.local/share/konsole/":                  "Eines mit --output angeben, z. B. -o ~/.local/share/konsole/",
+	"Preview with escape sequences in this terminal instead?":                  "Stattdessen mit Escape-Sequenzen in diesem Terminal anzeigen?",
+	"Preview with escape sequences instead: alacritty-colors preview %s --osc": "Stattdessen mit Escape-Sequenzen anzeigen: alacritty-colors preview %s --osc",
+	"Previous font restored":                                                   "Vorherige Schriftart wiederhergestellt",
+	"Previous theme restored":                                                  "Vorheriges Theme wiederhergestellt",
+	"Protected Themes":                                                         "Geschützte Themes",
+	"Protected from updates and clean-up: %s":                                  "Vor Aktualisierung und Bereinigung geschützt: %s",
+	"Proxy":                               "Proxy",
+	"Quality":                             "Qualität",
+	"Quarantined %d invalid themes in %s": "%d ungültige Themes in %s unter Quarantäne gestellt",
+	"Recorded %d theme change(s) over %s to %s":                                    "%d Theme-Wechsel über %s in %s aufgezeichnet",
+	"Recording theme changes":                                                      "Theme-Wechsel werden aufgezeichnet",
+	"Releases are listed at https://github.com/vitruves/alacritty-colors/releases": "Versionen sind unter https://github.com/vitruves/alacritty-colors/releases aufgeführt",
+	"Reload command failed: %v %s":                                                 "Neulade-Befehl fehlgeschlagen: %v %s",
+	"Remove the lock with: alacritty-colors unlock":                                "Sperre aufheben mit: alacritty-colors unlock",
+	"Removed %d old theme files":                                                   "%d alte Theme-Dateien entfernt",
+	"Removed %d theme(s)":                                                          "%d Theme(s) entfernt",
+	"Removed %s":                                                                   "%s entfernt",
+	"Removed files stay in the trash for %d days: alacritty-colors trash list":     "Entfernte Dateien bleiben %d Tage im Papierkorb: alacritty-colors trash list",
+	"Removed local theme from %s":                                                  "Lokales Theme aus %s entfernt",
+	"Rendered %d themes to %s":                                                     "%d Themes nach %s gerendert",
+	"Rendered %s to %s":                                                            "%s nach %s gerendert",
+	"Rendered template '%s' for '%s' to %s":                                        "Vorlage „%s“ für „%s“ nach %s gerendert",
+	"Replaced %s":                                                                  "%s ersetzt",
+	"Replay it with: alacritty-colors replay %s":                                   "Wiedergeben mit: alacritty-colors replay %s",
+	"Replayed %d theme change(s)":                                                  "%d Theme-Wechsel wiedergegeben",
+	"Reset %s":                                                                     "%s zurückgesetzt",
+	"Reset this terminal's colors":                                                 "Farben dieses Terminals zurückgesetzt",
+	"Reset to default theme":                                                       "Auf Standard-Theme zurückgesetzt",
+	"Restore cancelled":                                                            "Wiederherstellung abgebrochen",
+	"Restore with: alacritty-colors trash restore <id|name>":                       "Wiederherstellen mit: alacritty-colors trash restore <id|name>",
+	"Restored %s to %s":                                                            "%s nach %s wiederhergestellt",
+	"Restoring from backup: %s":                                                    "Wiederherstellung aus der Sicherung: %s",
+	"Restoring original theme...":                                                  "Ursprüngliches Theme wird wiederhergestellt...",
+	"Restoring previous theme...":                                                  "Vorheriges Theme wird wiederhergestellt...",
+	"Restoring slot: %s":                                                           "Platz wird wiederhergestellt: %s",
+	"Return to it with: alacritty-colors restore --slot %s":                        "Zurückkehren mit: alacritty-colors restore --slot %s",
+	"Reverted to theme '%s'":                                                       "Zurück zum Theme „%s“",
+	"Run 'alacritty-colors --version --check'.":                                    "„alacritty-colors --version --check“ ausführen.",
+	"Run 'alacritty-colors init' to download themes":                               "Mit „alacritty-colors init“ Themes herunterladen",
+	"Run 'alacritty-colors update --incremental' to fetch these changes":           "Mit „alacritty-colors update --incremental“ diese Änderungen holen",
+	"Run 'alacritty-colors update' first.":                                         "Zuerst „alacritty-colors update“ ausführen.",
+	"Run 'alacritty-colors update' once online to get the full collection":         "Sobald online, „alacritty-colors update“ ausführen, um die ganze Sammlung zu erhalten",
+	"Run 'alacritty-colors update' to download themes":                             "Mit „alacritty-colors update“ Themes herunterladen",
+	"Run it in a terminal, without --yes or --no-input.":                           "In einem Terminal ohne --yes oder --no-input ausführen.",
+	"Runner-up":  "Zweiter",
+	"Saturation": "Sättigung",
+	"Save Dir":   "Speicherverzeichnis",
+	"Save it with: alacritty-colors record stop":                        "Speichern mit: alacritty-colors record stop",
+	"Saved current.toml as theme '%s' (%s)":                             "current.toml als Theme „%s“ gespeichert (%s)",
+	"Saved harmonized theme '%s' (%s)":                                  "Harmonisiertes Theme „%s“ gespeichert (%s)",
+	"Saved slot '%s'":                                                   "Platz „%s“ gespeichert",
+	"Schedule changed: light at %s, dark at %s":                         "Zeitplan geändert: hell um %s, dunkel um %s",
+	"Scheduler disabled in settings; waiting until it is enabled again": "Zeitplaner in den Einstellungen deaktiviert; warte, bis er wieder aktiviert wird",
+	"Scheduler is disabled; set scheduler.enabled = true in %s":         "Der Zeitplaner ist deaktiviert; scheduler.enabled = true in %s setzen",
+	"Scheduler running: light at %s, dark at %s":                        "Zeitplaner läuft: hell um %s, dunkel um %s",
+	"Scheduler stopped":                                                 "Zeitplaner angehalten",
+	"Seasonal palette for %s: %s":                                       "Jahreszeiten-Palette für %s: %s",
+	"Selected random theme: %s":                                         "Zufällig gewähltes Theme: %s",
+	"Selected theme: %s":                                                "Gewähltes Theme: %s",
+	"Service uninstalled":                                               "Dienst deinstalliert",
+	"Set %s = %s":                                                       "%s = %s gesetzt",
+	"Set In":                                                            "Gesetzt in",
+	"Set apply.synthesize_brights = true in %s to give them a bright shade on apply": "apply.synthesize_brights = true in %s setzen, um ihnen beim Anwenden einen hellen Ton zu geben",
+	"Set live_config_reload = true under [general], or open a new window":            "live_config_reload = true unter [general] setzen oder ein neues Fenster öffnen",
+	"Setting Custom Paths":     "Eigene Pfade festlegen",
+	"Setting up configuration": "Konfiguration wird eingerichtet",
+	"Show the files and directories in use with: alacritty-colors config show --paths": "Verwendete Dateien und Verzeichnisse anzeigen mit: alacritty-colors config show --paths",
+	"Showcase":        "Schaukasten",
+	"Skipping %s: %v": "%s übersprungen: %v",
+	"Slideshow complete. Press SPACE to keep current theme or q to restore original.": "Diashow beendet. LEERTASTE behält das aktuelle Theme, q stellt das ursprüngliche wieder her.",
+	"Slots":                          "Plätze",
+	"Slowest Theme Files":            "Langsamste Theme-Dateien",
+	"Source":                         "Quelle",
+	"Speed decreased - interval: %v": "Tempo verringert - Intervall: %v",
+	"Speed increased - interval: %v": "Tempo erhöht - Intervall: %v",
+	"Start with: alacritty-colors record start": "Starten mit: alacritty-colors record start",
+	"Status": "Status",
+	"Stopped syncing after %d of %d themes: %v": "Synchronisation nach %d von %d Themes abgebrochen: %v",
+	"Sync %s: %v":                         "Synchronisation von %s: %v",
+	"Sync Targets":                        "Synchronisationsziele",
+	"Synced %d new and %d updated themes": "%d neue und %d aktualisierte Themes synchronisiert",
+	"Synced %s":                           "%s synchronisiert",
+	"Syncing with official repository...": "Synchronisation mit dem offiziellen Repository...",
+	"Tags":                                "Schlagwörter",
+	"Temperature":                         "Temperatur",
+	"Templates":                           "Vorlagen",
+	"Test it by running some commands or checking your editor.":                                "Testen Sie es mit ein paar Befehlen oder in Ihrem Editor.",
+	"The alacritty command is not on PATH, so running windows can't be checked":                "Der Befehl alacritty ist nicht im PATH, daher können laufende Fenster nicht geprüft werden",
+	"The font is now temporarily applied to your terminal!":                                    "Die Schriftart ist jetzt vorübergehend in Ihrem Terminal aktiv!",
+	"The only schedule is daily: alacritty-colors random --schedule daily":                     "Der einzige Zeitplan ist täglich: alacritty-colors random --schedule daily",
+	"The preview may not show in this terminal:":                                               "Die Vorschau wird in diesem Terminal eventuell nicht angezeigt:",
+	"The scheduler is not fully configured yet; see 'alacritty-colors schedule --help'":        "Der Zeitplaner ist noch nicht vollständig eingerichtet; siehe „alacritty-colors schedule --help“",
+	"The scheduler, daemon, slideshows and random won't change it; 'apply --force' still does": "Zeitplaner, Daemon, Diashows und random ändern es nicht; „apply --force“ schon",
+	"The theme is now temporarily applied to your terminal!":                                   "Das Theme ist jetzt vorübergehend in Ihrem Terminal aktiv!",
+	"The trash is empty":                      "Der Papierkorb ist leer",
+	"Theme Collection Stats":                  "Statistik der Theme-Sammlung",
+	"Theme Colors":                            "Theme-Farben",
+	"Theme Schedule":                          "Theme-Zeitplan",
+	"Theme Sources":                           "Theme-Quellen",
+	"Theme changes are not locked":            "Theme-Wechsel sind nicht gesperrt",
+	"Theme changes unlocked":                  "Theme-Wechsel entsperrt",
+	"Theme for %s set to '%s'":                "Theme für %s auf „%s“ gesetzt",
+	"Theme of the day for %s: %s":             "Theme des Tages für %s: %s",
+	"Theme of the day is already applied: %s": "Das Theme des Tages ist bereits angewendet: %s",
+	"Themes":                               "Themes",
+	"Themes are up to date (%d unchanged)": "Themes sind aktuell (%d unverändert)",
+	"Themes directory: %s":                 "Theme-Verzeichnis: %s",
+	"This backup has no current.toml; the colors were left as they are":                                             "Diese Sicherung enthält keine current.toml; die Farben bleiben unverändert",
+	"This backup was made with Alacritty %s, before configs moved from YAML to TOML in 0.13; you have %s":           "Diese Sicherung stammt von Alacritty %s, vor dem Wechsel von YAML zu TOML in 0.13; Sie haben %s",
+	"This backup was made with Alacritty %s, newer than your %s; some of its options may not exist in your version": "Diese Sicherung stammt von Alacritty %s, neuer als Ihre %s; manche Optionen gibt es in Ihrer Version eventuell nicht",
+	"This backup was made with Alacritty %s; you have %s":                                                           "Diese Sicherung stammt von Alacritty %s; Sie haben %s",
+	"Title": "Titel",
+	"Tournament abandoned, restoring original theme...":                   "Turnier abgebrochen, ursprüngliches Theme wird wiederhergestellt...",
+	"Try again once the limit lifts":                                      "Nach Ablauf des Limits erneut versuchen",
+	"Up to date":                                                          "Aktuell",
+	"Updated %d themes":                                                   "%d Themes aktualisiert",
+	"Updated %d themes from '%s'":                                         "%d Themes aus „%s“ aktualisiert",
+	"Updated %d themes from extra sources":                                "%d Themes aus zusätzlichen Quellen aktualisiert",
+	"Updated backup directory: %s -> %s":                                  "Sicherungsverzeichnis geändert: %s -> %s",
+	"Updated config path: %s -> %s":                                       "Konfigurationspfad geändert: %s -> %s",
+	"Updated save directory: %s -> %s":                                    "Speicherverzeichnis geändert: %s -> %s",
+	"Updated theme database (%d themes)":                                  "Theme-Datenbank aktualisiert (%d Themes)",
+	"Updated themes directory: %s -> %s":                                  "Theme-Verzeichnis geändert: %s -> %s",
+	"Updating theme database":                                             "Theme-Datenbank wird aktualisiert",
+	"Upstream":                                                            "Upstream",
+	"Use --format table or --format json.":                                "--format table oder --format json verwenden.",
+	"Use a plain file name, e.g. --name nord_even":                        "Einen einfachen Dateinamen verwenden, z. B. --name nord_even",
+	"Use a plain file name, e.g. alacritty-colors theme freeze my_tweaks": "Einen einfachen Dateinamen verwenden, z. B. alacritty-colors theme freeze my_tweaks",
+	"Use a plain name, e.g. --slot stable":                                "Einen einfachen Namen verwenden, z. B. --slot stable",
+	"Use a time like 18:00 or a duration like 2h30m.":                     "Eine Uhrzeit wie 18:00 oder eine Dauer wie 2h30m verwenden.",
+	"Use it with --profile %s or 'alacritty-colors config use %s'":        "Verwenden mit --profile %s oder „alacritty-colors config use %s“",
+	"Use on or off, e.g. alacritty-colors effects bold-bright on":         "on oder off verwenden, z. B. alacritty-colors effects bold-bright on",
+	"Use one of: theme, settings":                                         "Eines davon verwenden: theme, settings",
+	"Using profile %s":                                                    "Profil %s wird verwendet",
+	"Variant":                                                             "Variante",
+	"Version":                                                             "Version",
+	"Version Information":                                                 "Versionsinformationen",
+	"Wallpaper: %s":                                                       "Hintergrundbild: %s",
+	"Watching %s workspaces (Ctrl+C to stop)":                             "%s-Arbeitsbereiche werden beobachtet (Strg+C zum Beenden)",
+	"Watching the wallpaper every %s (Ctrl+C to stop)":                    "Hintergrundbild wird alle %s geprüft (Strg+C zum Beenden)",
+	"With draw_bold_text_with_bright_colors, bold text in those colors looks regular; apply.synthesize_brights gives them a brighter shade": "Mit draw_bold_text_with_bright_colors wirkt fetter Text in diesen Farben normal; apply.synthesize_brights gibt ihnen einen helleren Ton",
+	"Write a .png for one theme or a .gif for an animation.":                                                                                "Eine .png für ein Theme oder eine .gif für eine Animation schreiben.",
+	"Wrote %s": "%s geschrieben",
+	"alacritty-colors %s is available (you have %s): %s":                      "alacritty-colors %s ist verfügbar (Sie haben %s): %s",
+	"current.toml matches the applied theme; saving a copy anyway":            "current.toml entspricht dem angewendeten Theme; trotzdem wird eine Kopie gespeichert",
+	"current.toml was modified after '%s' was applied":                        "current.toml wurde geändert, nachdem „%s“ angewendet wurde",
+	"live_config_reload is off in %s; open windows keep their colors":         "live_config_reload ist in %s aus; offene Fenster behalten ihre Farben",
+	"or colors: alacritty-colors identify --colors '#282a36,#f8f8f2,#ff5555'": "oder Farben: alacritty-colors identify --colors '#282a36,#f8f8f2,#ff5555'",
+	"or discard them with 'alacritty-colors apply <theme> --force'.":          "oder mit „alacritty-colors apply <theme> --force“ verwerfen.",
+	"other settings":                         "übrige Einstellungen",
+	"🎨 Theme Slideshow":                      "🎨 Theme-Diashow",
+	"🏆 Tournament Winner":                    "🏆 Turniersieger",
+	"👤 Author: %s":                           "👤 Autor: %s",
+	"Alacritty config":                       "Alacritty-Konfiguration",
+	"Theme import line":                      "Theme-Import-Zeile",
+	"Bundled themes":                         "Mitgelieferte Themes",
+	"Theme download":                         "Theme-Download",
+	"Theme index":                            "Theme-Index",
+	"Building theme index":                   "Theme-Index wird erstellt",
+	"Parsing theme files":                    "Theme-Dateien werden gelesen",
+	"Applying themes in a scratch directory": "Themes werden in einem Testverzeichnis angewendet",
+	"Generating themes":                      "Themes werden erzeugt",
+	"Removed upstream (%d)":                  "Upstream entfernt (%d)",
+	"Archive to themes/%s":                   "Nach themes/%s archivieren",
+	"Delete":                                 "Löschen",
+	"Keep":                                   "Behalten",
+}
@@ -0,0 +1,476 @@
+package i18n
+
+var spanish = map[string]string{
+	// Prompts
+	"[y/N]: ":                  "[s/N]: ",
+	"Select option (number): ": "Elija una opción (número): ",
+	"Invalid choice. Please enter a number between 1 and %d.": "Opción no válida. Introduzca un número entre 1 y %d.",
+	"Do you want to keep this theme?":                         "¿Quiere conservar este tema?",
+	"Do you want to keep this font?":                          "¿Quiere conservar esta fuente?",
+	"Restore from '%s'?":                                      "¿Restaurar desde «%s»?",
+	"Permanently delete everything in the trash?":             "¿Borrar definitivamente todo el contenido de la papelera?",
+	"What should happen to these themes?":                     "¿Qué hacer con estos temas?",
+	"Yes":                                                     "Sí",
+	"No":                                                      "No",
+	"Select backup to restore":                                "Elija la copia de seguridad a restaurar",
+	"(arrows move, type to filter, Esc cancels)":              "(flechas para elegir, escriba para filtrar, Esc cancela)",
+	"No matches":                                              "Sin resultados",
+	"cancelled":                                               "cancelado",
+
+	// Errors and hints
+	"Error: %v":            "Error: %v",
+	"Did you mean: %s?":    "¿Quiso decir: %s?",
+	"theme '%s' not found": "no se encontró el tema «%s»",
+	"No themes are installed. Run 'alacritty-colors init' to download them.":                  "No hay temas instalados. Ejecute «alacritty-colors init» para descargarlos.",
+	"Search installed themes with: %s":                                                        "Busque entre los temas instalados con: %s",
+	"failed to apply theme: %w":                                                               "no se pudo aplicar el tema: %w",
+	"backup file not found: %s":                                                               "no se encontró la copia de seguridad: %s",
+	"themes directory not found: %s":                                                          "no se encontró el directorio de temas: %s",
+	"Run 'alacritty-colors init' to create it and download themes,":                           "Ejecute «alacritty-colors init» para crearlo y descargar temas,",
+	"or point to an existing one with 'alacritty-colors config set-path --themes-dir <dir>'.": "o indique uno existente con «alacritty-colors config set-path --themes-dir <dir>».",
+
+	// Command output
+	"Applying theme: %s":                    "Aplicando tema: %s",
+	"Applied theme '%s'":                    "Tema «%s» aplicado",
+	"Backup created: %s":                    "Copia de seguridad creada: %s",
+	"Failed to create backup: %v":           "No se pudo crear la copia de seguridad: %v",
+	"Failed to update theme tracking: %v":   "No se pudo registrar el tema aplicado: %v",
+	"No backups found":                      "No hay copias de seguridad",
+	"No themes found matching '%s'":         "Ningún tema coincide con «%s»",
+	"Generated theme saved: %s":             "Tema generado guardado: %s",
+	"Launching interactive color editor...": "Abriendo el editor de colores interactivo...",
+	"Use 'q' to quit, 's' to save changes":  "«q» para salir, «s» para guardar",
+	"Alacritty Colors Configuration":        "Configuración de Alacritty Colors",
+	"Profile":                               "Perfil",
+	"Config File":                           "Configuración",
+	"Themes Dir":                            "Temas",
+	"Backup Dir":                            "Copias",
+	"Current Theme":                         "Tema actual",
+	"Available Themes":                      "Disponibles",
+	"Backups":                               "Copias",
+	"None":                                  "Ninguno",
+	"Settings":                              "Ajustes",
+	"State Dir":                             "Estado",
+
+	// Interactive editor
+	"Tab: switch panels | ↑↓: navigate | ←→: adjust RGB values | Enter: edit | q: quit | s: save | r: reset": "Tab: cambiar panel | ↑↓: navegar | ←→: ajustar RGB | Intro: editar | q: salir | s: guardar | r: restablecer",
+	"Select a theme to start editing": "Elija un tema para editarlo",
+	"Primary":                         "Principal",
+	"Cursor":                          "Cursor",
+	"Vi Mode Cursor":                  "Cursor del modo vi",
+	"(default)":                       "(predeterminado)",
+	"Selection":                       "Selección",
+	"Normal":                          "Normal",
+	"Bright":                          "Brillante",
+	"Dim":                             "Atenuado",
+	"Focus: Color Panel | Use arrow keys to navigate, Enter to edit":  "Panel de colores | Flechas para navegar, Intro para editar",
+	"Focus: Theme List | Use arrow keys to navigate, Enter to select": "Lista de temas | Flechas para navegar, Intro para elegir",
+	"You have unsaved changes. Are you sure you want to quit?":        "Hay cambios sin guardar. ¿Salir de todos modos?",
+	"Save & Quit":                    "Guardar y salir",
+	"Quit":                           "Salir",
+	"Cancel":                         "Cancelar",
+	"No theme selected to save":      "No hay ningún tema seleccionado para guardar",
+	"Error loading themes: %v":       "Error al cargar los temas: %v",
+	"Error loading theme %s: %v":     "Error al cargar el tema %s: %v",
+	"Error saving theme: %v":         "Error al guardar el tema: %v",
+	"Theme '%s' saved successfully":  "Tema «%s» guardado",
+	"Theme reset to original values": "Tema restablecido a sus valores originales",
+
+	// Status messages, labels and hints
+	"\nBright Colors:": "\nColores brillantes:",
+	"\nNormal Colors:": "\nColores normales:",
+	"\nYou can now see how the theme looks in your terminal.":                     "\nAhora puede ver cómo queda el tema en su terminal.",
+	"\n⌨️  Controls: SPACE=select | n/p=nav | q=quit | +/-=speed":                 "\n⌨️  Controles: ESPACIO=elegir | n/p=navegar | q=salir | +/-=velocidad",
+	"\n⌨️  Controls: a/b or TAB=switch | SPACE/ENTER=pick the one shown | q=quit": "\n⌨️  Controles: a/b o TAB=cambiar | ESPACIO/INTRO=elegir el mostrado | q=salir",
+	"    Created: %s":                               "    Creado: %s",
+	"    Description: %s":                           "    Descripción: %s",
+	"    Effects: %s":                               "    Efectos: %s",
+	"    Made with: %s":                             "    Hecho con: %s",
+	"    Theme: %s":                                 "    Tema: %s",
+	"  +/-         - Increase/decrease speed":       "  +/-         - Aumentar/reducir la velocidad",
+	"  SPACE/ENTER - Select current theme and exit": "  ESPACIO/INTRO - Elegir el tema actual y salir",
+	"  Save it with 'alacritty-colors theme adopt <name>' or reset it with 'alacritty-colors apply %s --force'": "  Guárdelo con «alacritty-colors theme adopt <nombre>» o restablézcalo con «alacritty-colors apply %s --force»",
+	"  n/RIGHT     - Next theme immediately":                                                               "  n/DERECHA   - Siguiente tema al instante",
+	"  p/LEFT      - Previous theme":                                                                       "  p/IZQUIERDA - Tema anterior",
+	"  q/ESC       - Quit without applying":                                                                "  q/ESC       - Salir sin aplicar",
+	"  r           - Restart slideshow":                                                                    "  r           - Reiniciar la presentación",
+	"%d Alacritty instances are running; using %s (pick another with --socket)":                            "Hay %d instancias de Alacritty en ejecución; se usa %s (elija otra con --socket)",
+	"%d instance(s) did not pick up the theme; they may use another config file (alacritty --config-file)": "%d instancia(s) no tomaron el tema; puede que usen otro archivo de configuración (alacritty --config-file)",
+	"%d instance(s) still show the previous theme because live reload is off":                              "%d instancia(s) siguen mostrando el tema anterior porque la recarga en vivo está desactivada",
+	"%d of %d color settings come from the theme; the rest are Alacritty defaults":                         "%d de %d ajustes de color vienen del tema; el resto son los valores por defecto de Alacritty",
+	"%d smaller families not shown":                                                                        "%d familias más pequeñas no se muestran",
+	"%s has the same normal and bright %s, so bold text in them looks regular":                             "%s tiene el mismo %s normal y brillante, así que el texto en negrita se ve normal",
+	"A newer release is available":                                                                         "Hay una versión más reciente disponible",
+	"Add --animate to cycle through them in a GIF.":                                                        "Añada --animate para recorrerlos en un GIF.",
+	"Add --schedule daily.":                                                                                "Añada --schedule daily.",
+	"Add <theme>.toml, or a glob such as gruvbox*.toml, to %s":                                             "Añada <tema>.toml, o un patrón como gruvbox*.toml, a %s",
+	"Add one with: alacritty-colors exclude add <theme|pattern>":                                           "Añada uno con: alacritty-colors exclude add <tema|patrón>",
+	"Add one with: alacritty-colors protect add <theme|pattern>":                                           "Añada uno con: alacritty-colors protect add <tema|patrón>",
+	"Add targets to [sync] in %s or name one, e.g. 'alacritty-colors sync tmux'":                           "Añada destinos a [sync] en %s o indique uno, p. ej. «alacritty-colors sync tmux»",
+	"Add your own as <name>%s in %s":                                                                       "Añada los suyos como <nombre>%s en %s",
+	"Added import line to %s":                                                                              "Línea de importación añadida a %s",
+	"Added profile %s":                                                                                     "Perfil %s añadido",
+	"Added themes directory: %s":                                                                           "Directorio de temas añadido: %s",
+	"Alacritty Colors Paths":                                                                               "Rutas de Alacritty Colors",
+	"All %d theme(s) are valid":                                                                            "Los %d tema(s) son válidos",
+	"Already excluded":                                                                                     "Ya excluido",
+	"Already protected":                                                                                    "Ya protegido",
+	"Already searched: %s":                                                                                 "Ya buscado: %s",
+	"Also Searched":                                                                                        "También buscado",
+	"Applied '%s' to this terminal":                                                                        "«%s» aplicado a esta terminal",
+	"Applied theme '%s' to %s":                                                                             "Tema «%s» aplicado a %s",
+	"Applied theme: %s":                                                                                    "Tema aplicado: %s",
+	"Apply a theme anyway with: alacritty-colors apply <theme> --force":                                    "Aplique un tema de todos modos con: alacritty-colors apply <tema> --force",
+	"Apply a theme first: alacritty-colors apply <theme>":                                                  "Aplique primero un tema: alacritty-colors apply <tema>",
+	"Apply it with: alacritty-colors apply %s":                                                             "Aplíquelo con: alacritty-colors apply %s",
+	"Apply one first: alacritty-colors apply <theme>":                                                      "Aplique uno primero: alacritty-colors apply <tema>",
+	"Apply the best match: alacritty-colors apply %s":                                                      "Aplique la mejor coincidencia: alacritty-colors apply %s",
+	"Apply themes as usual, then save the session with: alacritty-colors record stop":                      "Aplique temas como siempre y luego guarde la sesión con: alacritty-colors record stop",
+	"Applying theme %s to %s":                                                                              "Aplicando el tema %s a %s",
+	"Archived %d themes to %s":                                                                             "%d temas archivados en %s",
+	"Author":                                                                                               "Autor",
+	"Author: %s":                                                                                           "Autor: %s",
+	"Auto-applying theme: %s":                                                                              "Aplicando automáticamente el tema: %s",
+	"Available Backups":                                                                                    "Copias de seguridad disponibles",
+	"Available themes":                                                                                     "Temas disponibles",
+	"Background Hue and Lightness":                                                                         "Tono y luminosidad del fondo",
+	"Backups directory: %s":                                                                                "Directorio de copias de seguridad: %s",
+	"Benchmark":                                                                                            "Prueba de rendimiento",
+	"Bright colors":                                                                                        "Colores brillantes",
+	"Built":                                                                                                "Compilado",
+	"CA Certs":                                                                                             "Certificados CA",
+	"Calibration":                                                                                          "Calibración",
+	"Cancelled":                                                                                            "Cancelado",
+	"Champion":                                                                                             "Campeón",
+	"Changelog":                                                                                            "Cambios",
+	"Check the token in GITHUB_TOKEN or network.github_token, it may have expired": "Compruebe el token en GITHUB_TOKEN o network.github_token, puede que haya caducado",
+	"Checking for theme updates...":                                     "Buscando actualizaciones de temas...",
+	"Choose another name with --name, or pass --force to overwrite it.": "Elija otro nombre con --name, o pase --force para sobrescribirlo.",
+	"Choose another name, or pass --force to overwrite it.":             "Elija otro nombre, o pase --force para sobrescribirlo.",
+	"Choose another name: alacritty-colors theme adopt <name>":          "Elija otro nombre: alacritty-colors theme adopt <nombre>",
+	"Chroma": "Croma",
+	"Cleaned up %d backup files, kept %d most recent":   "%d copias de seguridad eliminadas, se conservan las %d más recientes",
+	"Cleaned up %d theme files":                         "%d archivos de tema eliminados",
+	"Cleaning Backup Files":                             "Limpiando copias de seguridad",
+	"Cleaning Theme Files":                              "Limpiando archivos de tema",
+	"Cleaning up themes older than %d days":             "Eliminando temas de más de %d días",
+	"Color Palette":                                     "Paleta de colores",
+	"Colors adjusted: %s (the theme file is unchanged)": "Colores ajustados: %s (el archivo del tema no cambia)",
+	"Commit":          "Commit",
+	"Config Profiles": "Perfiles de configuración",
+	"Config file: %s": "Archivo de configuración: %s",
+	"Configuration and colors restored from backup":                "Configuración y colores restaurados desde la copia de seguridad",
+	"Configuration and theme '%s' restored from backup":            "Configuración y tema «%s» restaurados desde la copia de seguridad",
+	"Configuration complete":                                       "Configuración completada",
+	"Configuration paths updated successfully":                     "Rutas de configuración actualizadas",
+	"Configuration restored from backup":                           "Configuración restaurada desde la copia de seguridad",
+	"Configuration restored from backup (colors left as they are)": "Configuración restaurada desde la copia de seguridad (colores sin cambios)",
+	"Contrast":  "Contraste",
+	"Controls:": "Controles:",
+	"Converted %s from UTF-16 to UTF-8, which Alacritty requires (original kept as %s.utf16.bak)": "%s convertido de UTF-16 a UTF-8, que Alacritty requiere (original guardado como %s.utf16.bak)",
+	"Create it first, or check the path given to --to.":                                           "Créelo primero, o compruebe la ruta pasada a --to.",
+	"Create one with 'alacritty-colors backup'":                                                   "Cree una con «alacritty-colors backup»",
+	"Current":           "Actual",
+	"Current theme":     "Tema actual",
+	"Current theme: %s": "Tema actual: %s",
+	"Cycling through %d themes with %v intervals":                                       "Recorriendo %d temas cada %v",
+	"Define it in the settings file, e.g. collections.cozy = [\"gruvbox*\", \"nord\"]":  "Defínala en el archivo de ajustes, p. ej. collections.cozy = [\"gruvbox*\", \"nord\"]",
+	"Deleted %d files from the trash":                                                   "%d archivos borrados de la papelera",
+	"Deleted %d themes (recoverable for %d days with 'alacritty-colors trash restore')": "%d temas borrados (recuperables durante %d días con «alacritty-colors trash restore»)",
+	"Deployed %s with %s": "%s desplegado con %s",
+	"Deployed To":         "Desplegado en",
+	"Description: %s":     "Descripción: %s",
+	"Dominant hue":        "Tono dominante",
+	"Download themes with 'alacritty-colors update'.":             "Descargue temas con «alacritty-colors update».",
+	"Downloaded theme: %s":                                        "Tema descargado: %s",
+	"Downloading from official repository and %d more sources...": "Descargando del repositorio oficial y de %d fuentes más...",
+	"Downloading from official repository...":                     "Descargando del repositorio oficial...",
+	"Downloading from source '%s'...":                             "Descargando de la fuente «%s»...",
+	"Downloading theme from %s":                                   "Descargando el tema desde %s",
+	"Drop --socket to read the config files instead.":             "Quite --socket para leer en su lugar los archivos de configuración.",
+	"Dry run: '%s' was not saved":                                 "Simulación: «%s» no se guardó",
+	"Effective Settings":                                          "Ajustes efectivos",
+	"Excluded Themes":                                             "Temas excluidos",
+	"Excluded from random and slideshow: %s":                      "Excluido del azar y la presentación: %s",
+	"Exported %d theme(s) for %s to %s":                           "%d tema(s) exportado(s) para %s a %s",
+	"Exported '%s' for %s to %s":                                  "«%s» exportado para %s a %s",
+	"Exported the %s schema to %s":                                "Esquema %s exportado a %s",
+	"Exported the colors of '%s' to %s":                           "Colores de «%s» exportados a %s",
+	"Failed to apply scheduled theme: %v":                         "No se pudo aplicar el tema programado: %v",
+	"Failed to apply theme: %v":                                   "No se pudo aplicar el tema: %v",
+	"Failed to apply visual effects: %v":                          "No se pudieron aplicar los efectos visuales: %v",
+	"Failed to apply workspace theme: %v":                         "No se pudo aplicar el tema del espacio de trabajo: %v",
+	"Failed to back up current.toml: %v":                          "No se pudo respaldar current.toml: %v",
+	"Failed to create %s: %v":                                     "No se pudo crear %s: %v",
+	"Failed to download source '%s': %v":                          "No se pudo descargar la fuente «%s»: %v",
+	"Failed to download themes: %v":                               "No se pudieron descargar los temas: %v",
+	"Failed to export %s: %v":                                     "No se pudo exportar %s: %v",
+	"Failed to index theme metadata: %v":                          "No se pudieron indexar los metadatos de los temas: %v",
+	"Failed to parse theme %s: %v":                                "No se pudo analizar el tema %s: %v",
+	"Failed to prune %s: %v":                                      "No se pudo depurar %s: %v",
+	"Failed to quarantine %s: %v":                                 "No se pudo poner %s en cuarentena: %v",
+	"Failed to read %s: %v":                                       "No se pudo leer %s: %v",
+	"Failed to read theme colors for notifications: %v":           "No se pudieron leer los colores del tema para las notificaciones: %v",
+	"Failed to reload settings: %v":                               "No se pudieron recargar los ajustes: %v",
+	"Failed to remove %s: %v":                                     "No se pudo eliminar %s: %v",
+	"Failed to remove old backup %s: %v":                          "No se pudo eliminar la copia antigua %s: %v",
+	"Failed to reset settings: %v":                                "No se pudieron restablecer los ajustes: %v",
+	"Failed to restore %s: %v":                                    "No se pudo restaurar %s: %v",
+	"Failed to restore '%s': %v":                                  "No se pudo restaurar «%s»: %v",
+	"Failed to restore original theme: %v":                        "No se pudo restaurar el tema original: %v",
+	"Failed to restore previous theme: %v":                        "No se pudo restaurar el tema anterior: %v",
+	"Failed to set font: %v":                                      "No se pudo cambiar la fuente: %v",
+	"Failed to sync %s: %v":                                       "No se pudo sincronizar %s: %v",
+	"Failed to update manifest: %v":                               "No se pudo actualizar el manifiesto: %v",
+	"Failed to write quarantine report: %v":                       "No se pudo escribir el informe de cuarentena: %v",
+	"Fetch more themes with 'alacritty-colors update', or recreate it with 'alacritty-colors generate --from-screenshot'": "Obtenga más temas con «alacritty-colors update», o recréelo con «alacritty-colors generate --from-screenshot»",
+	"File": "Archivo",
+	"Font '%s' doesn't seem to be installed; Alacritty will fall back to its default": "La fuente «%s» no parece instalada; Alacritty usará su fuente por defecto",
+	"Font Pairings":                                      "Combinaciones de fuentes",
+	"Font set to %s":                                     "Fuente cambiada a %s",
+	"Force update: removing existing themes":             "Actualización forzada: eliminando los temas existentes",
+	"Froze %d colors from %s as theme '%s' (%s)":         "%d colores de %s congelados como tema «%s» (%s)",
+	"Generating %s theme":                                "Generando un tema %s",
+	"GitHub Token":                                       "Token de GitHub",
+	"GitHub rate limit reached, retrying in %s...":       "Límite de peticiones de GitHub alcanzado, reintentando en %s...",
+	"Give a query, or a color with --color \"#rrggbb\".": "Indique una búsqueda, o un color con --color \"#rrggbb\".",
+	"Globs use * and ?, e.g. 'gruvbox*'; regular expressions go between slashes, e.g. '/^nord|_night$/'.": "Los patrones usan * y ?, p. ej. «gruvbox*»; las expresiones regulares van entre barras, p. ej. «/^nord|_night$/».",
+	"If Alacritty reports renamed options, 'alacritty migrate' updates the restored config":               "Si Alacritty informa de opciones renombradas, «alacritty migrate» actualiza la configuración restaurada",
+	"If Alacritty reports unknown or renamed options, run 'alacritty migrate' on the restored config":     "Si Alacritty informa de opciones desconocidas o renombradas, ejecute «alacritty migrate» sobre la configuración restaurada",
+	"Initializing with verbose output enabled":                                                            "Inicializando con salida detallada",
+	"Installed the %s service": "Servicio %s instalado",
+	"Iterations":               "Iteraciones",
+	"Keep the changes as a new theme with 'alacritty-colors theme adopt <name>',": "Conserve los cambios como un tema nuevo con «alacritty-colors theme adopt <nombre>»,",
+	"Keeping %d most recent backups":                                              "Conservando las %d copias de seguridad más recientes",
+	"Keeping %d protected themes: %s":                                             "Conservando %d temas protegidos: %s",
+	"Keeping %s: it is protected":                                                 "Se conserva %s: está protegido",
+	"Keeping %s: it is the applied theme":                                         "Se conserva %s: es el tema aplicado",
+	"Keeping current theme %s although it was removed upstream":                   "Se conserva el tema actual %s aunque se eliminó en el origen",
+	"Keeping protected theme %s although it was removed upstream":                 "Se conserva el tema protegido %s aunque se eliminó en el origen",
+	"Keeping theme '%s'":                                                          "Se conserva el tema «%s»",
+	"Kept %d locally modified or protected themes: %s":                            "Se conservaron %d temas modificados localmente o protegidos: %s",
+	"Kept %d protected themes":                                                    "Se conservaron %d temas protegidos",
+	"Kept %d themes":                                                              "Se conservaron %d temas",
+	"Latest":                                                                      "Última",
+	"Lightness":                                                                   "Luminosidad",
+	"List backups with 'alacritty-colors restore --list'":                         "Liste las copias de seguridad con «alacritty-colors restore --list»",
+	"List backups with 'alacritty-colors restore --list', or run 'alacritty-colors restore' to pick one.": "Liste las copias de seguridad con «alacritty-colors restore --list», o ejecute «alacritty-colors restore» para elegir una.",
+	"List fonts with: alacritty-colors fonts list":                                                        "Liste las fuentes con: alacritty-colors fonts list",
+	"List the trash with: alacritty-colors trash list":                                                    "Liste la papelera con: alacritty-colors trash list",
+	"Live Reload": "Recarga en vivo",
+	"Live reload": "Recarga en vivo",
+	"Local Theme": "Tema local",
+	"Locked":      "Bloqueado",
+	"Loosen the filters, or apply the theme directly.": "Relaje los filtros, o aplique el tema directamente.",
+	"Matches":      "Coincidencias",
+	"Most Applied": "Más aplicados",
+	"Move or rename it first, then restore again":                                    "Muévalo o renómbrelo primero y vuelva a restaurar",
+	"Moved %d old backups to the trash (backup.keep = %d, backup.max_age_days = %d)": "%d copias de seguridad antiguas movidas a la papelera (backup.keep = %d, backup.max_age_days = %d)",
+	"Name a theme: alacritty-colors export alacritty-snippet <theme>":                "Indique un tema: alacritty-colors export alacritty-snippet <tema>",
+	"Name it with: alacritty-colors watch-workspaces --wm <name>":                    "Indíquelo con: alacritty-colors watch-workspaces --wm <nombre>",
+	"Name one or more themes, or pick a collection with --collection.":               "Indique uno o más temas, o elija una colección con --collection.",
+	"Nearest family": "Familia más cercana",
+	"No Alacritty named pipes found; Alacritty on Windows may not offer IPC, so only live reload applies themes to open windows": "No se encontraron tuberías con nombre de Alacritty; en Windows, Alacritty puede no ofrecer IPC, así que solo la recarga en vivo aplica temas a las ventanas abiertas",
+	"No answer within %s":                                             "Sin respuesta en %s",
+	"No backup files found":                                           "No se encontraron copias de seguridad",
+	"No backups to clean up (found %d, keeping %d)":                   "No hay copias que limpiar (%d encontradas, se conservan %d)",
+	"No close match; the theme may not be installed":                  "Ninguna coincidencia cercana; puede que el tema no esté instalado",
+	"No installed theme named '%s'":                                   "Ningún tema instalado se llama «%s»",
+	"No local theme for %s":                                           "No hay tema local para %s",
+	"No longer excluded: %s":                                          "Ya no está excluido: %s",
+	"No longer protected: %s":                                         "Ya no está protegido: %s",
+	"No matching monospace fonts found":                               "No se encontraron fuentes monoespaciadas que coincidan",
+	"No old themes to remove":                                         "No hay temas antiguos que eliminar",
+	"No overrides yet":                                                "Aún no hay sobrescrituras",
+	"No pairs found. Define some under [pairs] in the settings file.": "No se encontraron pares. Defina algunos en [pairs] del archivo de ajustes.",
+	"No running Alacritty instances found (set ALACRITTY_SOCKET if yours uses another path)": "No se encontraron instancias de Alacritty en ejecución (defina ALACRITTY_SOCKET si la suya usa otra ruta)",
+	"No service installed":                                                     "No hay ningún servicio instalado",
+	"No sync targets configured":                                               "No hay destinos de sincronización configurados",
+	"No theme currently applied":                                               "No hay ningún tema aplicado",
+	"No themes are excluded":                                                   "No hay temas excluidos",
+	"No themes are protected":                                                  "No hay temas protegidos",
+	"No themes found":                                                          "No se encontraron temas",
+	"No themes have a color within %.1f of %s":                                 "Ningún tema tiene un color a menos de %.1f de %s",
+	"No themes installed to compare against":                                   "No hay temas instalados con los que comparar",
+	"No themes to analyze":                                                     "No hay temas que analizar",
+	"No themes to remove":                                                      "No hay temas que eliminar",
+	"No window, font or color settings to reset":                               "No hay ajustes de ventana, fuente o color que restablecer",
+	"None of %s is installed; using the generic monospace font":                "Ninguna de %s está instalada; se usa la fuente monoespaciada genérica",
+	"Not found in the screenshot, derived instead: %s":                         "No encontrados en la captura, derivados en su lugar: %s",
+	"Notification failed: %s":                                                  "Falló la notificación: %s",
+	"Notifications not sent: %v":                                               "Notificaciones no enviadas: %v",
+	"On Linux, install fontconfig so 'fc-list' is available":                   "En Linux, instale fontconfig para disponer de «fc-list»",
+	"Or try again once the limit lifts":                                        "O vuelva a intentarlo cuando se levante el límite",
+	"Original theme restored":                                                  "Tema original restaurado",
+	"Override them in the [font_pairings] table of %s":                         "Sobrescríbalos en la tabla [font_pairings] de %s",
+	"Overrides are merged into current.toml when a theme is applied":           "Las sobrescrituras se combinan en current.toml al aplicar un tema",
+	"Pass --rotate-accents or --shuffle-accents.":                              "Pase --rotate-accents o --shuffle-accents.",
+	"Pass a screenshot: alacritty-colors identify shot.png":                    "Pase una captura: alacritty-colors identify shot.png",
+	"Pass one with --output, e.g. -o ~/.local/share/konsole/":                  "Indique uno con --output, p. ej. -o ~/.local/share/konsole/",
+	"Preview with escape sequences in this terminal instead?":                  "¿Previsualizar en su lugar con secuencias de escape en esta terminal?",
+	"Preview with escape sequences instead: alacritty-colors preview %s --osc": "Previsualice en su lugar con secuencias de escape: alacritty-colors preview %s --osc",
+	"Previous font restored":                                                   "Fuente anterior restaurada",
+	"Previous theme restored":                                                  "Tema anterior restaurado",
+	"Protected Themes":                                                         "Temas protegidos",
+	"Protected from updates and clean-up: %s":                                  "Protegido de actualizaciones y limpiezas: %s",
+	"Proxy":                               "Proxy",
+	"Quality":                             "Calidad",
+	"Quarantined %d invalid themes in %s": "%d temas no válidos puestos en cuarentena en %s",
+	"Recorded %d theme change(s) over %s to %s":                                    "%d cambio(s) de tema grabado(s) durante %s en %s",
+	"Recording theme changes":                                                      "Grabando cambios de tema",
+	"Releases are listed at https://github.com/vitruves/alacritty-colors/releases": "Las versiones aparecen en https://github.com/vitruves/alacritty-colors/releases",
+	"Reload command failed: %v %s":                                                 "Falló el comando de recarga: %v %s",
+	"Remove the lock with: alacritty-colors unlock":                                "Quite el bloqueo con: alacritty-colors unlock",
+	"Removed %d old theme files":                                                   "%d archivos de tema antiguos eliminados",
+	"Removed %d theme(s)":                                                          "%d tema(s) eliminado(s)",
+	"Removed %s":                                                                   "%s eliminado",
+	"Removed files stay in the trash for %d days: alacritty-colors trash list":     "Los archivos eliminados permanecen %d días en la papelera: alacritty-colors trash list",
+	"Removed local theme from %s":                                                  "Tema local eliminado de %s",
+	"Rendered %d themes to %s":                                                     "%d temas renderizados en %s",
+	"Rendered %s to %s":                                                            "%s renderizado en %s",
+	"Rendered template '%s' for '%s' to %s":                                        "Plantilla «%s» renderizada para «%s» en %s",
+	"Replaced %s":                                                                  "%s reemplazado",
+	"Replay it with: alacritty-colors replay %s":                                   "Reprodúzcala con: alacritty-colors replay %s",
+	"Replayed %d theme change(s)":                                                  "%d cambio(s) de tema reproducido(s)",
+	"Reset %s":                                                                     "%s restablecido",
+	"Reset this terminal's colors":                                                 "Colores de esta terminal restablecidos",
+	"Reset to default theme":                                                       "Tema por defecto restablecido",
+	"Restore cancelled":                                                            "Restauración cancelada",
+	"Restore with: alacritty-colors trash restore <id|name>":                       "Restaure con: alacritty-colors trash restore <id|nombre>",
+	"Restored %s to %s":                                                            "%s restaurado en %s",
+	"Restoring from backup: %s":                                                    "Restaurando desde la copia de seguridad: %s",
+	"Restoring original theme...":                                                  "Restaurando el tema original...",
+	"Restoring previous theme...":                                                  "Restaurando el tema anterior...",
+	"Restoring slot: %s":                                                           "Restaurando la ranura: %s",
+	"Return to it with: alacritty-colors restore --slot %s":                        "Vuelva a ella con: alacritty-colors restore --slot %s",
+	"Reverted to theme '%s'":                                                       "Vuelta al tema «%s»",
+	"Run 'alacritty-colors --version --check'.":                                    "Ejecute «alacritty-colors --version --check».",
+	"Run 'alacritty-colors init' to download themes":                               "Ejecute «alacritty-colors init» para descargar temas",
+	"Run 'alacritty-colors update --incremental' to fetch these changes":           "Ejecute «alacritty-colors update --incremental» para obtener estos cambios",
+	"Run 'alacritty-colors update' first.":                                         "Ejecute primero «alacritty-colors update».",
+	"Run 'alacritty-colors update' once online to get the full collection":         "Ejecute «alacritty-colors update» cuando tenga conexión para obtener la colección completa",
+	"Run 'alacritty-colors update' to download themes":                             "Ejecute «alacritty-colors update» para descargar temas",
+	"Run it in a terminal, without --yes or --no-input.":                           "Ejecútelo en una terminal, sin --yes ni --no-input.",
+	"Runner-up":  "Finalista",
+	"Saturation": "Saturación",
+	"Save Dir":   "Directorio de guardado",
+	"Save it with: alacritty-colors record stop":                        "Guárdela con: alacritty-colors record stop",
+	"Saved current.toml as theme '%s' (%s)":                             "current.toml guardado como tema «%s» (%s)",
+	"Saved harmonized theme '%s' (%s)":                                  "Tema armonizado «%s» guardado (%s)",
+	"Saved slot '%s'":                                                   "Ranura «%s» guardada",
+	"Schedule changed: light at %s, dark at %s":                         "Horario cambiado: claro a las %s, oscuro a las %s",
+	"Scheduler disabled in settings; waiting until it is enabled again": "Programador desactivado en los ajustes; esperando a que se vuelva a activar",
+	"Scheduler is disabled; set scheduler.enabled = true in %s":         "El programador está desactivado; ponga scheduler.enabled = true en %s",
+	"Scheduler running: light at %s, dark at %s":                        "Programador en marcha: claro a las %s, oscuro a las %s",
+	"Scheduler stopped":                                                 "Programador detenido",
+	"Seasonal palette for %s: %s":                                       "Paleta de temporada para %s: %s",
+	"Selected random theme: %s":                                         "Tema elegido al azar: %s",
+	"Selected theme: %s":                                                "Tema elegido: %s",
+	"Service uninstalled":                                               "Servicio desinstalado",
+	"Set %s = %s":                                                       "%s = %s establecido",
+	"Set In":                                                            "Definido en",
+	"Set apply.synthesize_brights = true in %s to give them a bright shade on apply": "Ponga apply.synthesize_brights = true en %s para darles un tono brillante al aplicar",
+	"Set live_config_reload = true under [general], or open a new window":            "Ponga live_config_reload = true en [general], o abra una ventana nueva",
+	"Setting Custom Paths":     "Definiendo rutas personalizadas",
+	"Setting up configuration": "Preparando la configuración",
+	"Show the files and directories in use with: alacritty-colors config show --paths": "Muestre los archivos y directorios en uso con: alacritty-colors config show --paths",
+	"Showcase":        "Muestrario",
+	"Skipping %s: %v": "Se omite %s: %v",
+	"Slideshow complete. Press SPACE to keep current theme or q to restore original.": "Presentación terminada. Pulse ESPACIO para conservar el tema actual o q para restaurar el original.",
+	"Slots":                          "Ranuras",
+	"Slowest Theme Files":            "Archivos de tema más lentos",
+	"Source":                         "Fuente",
+	"Speed decreased - interval: %v": "Velocidad reducida - intervalo: %v",
+	"Speed increased - interval: %v": "Velocidad aumentada - intervalo: %v",
+	"Start with: alacritty-colors record start": "Empiece con: alacritty-colors record start",
+	"Status": "Estado",
+	"Stopped syncing after %d of %d themes: %v": "Sincronización detenida tras %d de %d temas: %v",
+	"Sync %s: %v":                         "Sincronización de %s: %v",
+	"Sync Targets":                        "Destinos de sincronización",
+	"Synced %d new and %d updated themes": "Sincronizados %d temas nuevos y %d actualizados",
+	"Synced %s":                           "%s sincronizado",
+	"Syncing with official repository...": "Sincronizando con el repositorio oficial...",
+	"Tags":                                "Etiquetas",
+	"Temperature":                         "Temperatura",
+	"Templates":                           "Plantillas",
+	"Test it by running some commands or checking your editor.":                                "Pruébelo ejecutando algunos comandos o mirando su editor.",
+	"The alacritty command is not on PATH, so running windows can't be checked":                "El comando alacritty no está en el PATH, así que no se pueden comprobar las ventanas abiertas",
+	"The font is now temporarily applied to your terminal!":                                    "¡La fuente está ahora aplicada temporalmente a su terminal!",
+	"The only schedule is daily: alacritty-colors random --schedule daily":                     "El único horario es diario: alacritty-colors random --schedule daily",
+	"The preview may not show in this terminal:":                                               "Puede que la vista previa no se vea en esta terminal:",
+	"The scheduler is not fully configured yet; see 'alacritty-colors schedule --help'":        "El programador aún no está configurado del todo; vea «alacritty-colors schedule --help»",
+	"The scheduler, daemon, slideshows and random won't change it; 'apply --force' still does": "El programador, el demonio, las presentaciones y random no lo cambiarán; «apply --force» sí",
+	"The theme is now temporarily applied to your terminal!":                                   "¡El tema está ahora aplicado temporalmente a su terminal!",
+	"The trash is empty":                      "La papelera está vacía",
+	"Theme Collection Stats":                  "Estadísticas de la colección",
+	"Theme Colors":                            "Colores del tema",
+	"Theme Schedule":                          "Horario de temas",
+	"Theme Sources":                           "Fuentes de temas",
+	"Theme changes are not locked":            "Los cambios de tema no están bloqueados",
+	"Theme changes unlocked":                  "Cambios de tema desbloqueados",
+	"Theme for %s set to '%s'":                "Tema de %s cambiado a «%s»",
+	"Theme of the day for %s: %s":             "Tema del día para %s: %s",
+	"Theme of the day is already applied: %s": "El tema del día ya está aplicado: %s",
+	"Themes":                               "Temas",
+	"Themes are up to date (%d unchanged)": "Los temas están al día (%d sin cambios)",
+	"Themes directory: %s":                 "Directorio de temas: %s",
+	"This backup has no current.toml; the colors were left as they are":                                             "Esta copia no tiene current.toml; los colores no cambian",
+	"This backup was made with Alacritty %s, before configs moved from YAML to TOML in 0.13; you have %s":           "Esta copia se hizo con Alacritty %s, antes del paso de YAML a TOML en 0.13; usted tiene %s",
+	"This backup was made with Alacritty %s, newer than your %s; some of its options may not exist in your version": "Esta copia se hizo con Alacritty %s, más reciente que su %s; puede que algunas opciones no existan en su versión",
+	"This backup was made with Alacritty %s; you have %s":                                                           "Esta copia se hizo con Alacritty %s; usted tiene %s",
+	"Title": "Título",
+	"Tournament abandoned, restoring original theme...":                   "Torneo abandonado, restaurando el tema original...",
+	"Try again once the limit lifts":                                      "Vuelva a intentarlo cuando se levante el límite",
+	"Up to date":                                                          "Al día",
+	"Updated %d themes":                                                   "%d temas actualizados",
+	"Updated %d themes from '%s'":                                         "%d temas actualizados desde «%s»",
+	"Updated %d themes from extra sources":                                "%d temas actualizados desde fuentes adicionales",
+	"Updated backup directory: %s -> %s":                                  "Directorio de copias cambiado: %s -> %s",
+	"Updated config path: %s -> %s":                                       "Ruta de configuración cambiada: %s -> %s",
+	"Updated save directory: %s -> %s":                                    "Directorio de guardado cambiado: %s -> %s",
+	"Updated theme database (%d themes)":                                  "Base de temas actualizada (%d temas)",
+	"Updated themes directory: %s -> %s":                                  "Directorio de temas cambiado: %s -> %s",
+	"Updating theme database":                                             "Actualizando la base de temas",
+	"Upstream":                                                            "Origen",
+	"Use --format table or --format json.":                                "Use --format table o --format json.",
+	"Use a plain file name, e.g. --name nord_even":                        "Use un nombre de archivo simple, p. ej. --name nord_even",
+	"Use a plain file name, e.g. alacritty-colors theme freeze my_tweaks": "Use un nombre de archivo simple, p. ej. alacritty-colors theme freeze my_tweaks",
+	"Use a plain name, e.g. --slot stable":                                "Use un nombre simple, p. ej. --slot stable",
+	"Use a time like 18:00 or a duration like 2h30m.":                     "Use una hora como 18:00 o una duración como 2h30m.",
+	"Use it with --profile %s or 'alacritty-colors config use %s'":        "Úselo con --profile %s o «alacritty-colors config use %s»",
+	"Use on or off, e.g. alacritty-colors effects bold-bright on":         "Use on u off, p. ej. alacritty-colors effects bold-bright on",
+	"Use one of: theme, settings":                                         "Use uno de: theme, settings",
+	"Using profile %s":                                                    "Usando el perfil %s",
+	"Variant":                                                             "Variante",
+	"Version":                                                             "Versión",
+	"Version Information":                                                 "Información de la versión",
+	"Wallpaper: %s":                                                       "Fondo de pantalla: %s",
+	"Watching %s workspaces (Ctrl+C to stop)":                             "Vigilando los espacios de trabajo de %s (Ctrl+C para parar)",
+	"Watching the wallpaper every %s (Ctrl+C to stop)":                    "Vigilando el fondo de pantalla cada %s (Ctrl+C para parar)",
+	"With draw_bold_text_with_bright_colors, bold text in those colors looks regular; apply.synthesize_brights gives them a brighter shade": "Con draw_bold_text_with_bright_colors, el texto en negrita en esos colores se ve normal; apply.synthesize_brights les da un tono más brillante",
+	"Write a .png for one theme or a .gif for an animation.":                                                                                "Escriba un .png para un tema o un .gif para una animación.",
+	"Wrote %s": "%s escrito",
+	"alacritty-colors %s is available (you have %s): %s":                      "alacritty-colors %s está disponible (usted tiene %s): %s",
+	"current.toml matches the applied theme; saving a copy anyway":            "current.toml coincide con el tema aplicado; se guarda una copia de todos modos",
+	"current.toml was modified after '%s' was applied":                        "current.toml se modificó después de aplicar «%s»",
+	"live_config_reload is off in %s; open windows keep their colors":         "live_config_reload está desactivado en %s; las ventanas abiertas conservan sus colores",
+	"or colors: alacritty-colors identify --colors '#282a36,#f8f8f2,#ff5555'": "o colores: alacritty-colors identify --colors '#282a36,#f8f8f2,#ff5555'",
+	"or discard them with 'alacritty-colors apply <theme> --force'.":          "o descártelos con «alacritty-colors apply <tema> --force».",
+	"other settings":                         "otros ajustes",
+	"🎨 Theme Slideshow":                      "🎨 Presentación de temas",
+	"🏆 Tournament Winner":                    "🏆 Ganador del torneo",
+	"👤 Author: %s":                           "👤 Autor: %s",
+	"Alacritty config":                       "Configuración de Alacritty",
+	"Theme import line":                      "Línea de importación del tema",
+	"Bundled themes":                         "Temas incluidos",
+	"Theme download":                         "Descarga de temas",
+	"Theme index":                            "Índice de temas",
+	"Building theme index":                   "Construyendo el índice de temas",
+	"Parsing theme files":                    "Analizando los archivos de tema",
+	"Applying themes in a scratch directory": "Aplicando temas en un directorio temporal",
+	"Generating themes":                      "Generando temas",
+	"Removed upstream (%d)":                  "Eliminados en el origen (%d)",
+	"Archive to themes/%s":                   "Archivar en themes/%s",
+	"Delete":                                 "Borrar",
+	"Keep":                                   "Conservar",
+}
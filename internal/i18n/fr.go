@@ -0,0 +1,476 @@
+package i18n
+
+var french = map[string]string{
+	// Prompts
+	"[y/N]: ":                  "[o/N] : ",
+	"Select option (number): ": "Choisissez une option (numéro) : ",
+	"Invalid choice. Please enter a number between 1 and %d.": "Choix invalide. Entrez un nombre entre 1 et %d.",
+	"Do you want to keep this theme?":                         "Voulez-vous garder ce thème ?",
+	"Do you want to keep this font?":                          "Voulez-vous garder cette police ?",
+	"Restore from '%s'?":                                      "Restaurer depuis « %s » ?",
+	"Permanently delete everything in the trash?":             "Supprimer définitivement tout le contenu de la corbeille ?",
+	"What should happen to these themes?":                     "Que faire de ces thèmes ?",
+	"Yes":                                                     "Oui",
+	"No":                                                      "Non",
+	"Select backup to restore":                                "Choisissez la sauvegarde à restaurer",
+	"(arrows move, type to filter, Esc cancels)":              "(flèches pour choisir, tapez pour filtrer, Échap pour annuler)",
+	"No matches":                                              "Aucun résultat",
+	"cancelled":                                               "annulé",
+
+	// Errors and hints
+	"Error: %v":            "Erreur : %v",
+	"Did you mean: %s?":    "Vouliez-vous dire : %s ?",
+	"theme '%s' not found": "thème « %s » introuvable",
+	"No themes are installed. Run 'alacritty-colors init' to download them.":                  "Aucun thème n'est installé. Lancez « alacritty-colors init » pour les télécharger.",
+	"Search installed themes with: %s":                                                        "Cherchez parmi les thèmes installés avec : %s",
+	"failed to apply theme: %w":                                                               "impossible d'appliquer le thème : %w",
+	"backup file not found: %s":                                                               "sauvegarde introuvable : %s",
+	"themes directory not found: %s":                                                          "dossier des thèmes introuvable : %s",
+	"Run 'alacritty-colors init' to create it and download themes,":                           "Lancez « alacritty-colors init » pour le créer et télécharger les thèmes,",
+	"or point to an existing one with 'alacritty-colors config set-path --themes-dir <dir>'.": "ou indiquez-en un existant avec « alacritty-colors config set-path --themes-dir <dossier> ».",
+
+	// Command output
+	"Applying theme: %s":                    "Application du thème : %s",
+	"Applied theme '%s'":                    "Thème « %s » appliqué",
+	"Backup created: %s":                    "Sauvegarde créée : %s",
+	"Failed to create backup: %v":           "Impossible de créer la sauvegarde : %v",
+	"Failed to update theme tracking: %v":   "Impossible d'enregistrer le thème appliqué : %v",
+	"No backups found":                      "Aucune sauvegarde trouvée",
+	"No themes found matching '%s'":         "Aucun thème ne correspond à « %s »",
+	"Generated theme saved: %s":             "Thème généré enregistré : %s",
+	"Launching interactive color editor...": "Lancement de l'éditeur de couleurs interactif...",
+	"Use 'q' to quit, 's' to save changes":  "« q » pour quitter, « s » pour enregistrer",
+	"Alacritty Colors Configuration":        "Configuration d'Alacritty Colors",
+	"Profile":                               "Profil",
+	"Config File":                           "Configuration",
+	"Themes Dir":                            "Dossier thèmes",
+	"Backup Dir":                            "Sauvegardes",
+	"Current Theme":                         "Thème actuel",
+	"Available Themes":                      "Thèmes dispo.",
+	"Backups":                               "Sauvegardes",
+	"None":                                  "Aucun",
+	"Settings":                              "Réglages",
+	"State Dir":                             "Dossier d'état",
+
+	// Interactive editor
+	"Tab: switch panels | ↑↓: navigate | ←→: adjust RGB values | Enter: edit | q: quit | s: save | r: reset": "Tab : changer de panneau | ↑↓ : naviguer | ←→ : ajuster RVB | Entrée : modifier | q : quitter | s : enregistrer | r : réinitialiser",
+	"Select a theme to start editing": "Choisissez un thème à modifier",
+	"Primary":                         "Principal",
+	"Cursor":                          "Curseur",
+	"Vi Mode Cursor":                  "Curseur du mode vi",
+	"(default)":                       "(par défaut)",
+	"Selection":                       "Sélection",
+	"Normal":                          "Normal",
+	"Bright":                          "Vif",
+	"Dim":                             "Atténué",
+	"Focus: Color Panel | Use arrow keys to navigate, Enter to edit":  "Panneau des couleurs | Flèches pour naviguer, Entrée pour modifier",
+	"Focus: Theme List | Use arrow keys to navigate, Enter to select": "Liste des thèmes | Flèches pour naviguer, Entrée pour choisir",
+	"You have unsaved changes. Are you sure you want to quit?":        "Des modifications ne sont pas enregistrées. Quitter quand même ?",
+	"Save & Quit":                    "Enregistrer et quitter",
+	"Quit":                           "Quitter",
+	"Cancel":                         "Annuler",
+	"No theme selected to save":      "Aucun thème sélectionné à enregistrer",
+	"Error loading themes: %v":       "Erreur au chargement des thèmes : %v",
+	"Error loading theme %s: %v":     "Erreur au chargement du thème %s : %v",
+	"Error saving theme: %v":         "Erreur à l'enregistrement du thème : %v",
+	"Theme '%s' saved successfully":  "Thème « %s » enregistré",
+	"Theme reset to original values": "Thème remis à ses valeurs d'origine",
+
+	// Status messages, labels and hints
+	"\nBright Colors:": "\nCouleurs vives :",
+	"\nNormal Colors:": "\nCouleurs normales :",
+	"\nYou can now see how the theme looks in your terminal.":                     "\nVous voyez maintenant le rendu du thème dans votre terminal.",
+	"\n⌨️  Controls: SPACE=select | n/p=nav | q=quit | +/-=speed":                 "\n⌨️  Commandes : ESPACE=choisir | n/p=naviguer | q=quitter | +/-=vitesse",
+	"\n⌨️  Controls: a/b or TAB=switch | SPACE/ENTER=pick the one shown | q=quit": "\n⌨️  Commandes : a/b ou TAB=alterner | ESPACE/ENTRÉE=choisir celui affiché | q=quitter",
+	"    Created: %s":                               "    Créé : %s",
+	"    Description: %s":                           "    Description : %s",
+	"    Effects: %s":                               "    Effets : %s",
+	"    Made with: %s":                             "    Créé avec : %s",
+	"    Theme: %s":                                 "    Thème : %s",
+	"  +/-         - Increase/decrease speed":       "  +/-         - Accélérer/ralentir",
+	"  SPACE/ENTER - Select current theme and exit": "  ESPACE/ENTRÉE - Choisir le thème affiché et quitter",
+	"  Save it with 'alacritty-colors theme adopt <name>' or reset it with 'alacritty-colors apply %s --force'": "  Enregistrez-le avec « alacritty-colors theme adopt <nom> » ou réinitialisez-le avec « alacritty-colors apply %s --force »",
+	"  n/RIGHT     - Next theme immediately":                                                               "  n/DROITE    - Thème suivant tout de suite",
+	"  p/LEFT      - Previous theme":                                                                       "  p/GAUCHE    - Thème précédent",
+	"  q/ESC       - Quit without applying":                                                                "  q/ÉCHAP     - Quitter sans appliquer",
+	"  r           - Restart slideshow":                                                                    "  r           - Relancer le diaporama",
+	"%d Alacritty instances are running; using %s (pick another with --socket)":                            "%d instances d'Alacritty tournent ; utilisation de %s (choisissez-en une autre avec --socket)",
+	"%d instance(s) did not pick up the theme; they may use another config file (alacritty --config-file)": "%d instance(s) n'ont pas pris le thème ; elles utilisent peut-être un autre fichier de configuration (alacritty --config-file)",
+	"%d instance(s) still show the previous theme because live reload is off":                              "%d instance(s) affichent encore l'ancien thème car le rechargement à chaud est désactivé",
+	"%d of %d color settings come from the theme; the rest are Alacritty defaults":                         "%d réglages de couleur sur %d viennent du thème ; les autres sont les valeurs par défaut d'Alacritty",
+	"%d smaller families not shown":                                                                        "%d familles plus petites non affichées",
+	"%s has the same normal and bright %s, so bold text in them looks regular":                             "%s a le même %s normal et vif, le texte en gras y paraît donc normal",
+	"A newer release is available":                                                                         "Une nouvelle version est disponible",
+	"Add --animate to cycle through them in a GIF.":                                                        "Ajoutez --animate pour les faire défiler dans un GIF.",
+	"Add --schedule daily.":                                                                                "Ajoutez --schedule daily.",
+	"Add <theme>.toml, or a glob such as gruvbox*.toml, to %s":                                             "Ajoutez <thème>.toml, ou un motif comme gruvbox*.toml, à %s",
+	"Add one with: alacritty-colors exclude add <theme|pattern>":                                           "Ajoutez-en un avec : alacritty-colors exclude add <thème|motif>",
+	"Add one with: alacritty-colors protect add <theme|pattern>":                                           "Ajoutez-en un avec : alacritty-colors protect add <thème|motif>",
+	"Add targets to [sync] in %s or name one, e.g. 'alacritty-colors sync tmux'":                           "Ajoutez des cibles à [sync] dans %s ou nommez-en une, p. ex. « alacritty-colors sync tmux »",
+	"Add your own as <name>%s in %s":                                                                       "Ajoutez les vôtres sous la forme <nom>%s dans %s",
+	"Added import line to %s":                                                                              "Ligne d'import ajoutée à %s",
+	"Added profile %s":                                                                                     "Profil %s ajouté",
+	"Added themes directory: %s":                                                                           "Dossier de thèmes ajouté : %s",
+	"Alacritty Colors Paths":                                                                               "Chemins d'Alacritty Colors",
+	"All %d theme(s) are valid":                                                                            "Les %d thème(s) sont valides",
+	"Already excluded":                                                                                     "Déjà exclu",
+	"Already protected":                                                                                    "Déjà protégé",
+	"Already searched: %s":                                                                                 "Déjà cherché : %s",
+	"Also Searched":                                                                                        "Aussi cherché",
+	"Applied '%s' to this terminal":                                                                        "« %s » appliqué à ce terminal",
+	"Applied theme '%s' to %s":                                                                             "Thème « %s » appliqué à %s",
+	"Applied theme: %s":                                                                                    "Thème appliqué : %s",
+	"Apply a theme anyway with: alacritty-colors apply <theme> --force":                                    "Appliquez quand même un thème avec : alacritty-colors apply <thème> --force",
+	"Apply a theme first: alacritty-colors apply <theme>":                                                  "Appliquez d'abord un thème : alacritty-colors apply <thème>",
+	"Apply it with: alacritty-colors apply %s":                                                             "Appliquez-le avec : alacritty-colors apply %s",
+	"Apply one first: alacritty-colors apply <theme>":                                                      "Appliquez-en un d'abord : alacritty-colors apply <thème>",
+	"Apply the best match: alacritty-colors apply %s":                                                      "Appliquez le meilleur résultat : alacritty-colors apply %s",
+	"Apply themes as usual, then save the session with: alacritty-colors record stop":                      "Appliquez des thèmes comme d'habitude, puis enregistrez la session avec : alacritty-colors record stop",
+	"Applying theme %s to %s":                                                                              "Application du thème %s à %s",
+	"Archived %d themes to %s":                                                                             "%d thèmes archivés dans %s",
+	"Author":                                                                                               "Auteur",
+	"Author: %s":                                                                                           "Auteur : %s",
+	"Auto-applying theme: %s":                                                                              "Application automatique du thème : %s",
+	"Available Backups":                                                                                    "Sauvegardes disponibles",
+	"Available themes":                                                                                     "Thèmes disponibles",
+	"Background Hue and Lightness":                                                                         "Teinte et luminosité du fond",
+	"Backups directory: %s":                                                                                "Dossier des sauvegardes : %s",
+	"Benchmark":                                                                                            "Mesure des performances",
+	"Bright colors":                                                                                        "Couleurs vives",
+	"Built":                                                                                                "Compilé",
+	"CA Certs":                                                                                             "Certificats CA",
+	"Calibration":                                                                                          "Calibrage",
+	"Cancelled":                                                                                            "Annulé",
+	"Champion":                                                                                             "Champion",
+	"Changelog":                                                                                            "Journal des modifications",
+	"Check the token in GITHUB_TOKEN or network.github_token, it may have expired": "Vérifiez le jeton dans GITHUB_TOKEN ou network.github_token, il a peut-être expiré",
+	"Checking for theme updates...":                                     "Recherche de mises à jour des thèmes...",
+	"Choose another name with --name, or pass --force to overwrite it.": "Choisissez un autre nom avec --name, ou passez --force pour l'écraser.",
+	"Choose another name, or pass --force to overwrite it.":             "Choisissez un autre nom, ou passez --force pour l'écraser.",
+	"Choose another name: alacritty-colors theme adopt <name>":          "Choisissez un autre nom : alacritty-colors theme adopt <nom>",
+	"Chroma": "Chroma",
+	"Cleaned up %d backup files, kept %d most recent":   "%d sauvegardes supprimées, les %d plus récentes conservées",
+	"Cleaned up %d theme files":                         "%d fichiers de thème supprimés",
+	"Cleaning Backup Files":                             "Nettoyage des sauvegardes",
+	"Cleaning Theme Files":                              "Nettoyage des fichiers de thème",
+	"Cleaning up themes older than %d days":             "Suppression des thèmes de plus de %d jours",
+	"Color Palette":                                     "Palette de couleurs",
+	"Colors adjusted: %s (the theme file is unchanged)": "Couleurs ajustées : %s (le fichier du thème est inchangé)",
+	"Commit":          "Commit",
+	"Config Profiles": "Profils de configuration",
+	"Config file: %s": "Fichier de configuration : %s",
+	"Configuration and colors restored from backup":                "Configuration et couleurs restaurées depuis la sauvegarde",
+	"Configuration and theme '%s' restored from backup":            "Configuration et thème « %s » restaurés depuis la sauvegarde",
+	"Configuration complete":                                       "Configuration terminée",
+	"Configuration paths updated successfully":                     "Chemins de configuration mis à jour",
+	"Configuration restored from backup":                           "Configuration restaurée depuis la sauvegarde",
+	"Configuration restored from backup (colors left as they are)": "Configuration restaurée depuis la sauvegarde (couleurs inchangées)",
+	"Contrast":  "Contraste",
+	"Controls:": "Commandes :",
+	"Converted %s from UTF-16 to UTF-8, which Alacritty requires (original kept as %s.utf16.bak)": "%s converti de UTF-16 en UTF-8, requis par Alacritty (original conservé sous %s.utf16.bak)",
+	"Create it first, or check the path given to --to.":                                           "Créez-le d'abord, ou vérifiez le chemin passé à --to.",
+	"Create one with 'alacritty-colors backup'":                                                   "Créez-en une avec « alacritty-colors backup »",
+	"Current":           "Actuel",
+	"Current theme":     "Thème actuel",
+	"Current theme: %s": "Thème actuel : %s",
+	"Cycling through %d themes with %v intervals":                                       "Défilement de %d thèmes toutes les %v",
+	"Define it in the settings file, e.g. collections.cozy = [\"gruvbox*\", \"nord\"]":  "Définissez-la dans le fichier de réglages, p. ex. collections.cozy = [\"gruvbox*\", \"nord\"]",
+	"Deleted %d files from the trash":                                                   "%d fichiers supprimés de la corbeille",
+	"Deleted %d themes (recoverable for %d days with 'alacritty-colors trash restore')": "%d thèmes supprimés (récupérables pendant %d jours avec « alacritty-colors trash restore »)",
+	"Deployed %s with %s": "%s déployé avec %s",
+	"Deployed To":         "Déployé vers",
+	"Description: %s":     "Description : %s",
+	"Dominant hue":        "Teinte dominante",
+	"Download themes with 'alacritty-colors update'.":             "Téléchargez des thèmes avec « alacritty-colors update ».",
+	"Downloaded theme: %s":                                        "Thème téléchargé : %s",
+	"Downloading from official repository and %d more sources...": "Téléchargement depuis le dépôt officiel et %d autres sources...",
+	"Downloading from official repository...":                     "Téléchargement depuis le dépôt officiel...",
+	"Downloading from source '%s'...":                             "Téléchargement depuis la source « %s »...",
+	"Downloading theme from %s":                                   "Téléchargement du thème depuis %s",
+	"Drop --socket to read the config files instead.":             "Retirez --socket pour lire plutôt les fichiers de configuration.",
+	"Dry run: '%s' was not saved":                                 "Simulation : « %s » n'a pas été enregistré",
+	"Effective Settings":                                          "Réglages effectifs",
+	"Excluded Themes":                                             "Thèmes exclus",
+	"Excluded from random and slideshow: %s":                      "Exclu du hasard et du diaporama : %s",
+	"Exported %d theme(s) for %s to %s":                           "%d thème(s) exporté(s) pour %s vers %s",
+	"Exported '%s' for %s to %s":                                  "« %s » exporté pour %s vers %s",
+	"Exported the %s schema to %s":                                "Schéma %s exporté vers %s",
+	"Exported the colors of '%s' to %s":                           "Couleurs de « %s » exportées vers %s",
+	"Failed to apply scheduled theme: %v":                         "Impossible d'appliquer le thème planifié : %v",
+	"Failed to apply theme: %v":                                   "Impossible d'appliquer le thème : %v",
+	"Failed to apply visual effects: %v":                          "Impossible d'appliquer les effets visuels : %v",
+	"Failed to apply workspace theme: %v":                         "Impossible d'appliquer le thème de l'espace de travail : %v",
+	"Failed to back up current.toml: %v":                          "Impossible de sauvegarder current.toml : %v",
+	"Failed to create %s: %v":                                     "Impossible de créer %s : %v",
+	"Failed to download source '%s': %v":                          "Impossible de télécharger la source « %s » : %v",
+	"Failed to download themes: %v":                               "Impossible de télécharger les thèmes : %v",
+	"Failed to export %s: %v":                                     "Impossible d'exporter %s : %v",
+	"Failed to index theme metadata: %v":                          "Impossible d'indexer les métadonnées des thèmes : %v",
+	"Failed to parse theme %s: %v":                                "Impossible de lire le thème %s : %v",
+	"Failed to prune %s: %v":                                      "Impossible d'élaguer %s : %v",
+	"Failed to quarantine %s: %v":                                 "Impossible de mettre %s en quarantaine : %v",
+	"Failed to read %s: %v":                                       "Impossible de lire %s : %v",
+	"Failed to read theme colors for notifications: %v":           "Impossible de lire les couleurs du thème pour les notifications : %v",
+	"Failed to reload settings: %v":                               "Impossible de recharger les réglages : %v",
+	"Failed to remove %s: %v":                                     "Impossible de supprimer %s : %v",
+	"Failed to remove old backup %s: %v":                          "Impossible de supprimer l'ancienne sauvegarde %s : %v",
+	"Failed to reset settings: %v":                                "Impossible de réinitialiser les réglages : %v",
+	"Failed to restore %s: %v":                                    "Impossible de restaurer %s : %v",
+	"Failed to restore '%s': %v":                                  "Impossible de restaurer « %s » : %v",
+	"Failed to restore original theme: %v":                        "Impossible de restaurer le thème d'origine : %v",
+	"Failed to restore previous theme: %v":                        "Impossible de restaurer le thème précédent : %v",
+	"Failed to set font: %v":                                      "Impossible de changer la police : %v",
+	"Failed to sync %s: %v":                                       "Impossible de synchroniser %s : %v",
+	"Failed to update manifest: %v":                               "Impossible de mettre à jour le manifeste : %v",
+	"Failed to write quarantine report: %v":                       "Impossible d'écrire le rapport de quarantaine : %v",
+	"Fetch more themes with 'alacritty-colors update', or recreate it with 'alacritty-colors generate --from-screenshot'": "Récupérez plus de thèmes avec « alacritty-colors update », ou recréez-le avec « alacritty-colors generate --from-screenshot »",
+	"File": "Fichier",
+	"Font '%s' doesn't seem to be installed; Alacritty will fall back to its default": "La police « %s » ne semble pas installée ; Alacritty utilisera sa police par défaut",
+	"Font Pairings":                                      "Associations de polices",
+	"Font set to %s":                                     "Police réglée sur %s",
+	"Force update: removing existing themes":             "Mise à jour forcée : suppression des thèmes existants",
+	"Froze %d colors from %s as theme '%s' (%s)":         "%d couleurs de %s figées dans le thème « %s » (%s)",
+	"Generating %s theme":                                "Génération d'un thème %s",
+	"GitHub Token":                                       "Jeton GitHub",
+	"GitHub rate limit reached, retrying in %s...":       "Limite de requêtes GitHub atteinte, nouvel essai dans %s...",
+	"Give a query, or a color with --color \"#rrggbb\".": "Indiquez une recherche, ou une couleur avec --color \"#rrggbb\".",
+	"Globs use * and ?, e.g. 'gruvbox*'; regular expressions go between slashes, e.g. '/^nord|_night$/'.": "Les motifs utilisent * et ?, p. ex. « gruvbox* » ; les expressions régulières vont entre barres obliques, p. ex. « /^nord|_night$/ ».",
+	"If Alacritty reports renamed options, 'alacritty migrate' updates the restored config":               "Si Alacritty signale des options renommées, « alacritty migrate » met à jour la configuration restaurée",
+	"If Alacritty reports unknown or renamed options, run 'alacritty migrate' on the restored config":     "Si Alacritty signale des options inconnues ou renommées, lancez « alacritty migrate » sur la configuration restaurée",
+	"Initializing with verbose output enabled":                                                            "Initialisation avec la sortie détaillée",
+	"Installed the %s service": "Service %s installé",
+	"Iterations":               "Itérations",
+	"Keep the changes as a new theme with 'alacritty-colors theme adopt <name>',": "Gardez les modifications comme nouveau thème avec « alacritty-colors theme adopt <nom> »,",
+	"Keeping %d most recent backups":                                              "Conservation des %d sauvegardes les plus récentes",
+	"Keeping %d protected themes: %s":                                             "Conservation de %d thèmes protégés : %s",
+	"Keeping %s: it is protected":                                                 "%s conservé : il est protégé",
+	"Keeping %s: it is the applied theme":                                         "%s conservé : c'est le thème appliqué",
+	"Keeping current theme %s although it was removed upstream":                   "Le thème actuel %s est conservé bien qu'il ait été retiré en amont",
+	"Keeping protected theme %s although it was removed upstream":                 "Le thème protégé %s est conservé bien qu'il ait été retiré en amont",
+	"Keeping theme '%s'":                                                          "Thème « %s » conservé",
+	"Kept %d locally modified or protected themes: %s":                            "%d thèmes modifiés localement ou protégés conservés : %s",
+	"Kept %d protected themes":                                                    "%d thèmes protégés conservés",
+	"Kept %d themes":                                                              "%d thèmes conservés",
+	"Latest":                                                                      "Dernière",
+	"Lightness":                                                                   "Luminosité",
+	"List backups with 'alacritty-colors restore --list'":                         "Listez les sauvegardes avec « alacritty-colors restore --list »",
+	"List backups with 'alacritty-colors restore --list', or run 'alacritty-colors restore' to pick one.": "Listez les sauvegardes avec « alacritty-colors restore --list », ou lancez « alacritty-colors restore » pour en choisir une.",
+	"List fonts with: alacritty-colors fonts list":                                                        "Listez les polices avec : alacritty-colors fonts list",
+	"List the trash with: alacritty-colors trash list":                                                    "Listez la corbeille avec : alacritty-colors trash list",
+	"Live Reload": "Rechargement à chaud",
+	"Live reload": "Rechargement à chaud",
+	"Local Theme": "Thème local",
+	"Locked":      "Verrouillé",
+	"Loosen the filters, or apply the theme directly.": "Assouplissez les filtres, ou appliquez directement le thème.",
+	"Matches":      "Résultats",
+	"Most Applied": "Les plus appliqués",
+	"Move or rename it first, then restore again":                                    "Déplacez-le ou renommez-le d'abord, puis restaurez à nouveau",
+	"Moved %d old backups to the trash (backup.keep = %d, backup.max_age_days = %d)": "%d anciennes sauvegardes déplacées dans la corbeille (backup.keep = %d, backup.max_age_days = %d)",
+	"Name a theme: alacritty-colors export alacritty-snippet <theme>":                "Nommez un thème : alacritty-colors export alacritty-snippet <thème>",
+	"Name it with: alacritty-colors watch-workspaces --wm <name>":                    "Indiquez-le avec : alacritty-colors watch-workspaces --wm <nom>",
+	"Name one or more themes, or pick a collection with --collection.":               "Nommez un ou plusieurs thèmes, ou choisissez une collection avec --collection.",
+	"Nearest family": "Famille la plus proche",
+	"No Alacritty named pipes found; Alacritty on Windows may not offer IPC, so only live reload applies themes to open windows": "Aucun tube nommé d'Alacritty trouvé ; sous Windows, Alacritty n'offre peut-être pas d'IPC, seul le rechargement à chaud applique donc les thèmes aux fenêtres ouvertes",
+	"No answer within %s":                                             "Pas de réponse dans les %s",
+	"No backup files found":                                           "Aucune sauvegarde trouvée",
+	"No backups to clean up (found %d, keeping %d)":                   "Aucune sauvegarde à nettoyer (%d trouvées, %d conservées)",
+	"No close match; the theme may not be installed":                  "Aucun résultat proche ; le thème n'est peut-être pas installé",
+	"No installed theme named '%s'":                                   "Aucun thème installé nommé « %s »",
+	"No local theme for %s":                                           "Pas de thème local pour %s",
+	"No longer excluded: %s":                                          "N'est plus exclu : %s",
+	"No longer protected: %s":                                         "N'est plus protégé : %s",
+	"No matching monospace fonts found":                               "Aucune police à chasse fixe correspondante",
+	"No old themes to remove":                                         "Aucun ancien thème à supprimer",
+	"No overrides yet":                                                "Aucune surcharge pour l'instant",
+	"No pairs found. Define some under [pairs] in the settings file.": "Aucune paire trouvée. Définissez-en sous [pairs] dans le fichier de réglages.",
+	"No running Alacritty instances found (set ALACRITTY_SOCKET if yours uses another path)": "Aucune instance d'Alacritty en cours (définissez ALACRITTY_SOCKET si la vôtre utilise un autre chemin)",
+	"No service installed":                                                     "Aucun service installé",
+	"No sync targets configured":                                               "Aucune cible de synchronisation configurée",
+	"No theme currently applied":                                               "Aucun thème appliqué",
+	"No themes are excluded":                                                   "Aucun thème n'est exclu",
+	"No themes are protected":                                                  "Aucun thème n'est protégé",
+	"No themes found":                                                          "Aucun thème trouvé",
+	"No themes have a color within %.1f of %s":                                 "Aucun thème n'a de couleur à moins de %.1f de %s",
+	"No themes installed to compare against":                                   "Aucun thème installé pour comparer",
+	"No themes to analyze":                                                     "Aucun thème à analyser",
+	"No themes to remove":                                                      "Aucun thème à supprimer",
+	"No window, font or color settings to reset":                               "Aucun réglage de fenêtre, de police ou de couleur à réinitialiser",
+	"None of %s is installed; using the generic monospace font":                "Aucune de %s n'est installée ; utilisation de la police monospace générique",
+	"Not found in the screenshot, derived instead: %s":                         "Absentes de la capture, déduites à la place : %s",
+	"Notification failed: %s":                                                  "Échec de la notification : %s",
+	"Notifications not sent: %v":                                               "Notifications non envoyées : %v",
+	"On Linux, install fontconfig so 'fc-list' is available":                   "Sous Linux, installez fontconfig pour disposer de « fc-list »",
+	"Or try again once the limit lifts":                                        "Ou réessayez une fois la limite levée",
+	"Original theme restored":                                                  "Thème d'origine restauré",
+	"Override them in the [font_pairings] table of %s":                         "Redéfinissez-les dans la table [font_pairings] de %s",
+	"Overrides are merged into current.toml when a theme is applied":           "Les surcharges sont fusionnées dans current.toml à l'application d'un thème",
+	"Pass --rotate-accents or --shuffle-accents.":                              "Passez --rotate-accents ou --shuffle-accents.",
+	"Pass a screenshot: alacritty-colors identify shot.png":                    "Passez une capture d'écran : alacritty-colors identify shot.png",
+	"Pass one with --output, e.g. -o ~/.local/share/konsole/":                  "Indiquez-en un avec --output, p. ex. -o ~/.local/share/konsole/",
+	"Preview with escape sequences in this terminal instead?":                  "Prévisualiser plutôt avec des séquences d'échappement dans ce terminal ?",
+	"Preview with escape sequences instead: alacritty-colors preview %s --osc": "Prévisualisez plutôt avec des séquences d'échappement : alacritty-colors preview %s --osc",
+	"Previous font restored":                                                   "Police précédente restaurée",
+	"Previous theme restored":                                                  "Thème précédent restauré",
+	"Protected Themes":                                                         "Thèmes protégés",
+	"Protected from updates and clean-up: %s":                                  "Protégé des mises à jour et du nettoyage : %s",
+	"Proxy":                               "Proxy",
+	"Quality":                             "Qualité",
+	"Quarantined %d invalid themes in %s": "%d thèmes invalides mis en quarantaine dans %s",
+	"Recorded %d theme change(s) over %s to %s":                                    "%d changement(s) de thème enregistré(s) sur %s dans %s",
+	"Recording theme changes":                                                      "Enregistrement des changements de thème",
+	"Releases are listed at https://github.com/vitruves/alacritty-colors/releases": "Les versions sont listées sur https://github.com/vitruves/alacritty-colors/releases",
+	"Reload command failed: %v %s":                                                 "Échec de la commande de rechargement : %v %s",
+	"Remove the lock with: alacritty-colors unlock":                                "Levez le verrou avec : alacritty-colors unlock",
+	"Removed %d old theme files":                                                   "%d anciens fichiers de thème supprimés",
+	"Removed %d theme(s)":                                                          "%d thème(s) supprimé(s)",
+	"Removed %s":                                                                   "%s supprimé",
+	"Removed files stay in the trash for %d days: alacritty-colors trash list":     "Les fichiers supprimés restent %d jours dans la corbeille : alacritty-colors trash list",
+	"Removed local theme from %s":                                                  "Thème local de %s supprimé",
+	"Rendered %d themes to %s":                                                     "%d thèmes rendus dans %s",
+	"Rendered %s to %s":                                                            "%s rendu dans %s",
+	"Rendered template '%s' for '%s' to %s":                                        "Modèle « %s » rendu pour « %s » dans %s",
+	"Replaced %s":                                                                  "%s remplacé",
+	"Replay it with: alacritty-colors replay %s":                                   "Rejouez-la avec : alacritty-colors replay %s",
+	"Replayed %d theme change(s)":                                                  "%d changement(s) de thème rejoué(s)",
+	"Reset %s":                                                                     "%s réinitialisé",
+	"Reset this terminal's colors":                                                 "Couleurs de ce terminal réinitialisées",
+	"Reset to default theme":                                                       "Thème par défaut rétabli",
+	"Restore cancelled":                                                            "Restauration annulée",
+	"Restore with: alacritty-colors trash restore <id|name>":                       "Restaurez avec : alacritty-colors trash restore <id|nom>",
+	"Restored %s to %s":                                                            "%s restauré dans %s",
+	"Restoring from backup: %s":                                                    "Restauration depuis la sauvegarde : %s",
+	"Restoring original theme...":                                                  "Restauration du thème d'origine...",
+	"Restoring previous theme...":                                                  "Restauration du thème précédent...",
+	"Restoring slot: %s":                                                           "Restauration de l'emplacement : %s",
+	"Return to it with: alacritty-colors restore --slot %s":                        "Revenez-y avec : alacritty-colors restore --slot %s",
+	"Reverted to theme '%s'":                                                       "Retour au thème « %s »",
+	"Run 'alacritty-colors --version --check'.":                                    "Lancez « alacritty-colors --version --check ».",
+	"Run 'alacritty-colors init' to download themes":                               "Lancez « alacritty-colors init » pour télécharger des thèmes",
+	"Run 'alacritty-colors update --incremental' to fetch these changes":           "Lancez « alacritty-colors update --incremental » pour récupérer ces changements",
+	"Run 'alacritty-colors update' first.":                                         "Lancez d'abord « alacritty-colors update ».",
+	"Run 'alacritty-colors update' once online to get the full collection":         "Lancez « alacritty-colors update » une fois en ligne pour obtenir toute la collection",
+	"Run 'alacritty-colors update' to download themes":                             "Lancez « alacritty-colors update » pour télécharger des thèmes",
+	"Run it in a terminal, without --yes or --no-input.":                           "Lancez-la dans un terminal, sans --yes ni --no-input.",
+	"Runner-up":  "Finaliste",
+	"Saturation": "Saturation",
+	"Save Dir":   "Dossier d'enregistrement",
+	"Save it with: alacritty-colors record stop":                        "Enregistrez-la avec : alacritty-colors record stop",
+	"Saved current.toml as theme '%s' (%s)":                             "current.toml enregistré comme thème « %s » (%s)",
+	"Saved harmonized theme '%s' (%s)":                                  "Thème harmonisé « %s » enregistré (%s)",
+	"Saved slot '%s'":                                                   "Emplacement « %s » enregistré",
+	"Schedule changed: light at %s, dark at %s":                         "Planning modifié : clair à %s, sombre à %s",
+	"Scheduler disabled in settings; waiting until it is enabled again": "Planificateur désactivé dans les réglages ; attente de sa réactivation",
+	"Scheduler is disabled; set scheduler.enabled = true in %s":         "Le planificateur est désactivé ; réglez scheduler.enabled = true dans %s",
+	"Scheduler running: light at %s, dark at %s":                        "Planificateur actif : clair à %s, sombre à %s",
+	"Scheduler stopped":                                                 "Planificateur arrêté",
+	"Seasonal palette for %s: %s":                                       "Palette de saison pour %s : %s",
+	"Selected random theme: %s":                                         "Thème tiré au hasard : %s",
+	"Selected theme: %s":                                                "Thème choisi : %s",
+	"Service uninstalled":                                               "Service désinstallé",
+	"Set %s = %s":                                                       "%s = %s défini",
+	"Set In":                                                            "Défini dans",
+	"Set apply.synthesize_brights = true in %s to give them a bright shade on apply": "Réglez apply.synthesize_brights = true dans %s pour leur donner une teinte vive à l'application",
+	"Set live_config_reload = true under [general], or open a new window":            "Réglez live_config_reload = true sous [general], ou ouvrez une nouvelle fenêtre",
+	"Setting Custom Paths":     "Définition des chemins personnalisés",
+	"Setting up configuration": "Mise en place de la configuration",
+	"Show the files and directories in use with: alacritty-colors config show --paths": "Affichez les fichiers et dossiers utilisés avec : alacritty-colors config show --paths",
+	"Showcase":        "Vitrine",
+	"Skipping %s: %v": "%s ignoré : %v",
+	"Slideshow complete. Press SPACE to keep current theme or q to restore original.": "Diaporama terminé. Appuyez sur ESPACE pour garder le thème affiché ou sur q pour revenir à l'original.",
+	"Slots":                          "Emplacements",
+	"Slowest Theme Files":            "Fichiers de thème les plus lents",
+	"Source":                         "Source",
+	"Speed decreased - interval: %v": "Vitesse réduite - intervalle : %v",
+	"Speed increased - interval: %v": "Vitesse augmentée - intervalle : %v",
+	"Start with: alacritty-colors record start": "Commencez avec : alacritty-colors record start",
+	"Status": "État",
+	"Stopped syncing after %d of %d themes: %v": "Synchronisation arrêtée après %d thèmes sur %d : %v",
+	"Sync %s: %v":                         "Synchronisation de %s : %v",
+	"Sync Targets":                        "Cibles de synchronisation",
+	"Synced %d new and %d updated themes": "%d nouveaux thèmes et %d mis à jour synchronisés",
+	"Synced %s":                           "%s synchronisé",
+	"Syncing with official repository...": "Synchronisation avec le dépôt officiel...",
+	"Tags":                                "Étiquettes",
+	"Temperature":                         "Température",
+	"Templates":                           "Modèles",
+	"Test it by running some commands or checking your editor.":                                "Essayez-le en lançant quelques commandes ou en regardant votre éditeur.",
+	"The alacritty command is not on PATH, so running windows can't be checked":                "La commande alacritty n'est pas dans le PATH, les fenêtres ouvertes ne peuvent donc pas être vérifiées",
+	"The font is now temporarily applied to your terminal!":                                    "La police est maintenant appliquée temporairement à votre terminal !",
+	"The only schedule is daily: alacritty-colors random --schedule daily":                     "Le seul planning est quotidien : alacritty-colors random --schedule daily",
+	"The preview may not show in this terminal:":                                               "L'aperçu risque de ne pas s'afficher dans ce terminal :",
+	"The scheduler is not fully configured yet; see 'alacritty-colors schedule --help'":        "Le planificateur n'est pas encore entièrement configuré ; voir « alacritty-colors schedule --help »",
+	"The scheduler, daemon, slideshows and random won't change it; 'apply --force' still does": "Le planificateur, le démon, les diaporamas et random ne le changeront pas ; « apply --force » le peut toujours",
+	"The theme is now temporarily applied to your terminal!":                                   "Le thème est maintenant appliqué temporairement à votre terminal !",
+	"The trash is empty":                      "La corbeille est vide",
+	"Theme Collection Stats":                  "Statistiques de la collection",
+	"Theme Colors":                            "Couleurs du thème",
+	"Theme Schedule":                          "Planning des thèmes",
+	"Theme Sources":                           "Sources de thèmes",
+	"Theme changes are not locked":            "Les changements de thème ne sont pas verrouillés",
+	"Theme changes unlocked":                  "Changements de thème déverrouillés",
+	"Theme for %s set to '%s'":                "Thème de %s réglé sur « %s »",
+	"Theme of the day for %s: %s":             "Thème du jour pour %s : %s",
+	"Theme of the day is already applied: %s": "Le thème du jour est déjà appliqué : %s",
+	"Themes":                               "Thèmes",
+	"Themes are up to date (%d unchanged)": "Les thèmes sont à jour (%d inchangés)",
+	"Themes directory: %s":                 "Dossier des thèmes : %s",
+	"This backup has no current.toml; the colors were left as they are":                                             "Cette sauvegarde n'a pas de current.toml ; les couleurs sont inchangées",
+	"This backup was made with Alacritty %s, before configs moved from YAML to TOML in 0.13; you have %s":           "Cette sauvegarde a été faite avec Alacritty %s, avant le passage de YAML à TOML en 0.13 ; vous avez %s",
+	"This backup was made with Alacritty %s, newer than your %s; some of its options may not exist in your version": "Cette sauvegarde a été faite avec Alacritty %s, plus récent que votre %s ; certaines options peuvent manquer dans votre version",
+	"This backup was made with Alacritty %s; you have %s":                                                           "Cette sauvegarde a été faite avec Alacritty %s ; vous avez %s",
+	"Title": "Titre",
+	"Tournament abandoned, restoring original theme...":                   "Tournoi abandonné, restauration du thème d'origine...",
+	"Try again once the limit lifts":                                      "Réessayez une fois la limite levée",
+	"Up to date":                                                          "À jour",
+	"Updated %d themes":                                                   "%d thèmes mis à jour",
+	"Updated %d themes from '%s'":                                         "%d thèmes mis à jour depuis « %s »",
+	"Updated %d themes from extra sources":                                "%d thèmes mis à jour depuis les sources supplémentaires",
+	"Updated backup directory: %s -> %s":                                  "Dossier des sauvegardes modifié : %s -> %s",
+	"Updated config path: %s -> %s":                                       "Chemin de configuration modifié : %s -> %s",
+	"Updated save directory: %s -> %s":                                    "Dossier d'enregistrement modifié : %s -> %s",
+	"Updated theme database (%d themes)":                                  "Base de thèmes mise à jour (%d thèmes)",
+	"Updated themes directory: %s -> %s":                                  "Dossier des thèmes modifié : %s -> %s",
+	"Updating theme database":                                             "Mise à jour de la base de thèmes",
+	"Upstream":                                                            "En amont",
+	"Use --format table or --format json.":                                "Utilisez --format table ou --format json.",
+	"Use a plain file name, e.g. --name nord_even":                        "Utilisez un simple nom de fichier, p. ex. --name nord_even",
+	"Use a plain file name, e.g. alacritty-colors theme freeze my_tweaks": "Utilisez un simple nom de fichier, p. ex. alacritty-colors theme freeze my_tweaks",
+	"Use a plain name, e.g. --slot stable":                                "Utilisez un simple nom, p. ex. --slot stable",
+	"Use a time like 18:00 or a duration like 2h30m.":                     "Utilisez une heure comme 18:00 ou une durée comme 2h30m.",
+	"Use it with --profile %s or 'alacritty-colors config use %s'":        "Utilisez-le avec --profile %s ou « alacritty-colors config use %s »",
+	"Use on or off, e.g. alacritty-colors effects bold-bright on":         "Utilisez on ou off, p. ex. alacritty-colors effects bold-bright on",
+	"Use one of: theme, settings":                                         "Utilisez l'un de : theme, settings",
+	"Using profile %s":                                                    "Profil utilisé : %s",
+	"Variant":                                                             "Variante",
+	"Version":                                                             "Version",
+	"Version Information":                                                 "Informations de version",
+	"Wallpaper: %s":                                                       "Fond d'écran : %s",
+	"Watching %s workspaces (Ctrl+C to stop)":                             "Surveillance des espaces de travail %s (Ctrl+C pour arrêter)",
+	"Watching the wallpaper every %s (Ctrl+C to stop)":                    "Surveillance du fond d'écran toutes les %s (Ctrl+C pour arrêter)",
+	"With draw_bold_text_with_bright_colors, bold text in those colors looks regular; apply.synthesize_brights gives them a brighter shade": "Avec draw_bold_text_with_bright_colors, le texte en gras dans ces couleurs paraît normal ; apply.synthesize_brights leur donne une teinte plus vive",
+	"Write a .png for one theme or a .gif for an animation.":                                                                                "Écrivez un .png pour un thème ou un .gif pour une animation.",
+	"Wrote %s": "%s écrit",
+	"alacritty-colors %s is available (you have %s): %s":                      "alacritty-colors %s est disponible (vous avez %s) : %s",
+	"current.toml matches the applied theme; saving a copy anyway":            "current.toml correspond au thème appliqué ; une copie est enregistrée quand même",
+	"current.toml was modified after '%s' was applied":                        "current.toml a été modifié après l'application de « %s »",
+	"live_config_reload is off in %s; open windows keep their colors":         "live_config_reload est désactivé dans %s ; les fenêtres ouvertes gardent leurs couleurs",
+	"or colors: alacritty-colors identify --colors '#282a36,#f8f8f2,#ff5555'": "ou des couleurs : alacritty-colors identify --colors '#282a36,#f8f8f2,#ff5555'",
+	"or discard them with 'alacritty-colors apply <theme> --force'.":          "ou abandonnez-les avec « alacritty-colors apply <thème> --force ».",
+	"other settings":                         "autres réglages",
+	"🎨 Theme Slideshow":                      "🎨 Diaporama de thèmes",
+	"🏆 Tournament Winner":                    "🏆 Vainqueur du tournoi",
+	"👤 Author: %s":                           "👤 Auteur : %s",
+	"Alacritty config":                       "Configuration d'Alacritty",
+	"Theme import line":                      "Ligne d'import du thème",
+	"Bundled themes":                         "Thèmes fournis",
+	"Theme download":                         "Téléchargement des thèmes",
+	"Theme index":                            "Index des thèmes",
+	"Building theme index":                   "Construction de l'index des thèmes",
+	"Parsing theme files":                    "Lecture des fichiers de thème",
+	"Applying themes in a scratch directory": "Application des thèmes dans un dossier temporaire",
+	"Generating themes":                      "Génération de thèmes",
+	"Removed upstream (%d)":                  "Retirés en amont (%d)",
+	"Archive to themes/%s":                   "Archiver dans themes/%s",
+	"Delete":                                 "Supprimer",
+	"Keep":                                   "Garder",
+}
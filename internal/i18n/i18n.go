@@ -0,0 +1,105 @@
+// Package i18n translates user-facing messages. Catalogs are keyed by the
+// English text, so a message missing from a catalog is shown in English,
+// and the language is detected from LC_ALL, LC_MESSAGES and LANG.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Languages lists the languages with a catalog, English first
+var Languages = []string{"en", "fr", "de", "es"}
+
+// catalogs maps a language to its translations, keyed by the English text
+var catalogs = map[string]map[string]string{
+	"fr": french,
+	"de": german,
+	"es": spanish,
+}
+
+// yesAnswers are accepted by confirmation prompts besides "y" and "yes"
+var yesAnswers = map[string][]string{
+	"fr": {"o", "oui"},
+	"de": {"j", "ja"},
+	"es": {"s", "si", "sí"},
+}
+
+var (
+	once     sync.Once
+	language = "en"
+)
+
+// Language returns the detected language, "en" when the locale has no
+// catalog
+func Language() string {
+	once.Do(func() {
+		language = detect()
+	})
+	return language
+}
+
+// SetLanguage overrides the detected language; unknown languages fall back
+// to English
+func SetLanguage(lang string) {
+	once.Do(func() {})
+	language = "en"
+	if _, ok := catalogs[lang]; ok {
+		language = lang
+	}
+}
+
+// detect reads the language from the first locale variable that is set,
+// e.g. "fr" from "fr_FR.UTF-8"
+func detect() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		lang := strings.ToLower(value)
+		if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+			lang = lang[:i]
+		}
+		if _, ok := catalogs[lang]; ok {
+			return lang
+		}
+		return "en"
+	}
+	return "en"
+}
+
+// T returns the translation of an English message, or the message itself
+func T(message string) string {
+	if translated, ok := catalogs[Language()][message]; ok {
+		return translated
+	}
+	return message
+}
+
+// Sprintf formats the translation of an English format string
+func Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Errorf is fmt.Errorf with a translated format string, so %w still wraps
+func Errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(T(format), args...)
+}
+
+// IsYes reports whether a prompt answer means yes in English or the
+// current language
+func IsYes(answer string) bool {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer == "y" || answer == "yes" {
+		return true
+	}
+	for _, yes := range yesAnswers[Language()] {
+		if answer == yes {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package i18n
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+)
+
+// verb matches a fmt verb, so translations can be checked to take the
+// same arguments in the same order
+var verb = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestCatalogsTakeTheSameArguments(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for message, translated := range catalog {
+			want, got := verb.FindAllString(message, -1), verb.FindAllString(translated, -1)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: %q has verbs %q, want %q", lang, translated, got, want)
+			}
+		}
+	}
+}
+
+func TestCatalogsCoverTheSameMessages(t *testing.T) {
+	keys := func(catalog map[string]string) []string {
+		var out []string
+		for message := range catalog {
+			out = append(out, message)
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	want := keys(french)
+	for lang, catalog := range catalogs {
+		got := keys(catalog)
+		if !reflect.DeepEqual(got, want) {
+			for _, message := range want {
+				if _, ok := catalog[message]; !ok {
+					t.Errorf("%s: missing %q", lang, message)
+				}
+			}
+			for _, message := range got {
+				if _, ok := french[message]; !ok {
+					t.Errorf("%s: %q is not in the French catalog", lang, message)
+				}
+			}
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name                       string
+		lcAll, lcMessages, langVar string
+		want                       string
+	}{
+		{name: "unset", want: "en"},
+		{name: "LANG", langVar: "fr_FR.UTF-8", want: "fr"},
+		{name: "LC_MESSAGES over LANG", lcMessages: "de_DE.UTF-8", langVar: "fr_FR.UTF-8", want: "de"},
+		{name: "LC_ALL over all", lcAll: "es_ES.UTF-8", lcMessages: "de_DE.UTF-8", langVar: "fr_FR.UTF-8", want: "es"},
+		{name: "C locale", langVar: "C", want: "en"},
+		{name: "no catalog", langVar: "ja_JP.UTF-8", want: "en"},
+		{name: "first set variable decides", lcAll: "C.UTF-8", langVar: "fr_FR.UTF-8", want: "en"},
+		{name: "modifier", langVar: "de_DE@euro", want: "de"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_MESSAGES", tt.lcMessages)
+			t.Setenv("LANG", tt.langVar)
+			if got := detect(); got != tt.want {
+				t.Errorf("detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package notify
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// MQTT 3.1.1 control packets; only what publishing at QoS 0 needs
+const (
+	mqttConnect    = 0x10
+	mqttConnAck    = 0x20
+	mqttPublish    = 0x30
+	mqttDisconnect = 0xe0
+)
+
+// Publish sends the payload to each topic on an MQTT broker, given as
+// mqtt://[user:password@]host[:1883] or mqtts:// for TLS on port 8883.
+// TLS connections trust the CA certificates of transport; MQTT connects
+// directly, so its proxy does not apply.
+func Publish(transport *http.Transport, broker string, topics []string, p Payload) error {
+	u, err := url.Parse(broker)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid MQTT broker URL: %s", broker)
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	conn, err := dialMQTT(u, transport.TLSClientConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to MQTT broker: %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(Timeout))
+
+	w := bufio.NewWriter(conn)
+	if err := writePacket(w, mqttConnect, connectBody(u)); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	ack := make([]byte, 4)
+	if _, err := io.ReadFull(conn, ack); err != nil {
+		return fmt.Errorf("no reply from MQTT broker: %w", err)
+	}
+	if ack[0] != mqttConnAck || ack[3] != 0 {
+		return fmt.Errorf("MQTT broker refused the connection (code %d)", ack[3])
+	}
+
+	for _, topic := range topics {
+		if err := writePacket(w, mqttPublish, append(mqttString(topic), body...)); err != nil {
+			return err
+		}
+	}
+	if err := writePacket(w, mqttDisconnect, nil); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func dialMQTT(u *url.URL, base *tls.Config) (net.Conn, error) {
+	host := u.Host
+	dialer := &net.Dialer{Timeout: Timeout}
+
+	switch u.Scheme {
+	case "mqtts", "ssl", "tls":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "8883")
+		}
+		config := &tls.Config{}
+		if base != nil {
+			config = base.Clone()
+		}
+		config.ServerName = u.Hostname()
+		return tls.DialWithDialer(dialer, "tcp", host, config)
+	case "mqtt", "tcp", "":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "1883")
+		}
+		return dialer.Dial("tcp", host)
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q (use mqtt:// or mqtts://)", u.Scheme)
+	}
+}
+
+func connectBody(u *url.URL) []byte {
+	flags := byte(0x02) // clean session
+	var credentials []byte
+	if u.User != nil {
+		flags |= 0x80
+		credentials = append(credentials, mqttString(u.User.Username())...)
+		if password, ok := u.User.Password(); ok {
+			flags |= 0x40
+			credentials = append(credentials, mqttString(password)...)
+		}
+	}
+
+	body := append(mqttString("MQTT"), 4, flags, 0, 30) // level 4, 30s keep-alive
+	body = append(body, mqttString(fmt.Sprintf("alacritty-colors-%d", os.Getpid()))...)
+	return append(body, credentials...)
+}
+
+func writePacket(w io.Writer, packetType byte, body []byte) error {
+	header := []byte{packetType}
+	// Remaining length is a base-128 varint
+	n := len(body)
+	for {
+		b := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			b |= 0x80
+		}
+		header = append(header, b)
+		if n == 0 {
+			break
+		}
+	}
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(body)
+	return err
+}
+
+func mqttString(s string) []byte {
+	return append([]byte{byte(len(s) >> 8), byte(len(s))}, s...)
+}
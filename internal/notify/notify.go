@@ -0,0 +1,52 @@
+// Package notify tells other systems, such as home automation, that the
+// theme changed: webhooks receive a JSON payload by HTTP POST and MQTT
+// topics receive the same payload as a message.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Timeout bounds each webhook request and MQTT session
+const Timeout = 5 * time.Second
+
+// Payload describes an applied theme
+type Payload struct {
+	Event     string            `json:"event"`
+	Theme     string            `json:"theme"`
+	Previous  string            `json:"previous,omitempty"`
+	AppliedAt time.Time         `json:"applied_at"`
+	Palette   map[string]string `json:"palette"`
+}
+
+// Webhook posts the payload as JSON to url through transport, which
+// carries the proxy and CA certificates
+func Webhook(transport *http.Transport, url string, p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "alacritty-colors")
+
+	client := &http.Client{Timeout: Timeout, Transport: transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Package prompt reads and renders the prompt cache. It imports nothing
+// heavier than the standard library, so the prompt helper binary starts
+// in a few milliseconds.
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CacheFile holds the last applied theme and its swatch colors, so the
+// prompt helper never has to load settings or parse a theme
+const CacheFile = "prompt.cache"
+
+// Styles wrap escape sequences the way each consumer needs them
+const (
+	Plain = "plain"
+	Bash  = "bash"
+	Zsh   = "zsh"
+	Tmux  = "tmux"
+	None  = "none"
+)
+
+// CheckStyle reports an unknown style
+func CheckStyle(style string) error {
+	switch style {
+	case Plain, Bash, Zsh, Tmux, None:
+		return nil
+	}
+	return fmt.Errorf("unknown prompt style '%s' (use plain, bash, zsh, tmux or none)", style)
+}
+
+// Entry is the cached prompt state
+type Entry struct {
+	Theme  string
+	Colors []string
+}
+
+// WriteCache records the applied theme and its swatch colors in stateDir
+func WriteCache(stateDir string, entry Entry) error {
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return err
+	}
+	content := entry.Theme + "\n" + strings.Join(entry.Colors, " ") + "\n"
+	return os.WriteFile(filepath.Join(stateDir, CacheFile), []byte(content), 0644)
+}
+
+// ReadCache loads the prompt cache from stateDir
+func ReadCache(stateDir string) (Entry, error) {
+	data, err := os.ReadFile(filepath.Join(stateDir, CacheFile))
+	if err != nil {
+		return Entry{}, err
+	}
+
+	lines := strings.SplitN(string(data), "\n", 3)
+	entry := Entry{Theme: strings.TrimSpace(lines[0])}
+	if len(lines) > 1 {
+		entry.Colors = strings.Fields(lines[1])
+	}
+	if entry.Theme == "" {
+		return Entry{}, fmt.Errorf("empty prompt cache")
+	}
+	return entry, nil
+}
+
+// Render formats the entry as "name ●●●●●●" with truecolor swatches.
+// Escape sequences are wrapped for bash and zsh so line editing keeps
+// working, and tmux gets its own #[fg=] markup.
+func Render(entry Entry, style, symbol string) (string, error) {
+	if err := CheckStyle(style); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString(entry.Theme)
+
+	if style == None || symbol == "" || len(entry.Colors) == 0 {
+		return b.String(), nil
+	}
+
+	b.WriteByte(' ')
+	for _, color := range entry.Colors {
+		if style == Tmux {
+			fmt.Fprintf(&b, "#[fg=%s]%s", color, symbol)
+		} else {
+			b.WriteString(escape(style, truecolor(color)))
+			b.WriteString(symbol)
+		}
+	}
+
+	if style == Tmux {
+		b.WriteString("#[default]")
+	} else {
+		b.WriteString(escape(style, "\033[0m"))
+	}
+	return b.String(), nil
+}
+
+func truecolor(hex string) string {
+	var r, g, bl int
+	fmt.Sscanf(hex, "#%02x%02x%02x", &r, &g, &bl)
+	return fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, bl)
+}
+
+func escape(style, seq string) string {
+	switch style {
+	case Bash:
+		// \001 and \002 are what \[ and \] become, and also work in
+		// command substitutions
+		return "\001" + seq + "\002"
+	case Zsh:
+		return "%{" + seq + "%}"
+	}
+	return seq
+}
@@ -0,0 +1,75 @@
+// Package schema builds JSON Schema documents from Go types, so the schemas
+// follow the structs they describe
+package schema
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Draft is the JSON Schema dialect of the generated documents
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema object
+type Schema map[string]any
+
+// Document returns s as a top-level schema with the given title
+func Document(title string, s Schema) Schema {
+	doc := Schema{"$schema": Draft, "title": title}
+	for key, value := range s {
+		doc[key] = value
+	}
+	return doc
+}
+
+// Of returns the schema of values of type t. Struct fields are named by
+// the given struct tag, such as "json" or "toml"; fields without one or
+// tagged "-" are left out.
+func Of(t reflect.Type, tag string) Schema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return Of(t.Elem(), tag)
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return Schema{"type": "array", "items": Of(t.Elem(), tag)}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": Of(t.Elem(), tag)}
+	case reflect.Struct:
+		properties := Schema{}
+		for i := 0; i < t.NumField(); i++ {
+			if name, ok := FieldName(t.Field(i), tag); ok {
+				properties[name] = Of(t.Field(i).Type, tag)
+			}
+		}
+		return Schema{"type": "object", "properties": properties}
+	default:
+		return Schema{}
+	}
+}
+
+// FieldName returns the name a struct field goes by under tag
+func FieldName(f reflect.StructField, tag string) (string, bool) {
+	name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+	if !f.IsExported() || name == "" || name == "-" {
+		return "", false
+	}
+	return name, true
+}
+
+// Field returns the field of struct type t named name under tag
+func Field(t reflect.Type, name, tag string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if fieldName, ok := FieldName(t.Field(i), tag); ok && fieldName == name {
+			return t.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
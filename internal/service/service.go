@@ -0,0 +1,280 @@
+// Package service installs user-level background services that keep the
+// scheduled theme applied: systemd units on Linux and launchd agents on macOS.
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Modes
+const (
+	// ModeScheduler runs 'schedule run' from a timer at each transition
+	ModeScheduler = "scheduler"
+	// ModeDaemon keeps 'daemon' running in the background
+	ModeDaemon = "daemon"
+)
+
+const (
+	unitPrefix   = "alacritty-colors"
+	launchdLabel = "com.vitruves.alacritty-colors"
+)
+
+// Schedule holds the transition times the scheduler timer fires at
+type Schedule struct {
+	LightAt string
+	DarkAt  string
+}
+
+// File is a unit or agent file to write
+type File struct {
+	Path    string
+	Content []byte
+	// Enable is set on the file to enable and start once everything is written
+	Enable bool
+}
+
+// Files renders the service files for a mode. args are passed to the
+// executable before the subcommand, e.g. --profile work.
+func Files(mode, executable string, args []string, s Schedule) ([]File, error) {
+	if mode != ModeScheduler && mode != ModeDaemon {
+		return nil, fmt.Errorf("unknown service mode '%s'", mode)
+	}
+
+	switch runtime.GOOS {
+	case "linux":
+		return systemdFiles(mode, executable, args, s)
+	case "darwin":
+		return launchdFiles(mode, executable, args, s)
+	default:
+		return nil, fmt.Errorf("services are supported on Linux (systemd) and macOS (launchd), not %s", runtime.GOOS)
+	}
+}
+
+// Install writes the service files for a mode and enables them, replacing
+// a service installed in the other mode
+func Install(mode, executable string, args []string, s Schedule) ([]string, error) {
+	files, err := Files(mode, executable, args, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := Uninstall(); err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, f := range files {
+		if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create service directory: %w", err)
+		}
+		if err := os.WriteFile(f.Path, f.Content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", f.Path, err)
+		}
+		paths = append(paths, f.Path)
+	}
+
+	for _, f := range files {
+		if f.Enable {
+			if err := enable(f.Path); err != nil {
+				return paths, err
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+// Uninstall disables and removes every service file this package installs
+func Uninstall() ([]string, error) {
+	var removed []string
+	for _, path := range installedPaths() {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		disable(path)
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		removed = append(removed, path)
+	}
+
+	if len(removed) > 0 && runtime.GOOS == "linux" {
+		exec.Command("systemctl", "--user", "daemon-reload").Run()
+	}
+	return removed, nil
+}
+
+// Installed returns the service files currently present
+func Installed() []string {
+	var present []string
+	for _, path := range installedPaths() {
+		if _, err := os.Stat(path); err == nil {
+			present = append(present, path)
+		}
+	}
+	return present
+}
+
+// installedPaths lists every file Install may have written
+func installedPaths() []string {
+	switch runtime.GOOS {
+	case "linux":
+		dir := systemdDir()
+		return []string{
+			filepath.Join(dir, unitPrefix+"-scheduler.timer"),
+			filepath.Join(dir, unitPrefix+"-scheduler.service"),
+			filepath.Join(dir, unitPrefix+".service"),
+		}
+	case "darwin":
+		dir := launchdDir()
+		return []string{
+			filepath.Join(dir, launchdLabel+"."+ModeScheduler+".plist"),
+			filepath.Join(dir, launchdLabel+"."+ModeDaemon+".plist"),
+		}
+	}
+	return nil
+}
+
+func systemdDir() string {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" || !filepath.IsAbs(configHome) {
+		home, _ := os.UserHomeDir()
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "systemd", "user")
+}
+
+func launchdDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, "Library", "LaunchAgents")
+}
+
+func systemdFiles(mode, executable string, args []string, s Schedule) ([]File, error) {
+	dir := systemdDir()
+
+	if mode == ModeDaemon {
+		var b bytes.Buffer
+		b.WriteString("[Unit]\nDescription=Alacritty Colors theme scheduler\nAfter=graphical-session.target\n\n")
+		fmt.Fprintf(&b, "[Service]\nType=simple\nExecStart=%s\nRestart=on-failure\nRestartSec=30\n\n", systemdCommand(executable, args, "daemon"))
+		b.WriteString("[Install]\nWantedBy=default.target\n")
+		return []File{{Path: filepath.Join(dir, unitPrefix+".service"), Content: b.Bytes(), Enable: true}}, nil
+	}
+
+	var service bytes.Buffer
+	service.WriteString("[Unit]\nDescription=Apply the scheduled Alacritty Colors theme\n\n")
+	fmt.Fprintf(&service, "[Service]\nType=oneshot\nExecStart=%s\n", systemdCommand(executable, args, "schedule", "run"))
+
+	var timer bytes.Buffer
+	timer.WriteString("[Unit]\nDescription=Switch Alacritty Colors between light and dark themes\n\n[Timer]\n")
+	for _, clock := range []string{s.LightAt, s.DarkAt} {
+		if _, err := time.Parse("15:04", clock); err != nil {
+			return nil, fmt.Errorf("invalid schedule time %q", clock)
+		}
+		fmt.Fprintf(&timer, "OnCalendar=*-*-* %s:00\n", clock)
+	}
+	// Catch up after login or resume, when a transition may have been missed
+	timer.WriteString("OnStartupSec=15s\nPersistent=true\n\n[Install]\nWantedBy=timers.target\n")
+
+	return []File{
+		{Path: filepath.Join(dir, unitPrefix+"-scheduler.service"), Content: service.Bytes()},
+		{Path: filepath.Join(dir, unitPrefix+"-scheduler.timer"), Content: timer.Bytes(), Enable: true},
+	}, nil
+}
+
+// systemdCommand quotes an ExecStart command line
+func systemdCommand(executable string, args []string, subcommand ...string) string {
+	all := append(append([]string{executable}, args...), subcommand...)
+	quoted := make([]string, len(all))
+	for i, arg := range all {
+		if strings.ContainsAny(arg, " \t\"'\\") {
+			quoted[i] = strconv.Quote(arg)
+		} else {
+			quoted[i] = arg
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+func launchdFiles(mode, executable string, args []string, s Schedule) ([]File, error) {
+	label := launchdLabel + "." + mode
+	subcommand := []string{"daemon"}
+	if mode == ModeScheduler {
+		subcommand = []string{"schedule", "run"}
+	}
+
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+	fmt.Fprintf(&b, "\t<key>Label</key>\n\t<string>%s</string>\n", label)
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range append(append([]string{executable}, args...), subcommand...) {
+		fmt.Fprintf(&b, "\t\t<string>%s</string>\n", xmlEscape(arg))
+	}
+	b.WriteString("\t</array>\n\t<key>RunAtLoad</key>\n\t<true/>\n")
+
+	if mode == ModeDaemon {
+		b.WriteString("\t<key>KeepAlive</key>\n\t<true/>\n")
+	} else {
+		b.WriteString("\t<key>StartCalendarInterval</key>\n\t<array>\n")
+		for _, clock := range []string{s.LightAt, s.DarkAt} {
+			t, err := time.Parse("15:04", clock)
+			if err != nil {
+				return nil, fmt.Errorf("invalid schedule time %q", clock)
+			}
+			fmt.Fprintf(&b, "\t\t<dict>\n\t\t\t<key>Hour</key>\n\t\t\t<integer>%d</integer>\n\t\t\t<key>Minute</key>\n\t\t\t<integer>%d</integer>\n\t\t</dict>\n", t.Hour(), t.Minute())
+		}
+		b.WriteString("\t</array>\n")
+	}
+
+	b.WriteString("</dict>\n</plist>\n")
+
+	return []File{{Path: filepath.Join(launchdDir(), label+".plist"), Content: b.Bytes(), Enable: true}}, nil
+}
+
+func enable(path string) error {
+	var cmds [][]string
+	switch runtime.GOOS {
+	case "linux":
+		cmds = [][]string{
+			{"systemctl", "--user", "daemon-reload"},
+			{"systemctl", "--user", "enable", "--now", filepath.Base(path)},
+		}
+	case "darwin":
+		cmds = [][]string{{"launchctl", "load", "-w", path}}
+	}
+
+	for _, args := range cmds {
+		if out, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to run %s: %v %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// disable stops a service before its file is removed. Errors are ignored
+// since the service may never have been enabled.
+func disable(path string) {
+	switch runtime.GOOS {
+	case "linux":
+		if strings.HasSuffix(path, ".timer") || filepath.Base(path) == unitPrefix+".service" {
+			exec.Command("systemctl", "--user", "disable", "--now", filepath.Base(path)).Run()
+		}
+	case "darwin":
+		exec.Command("launchctl", "unload", "-w", path).Run()
+	}
+}
+
+func xmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
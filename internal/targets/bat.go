@@ -0,0 +1,126 @@
+package targets
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// BatTheme is the theme name to select with BAT_THEME or --theme
+const BatTheme = "alacritty-colors"
+
+type bat struct{}
+
+func init() {
+	register(bat{})
+}
+
+func (bat) Name() string {
+	return "bat"
+}
+
+// DefaultPath asks bat for its config directory, since it differs per
+// platform, and falls back to the XDG location
+func (bat) DefaultPath() string {
+	dir := filepath.Join(configHome(), "bat")
+	if exe := batExecutable(); exe != "" {
+		if out, err := exec.Command(exe, "--config-dir").Output(); err == nil {
+			dir = strings.TrimSpace(string(out))
+		}
+	}
+	return filepath.Join(dir, "themes", BatTheme+".tmTheme")
+}
+
+// batScopes maps TextMate scopes to theme colors. Delta reads bat themes, so
+// the markup scopes also color its diffs.
+var batScopes = []struct {
+	scope  string
+	colors []string
+	style  string
+}{
+	{"comment", []string{"bright_black", "normal_black"}, "italic"},
+	{"string", []string{"normal_green"}, ""},
+	{"constant.numeric, constant.language, constant.character", []string{"normal_magenta"}, ""},
+	{"constant, variable.other.constant", []string{"normal_cyan"}, ""},
+	{"keyword, storage", []string{"normal_red"}, ""},
+	{"entity.name.function, support.function", []string{"normal_blue"}, ""},
+	{"entity.name.type, entity.name.class, support.type, support.class", []string{"normal_yellow"}, ""},
+	{"variable.parameter", []string{"bright_cyan", "normal_cyan"}, "italic"},
+	{"entity.name.tag", []string{"normal_red"}, ""},
+	{"entity.other.attribute-name", []string{"normal_yellow"}, ""},
+	{"invalid", []string{"bright_red", "normal_red"}, "bold"},
+	{"markup.heading", []string{"normal_blue"}, "bold"},
+	{"markup.inserted", []string{"normal_green"}, ""},
+	{"markup.deleted", []string{"normal_red"}, ""},
+	{"markup.changed", []string{"normal_yellow"}, ""},
+}
+
+func (bat) Render(theme string, c Colors) []byte {
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+	fmt.Fprintf(&b, "\t<key>name</key>\n\t<string>%s</string>\n", BatTheme)
+	fmt.Fprintf(&b, "\t<key>comment</key>\n\t<string>Generated by alacritty-colors from theme %s</string>\n", xmlEscape(theme))
+	b.WriteString("\t<key>settings</key>\n\t<array>\n")
+
+	b.WriteString("\t\t<dict>\n\t\t\t<key>settings</key>\n\t\t\t<dict>\n")
+	plistString(&b, "background", c.Get("background"))
+	plistString(&b, "foreground", c.Get("foreground"))
+	plistString(&b, "caret", c.Get("cursor", "foreground"))
+	plistString(&b, "selection", c.Get("bright_black", "normal_black"))
+	plistString(&b, "lineHighlight", c.Get("normal_black", "bright_black"))
+	plistString(&b, "gutterForeground", c.Get("bright_black", "normal_black"))
+	b.WriteString("\t\t\t</dict>\n\t\t</dict>\n")
+
+	for _, s := range batScopes {
+		b.WriteString("\t\t<dict>\n")
+		fmt.Fprintf(&b, "\t\t\t<key>scope</key>\n\t\t\t<string>%s</string>\n", s.scope)
+		b.WriteString("\t\t\t<key>settings</key>\n\t\t\t<dict>\n")
+		plistString(&b, "foreground", c.Get(s.colors[0], s.colors[1:]...))
+		if s.style != "" {
+			plistString(&b, "fontStyle", s.style)
+		}
+		b.WriteString("\t\t\t</dict>\n\t\t</dict>\n")
+	}
+
+	b.WriteString("\t</array>\n</dict>\n</plist>\n")
+	return b.Bytes()
+}
+
+// Reload rebuilds bat's theme cache, without which the new theme is ignored
+func (bat) Reload(string) error {
+	exe := batExecutable()
+	if exe == "" {
+		return nil
+	}
+
+	out, err := exec.Command(exe, "cache", "--build").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// batExecutable returns bat's command name, which Debian and Ubuntu rename to
+// batcat, or "" when bat is not installed
+func batExecutable() string {
+	for _, name := range []string{"bat", "batcat"} {
+		if _, err := exec.LookPath(name); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+func plistString(b *bytes.Buffer, key, value string) {
+	fmt.Fprintf(b, "\t\t\t\t<key>%s</key>\n\t\t\t\t<string>%s</string>\n", key, xmlEscape(value))
+}
+
+func xmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
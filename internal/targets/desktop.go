@@ -0,0 +1,154 @@
+package targets
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+type waybar struct{}
+
+type polybar struct{}
+
+// windowManager covers i3 and sway, which share the client.* syntax
+type windowManager struct {
+	name string
+	msg  string // i3-msg or swaymsg
+}
+
+func init() {
+	register(waybar{})
+	register(polybar{})
+	register(windowManager{name: "i3", msg: "i3-msg"})
+	register(windowManager{name: "sway", msg: "swaymsg"})
+}
+
+func (waybar) Name() string {
+	return "waybar"
+}
+
+func (waybar) DefaultPath() string {
+	return filepath.Join(configHome(), "waybar", "alacritty-colors.css")
+}
+
+// Render defines GTK named colors, used in style.css as @background etc.
+func (waybar) Render(theme string, c Colors) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "/* Generated by alacritty-colors from theme %s */\n", theme)
+	fmt.Fprintf(&b, "@define-color background %s;\n", c.Get("background"))
+	fmt.Fprintf(&b, "@define-color foreground %s;\n", c.Get("foreground"))
+	for _, name := range ansiColors {
+		fmt.Fprintf(&b, "@define-color %s %s;\n", name, c.Get("normal_"+name))
+		fmt.Fprintf(&b, "@define-color bright_%s %s;\n", name, c.Get("bright_"+name, "normal_"+name))
+	}
+	return b.Bytes()
+}
+
+// Reload asks waybar to reload its config and style
+func (waybar) Reload(string) error {
+	return signalProcess("waybar", "USR2")
+}
+
+func (polybar) Name() string {
+	return "polybar"
+}
+
+func (polybar) DefaultPath() string {
+	return filepath.Join(configHome(), "polybar", "alacritty-colors.ini")
+}
+
+// Render writes a [colors] section matching the names polybar's default
+// config uses, plus the sixteen terminal colors
+func (polybar) Render(theme string, c Colors) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "; Generated by alacritty-colors from theme %s\n", theme)
+	b.WriteString("[colors]\n")
+	fmt.Fprintf(&b, "background = %s\n", c.Get("background"))
+	fmt.Fprintf(&b, "background-alt = %s\n", c.Get("normal_black", "bright_black"))
+	fmt.Fprintf(&b, "foreground = %s\n", c.Get("foreground"))
+	fmt.Fprintf(&b, "primary = %s\n", c.Get("normal_blue"))
+	fmt.Fprintf(&b, "secondary = %s\n", c.Get("normal_magenta"))
+	fmt.Fprintf(&b, "alert = %s\n", c.Get("normal_red"))
+	fmt.Fprintf(&b, "disabled = %s\n", c.Get("bright_black", "normal_black"))
+	for _, name := range ansiColors {
+		fmt.Fprintf(&b, "%s = %s\n", name, c.Get("normal_"+name))
+		fmt.Fprintf(&b, "bright-%s = %s\n", name, c.Get("bright_"+name, "normal_"+name))
+	}
+	return b.Bytes()
+}
+
+// Reload restarts running bars through polybar's IPC
+func (polybar) Reload(string) error {
+	return runIfInstalled("polybar-msg", "cmd", "restart")
+}
+
+func (w windowManager) Name() string {
+	return w.name
+}
+
+func (w windowManager) DefaultPath() string {
+	return filepath.Join(configHome(), w.name, "alacritty-colors")
+}
+
+// Render emits client.* color commands for the config's include directive
+func (w windowManager) Render(theme string, c Colors) []byte {
+	bg := c.Get("background")
+	fg := c.Get("foreground")
+	accent := c.Get("normal_blue")
+	muted := c.Get("bright_black", "normal_black")
+	urgent := c.Get("normal_red")
+	indicator := c.Get("normal_cyan", "bright_blue")
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# Generated by alacritty-colors from theme %s\n", theme)
+	b.WriteString("# class                 border  backgr. text    indicator child_border\n")
+	fmt.Fprintf(&b, "client.focused          %s %s %s %s %s\n", accent, accent, bg, indicator, accent)
+	fmt.Fprintf(&b, "client.focused_inactive %s %s %s %s %s\n", muted, muted, fg, muted, muted)
+	fmt.Fprintf(&b, "client.unfocused        %s %s %s %s %s\n", bg, bg, muted, bg, bg)
+	fmt.Fprintf(&b, "client.urgent           %s %s %s %s %s\n", urgent, urgent, bg, urgent, urgent)
+	fmt.Fprintf(&b, "client.placeholder      %s %s %s %s %s\n", bg, bg, fg, bg, bg)
+	fmt.Fprintf(&b, "client.background       %s\n", bg)
+	return b.Bytes()
+}
+
+// Reload reloads the window manager's config, if it is running
+func (w windowManager) Reload(string) error {
+	return runIfInstalled(w.msg, "reload")
+}
+
+// signalProcess sends a signal to every process with the given name. Having
+// no such process is not an error.
+func signalProcess(name, signal string) error {
+	if _, err := exec.LookPath("pkill"); err != nil {
+		return nil
+	}
+	// pkill exits with 1 when nothing matched
+	if err := exec.Command("pkill", "-"+signal, "-x", name).Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// runIfInstalled runs a reload command when the program is installed. Errors
+// from a program that is installed but not running are ignored.
+func runIfInstalled(name string, args ...string) error {
+	if _, err := exec.LookPath(name); err != nil {
+		return nil
+	}
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		msg := strings.ToLower(string(out))
+		for _, notRunning := range []string{"no server", "not running", "unable to", "could not", "no active", "socket"} {
+			if strings.Contains(msg, notRunning) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
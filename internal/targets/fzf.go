@@ -0,0 +1,53 @@
+package targets
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+type fzf struct{}
+
+func init() {
+	register(fzf{})
+}
+
+func (fzf) Name() string {
+	return "fzf"
+}
+
+// DefaultPath is a shell file meant to be sourced from the shell's startup
+// file, so new shells pick up the colors
+func (fzf) DefaultPath() string {
+	return filepath.Join(configHome(), "alacritty-colors", "fzf.sh")
+}
+
+func (fzf) Render(theme string, c Colors) []byte {
+	muted := c.Get("bright_black", "normal_black")
+	colors := []string{
+		"fg:" + c.Get("foreground"),
+		"bg:" + c.Get("background"),
+		"hl:" + c.Get("normal_red"),
+		"fg+:" + c.Get("bright_white", "foreground"),
+		"bg+:" + muted,
+		"hl+:" + c.Get("bright_red", "normal_red"),
+		"info:" + c.Get("normal_magenta"),
+		"prompt:" + c.Get("normal_blue"),
+		"pointer:" + c.Get("normal_magenta"),
+		"marker:" + c.Get("normal_green"),
+		"spinner:" + c.Get("normal_cyan"),
+		"header:" + c.Get("normal_red"),
+		"border:" + muted,
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# Generated by alacritty-colors from theme %s\n", theme)
+	fmt.Fprintf(&b, "export FZF_DEFAULT_OPTS=\"${FZF_DEFAULT_OPTS:+$FZF_DEFAULT_OPTS }--color=%s\"\n", strings.Join(colors, ","))
+	return b.Bytes()
+}
+
+// Reload is a no-op: fzf reads its options from the environment of new shells
+func (fzf) Reload(string) error {
+	return nil
+}
@@ -0,0 +1,50 @@
+package targets
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+)
+
+type kitty struct{}
+
+func init() {
+	register(kitty{})
+}
+
+func (kitty) Name() string {
+	return "kitty"
+}
+
+func (kitty) DefaultPath() string {
+	return filepath.Join(configHome(), "kitty", "alacritty-colors.conf")
+}
+
+func (kitty) Render(theme string, c Colors) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# Generated by alacritty-colors from theme %s\n", theme)
+	fmt.Fprintf(&b, "foreground %s\n", c.Get("foreground"))
+	fmt.Fprintf(&b, "background %s\n", c.Get("background"))
+	fmt.Fprintf(&b, "cursor %s\n", c.Get("cursor", "foreground"))
+	fmt.Fprintf(&b, "selection_foreground %s\n", c.Get("background"))
+	fmt.Fprintf(&b, "selection_background %s\n", c.Get("foreground"))
+	for i, color := range c.Palette() {
+		fmt.Fprintf(&b, "color%d %s\n", i, color)
+	}
+	return b.Bytes()
+}
+
+// Reload recolors running kitty windows over remote control, and otherwise
+// asks every kitty process to reload its config with SIGUSR1
+func (kitty) Reload(path string) error {
+	if _, err := exec.LookPath("kitty"); err != nil {
+		return nil
+	}
+
+	if exec.Command("kitty", "@", "set-colors", "--all", "--configured", path).Run() == nil {
+		return nil
+	}
+
+	return signalProcess("kitty", "USR1")
+}
@@ -0,0 +1,121 @@
+package targets
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// starshipPalette is the palette name written to starship.toml
+const starshipPalette = "alacritty_colors"
+
+type starship struct{}
+
+func init() {
+	register(starship{})
+}
+
+func (starship) Name() string {
+	return "starship"
+}
+
+func (starship) DefaultPath() string {
+	if path := os.Getenv("STARSHIP_CONFIG"); path != "" {
+		return path
+	}
+	return filepath.Join(configHome(), "starship.toml")
+}
+
+// Render returns the palette table. Its keys are starship's color names, so
+// existing styles such as "bold red" follow the theme without edits.
+func (starship) Render(theme string, c Colors) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "[palettes.%s]\n", starshipPalette)
+	fmt.Fprintf(&b, "# Generated by alacritty-colors from theme %s\n", theme)
+	fmt.Fprintf(&b, "background = %q\n", c.Get("background"))
+	fmt.Fprintf(&b, "foreground = %q\n", c.Get("foreground"))
+	for _, name := range ansiColors {
+		key := name
+		if key == "magenta" {
+			key = "purple"
+		}
+		fmt.Fprintf(&b, "%s = %q\n", key, c.Get("normal_"+name))
+		fmt.Fprintf(&b, "bright-%s = %q\n", key, c.Get("bright_"+name, "normal_"+name))
+	}
+	return b.Bytes()
+}
+
+// Patch selects the palette at the top level and replaces the palette table,
+// leaving every other line of the file untouched
+func (starship) Patch(existing, rendered []byte) []byte {
+	lines := strings.Split(strings.TrimRight(string(existing), "\n"), "\n")
+	if len(existing) == 0 {
+		lines = nil
+	}
+
+	header := fmt.Sprintf("[palettes.%s]", starshipPalette)
+	selector := fmt.Sprintf("palette = %q", starshipPalette)
+
+	var out []string
+	topLevel, selected, replaced, skipping := true, false, false, false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		isHeader := strings.HasPrefix(trimmed, "[")
+
+		if isHeader && topLevel {
+			topLevel = false
+			if !selected {
+				out = append(out, selector, "")
+				selected = true
+			}
+		}
+
+		if skipping {
+			if !isHeader {
+				continue
+			}
+			skipping = false
+		}
+
+		switch {
+		case topLevel && tomlKey(trimmed) == "palette":
+			out = append(out, selector)
+			selected = true
+		case trimmed == header:
+			out = append(out, strings.TrimRight(string(rendered), "\n"), "")
+			replaced, skipping = true, true
+		default:
+			out = append(out, line)
+		}
+	}
+
+	if !selected {
+		out = append(out, selector)
+	}
+	if !replaced {
+		if len(out) > 0 && out[len(out)-1] != "" {
+			out = append(out, "")
+		}
+		out = append(out, strings.TrimRight(string(rendered), "\n"))
+	}
+
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return []byte(strings.Join(out, "\n") + "\n")
+}
+
+// Reload is a no-op: starship reads its config on every prompt
+func (starship) Reload(string) error {
+	return nil
+}
+
+// tomlKey returns the key of a "key = value" line
+func tomlKey(line string) string {
+	if eq := strings.Index(line, "="); eq > 0 {
+		return strings.Trim(strings.TrimSpace(line[:eq]), `"'`)
+	}
+	return ""
+}
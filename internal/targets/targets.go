@@ -0,0 +1,161 @@
+// Package targets writes the applied theme's colors into the configuration of
+// other programs, such as tmux, so they match Alacritty.
+package targets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// Target renders a color snippet for one program
+type Target interface {
+	// Name is the identifier used in the [sync] settings
+	Name() string
+	// DefaultPath is where the snippet is written unless configured otherwise
+	DefaultPath() string
+	// Render returns the snippet for a theme's colors
+	Render(theme string, colors Colors) []byte
+	// Reload makes a running program pick up the snippet at path
+	Reload(path string) error
+}
+
+// Patcher is implemented by targets that own only part of a file the user
+// also edits, such as starship.toml. Patch merges the rendered snippet into
+// the existing contents, which are empty when the file does not exist yet.
+type Patcher interface {
+	Patch(existing, rendered []byte) []byte
+}
+
+// Options configures a target from the [sync.<name>] settings table
+type Options struct {
+	Path   string
+	Reload bool
+}
+
+var registry = make(map[string]Target)
+
+func register(t Target) {
+	registry[t.Name()] = t
+}
+
+// Get returns the target with the given name
+func Get(name string) (Target, bool) {
+	t, ok := registry[name]
+	return t, ok
+}
+
+// Names returns the available targets in alphabetical order
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Sync writes the snippet for a target and reloads it when requested. It
+// returns the path that was written.
+func Sync(name, theme string, colors Colors, opts Options) (string, error) {
+	t, ok := Get(name)
+	if !ok {
+		return "", ui.WithHints(fmt.Errorf("unknown sync target '%s' (available: %s)", name, strings.Join(Names(), ", ")),
+			ui.DidYouMean(name, Names()))
+	}
+
+	path := opts.Path
+	if path == "" {
+		path = t.DefaultPath()
+	}
+	path = ExpandHome(path)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory for %s: %w", name, err)
+	}
+
+	content := t.Render(theme, colors)
+	if p, ok := t.(Patcher); ok {
+		existing, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		content = p.Patch(existing, content)
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if opts.Reload {
+		if err := t.Reload(path); err != nil {
+			return path, fmt.Errorf("failed to reload %s: %w", name, err)
+		}
+	}
+
+	return path, nil
+}
+
+// Colors holds a theme's colors keyed like the theme manager does, e.g.
+// "background", "normal_blue" or "bright_black"
+type Colors map[string]string
+
+// Get returns a color, falling back to each alternative key in turn
+func (c Colors) Get(key string, fallbacks ...string) string {
+	for _, k := range append([]string{key}, fallbacks...) {
+		if v := c[k]; v != "" {
+			return normalize(v)
+		}
+	}
+	return ""
+}
+
+// normalize turns "0xrrggbb" into "#rrggbb"
+func normalize(value string) string {
+	if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+		return "#" + value[2:]
+	}
+	return value
+}
+
+// ExpandHome replaces a leading ~ with the home directory
+func ExpandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
+// configHome returns $XDG_CONFIG_HOME or ~/.config
+func configHome() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" && filepath.IsAbs(dir) {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".config"
+	}
+	return filepath.Join(home, ".config")
+}
+
+// ansiColors are the eight terminal colors in palette order
+var ansiColors = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// Palette returns the sixteen terminal colors, normal then bright. Missing
+// bright colors fall back to their normal counterpart.
+func (c Colors) Palette() []string {
+	palette := make([]string, 0, 16)
+	for _, name := range ansiColors {
+		palette = append(palette, c.Get("normal_"+name))
+	}
+	for _, name := range ansiColors {
+		palette = append(palette, c.Get("bright_"+name, "normal_"+name))
+	}
+	return palette
+}
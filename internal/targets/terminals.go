@@ -0,0 +1,131 @@
+package targets
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Exporter renders a theme for a terminal that is configured once, by
+// importing the result, rather than kept in sync after every apply
+type Exporter interface {
+	Name() string
+	// FileName suggests where the result is saved for a theme
+	FileName(theme string) string
+	Render(theme string, colors Colors) []byte
+}
+
+var exporters = make(map[string]Exporter)
+
+func init() {
+	for _, e := range []Exporter{gnomeTerminal{}, konsole{}} {
+		exporters[e.Name()] = e
+	}
+}
+
+// GetExporter returns the terminal exporter with the given name
+func GetExporter(name string) (Exporter, bool) {
+	e, ok := exporters[name]
+	return e, ok
+}
+
+// ExporterNames returns the available terminal exporters in alphabetical order
+func ExporterNames() []string {
+	names := make([]string, 0, len(exporters))
+	for name := range exporters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// gnomeTerminal emits a shell script that adds the theme as a new GNOME
+// Terminal profile through dconf
+type gnomeTerminal struct{}
+
+func (gnomeTerminal) Name() string {
+	return "gnome"
+}
+
+func (gnomeTerminal) FileName(theme string) string {
+	return theme + "-gnome-terminal.sh"
+}
+
+func (gnomeTerminal) Render(theme string, c Colors) []byte {
+	palette := c.Palette()
+	quoted := make([]string, len(palette))
+	for i, color := range palette {
+		quoted[i] = "'" + color + "'"
+	}
+
+	var b bytes.Buffer
+	b.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&b, "# Generated by alacritty-colors: adds theme %s as a GNOME Terminal profile\n", theme)
+	b.WriteString(`set -e
+id=$(cat /proc/sys/kernel/random/uuid 2>/dev/null || uuidgen)
+dir=/org/gnome/terminal/legacy/profiles:
+list=$(dconf read "$dir/list" | tr -d "[]' ")
+[ -n "$list" ] && list="$list,"
+dconf write "$dir/list" "[$(echo "$list$id" | sed "s/[^,]*/'&'/g")]"
+dconf load "$dir/:$id/" <<'PROFILE'
+[/]
+`)
+	fmt.Fprintf(&b, "visible-name='%s'\n", strings.ReplaceAll(theme, "'", ""))
+	b.WriteString("use-theme-colors=false\n")
+	fmt.Fprintf(&b, "background-color='%s'\n", c.Get("background"))
+	fmt.Fprintf(&b, "foreground-color='%s'\n", c.Get("foreground"))
+	b.WriteString("bold-color-same-as-fg=true\n")
+	fmt.Fprintf(&b, "cursor-colors-set=true\ncursor-background-color='%s'\ncursor-foreground-color='%s'\n",
+		c.Get("cursor", "foreground"), c.Get("background"))
+	fmt.Fprintf(&b, "palette=[%s]\n", strings.Join(quoted, ", "))
+	b.WriteString("PROFILE\n")
+	fmt.Fprintf(&b, "echo \"Added GNOME Terminal profile '%s'\"\n", strings.ReplaceAll(theme, "'", ""))
+	return b.Bytes()
+}
+
+// konsole emits a .colorscheme file for ~/.local/share/konsole
+type konsole struct{}
+
+func (konsole) Name() string {
+	return "konsole"
+}
+
+func (konsole) FileName(theme string) string {
+	return theme + ".colorscheme"
+}
+
+func (konsole) Render(theme string, c Colors) []byte {
+	palette := c.Palette()
+
+	var b bytes.Buffer
+	section := func(name, color string) {
+		fmt.Fprintf(&b, "[%s]\nColor=%s\n\n", name, konsoleRGB(color))
+	}
+
+	section("Background", c.Get("background"))
+	section("BackgroundIntense", c.Get("background"))
+	for i := 0; i < 8; i++ {
+		section(fmt.Sprintf("Color%d", i), palette[i])
+		section(fmt.Sprintf("Color%dIntense", i), palette[i+8])
+	}
+	section("Foreground", c.Get("foreground"))
+	section("ForegroundIntense", c.Get("bright_white", "foreground"))
+
+	fmt.Fprintf(&b, "[General]\nDescription=%s\nOpacity=1\n", theme)
+	return b.Bytes()
+}
+
+// konsoleRGB converts "#rrggbb" to Konsole's "r,g,b"
+func konsoleRGB(hex string) string {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return "0,0,0"
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return "0,0,0"
+	}
+	return fmt.Sprintf("%d,%d,%d", v>>16&0xff, v>>8&0xff, v&0xff)
+}
@@ -0,0 +1,62 @@
+package targets
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+type tmux struct{}
+
+func init() {
+	register(tmux{})
+}
+
+func (tmux) Name() string {
+	return "tmux"
+}
+
+func (tmux) DefaultPath() string {
+	return filepath.Join(configHome(), "tmux", "alacritty-colors.conf")
+}
+
+func (tmux) Render(theme string, c Colors) []byte {
+	bg := c.Get("background")
+	fg := c.Get("foreground")
+	accent := c.Get("normal_blue", "bright_blue", "foreground")
+	muted := c.Get("bright_black", "normal_white", "foreground")
+	warn := c.Get("normal_yellow", "bright_yellow", "foreground")
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# Generated by alacritty-colors from theme %s\n", theme)
+	fmt.Fprintf(&b, "set -g status-style \"bg=%s,fg=%s\"\n", bg, fg)
+	fmt.Fprintf(&b, "set -g status-left-style \"bg=%s,fg=%s,bold\"\n", accent, bg)
+	fmt.Fprintf(&b, "set -g status-right-style \"bg=%s,fg=%s\"\n", bg, muted)
+	fmt.Fprintf(&b, "set -g window-status-style \"bg=%s,fg=%s\"\n", bg, muted)
+	fmt.Fprintf(&b, "set -g window-status-current-style \"bg=%s,fg=%s,bold\"\n", accent, bg)
+	fmt.Fprintf(&b, "set -g pane-border-style \"fg=%s\"\n", muted)
+	fmt.Fprintf(&b, "set -g pane-active-border-style \"fg=%s\"\n", accent)
+	fmt.Fprintf(&b, "set -g message-style \"bg=%s,fg=%s\"\n", warn, bg)
+	fmt.Fprintf(&b, "set -g message-command-style \"bg=%s,fg=%s\"\n", warn, bg)
+	fmt.Fprintf(&b, "set -g mode-style \"bg=%s,fg=%s\"\n", accent, bg)
+	fmt.Fprintf(&b, "set -g clock-mode-colour \"%s\"\n", accent)
+	return b.Bytes()
+}
+
+// Reload sources the snippet in the running tmux server, if there is one
+func (tmux) Reload(path string) error {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		return nil
+	}
+
+	out, err := exec.Command("tmux", "source-file", path).CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(out), "no server running") || strings.Contains(string(out), "error connecting") {
+			return nil
+		}
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package targets
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+type wezterm struct{}
+
+func init() {
+	register(wezterm{})
+}
+
+func (wezterm) Name() string {
+	return "wezterm"
+}
+
+// DefaultPath is a Lua module next to wezterm.lua, loaded with
+// require("alacritty_colors")
+func (wezterm) DefaultPath() string {
+	return filepath.Join(configHome(), "wezterm", "alacritty_colors.lua")
+}
+
+func (wezterm) Render(theme string, c Colors) []byte {
+	palette := c.Palette()
+	quoted := make([]string, len(palette))
+	for i, color := range palette {
+		quoted[i] = fmt.Sprintf("%q", color)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "-- Generated by alacritty-colors from theme %s\n", theme)
+	b.WriteString("return {\n")
+	fmt.Fprintf(&b, "  foreground = %q,\n", c.Get("foreground"))
+	fmt.Fprintf(&b, "  background = %q,\n", c.Get("background"))
+	fmt.Fprintf(&b, "  cursor_bg = %q,\n", c.Get("cursor", "foreground"))
+	fmt.Fprintf(&b, "  cursor_fg = %q,\n", c.Get("background"))
+	fmt.Fprintf(&b, "  cursor_border = %q,\n", c.Get("cursor", "foreground"))
+	fmt.Fprintf(&b, "  selection_fg = %q,\n", c.Get("background"))
+	fmt.Fprintf(&b, "  selection_bg = %q,\n", c.Get("foreground"))
+	fmt.Fprintf(&b, "  ansi = { %s },\n", strings.Join(quoted[:8], ", "))
+	fmt.Fprintf(&b, "  brights = { %s },\n", strings.Join(quoted[8:], ", "))
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+// Reload is a no-op: WezTerm watches the modules its config requires and
+// reloads on its own
+func (wezterm) Reload(string) error {
+	return nil
+}
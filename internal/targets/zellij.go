@@ -0,0 +1,40 @@
+package targets
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+)
+
+type zellij struct{}
+
+func init() {
+	register(zellij{})
+}
+
+func (zellij) Name() string {
+	return "zellij"
+}
+
+func (zellij) DefaultPath() string {
+	return filepath.Join(configHome(), "zellij", "themes", "alacritty-colors.kdl")
+}
+
+func (zellij) Render(theme string, c Colors) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Generated by alacritty-colors from theme %s\n", theme)
+	b.WriteString("themes {\n    alacritty-colors {\n")
+	fmt.Fprintf(&b, "        fg %q\n", c.Get("foreground"))
+	fmt.Fprintf(&b, "        bg %q\n", c.Get("background"))
+	for _, name := range ansiColors {
+		fmt.Fprintf(&b, "        %s %q\n", name, c.Get("normal_"+name))
+	}
+	fmt.Fprintf(&b, "        orange %q\n", c.Get("bright_yellow", "normal_yellow"))
+	b.WriteString("    }\n}\n")
+	return b.Bytes()
+}
+
+// Reload is a no-op: Zellij loads themes when a session starts
+func (zellij) Reload(string) error {
+	return nil
+}
@@ -0,0 +1,219 @@
+// Package templates renders text files from a theme's colors. Built-in
+// templates cover common desktop programs; users can add their own or
+// override a built-in by dropping <name>.tmpl files in a templates directory.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+//go:embed builtin/*.tmpl
+var builtinFS embed.FS
+
+// Extension is the file extension of template files
+const Extension = ".tmpl"
+
+// Template is a named text/template with optional directives, written as
+// template comments at the top of the file:
+//
+//	{{/* output: ~/.config/rofi/alacritty-colors.rasi */}}
+//	{{/* reload: dunstctl reload */}}
+type Template struct {
+	Name    string
+	Source  string // "built-in" or the file path
+	Output  string
+	Reload  string
+	Builtin bool
+	text    string
+}
+
+// Palette holds the eight normal or bright terminal colors
+type Palette struct {
+	Black, Red, Green, Yellow, Blue, Magenta, Cyan, White string
+}
+
+// Data is what templates are executed with
+type Data struct {
+	Theme      string
+	Background string
+	Foreground string
+	Cursor     string
+	Normal     Palette
+	Bright     Palette
+	// Colors holds every color the theme defines, keyed like "normal_blue"
+	Colors map[string]string
+}
+
+var directiveRegex = regexp.MustCompile(`^\{\{/\*\s*(output|reload):\s*(.*?)\s*\*/\s*-?\}\}$`)
+
+// List returns the built-in templates and those in dir, by name. Templates
+// in dir replace built-ins of the same name.
+func List(dir string) ([]Template, error) {
+	byName := make(map[string]Template)
+
+	entries, err := builtinFS.ReadDir("builtin")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		data, err := builtinFS.ReadFile("builtin/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		t := parse(strings.TrimSuffix(entry.Name(), Extension), "built-in", string(data))
+		t.Builtin = true
+		byName[t.Name] = t
+	}
+
+	if dir != "" {
+		files, err := filepath.Glob(filepath.Join(dir, "*"+Extension))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range files {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read template: %w", err)
+			}
+			t := parse(strings.TrimSuffix(filepath.Base(path), Extension), path, string(data))
+			byName[t.Name] = t
+		}
+	}
+
+	list := make([]Template, 0, len(byName))
+	for _, t := range byName {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// Find returns the template with the given name
+func Find(dir, name string) (Template, error) {
+	list, err := List(dir)
+	if err != nil {
+		return Template{}, err
+	}
+
+	names := make([]string, len(list))
+	for i, t := range list {
+		if t.Name == name {
+			return t, nil
+		}
+		names[i] = t.Name
+	}
+	return Template{}, ui.WithHints(fmt.Errorf("template '%s' not found (available: %s)", name, strings.Join(names, ", ")),
+		ui.DidYouMean(name, names))
+}
+
+func parse(name, source, text string) Template {
+	t := Template{Name: name, Source: source, text: text}
+	for _, line := range strings.Split(text, "\n") {
+		m := directiveRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			break
+		}
+		switch m[1] {
+		case "output":
+			t.Output = m[2]
+		case "reload":
+			t.Reload = m[2]
+		}
+	}
+	return t
+}
+
+// Text returns the template source
+func (t Template) Text() string {
+	return t.text
+}
+
+// Render executes the template with a theme's colors
+func (t Template) Render(theme string, colors map[string]string) ([]byte, error) {
+	tmpl, err := template.New(t.Name).Funcs(funcs).Option("missingkey=zero").Parse(t.text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", t.Name, err)
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, NewData(theme, colors)); err != nil {
+		return nil, fmt.Errorf("failed to render template %s: %w", t.Name, err)
+	}
+	return b.Bytes(), nil
+}
+
+// NewData builds template data from the colors the theme manager parses
+func NewData(theme string, colors map[string]string) Data {
+	get := func(keys ...string) string {
+		for _, k := range keys {
+			if v := colors[k]; v != "" {
+				return normalize(v)
+			}
+		}
+		return ""
+	}
+	palette := func(prefix, fallback string) Palette {
+		return Palette{
+			Black:   get(prefix+"black", fallback+"black"),
+			Red:     get(prefix+"red", fallback+"red"),
+			Green:   get(prefix+"green", fallback+"green"),
+			Yellow:  get(prefix+"yellow", fallback+"yellow"),
+			Blue:    get(prefix+"blue", fallback+"blue"),
+			Magenta: get(prefix+"magenta", fallback+"magenta"),
+			Cyan:    get(prefix+"cyan", fallback+"cyan"),
+			White:   get(prefix+"white", fallback+"white"),
+		}
+	}
+
+	return Data{
+		Theme:      theme,
+		Background: get("background"),
+		Foreground: get("foreground"),
+		Cursor:     get("cursor", "foreground"),
+		Normal:     palette("normal_", "normal_"),
+		Bright:     palette("bright_", "normal_"),
+		Colors:     colors,
+	}
+}
+
+var funcs = template.FuncMap{
+	// strip drops the leading '#', e.g. for programs expecting "rrggbb"
+	"strip": func(hex string) string { return strings.TrimPrefix(hex, "#") },
+	// alpha appends an opacity between 0 and 1 as "#rrggbbaa"
+	"alpha": func(opacity float64, hex string) string {
+		a := int(opacity*255 + 0.5)
+		if a < 0 {
+			a = 0
+		} else if a > 255 {
+			a = 255
+		}
+		return fmt.Sprintf("%s%02x", hex, a)
+	},
+	// rgb formats a color as "r, g, b"
+	"rgb": func(hex string) string {
+		v, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+		if err != nil {
+			return hex
+		}
+		return fmt.Sprintf("%d, %d, %d", v>>16&0xff, v>>8&0xff, v&0xff)
+	},
+}
+
+// normalize turns "0xrrggbb" into "#rrggbb"
+func normalize(value string) string {
+	if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+		return "#" + value[2:]
+	}
+	return value
+}
@@ -0,0 +1,91 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/vitruves/alacritty-colors/internal/targets"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// ApplyThemeTo applies a theme to another Alacritty config file, such as one
+// kept in a dotfiles repository. The theme is copied to themes/current.toml
+// next to that file and the import line is added if it's missing. The
+// managed config and the current theme tracking are left unchanged.
+func (m *Manager) ApplyThemeTo(themeName, configPath string, opts *ApplyOptions) error {
+	target, err := filepath.Abs(targets.ExpandHome(configPath))
+	if err != nil {
+		return fmt.Errorf("failed to resolve config path: %w", err)
+	}
+	if info, err := os.Stat(target); err != nil {
+		return ui.WithHints(fmt.Errorf("config file not found: %s", target),
+			"Create it first, or check the path given to --to.")
+	} else if info.IsDir() {
+		return fmt.Errorf("not a config file: %s", target)
+	}
+
+	selectedTheme, err := m.findTheme(themeName)
+	if err != nil {
+		return err
+	}
+	if opts != nil {
+		if err := opts.Transform.Validate(); err != nil {
+			return err
+		}
+	}
+
+	cfg := *m.config
+	cfg.ConfigFile = target
+	cfg.ThemesDir = filepath.Join(filepath.Dir(target), "themes")
+	tm := &Manager{config: &cfg, verbose: m.verbose}
+	if opts != nil {
+		tm.transform = opts.Transform
+	}
+
+	ui.PrintInfo("Applying theme %s to %s", selectedTheme.Name, target)
+
+	if err := os.MkdirAll(cfg.ThemesDir, 0755); err != nil {
+		return fmt.Errorf("failed to create themes directory: %w", err)
+	}
+	// Always a plain copy: a symlink into this machine's themes directory
+	// would break once the file is synced elsewhere
+	data, err := os.ReadFile(selectedTheme.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to apply theme: %w", err)
+	}
+	if tm.transform.Any() {
+		data = transformTheme(data, tm.transform)
+	}
+	if err := tm.replaceCurrent(primaryFirst(data)); err != nil {
+		return fmt.Errorf("failed to apply theme: %w", err)
+	}
+
+	if !tm.hasImportLine() {
+		if err := tm.addImportLine(); err != nil {
+			return fmt.Errorf("failed to add import line: %w", err)
+		}
+		ui.PrintInfo("Added import line to %s", filepath.Base(target))
+	}
+
+	if opts != nil {
+		if opts.WithFont {
+			if err := tm.applyThemeFont(selectedTheme.Name, opts.FontFamily, opts.FontSize); err != nil {
+				ui.PrintWarning("Failed to set font: %v", err)
+			}
+		}
+		if opts.Opacity > 0 || opts.Blur > 0 {
+			if err := tm.applyVisualEffects(opts.Opacity, opts.Blur); err != nil {
+				ui.PrintWarning("Failed to apply visual effects: %v", err)
+			}
+		}
+		if opts.Reset.Any() {
+			if err := tm.ResetEffects(opts.Reset); err != nil {
+				ui.PrintWarning("Failed to reset settings: %v", err)
+			}
+		}
+	}
+
+	ui.PrintSuccess("Applied theme '%s' to %s", selectedTheme.Name, target)
+	return nil
+}
@@ -0,0 +1,115 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/downloader"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// LastUpdateFile lists the themes added or changed by the latest update
+const LastUpdateFile = "last_update.json"
+
+// UpdateChanges are the themes the latest update brought in
+type UpdateChanges struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	Added     []string  `json:"added"`
+	Changed   []string  `json:"changed"`
+}
+
+// recordUpdateChanges compares manifests from before and after an update
+// and saves which themes are new and which changed upstream
+func (m *Manager) recordUpdateChanges(before, after map[string]downloader.SourceRecord) {
+	known := make(map[string]string)
+	for _, record := range before {
+		for name, sha := range record.Files {
+			known[name] = sha
+		}
+	}
+
+	changes := UpdateChanges{UpdatedAt: time.Now(), Added: []string{}, Changed: []string{}}
+	seen := make(map[string]bool)
+	for _, record := range after {
+		for name, sha := range record.Files {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			theme := strings.TrimSuffix(name, filepath.Ext(name))
+			if old, ok := known[name]; !ok {
+				changes.Added = append(changes.Added, theme)
+			} else if old != sha {
+				changes.Changed = append(changes.Changed, theme)
+			}
+		}
+	}
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Changed)
+
+	data, err := json.MarshalIndent(changes, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(m.config.StateDir, LastUpdateFile), data, 0644)
+	}
+	if err != nil {
+		m.logVerbose("Failed to record update changes: %v", err)
+	}
+}
+
+// loadUpdateChanges reads what the latest update brought in
+func (m *Manager) loadUpdateChanges() (*UpdateChanges, error) {
+	data, err := os.ReadFile(filepath.Join(m.config.StateDir, LastUpdateFile))
+	if os.IsNotExist(err) {
+		return nil, ui.WithHints(fmt.Errorf("no update recorded yet"),
+			"Run 'alacritty-colors update' first.")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var changes UpdateChanges
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", LastUpdateFile, err)
+	}
+	return &changes, nil
+}
+
+// filterArrivals keeps the themes added (newOnly) or changed (updated) by
+// the latest update
+func (m *Manager) filterArrivals(themes []ThemeInfo, newOnly, updated bool) ([]ThemeInfo, error) {
+	if !newOnly && !updated {
+		return themes, nil
+	}
+
+	changes, err := m.loadUpdateChanges()
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool)
+	if newOnly {
+		for _, name := range changes.Added {
+			wanted[name] = true
+		}
+	}
+	if updated {
+		for _, name := range changes.Changed {
+			wanted[name] = true
+		}
+	}
+
+	var kept []ThemeInfo
+	for _, t := range themes {
+		if wanted[t.Name] {
+			kept = append(kept, t)
+		}
+	}
+	m.logVerbose("Update on %s: %d new, %d changed", changes.UpdatedAt.Format("2006-01-02 15:04"), len(changes.Added), len(changes.Changed))
+	return kept, nil
+}
@@ -0,0 +1,419 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/downloader"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// Version is the alacritty-colors version recorded in backups
+var Version string
+
+// Companion files written next to a backup's alacritty.toml copy: the
+// current.toml of the time, and an info file with the theme name, the
+// Alacritty and tool versions, the effect settings and an optional
+// description
+const (
+	backupCurrentExt = ".current"
+	backupInfoExt    = ".info"
+)
+
+func backupCompanion(backupPath, ext string) string {
+	return strings.TrimSuffix(backupPath, ".toml") + ext
+}
+
+// backupInfo holds the "Key: value" lines of a backup's info file
+type backupInfo map[string]string
+
+func readBackupInfo(backupPath string) backupInfo {
+	info := make(backupInfo)
+	content, err := os.ReadFile(backupCompanion(backupPath, backupInfoExt))
+	if err != nil {
+		return info
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if key, value, ok := strings.Cut(line, ": "); ok {
+			info[key] = strings.TrimSpace(value)
+		}
+	}
+	return info
+}
+
+// writeBackup copies the Alacritty config to backupPath, with current.toml
+// and the applied theme name alongside
+func (m *Manager) writeBackup(backupPath, description string) error {
+	if err := m.copyFile(m.config.ConfigFile, backupPath); err != nil {
+		return err
+	}
+
+	// A symlinked current.toml is saved by content so the backup keeps the
+	// colors even if the theme file changes later
+	if _, err := os.Stat(m.currentThemeFile()); err == nil {
+		if err := m.copyFile(m.currentThemeFile(), backupCompanion(backupPath, backupCurrentExt)); err != nil {
+			ui.PrintWarning("Failed to back up current.toml: %v", err)
+		}
+	}
+
+	var lines []string
+	if description != "" {
+		lines = append(lines, "Description: "+description)
+	}
+	if theme := m.GetCurrentTheme(); theme != "" {
+		lines = append(lines, "Theme: "+theme)
+	}
+	if effects := m.configEffects(); effects != "" {
+		lines = append(lines, "Effects: "+effects)
+	}
+	if version := alacrittyVersion(); version != "" {
+		lines = append(lines, "Alacritty: "+version)
+	}
+	if Version != "" {
+		lines = append(lines, "Tool: "+Version)
+	}
+	lines = append(lines, "Created: "+time.Now().Format("2006-01-02 15:04:05"))
+	return os.WriteFile(backupCompanion(backupPath, backupInfoExt), []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
+
+// restoreBackupFiles puts a backup's config back, and unless configOnly is
+// set its current.toml and theme name too. Backups made before current.toml
+// was saved restore the config alone.
+func (m *Manager) restoreBackupFiles(backupPath string, configOnly bool) error {
+	warnBackupCompatibility(readBackupInfo(backupPath))
+	if err := m.copyFile(backupPath, m.config.ConfigFile); err != nil {
+		return fmt.Errorf("failed to restore config: %w", err)
+	}
+	if configOnly {
+		ui.PrintSuccess("Configuration restored from backup (colors left as they are)")
+		return nil
+	}
+
+	saved := backupCompanion(backupPath, backupCurrentExt)
+	if _, err := os.Stat(saved); err != nil {
+		ui.PrintSuccess("Configuration restored from backup")
+		ui.PrintInfo("This backup has no current.toml; the colors were left as they are")
+		return nil
+	}
+	data, err := os.ReadFile(saved)
+	if err == nil {
+		err = m.replaceCurrent(data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restore current.toml: %w", err)
+	}
+
+	theme := readBackupInfo(backupPath)["Theme"]
+	if theme != "" {
+		if err := m.config.SetCurrentTheme(theme); err != nil {
+			ui.PrintWarning("Failed to update theme tracking: %v", err)
+		}
+		if err := m.recordApplied(theme); err != nil {
+			m.logVerbose("Failed to record applied theme: %v", err)
+		}
+		ui.PrintSuccess("Configuration and theme '%s' restored from backup", theme)
+		return nil
+	}
+	ui.PrintSuccess("Configuration and colors restored from backup")
+	return nil
+}
+
+// configEffects lists the window, font and bold-bright settings the config
+// has, as "key = value" pairs
+func (m *Manager) configEffects() string {
+	text, err := readConfigText(m.config.ConfigFile)
+	if err != nil {
+		return ""
+	}
+	var effects []string
+	for _, key := range (ResetOptions{Opacity: true, Blur: true, Font: true, BoldBright: true}).keys() {
+		table, name := splitKey(key)
+		if _, value, found := findConfigValue(text.Lines, table, name); found {
+			effects = append(effects, key+" = "+value)
+		}
+	}
+	return strings.Join(effects, ", ")
+}
+
+// alacrittyVersion returns the version of the alacritty command on PATH,
+// from output such as "alacritty 0.13.2 (bb8ea18e)", or "" without one
+func alacrittyVersion() string {
+	out, err := exec.Command("alacritty", "--version").Output()
+	if err != nil {
+		return ""
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) < 2 {
+		return ""
+	}
+	return strings.TrimPrefix(fields[1], "v")
+}
+
+// configFormatVersion is the Alacritty release that replaced YAML configs
+// with TOML
+const configFormatVersion = "0.13.0"
+
+// warnBackupCompatibility warns when a backup was made with another
+// Alacritty release series than the one installed, whose config options
+// may have been renamed or removed since
+func warnBackupCompatibility(info backupInfo) {
+	saved, installed := info["Alacritty"], alacrittyVersion()
+	if saved == "" || installed == "" || releaseSeries(saved) == releaseSeries(installed) {
+		return
+	}
+
+	switch {
+	case downloader.NewerVersion(saved, configFormatVersion) && !downloader.NewerVersion(installed, configFormatVersion):
+		ui.PrintWarning("This backup was made with Alacritty %s, before configs moved from YAML to TOML in 0.13; you have %s", saved, installed)
+		ui.PrintInfo("If Alacritty reports unknown or renamed options, run 'alacritty migrate' on the restored config")
+	case downloader.NewerVersion(installed, saved):
+		ui.PrintWarning("This backup was made with Alacritty %s, newer than your %s; some of its options may not exist in your version", saved, installed)
+	default:
+		ui.PrintWarning("This backup was made with Alacritty %s; you have %s", saved, installed)
+		ui.PrintInfo("If Alacritty reports renamed options, 'alacritty migrate' updates the restored config")
+	}
+}
+
+// releaseSeries returns the major and minor parts of a version, e.g. "0.13"
+// for 0.13.2
+func releaseSeries(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// backupFiles returns the backups in the backup directory, newest first
+func (m *Manager) backupFiles() ([]string, error) {
+	entries, err := os.ReadDir(m.config.BackupDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	type backup struct {
+		name    string
+		modTime time.Time
+	}
+	var backups []backup
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".toml") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{entry.Name(), info.ModTime()})
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		if !backups[i].modTime.Equal(backups[j].modTime) {
+			return backups[i].modTime.After(backups[j].modTime)
+		}
+		// Same second: the timestamp in the name breaks the tie
+		return backups[i].name > backups[j].name
+	})
+
+	names := make([]string, len(backups))
+	for i, b := range backups {
+		names[i] = b.name
+	}
+	return names, nil
+}
+
+// autoBackupPrefix starts the names of backups made without a name, by
+// apply or a plain 'backup'; only those are pruned
+const autoBackupPrefix = "alacritty_"
+
+// pruneBackups moves unnamed backups beyond backup.keep, or older than
+// backup.max_age_days, to the trash with their companion files
+func (m *Manager) pruneBackups() {
+	policy := m.config.Backup
+	if policy.Keep == 0 && policy.MaxAgeDays == 0 {
+		return
+	}
+	names, err := m.backupFiles()
+	if err != nil {
+		m.logVerbose("Failed to list backups: %v", err)
+		return
+	}
+
+	kept, pruned := 0, 0
+	for _, name := range names {
+		if !strings.HasPrefix(name, autoBackupPrefix) {
+			continue
+		}
+		path := filepath.Join(m.config.BackupDir, name)
+		expired := false
+		if policy.MaxAgeDays > 0 {
+			if info, err := os.Stat(path); err == nil {
+				expired = time.Since(info.ModTime()) > time.Duration(policy.MaxAgeDays)*24*time.Hour
+			}
+		}
+		if !expired && (policy.Keep == 0 || kept < policy.Keep) {
+			kept++
+			continue
+		}
+
+		if err := m.MoveToTrash(path, TrashBackup); err != nil {
+			ui.PrintWarning("Failed to remove old backup %s: %v", name, err)
+			continue
+		}
+		for _, ext := range []string{backupCurrentExt, backupInfoExt} {
+			companion := backupCompanion(path, ext)
+			if _, err := os.Stat(companion); err == nil {
+				if err := m.MoveToTrash(companion, TrashBackup); err != nil {
+					m.logVerbose("Failed to remove %s: %v", filepath.Base(companion), err)
+				}
+			}
+		}
+		pruned++
+	}
+	if pruned > 0 {
+		ui.PrintInfo("Moved %d old backups to the trash (backup.keep = %d, backup.max_age_days = %d)", pruned, policy.Keep, policy.MaxAgeDays)
+	}
+}
+
+// nthBackup returns the nth most recent backup, counting from 1
+func (m *Manager) nthBackup(n int) (string, error) {
+	if n < 1 {
+		return "", fmt.Errorf("backup number must be at least 1")
+	}
+	backups, err := m.backupFiles()
+	if err != nil {
+		return "", err
+	}
+	if len(backups) == 0 {
+		return "", ui.WithHints(fmt.Errorf("no backups found in %s", m.config.BackupDir),
+			"Create one with 'alacritty-colors backup'")
+	}
+	if n > len(backups) {
+		return "", ui.WithHints(fmt.Errorf("only %d backups exist", len(backups)),
+			"List backups with 'alacritty-colors restore --list'")
+	}
+	return backups[n-1], nil
+}
+
+// resolveBackup turns a file name, path or unique name prefix into the
+// path of a backup
+func (m *Manager) resolveBackup(backupFile string) (string, error) {
+	if filepath.IsAbs(backupFile) {
+		if _, err := os.Stat(backupFile); err != nil {
+			return "", m.backupNotFound(backupFile)
+		}
+		return backupFile, nil
+	}
+
+	path := filepath.Join(m.config.BackupDir, backupFile)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	backups, err := m.backupFiles()
+	if err != nil {
+		return "", err
+	}
+	var matches []string
+	for _, name := range backups {
+		if strings.HasPrefix(name, backupFile) {
+			matches = append(matches, name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", m.backupNotFound(path)
+	case 1:
+		return filepath.Join(m.config.BackupDir, matches[0]), nil
+	default:
+		hints := []string{fmt.Sprintf("'%s' matches %d backups; use a longer prefix:", backupFile, len(matches))}
+		for i, name := range matches {
+			if i == 5 {
+				hints = append(hints, fmt.Sprintf("  ... and %d more", len(matches)-i))
+				break
+			}
+			hints = append(hints, "  "+name)
+		}
+		return "", ui.WithHints(fmt.Errorf("ambiguous backup name: %s", backupFile), hints...)
+	}
+}
+
+// backupSlotsDir, in the backup directory, holds the named slots. Each slot
+// is a backup under a fixed name that is overwritten in place, so it never
+// piles up with the timestamped ones.
+const backupSlotsDir = "slots"
+
+func (m *Manager) slotPath(slot string) string {
+	return filepath.Join(m.config.BackupDir, backupSlotsDir, slot+".toml")
+}
+
+func validSlotName(slot string) error {
+	if slot == "" || strings.ContainsAny(slot, `/\`) || strings.HasPrefix(slot, ".") {
+		return ui.WithHints(fmt.Errorf("invalid slot name '%s'", slot), "Use a plain name, e.g. --slot stable")
+	}
+	return nil
+}
+
+// backupSlots returns the names of the saved slots, sorted
+func (m *Manager) backupSlots() []string {
+	files, _ := filepath.Glob(filepath.Join(m.config.BackupDir, backupSlotsDir, "*.toml"))
+	slots := make([]string, len(files))
+	for i, file := range files {
+		slots[i] = strings.TrimSuffix(filepath.Base(file), ".toml")
+	}
+	return slots
+}
+
+// saveSlot backs up the config and theme to a named slot, replacing what
+// the slot held
+func (m *Manager) saveSlot(slot, description string) error {
+	if err := validSlotName(slot); err != nil {
+		return err
+	}
+	path := m.slotPath(slot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create slot directory: %w", err)
+	}
+
+	previous := readBackupInfo(path)
+	// A slot saved while no current.toml exists must not keep the old one
+	os.Remove(backupCompanion(path, backupCurrentExt))
+	m.logVerbose("Saving slot: %s", path)
+	if err := m.writeBackup(path, description); err != nil {
+		return fmt.Errorf("failed to save slot: %w", err)
+	}
+
+	ui.PrintSuccess("Saved slot '%s'", slot)
+	if created := previous["Created"]; created != "" {
+		replaced := created
+		if previous["Theme"] != "" {
+			replaced = fmt.Sprintf("'%s' from %s", previous["Theme"], created)
+		}
+		ui.PrintInfo("Replaced %s", replaced)
+	}
+	ui.PrintInfo("Return to it with: alacritty-colors restore --slot %s", slot)
+	return nil
+}
+
+// restoreSlot restores the backup saved in a named slot
+func (m *Manager) restoreSlot(slot string, configOnly bool) error {
+	if err := validSlotName(slot); err != nil {
+		return err
+	}
+	path := m.slotPath(slot)
+	if _, err := os.Stat(path); err != nil {
+		hint := fmt.Sprintf("Save it first: alacritty-colors backup --slot %s", slot)
+		if slots := m.backupSlots(); len(slots) > 0 {
+			return ui.WithHints(fmt.Errorf("no slot named '%s'", slot),
+				"Saved slots: "+strings.Join(slots, ", "), hint)
+		}
+		return ui.WithHints(fmt.Errorf("no slot named '%s'", slot), hint)
+	}
+
+	ui.PrintInfo("Restoring slot: %s", slot)
+	return m.restoreBackupFiles(path, configOnly)
+}
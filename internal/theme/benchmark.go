@@ -0,0 +1,219 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// BenchmarkOptions control the benchmark command
+type BenchmarkOptions struct {
+	// Iterations is how many times each operation runs
+	Iterations int
+	// Slowest is how many of the slowest theme files to list
+	Slowest int
+	Format  string
+}
+
+// BenchmarkResult holds the timings of one operation
+type BenchmarkResult struct {
+	Operation string        `json:"operation"`
+	Runs      int           `json:"runs"`
+	Min       time.Duration `json:"min_ns"`
+	Avg       time.Duration `json:"avg_ns"`
+	P95       time.Duration `json:"p95_ns"`
+	Max       time.Duration `json:"max_ns"`
+	Total     time.Duration `json:"total_ns"`
+}
+
+// FileTiming is the average parse time of a theme file
+type FileTiming struct {
+	File     string        `json:"file"`
+	Duration time.Duration `json:"avg_ns"`
+}
+
+// BenchmarkReport is the full output of a benchmark run
+type BenchmarkReport struct {
+	Themes     int               `json:"themes"`
+	Iterations int               `json:"iterations"`
+	Results    []BenchmarkResult `json:"results"`
+	Slowest    []FileTiming      `json:"slowest_files"`
+}
+
+func summarize(operation string, samples []time.Duration) BenchmarkResult {
+	result := BenchmarkResult{Operation: operation, Runs: len(samples)}
+	if len(samples) == 0 {
+		return result
+	}
+
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for _, d := range sorted {
+		result.Total += d
+	}
+	result.Min = sorted[0]
+	result.Max = sorted[len(sorted)-1]
+	result.Avg = result.Total / time.Duration(len(sorted))
+	result.P95 = sorted[(len(sorted)*95+99)/100-1]
+	return result
+}
+
+// Benchmark times the index build, theme parsing, apply and generation over
+// the installed themes. Apply runs against a scratch directory so the real
+// configuration is never touched.
+func (m *Manager) Benchmark(opts *BenchmarkOptions) error {
+	iterations := max(opts.Iterations, 1)
+
+	files, err := m.getThemeFiles()
+	if err != nil {
+		return err
+	}
+	var themeFiles []string
+	for _, file := range files {
+		if filepath.Base(file) != "current.toml" {
+			themeFiles = append(themeFiles, file)
+		}
+	}
+	if len(themeFiles) == 0 {
+		return ui.WithHints(fmt.Errorf("no themes to benchmark"),
+			"Download themes with 'alacritty-colors update'.")
+	}
+
+	report := BenchmarkReport{Themes: len(themeFiles), Iterations: iterations}
+	quiet := opts.Format == "json"
+	step := func(n int, text string) {
+		if !quiet {
+			ui.PrintStep(n, 4, text)
+		}
+	}
+
+	step(1, "Building theme index")
+	var index []time.Duration
+	for i := 0; i < iterations; i++ {
+		start := time.Now()
+		if _, err := m.getThemeInfos(); err != nil {
+			return err
+		}
+		index = append(index, time.Since(start))
+	}
+	report.Results = append(report.Results, summarize("index build", index))
+
+	step(2, "Parsing theme files")
+	var parse []time.Duration
+	var perFile []FileTiming
+	for _, file := range themeFiles {
+		var total time.Duration
+		for i := 0; i < iterations; i++ {
+			start := time.Now()
+			if _, err := m.parseThemeFile(file); err != nil {
+				m.logVerbose("Failed to parse %s: %v", file, err)
+			}
+			d := time.Since(start)
+			parse = append(parse, d)
+			total += d
+		}
+		perFile = append(perFile, FileTiming{File: file, Duration: total / time.Duration(iterations)})
+	}
+	report.Results = append(report.Results, summarize("parse (per file)", parse))
+
+	sort.Slice(perFile, func(i, j int) bool { return perFile[i].Duration > perFile[j].Duration })
+	report.Slowest = perFile[:min(max(opts.Slowest, 0), len(perFile))]
+
+	step(3, "Applying themes in a scratch directory")
+	scratch, err := os.MkdirTemp("", "alacritty-colors-bench-")
+	if err != nil {
+		return fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+	defer os.RemoveAll(scratch)
+
+	scratchCurrent := filepath.Join(scratch, "current.toml")
+	var apply []time.Duration
+	for _, file := range themeFiles {
+		for i := 0; i < iterations; i++ {
+			start := time.Now()
+			if err := m.copyFile(file, scratchCurrent); err != nil {
+				return fmt.Errorf("failed to apply %s: %w", filepath.Base(file), err)
+			}
+			m.hasImportLine()
+			apply = append(apply, time.Since(start))
+		}
+	}
+	report.Results = append(report.Results, summarize("apply (per theme)", apply))
+
+	step(4, "Generating themes")
+	var generate []time.Duration
+	for _, scheme := range GeneratorSchemes {
+		for i := 0; i < iterations; i++ {
+			start := time.Now()
+			colors, err := m.generateColorSchemeWithVariant(scheme, false, false)
+			if err != nil {
+				return err
+			}
+			m.createThemeContent(colors, scheme, generateRandomName(scheme))
+			generate = append(generate, time.Since(start))
+		}
+	}
+	report.Results = append(report.Results, summarize("generate (per scheme)", generate))
+
+	if quiet {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	m.printBenchmarkReport(report)
+	return nil
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return strconv.FormatFloat(d.Seconds(), 'f', 2, 64) + "s"
+	case d >= time.Millisecond:
+		return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 2, 64) + "ms"
+	case ui.SupportsUnicode():
+		return strconv.FormatFloat(float64(d)/float64(time.Microsecond), 'f', 1, 64) + "µs"
+	default:
+		return strconv.FormatFloat(float64(d)/float64(time.Microsecond), 'f', 1, 64) + "us"
+	}
+}
+
+func (m *Manager) printBenchmarkReport(report BenchmarkReport) {
+	fmt.Println()
+	ui.PrintHeader("Benchmark")
+	ui.PrintKeyValue("Themes", strconv.Itoa(report.Themes))
+	ui.PrintKeyValue("Iterations", strconv.Itoa(report.Iterations))
+	fmt.Println()
+
+	var rows [][]string
+	for _, r := range report.Results {
+		rows = append(rows, []string{
+			r.Operation,
+			strconv.Itoa(r.Runs),
+			formatDuration(r.Min),
+			formatDuration(r.Avg),
+			formatDuration(r.P95),
+			formatDuration(r.Max),
+			formatDuration(r.Total),
+		})
+	}
+	ui.PrintTable([]string{"Operation", "Runs", "Min", "Avg", "P95", "Max", "Total"}, rows)
+
+	if len(report.Slowest) > 0 {
+		ui.PrintSubHeader("Slowest Theme Files")
+		rows = nil
+		for _, f := range report.Slowest {
+			rows = append(rows, []string{filepath.Base(f.File), formatDuration(f.Duration), filepath.Dir(f.File)})
+		}
+		ui.PrintTable([]string{"File", "Avg Parse", "Directory"}, rows)
+	}
+}
@@ -0,0 +1,153 @@
+package theme
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+	"github.com/vitruves/alacritty-colors/pkg/alacritty"
+)
+
+// brightStep is the OKLCH lightness between a normal color and the bright
+// variant synthesized for it
+const brightStep = 0.1
+
+// identicalBrights returns the colors of a theme file whose bright variant
+// repeats the normal one
+func identicalBrights(themePath string) []string {
+	cfg, err := alacritty.NewParser().ParseFile(themePath)
+	if err != nil {
+		return nil
+	}
+	return cfg.Colors.IdenticalBrights()
+}
+
+// synthesizesBrights reports whether synthesize_brights is on and the theme
+// has bright colors it would change
+func (m *Manager) synthesizesBrights(themePath string) bool {
+	return m.config.Apply.SynthesizeBrights && len(identicalBrights(themePath)) > 0
+}
+
+// synthesizeBrights gives bright colors that repeat the normal ones their
+// own shade: lighter on dark themes and darker on light ones, so bold text
+// drawn in bright colors stands out. It returns the new theme and the
+// slots it changed.
+func synthesizeBrights(data []byte) ([]byte, []string) {
+	lines := strings.Split(string(data), "\n")
+	values, _ := colorTables(lines)
+	bg, err := ParseColor(values["primary.background"])
+	if err != nil {
+		return data, nil
+	}
+	step := brightStep
+	if bg.toOKLCH().L >= 0.5 {
+		step = -brightStep
+	}
+
+	var changed []string
+	for _, name := range ansiColors {
+		normal, errNormal := ParseColor(values["normal."+name])
+		bright, errBright := ParseColor(values["bright."+name])
+		if errNormal != nil || errBright != nil || normal != bright {
+			continue
+		}
+		c := normal.toOKLCH()
+		shade := oklch{L: math.Max(0, math.Min(1, c.L+step)), C: c.C, H: c.H}.toRGB()
+		if shade == normal {
+			// Already as light, or as dark, as it gets; go the other way
+			shade = oklch{L: math.Max(0, math.Min(1, c.L-step)), C: c.C, H: c.H}.toRGB()
+		}
+		hex := shade.ToHex()
+		i, _, ok := findConfigValue(lines, "colors.bright", name)
+		if !ok {
+			continue
+		}
+		lines[i] = hexColorPattern.ReplaceAllStringFunc(lines[i], func(value string) string {
+			if strings.HasPrefix(value, "0x") {
+				return "0x" + strings.TrimPrefix(hex, "#")
+			}
+			return hex
+		})
+		changed = append(changed, "bright."+name)
+	}
+	if len(changed) == 0 {
+		return data, nil
+	}
+	return []byte(strings.Join(lines, "\n")), changed
+}
+
+// boldBrightSetting returns draw_bold_text_with_bright_colors as the config
+// sets it, and whether it does
+func (m *Manager) boldBrightSetting() (string, bool) {
+	text, err := readConfigText(m.config.ConfigFile)
+	if err != nil {
+		return "", false
+	}
+	table, key := splitKey(keyBoldBright)
+	_, value, found := findConfigValue(text.Lines, table, key)
+	return value, found
+}
+
+// BoldBright shows or sets draw_bold_text_with_bright_colors, which makes
+// Alacritty draw bold text in the bright variant of its color. value is
+// "on", "off" or empty to show the setting.
+func (m *Manager) BoldBright(value string) error {
+	if value == "" {
+		current, found := m.boldBrightSetting()
+		if !found {
+			current = "false (Alacritty's default)"
+		}
+		ui.PrintKeyValue(alacritty.DrawBoldBrightKey, current)
+		m.warnIdenticalBrights(current == "true")
+		return nil
+	}
+
+	var setting string
+	switch strings.ToLower(value) {
+	case "on", "true", "yes":
+		setting = "true"
+	case "off", "false", "no":
+		setting = "false"
+	default:
+		return ui.WithHints(fmt.Errorf("invalid value: %s", value),
+			"Use on or off, e.g. alacritty-colors effects bold-bright on")
+	}
+
+	m.rememberBase(keyBoldBright)
+	text, err := readConfigText(m.config.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	table, key := splitKey(keyBoldBright)
+	lines := text.Lines
+	if i, _, found := findConfigValue(lines, table, key); found {
+		lines[i] = key + " = " + setting
+	} else {
+		lines = insertConfigValue(lines, table, key+" = "+setting)
+	}
+	if err := text.write(m.config.ConfigFile, lines); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	ui.PrintSuccess("Set %s = %s", alacritty.DrawBoldBrightKey, setting)
+	m.warnIdenticalBrights(setting == "true")
+	return nil
+}
+
+// warnIdenticalBrights points out, while bold text is drawn in bright
+// colors, the colors of the applied theme in which it won't stand out
+func (m *Manager) warnIdenticalBrights(boldBright bool) {
+	if !boldBright || m.config.Apply.SynthesizeBrights {
+		return
+	}
+	t, err := m.findTheme(m.GetCurrentTheme())
+	if err != nil {
+		return
+	}
+	if same := identicalBrights(t.FilePath); len(same) > 0 {
+		ui.PrintWarning("%s has the same normal and bright %s, so bold text in them looks regular",
+			t.Name, strings.Join(same, ", "))
+		ui.PrintInfo("Set apply.synthesize_brights = true in %s to give them a bright shade on apply", m.config.Path())
+	}
+}
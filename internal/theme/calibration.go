@@ -0,0 +1,50 @@
+package theme
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/config"
+)
+
+// hexColorPattern finds colors written as "#rrggbb" or "0xrrggbb"
+var hexColorPattern = regexp.MustCompile(`(#|0x)[0-9a-fA-F]{6}\b`)
+
+// calibrate applies the display calibration to a color
+func calibrate(c RGB, d config.DisplayCalibration) RGB {
+	channel := func(v int) int {
+		x := math.Pow(float64(v)/255, 1/d.Gamma) + d.BrightnessOffset
+		return int(math.Round(math.Max(0, math.Min(1, x)) * 255))
+	}
+	return RGB{R: channel(c.R), G: channel(c.G), B: channel(c.B)}
+}
+
+// calibrateTheme rewrites every color of the [colors] tables of a theme
+// with the display calibration, keeping the rest of the file as is
+func calibrateTheme(data []byte, d config.DisplayCalibration) []byte {
+	lines := strings.Split(string(data), "\n")
+	inColors := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			inColors = strings.HasPrefix(strings.TrimLeft(trimmed, "["), "colors")
+			continue
+		}
+		if !inColors || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		lines[i] = hexColorPattern.ReplaceAllStringFunc(line, func(value string) string {
+			rgb, err := ParseColor(value)
+			if err != nil {
+				return value
+			}
+			hex := calibrate(rgb, d).ToHex()
+			if strings.HasPrefix(value, "0x") {
+				return "0x" + strings.TrimPrefix(hex, "#")
+			}
+			return hex
+		})
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
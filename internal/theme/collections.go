@@ -0,0 +1,37 @@
+package theme
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// filterCollection keeps the themes matching one of the collection's names
+// or glob patterns. An empty name keeps every theme.
+func (m *Manager) filterCollection(themes []ThemeInfo, name string) ([]ThemeInfo, error) {
+	if name == "" {
+		return themes, nil
+	}
+
+	patterns, ok := m.config.Collections[name]
+	if !ok {
+		names := m.config.CollectionNames()
+		if len(names) == 0 {
+			return nil, ui.WithHints(fmt.Errorf("collection '%s' not found", name),
+				`Define it in the settings file, e.g. collections.cozy = ["gruvbox*", "nord"]`)
+		}
+		return nil, ui.WithHints(fmt.Errorf("collection '%s' not found", name),
+			ui.DidYouMean(name, names),
+			"Available collections: "+strings.Join(names, ", "))
+	}
+
+	var kept []ThemeInfo
+	for _, t := range themes {
+		if matchesPatterns(t.Name, patterns) {
+			kept = append(kept, t)
+		}
+	}
+	m.logVerbose("Collection %s matches %d themes", name, len(kept))
+	return kept, nil
+}
@@ -202,7 +202,7 @@ func EnsureContrast(foreground, background RGB, minRatio float64) RGB {
 
 	// Try making foreground lighter or darker
 	for i := 0; i < 100; i++ {
-		if bgLum > 0.5 {
+		if bgLum < 0.5 {
 			// Dark background, make foreground lighter
 			fgHSL.L = math.Min(1.0, fgHSL.L+0.01)
 		} else {
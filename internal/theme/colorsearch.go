@@ -0,0 +1,124 @@
+package theme
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// DefaultColorTolerance is the largest CIE76 distance counted as a match;
+// around 2 is barely noticeable, 10 is close at a glance
+const DefaultColorTolerance = 10.0
+
+// ParseColor reads "#rrggbb", "0xrrggbb", "rrggbb" or "#rgb"
+func ParseColor(value string) (RGB, error) {
+	hex := strings.TrimSpace(value)
+	hex = strings.TrimPrefix(strings.TrimPrefix(hex, "#"), "0x")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return RGB{}, fmt.Errorf("invalid color %q (use #rrggbb)", value)
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return RGB{}, fmt.Errorf("invalid color %q (use #rrggbb)", value)
+	}
+	return RGB{R: int(n >> 16), G: int(n >> 8 & 0xff), B: int(n & 0xff)}, nil
+}
+
+// lab is a color in CIE L*a*b* under the D65 white point
+type lab struct {
+	L, A, B float64
+}
+
+func (rgb RGB) toLab() lab {
+	linear := func(c int) float64 {
+		v := float64(c) / 255
+		if v <= 0.04045 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	r, g, b := linear(rgb.R), linear(rgb.G), linear(rgb.B)
+
+	x := (0.4124*r + 0.3576*g + 0.1805*b) / 0.95047
+	y := 0.2126*r + 0.7152*g + 0.0722*b
+	z := (0.0193*r + 0.1192*g + 0.9505*b) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return lab{L: 116*fy - 16, A: 500 * (fx - fy), B: 200 * (fy - fz)}
+}
+
+// ColorDistance is the CIE76 difference between two colors
+func ColorDistance(a, b RGB) float64 {
+	la, lb := a.toLab(), b.toLab()
+	return math.Sqrt((la.L-lb.L)*(la.L-lb.L) + (la.A-lb.A)*(la.A-lb.A) + (la.B-lb.B)*(la.B-lb.B))
+}
+
+// colorMatch is the slot of a theme closest to the searched color
+type colorMatch struct {
+	Theme    ThemeInfo
+	Slot     string
+	Value    RGB
+	Distance float64
+}
+
+// slotMatches reports whether a color key such as "normal_red" is the
+// requested slot; "red" matches both the normal and bright variants
+func slotMatches(key, slot string) bool {
+	return slot == "" || strings.EqualFold(key, slot) || strings.HasSuffix(strings.ToLower(key), "_"+strings.ToLower(slot))
+}
+
+// findByColor returns the themes with a color within tolerance of target,
+// closest first
+func (m *Manager) findByColor(themes []ThemeInfo, target RGB, tolerance float64, slot string) []colorMatch {
+	var matches []colorMatch
+	for _, t := range themes {
+		best := colorMatch{Distance: math.Inf(1)}
+		for key, value := range t.Colors {
+			if !slotMatches(key, slot) {
+				continue
+			}
+			rgb, err := ParseColor(value)
+			if err != nil {
+				continue
+			}
+			if d := ColorDistance(target, rgb); d < best.Distance {
+				best = colorMatch{Theme: t, Slot: key, Value: rgb, Distance: d}
+			}
+		}
+		if best.Distance <= tolerance {
+			matches = append(matches, best)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Distance < matches[j].Distance })
+	return matches
+}
+
+// printColorMatches lists matches with the slot and how close it is
+func (m *Manager) printColorMatches(target RGB, matches []colorMatch) {
+	ui.PrintHeader(fmt.Sprintf("Themes near %s (%d)", target.ToHex(), len(matches)))
+
+	rows := make([][]string, 0, len(matches))
+	for _, match := range matches {
+		rows = append(rows, []string{
+			match.Theme.Name,
+			match.Slot,
+			match.Value.ToHex(),
+			strconv.FormatFloat(match.Distance, 'f', 1, 64),
+		})
+	}
+	ui.PrintTable([]string{"Theme", "Slot", "Color", "Distance"}, rows)
+}
@@ -0,0 +1,123 @@
+package theme
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// configText is a config file split into lines. It remembers the byte order
+// mark and line endings so edits can be written back without touching the
+// rest of the file.
+type configText struct {
+	Lines []string
+	crlf  bool
+	bom   bool
+	// fromUTF16 is set when the file was UTF-16, which Alacritty cannot read
+	fromUTF16 bool
+}
+
+// readConfigText reads a config file, decoding UTF-16 files some Windows
+// editors produce
+func readConfigText(path string) (*configText, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	text := &configText{}
+	if order, bomLen, ok := detectUTF16(data); ok {
+		decoded, err := decodeUTF16(data[bomLen:], order)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode UTF-16 config %s: %w", path, err)
+		}
+		data = decoded
+		text.fromUTF16 = true
+	} else if bytes.HasPrefix(data, utf8BOM) {
+		data = data[len(utf8BOM):]
+		text.bom = true
+	}
+
+	content := string(data)
+	crlf := strings.Count(content, "\r\n")
+	text.crlf = crlf > 0 && crlf >= strings.Count(content, "\n")-crlf
+
+	text.Lines = strings.Split(content, "\n")
+	if text.crlf {
+		for i, line := range text.Lines {
+			text.Lines[i] = strings.TrimSuffix(line, "\r")
+		}
+	}
+	return text, nil
+}
+
+// String returns the lines joined with the file's line endings
+func (t *configText) String() string {
+	sep := "\n"
+	if t.crlf {
+		sep = "\r\n"
+	}
+	return strings.Join(t.Lines, sep)
+}
+
+// write saves lines with the original line endings and byte order mark. A
+// UTF-16 file is converted to UTF-8, keeping the original next to it.
+func (t *configText) write(path string, lines []string) error {
+	if t.fromUTF16 {
+		original, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path+".utf16.bak", original, 0644); err != nil {
+			return fmt.Errorf("failed to keep the UTF-16 original: %w", err)
+		}
+		ui.PrintWarning("Converted %s from UTF-16 to UTF-8, which Alacritty requires (original kept as %s.utf16.bak)", path, path)
+		t.fromUTF16 = false
+	}
+
+	t.Lines = lines
+	var buf bytes.Buffer
+	if t.bom {
+		buf.Write(utf8BOM)
+	}
+	buf.WriteString(t.String())
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// detectUTF16 recognizes UTF-16 by its byte order mark, or by the zero
+// bytes ASCII text has in every other position
+func detectUTF16(data []byte) (binary.ByteOrder, int, bool) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return binary.LittleEndian, 2, true
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return binary.BigEndian, 2, true
+	case len(data) >= 4 && len(data)%2 == 0:
+		if data[0] != 0 && data[1] == 0 && data[2] != 0 && data[3] == 0 {
+			return binary.LittleEndian, 0, true
+		}
+		if data[0] == 0 && data[1] != 0 && data[2] == 0 && data[3] != 0 {
+			return binary.BigEndian, 0, true
+		}
+	}
+	return nil, 0, false
+}
+
+func decodeUTF16(data []byte, order binary.ByteOrder) ([]byte, error) {
+	if len(data)%2 != 0 {
+		return nil, fmt.Errorf("odd number of bytes")
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+	return []byte(string(utf16.Decode(units))), nil
+}
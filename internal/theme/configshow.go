@@ -0,0 +1,189 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/config"
+	"github.com/vitruves/alacritty-colors/internal/downloader"
+	"github.com/vitruves/alacritty-colors/internal/prompt"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// ShowConfigOptions select what 'config show' prints
+type ShowConfigOptions struct {
+	// Paths shows the files and directories in use instead of the settings
+	Paths bool
+	JSON  bool
+}
+
+// configPaths groups the files and directories the tool uses
+type configPaths struct {
+	// AlacrittyConfigs are the places Alacritty looks for its config
+	AlacrittyConfigs []config.PathInfo `json:"alacritty_configs"`
+	Directories      []config.PathInfo `json:"directories"`
+	Caches           []config.PathInfo `json:"caches"`
+}
+
+// themeSource is a theme archive 'init' and 'update' download
+type themeSource struct {
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`
+	Mirrors     []string `json:"mirrors,omitempty"`
+	ChecksumURL string   `json:"checksum_url,omitempty"`
+	Official    bool     `json:"official"`
+}
+
+// configDump is 'config show --json'
+type configDump struct {
+	Settings []config.Setting `json:"settings,omitempty"`
+	Sources  []themeSource    `json:"sources,omitempty"`
+	Paths    configPaths      `json:"paths"`
+}
+
+func (m *Manager) configPaths() configPaths {
+	paths := configPaths{AlacrittyConfigs: m.config.ConfigCandidates()}
+	if len(paths.AlacrittyConfigs) == 0 || !containsUsed(paths.AlacrittyConfigs) {
+		// A config file set by flag, environment or profile
+		used := config.NewPathInfo("Alacritty config", m.deployedConfigFile())
+		used.Used = true
+		paths.AlacrittyConfigs = append(paths.AlacrittyConfigs, used)
+	}
+
+	dir := func(name, path string) {
+		paths.Directories = append(paths.Directories, config.NewPathInfo(name, path))
+	}
+	if m.config.DeployedConfigFile != "" {
+		dir("Dotfiles Source", m.config.ConfigFile)
+	}
+	dir("Themes Dir", m.config.ThemesDir)
+	for _, extra := range m.config.ExtraThemesDirs {
+		dir("Also Searched", extra)
+	}
+	if m.config.SaveDir != "" {
+		dir("Save Dir", m.config.SaveDir)
+	}
+	dir("current.toml", m.currentThemeFile())
+	dir("Backup Dir", m.config.BackupDir)
+	dir("Settings", m.config.Path())
+	dir("Data Dir", m.config.DataDir)
+	dir("Overrides", m.OverridesDir())
+	dir("Templates", m.TemplatesDir())
+	dir("Recordings", m.recordingsDir())
+	dir("Trash", m.trashDir())
+	dir("State Dir", m.config.StateDir)
+
+	for _, cache := range []struct{ name, file string }{
+		{"History", HistoryFile},
+		{"Applied Theme", AppliedStateFile},
+		{"Prompt Cache", prompt.CacheFile},
+		{"Effect Overrides", OverridesFile},
+		{"Last Update", LastUpdateFile},
+		{"Download Manifest", downloader.ManifestFile},
+		{"Theme Metadata", downloader.MetadataFile},
+		{"Release Check", downloader.ReleaseCacheFile},
+	} {
+		paths.Caches = append(paths.Caches, config.NewPathInfo(cache.name, filepath.Join(m.config.StateDir, cache.file)))
+	}
+	return paths
+}
+
+func containsUsed(paths []config.PathInfo) bool {
+	for _, p := range paths {
+		if p.Used {
+			return true
+		}
+	}
+	return false
+}
+
+// themeSources lists the official collection and the configured sources,
+// in download order
+func (m *Manager) themeSources() []themeSource {
+	sources := []themeSource{{
+		Name:     downloader.OfficialSource.Name,
+		URL:      downloader.OfficialSource.URL,
+		Mirrors:  m.config.OfficialMirrors,
+		Official: true,
+	}}
+	for _, s := range m.config.Sources {
+		sources = append(sources, themeSource{Name: s.Name, URL: s.URL, Mirrors: s.Mirrors, ChecksumURL: s.ChecksumURL})
+	}
+	return sources
+}
+
+// ShowConfigWithOptions prints the configuration with every effective
+// setting and where it comes from (flag, environment, profile, settings file
+// or default), or with --paths the files and directories in use. JSON holds
+// all of it, for attaching to bug reports.
+func (m *Manager) ShowConfigWithOptions(opts ShowConfigOptions) error {
+	if opts.JSON {
+		dump := configDump{Paths: m.configPaths()}
+		if !opts.Paths {
+			dump.Settings = m.config.EffectiveSettings()
+			dump.Sources = m.themeSources()
+		}
+		data, err := json.MarshalIndent(dump, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if opts.Paths {
+		m.printConfigPaths()
+		return nil
+	}
+
+	if err := m.ShowConfig(); err != nil {
+		return err
+	}
+
+	ui.PrintSubHeader("Effective Settings")
+	var rows [][]string
+	for _, s := range m.config.EffectiveSettings() {
+		rows = append(rows, []string{s.Key, s.Value, s.Source})
+	}
+	ui.PrintTable([]string{"Setting", "Value", "Source"}, rows)
+
+	ui.PrintSubHeader("Theme Sources")
+	rows = nil
+	for _, s := range m.themeSources() {
+		rows = append(rows, []string{s.Name, s.URL, strings.Join(s.Mirrors, ", ")})
+	}
+	ui.PrintTable([]string{"Name", "URL", "Mirrors"}, rows)
+	ui.PrintInfo("Show the files and directories in use with: alacritty-colors config show --paths")
+	return nil
+}
+
+// printConfigPaths shows where the tool looks for and keeps its files
+func (m *Manager) printConfigPaths() {
+	ui.PrintHeader("Alacritty Colors Paths")
+	paths := m.configPaths()
+
+	state := func(p config.PathInfo) string {
+		switch {
+		case p.Used && p.Exists:
+			return "in use"
+		case p.Used:
+			return "in use, missing"
+		case p.Exists:
+			return "found"
+		}
+		return "-"
+	}
+	section := func(title string, paths []config.PathInfo) {
+		ui.PrintSubHeader(title)
+		var rows [][]string
+		for _, p := range paths {
+			rows = append(rows, []string{p.Name, p.Path, state(p)})
+		}
+		ui.PrintTable([]string{"Name", "Path", "State"}, rows)
+	}
+	section("Alacritty Config (in lookup order)", paths.AlacrittyConfigs)
+	section("Directories", paths.Directories)
+	section("Caches and State", paths.Caches)
+}
@@ -0,0 +1,44 @@
+package theme
+
+import "strconv"
+
+// themeContrast returns the WCAG contrast ratio between a theme's
+// foreground and background, or 0 when either is missing
+func themeContrast(t ThemeInfo) float64 {
+	fg, err := ParseColor(t.Colors["foreground"])
+	if err != nil {
+		return 0
+	}
+	bg, err := ParseColor(t.Colors["background"])
+	if err != nil {
+		return 0
+	}
+	return GetContrastRatio(fg, bg)
+}
+
+// withContrast attaches the foreground/background contrast to each theme
+func (m *Manager) withContrast(themes []ThemeInfo) []ThemeInfo {
+	for i := range themes {
+		themes[i].Contrast = themeContrast(themes[i])
+	}
+	return themes
+}
+
+// filterContrast keeps the themes whose contrast is at least min
+func (m *Manager) filterContrast(themes []ThemeInfo, min float64) []ThemeInfo {
+	var kept []ThemeInfo
+	for _, t := range themes {
+		if t.Contrast >= min {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// contrastBadge renders a ratio such as "12.6:1"
+func contrastBadge(contrast float64) string {
+	if contrast <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(contrast, 'f', 1, 64) + ":1"
+}
@@ -0,0 +1,303 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/config"
+)
+
+// currentThemeFile returns the path of current.toml, the file imported by
+// the Alacritty config
+func (m *Manager) currentThemeFile() string {
+	return filepath.Join(m.config.ThemesDir, "current.toml")
+}
+
+// installCurrent makes current.toml show the given theme file, as a copy or
+// as a symlink depending on the settings. Symlinks fall back to a copy on
+// Windows and wherever they cannot be created, and when overrides or a
+// display calibration apply, since a link cannot carry the changes.
+func (m *Manager) installCurrent(themePath string) error {
+	current := m.currentThemeFile()
+	if m.config.Apply.CurrentFile != config.CurrentSymlink || runtime.GOOS == "windows" {
+		return m.copyCurrent(themePath)
+	}
+	if m.adjustsCurrent(themePath) {
+		m.logVerbose("Overrides, a transform or a display calibration apply, copying instead of linking")
+		return m.copyCurrent(themePath)
+	}
+
+	// Themes next to current.toml are linked relatively so the directory
+	// can move, e.g. inside a dotfiles repo
+	target, err := filepath.Abs(themePath)
+	if err != nil {
+		return err
+	}
+	if filepath.Dir(target) == filepath.Clean(m.config.ThemesDir) {
+		target = filepath.Base(target)
+	}
+
+	// Replace the link atomically so Alacritty never sees a missing file
+	tmp := current + ".tmp"
+	os.Remove(tmp)
+	if err := os.Symlink(target, tmp); err != nil {
+		m.logVerbose("Cannot create symlink, copying instead: %v", err)
+		return m.copyCurrent(themePath)
+	}
+	m.settleCurrent()
+	if err := os.Rename(tmp, current); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// copyCurrent copies a theme over current.toml with replaceCurrent, its
+// [colors.primary] table first. Overrides and the display calibration are
+// applied on the way.
+func (m *Manager) copyCurrent(themePath string) error {
+	read := os.ReadFile
+	if m.adjustsCurrent(themePath) {
+		read = m.adjustedTheme
+	}
+	data, err := read(themePath)
+	if err != nil {
+		return err
+	}
+	return m.replaceCurrent(primaryFirst(data))
+}
+
+// showCurrent puts a theme into current.toml as it is, without overrides,
+// for slideshows and previews that flip through themes
+func (m *Manager) showCurrent(themePath string) error {
+	data, err := os.ReadFile(themePath)
+	if err != nil {
+		return err
+	}
+	return m.replaceCurrent(primaryFirst(data))
+}
+
+// replaceCurrent writes data over current.toml through a temporary file,
+// synced to disk before it is renamed into place, so Alacritty's live reload
+// and other alacritty-colors processes never read half a theme
+func (m *Manager) replaceCurrent(data []byte) error {
+	current := m.currentThemeFile()
+	tmp := fmt.Sprintf("%s.%d.tmp", current, os.Getpid())
+	if err := writeSynced(tmp, data); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	m.settleCurrent()
+	if err := os.Rename(tmp, current); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func writeSynced(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// settleCurrent debounces changes to current.toml: with apply.debounce_ms
+// set, a change that comes sooner than that after the last one waits, so a
+// fast slideshow or a burst of applies doesn't reload Alacritty faster than
+// it redraws
+func (m *Manager) settleCurrent() {
+	gap := time.Duration(m.config.Apply.DebounceMS) * time.Millisecond
+	if gap <= 0 {
+		return
+	}
+	info, err := os.Lstat(m.currentThemeFile())
+	if err != nil {
+		return
+	}
+	if wait := gap - time.Since(info.ModTime()); wait > 0 {
+		m.logVerbose("Waiting %s before replacing current.toml", wait.Round(time.Millisecond))
+		time.Sleep(wait)
+	}
+}
+
+// primaryFirst moves a theme's [colors.primary] table, with the comments
+// right above it, ahead of its other tables, so the background and
+// foreground are the first colors a reader of the file comes across. Themes
+// that set them some other way are returned as they are.
+func primaryFirst(data []byte) []byte {
+	lines := strings.Split(string(data), "\n")
+	first, start, end := -1, -1, -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "[") {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		if start >= 0 && end < 0 {
+			end = i
+		}
+		if start < 0 && strings.Trim(trimmed, "[] ") == "colors.primary" {
+			start = i
+		}
+	}
+	for start > first && strings.HasPrefix(strings.TrimSpace(lines[start-1]), "#") {
+		start--
+	}
+	if start <= first {
+		return data
+	}
+	if end < 0 {
+		end = len(lines)
+	}
+
+	block := lines[start:end]
+	for len(block) > 0 && strings.TrimSpace(block[len(block)-1]) == "" {
+		block = block[:len(block)-1]
+	}
+	ordered := append([]string{}, lines[:first]...)
+	ordered = append(ordered, block...)
+	ordered = append(ordered, "")
+	ordered = append(ordered, lines[first:start]...)
+	ordered = append(ordered, lines[end:]...)
+	return []byte(strings.Join(ordered, "\n"))
+}
+
+// adjustsCurrent reports whether current.toml differs from the theme file
+// because of overrides, a color transform, derived or synthesized colors or
+// the display calibration
+func (m *Manager) adjustsCurrent(themePath string) bool {
+	return m.config.Display.Calibrated() || m.transform.Any() || len(m.themeOverrides(themeNameOf(themePath))) > 0 ||
+		m.derivesColors(themePath) || m.synthesizesBrights(themePath)
+}
+
+// derivesColors reports whether derive_colors is on and the theme leaves
+// out colors it would fill in
+func (m *Manager) derivesColors(themePath string) bool {
+	if !m.config.Apply.DeriveColors {
+		return false
+	}
+	data, err := os.ReadFile(themePath)
+	if err != nil {
+		return false
+	}
+	_, added := deriveMissingColors(data)
+	return len(added) > 0
+}
+
+// adjustedTheme returns a theme with its overrides merged in, then the
+// color transform, derived and synthesized colors and the display
+// calibration applied
+func (m *Manager) adjustedTheme(themePath string) ([]byte, error) {
+	data, err := os.ReadFile(themePath)
+	if err != nil {
+		return nil, err
+	}
+	data, err = m.applyOverrides(themeNameOf(themePath), data)
+	if err != nil {
+		return nil, err
+	}
+	if m.transform.Any() {
+		data = transformTheme(data, m.transform)
+	}
+	if m.config.Apply.DeriveColors {
+		var added []string
+		if data, added = deriveMissingColors(data); len(added) > 0 {
+			m.logVerbose("Derived missing colors: %s", strings.Join(added, ", "))
+		}
+	}
+	if m.config.Apply.SynthesizeBrights {
+		var lifted []string
+		if data, lifted = synthesizeBrights(data); len(lifted) > 0 {
+			m.logVerbose("Synthesized bright colors: %s", strings.Join(lifted, ", "))
+		}
+	}
+	if m.config.Display.Calibrated() {
+		data = calibrateTheme(data, m.config.Display)
+	}
+	return data, nil
+}
+
+func themeNameOf(themePath string) string {
+	return strings.TrimSuffix(filepath.Base(themePath), ".toml")
+}
+
+// lockCurrent serializes changes to current.toml and the applied-theme
+// state between processes, e.g. the scheduler daemon and the CLI
+func (m *Manager) lockCurrent() (func(), error) {
+	if err := os.MkdirAll(m.config.StateDir, 0755); err != nil {
+		return nil, err
+	}
+	return config.LockFile(filepath.Join(m.config.StateDir, "current"))
+}
+
+// saveCurrent keeps current.toml at backupPath, preserving it as a symlink
+// when it is one
+func (m *Manager) saveCurrent(backupPath string) error {
+	current := m.currentThemeFile()
+	target, err := os.Readlink(current)
+	if err != nil {
+		return m.copyFile(current, backupPath)
+	}
+
+	os.Remove(backupPath)
+	return os.Symlink(target, backupPath)
+}
+
+// restoreCurrent moves a file saved by saveCurrent back to current.toml
+func (m *Manager) restoreCurrent(backupPath string) error {
+	info, err := os.Lstat(backupPath)
+	if err != nil {
+		return err
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		m.settleCurrent()
+		return os.Rename(backupPath, m.currentThemeFile())
+	}
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return err
+	}
+	if err := m.replaceCurrent(data); err != nil {
+		return err
+	}
+	return os.Remove(backupPath)
+}
+
+// linkedTheme returns the name of the theme current.toml links to, or
+// false when it is not a symlink
+func (m *Manager) linkedTheme() (string, bool) {
+	target, err := os.Readlink(m.currentThemeFile())
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSuffix(filepath.Base(target), ".toml"), true
+}
+
+// describeCurrentFile explains how current.toml shows the applied theme
+func (m *Manager) describeCurrentFile() string {
+	target, err := os.Readlink(m.currentThemeFile())
+	if err != nil {
+		return "copy"
+	}
+	if _, err := os.Stat(m.currentThemeFile()); err != nil {
+		return fmt.Sprintf("broken symlink to %s", target)
+	}
+	return fmt.Sprintf("symlink to %s", target)
+}
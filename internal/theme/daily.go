@@ -0,0 +1,63 @@
+package theme
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// dailyLayout formats the date a daily pick is derived from
+const dailyLayout = "2006-01-02"
+
+// dailyTheme picks the theme of the day for date. Each theme is scored by
+// hashing the seed, the date and its name, and the highest score wins, so
+// machines with the same seed agree without talking to each other, and a
+// theme missing on one machine only changes the pick on days it would have
+// won.
+func dailyTheme(themes []ThemeInfo, seed string, date time.Time) ThemeInfo {
+	day := date.Format(dailyLayout)
+	var best ThemeInfo
+	var bestScore uint64
+	for i, t := range themes {
+		h := fnv.New64a()
+		fmt.Fprintf(h, "%s|%s|%s", seed, day, t.Name)
+		if score := h.Sum64(); i == 0 || score > bestScore {
+			best, bestScore = t, score
+		}
+	}
+	return best
+}
+
+// randomDaily applies the theme of the day, or with PreviewTomorrow only
+// names tomorrow's
+func (m *Manager) randomDaily(themes []ThemeInfo, opts *RandomOptions) error {
+	seed := opts.Seed
+	if seed == "" {
+		seed = m.config.Random.Seed
+	}
+
+	today := time.Now()
+	if opts.PreviewTomorrow {
+		tomorrow := today.AddDate(0, 0, 1)
+		picked := dailyTheme(themes, seed, tomorrow)
+		ui.PrintInfo("Theme of the day for %s: %s", tomorrow.Format(dailyLayout), picked.Name)
+		return nil
+	}
+
+	picked := dailyTheme(themes, seed, today)
+	m.logVerbose("Theme of the day for %s: %s", today.Format(dailyLayout), picked.Name)
+	// Running it again the same day, e.g. from a shell profile, is a no-op
+	if m.config.CurrentTheme == picked.Name && !opts.WithFont && opts.Opacity == 0 && opts.Blur == 0 {
+		ui.PrintInfo("Theme of the day is already applied: %s", picked.Name)
+		return nil
+	}
+
+	applyOpts := &ApplyOptions{
+		WithFont: opts.WithFont,
+		Opacity:  opts.Opacity,
+		Blur:     opts.Blur,
+	}
+	return m.ApplyThemeWithOptions(picked.Name, applyOpts)
+}
@@ -0,0 +1,139 @@
+package theme
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Contrast targets for derived colors: text needs to be readable, while a
+// selection only needs to stand out from the background
+const (
+	derivedTextContrast   = 4.5
+	derivedCursorContrast = 3.0
+	selectionContrast     = 1.6
+	selectionBlendStep    = 0.05
+)
+
+// derivedTables lists, in write order, the tables derive fills in and the
+// keys each holds
+var derivedTables = []struct {
+	table string
+	keys  []string
+}{
+	{"selection", []string{"text", "background"}},
+	{"cursor", []string{"text", "cursor"}},
+	{"search.matches", []string{"foreground", "background"}},
+	{"search.focused_match", []string{"foreground", "background"}},
+}
+
+// deriveMissingColors adds readable selection, cursor and search match
+// colors to a theme that leaves them out, computed from its background,
+// foreground and accents. Colors the theme sets are kept, and the derived
+// ones are made to contrast with them; a table using cell references like
+// CellForeground is left alone. It returns the new theme and the slots it
+// added.
+func deriveMissingColors(data []byte) ([]byte, []string) {
+	lines := strings.Split(string(data), "\n")
+	values, _ := colorTables(lines)
+	bg, errBg := ParseColor(values["primary.background"])
+	fg, errFg := ParseColor(values["primary.foreground"])
+	if errBg != nil || errFg != nil {
+		return data, nil
+	}
+
+	derived := derivedColors(values, bg, fg)
+	var added []string
+	for _, t := range derivedTables {
+		var entries, slots []string
+		for _, key := range t.keys {
+			slot := t.table + "." + key
+			if value, ok := values[slot]; ok {
+				// A table using cell references works as a pair; leave it be
+				if _, err := ParseColor(value); err != nil {
+					entries, slots = nil, nil
+					break
+				}
+				continue
+			}
+			entries = append(entries, fmt.Sprintf("%s = %q", key, derived[slot].ToHex()))
+			slots = append(slots, slot)
+		}
+		if len(entries) > 0 {
+			lines = insertIntoTable(lines, "colors."+t.table, entries)
+			added = append(added, slots...)
+		}
+	}
+	if len(added) == 0 {
+		return data, nil
+	}
+	return []byte(strings.Join(lines, "\n")), added
+}
+
+// derivedColors computes every slot derive can add. Slots the theme sets
+// are used as given when another slot has to contrast with them.
+func derivedColors(values map[string]string, bg, fg RGB) map[string]RGB {
+	colors := make(map[string]RGB)
+	pick := func(slot string, fallback RGB) RGB {
+		if rgb, err := ParseColor(values[slot]); err == nil {
+			return rgb
+		}
+		return fallback
+	}
+
+	// The selection moves from the background towards the foreground until
+	// it stands out, and its text is the foreground made readable on it
+	selection := bg
+	for amount := selectionBlendStep; amount <= 1 && GetContrastRatio(selection, bg) < selectionContrast; amount += selectionBlendStep {
+		selection = mix(bg, fg, amount)
+	}
+	colors["selection.background"] = pick("selection.background", selection)
+	colors["selection.text"] = readableOn(colors["selection.background"], fg, bg)
+
+	colors["cursor.cursor"] = pick("cursor.cursor", EnsureContrast(fg, bg, derivedCursorContrast))
+	colors["cursor.text"] = readableOn(colors["cursor.cursor"], bg, fg)
+
+	// Search matches take the theme's red and the focused match its yellow,
+	// like Alacritty's defaults but in the theme's own palette
+	matches := pick("search.matches.background", EnsureContrast(pick("normal.red", fg), bg, derivedCursorContrast))
+	focused := pick("search.focused_match.background", EnsureContrast(pick("normal.yellow", fg), bg, derivedCursorContrast))
+	colors["search.matches.background"] = matches
+	colors["search.matches.foreground"] = readableOn(matches, bg, fg)
+	colors["search.focused_match.background"] = focused
+	colors["search.focused_match.foreground"] = readableOn(focused, bg, fg)
+	return colors
+}
+
+// readableOn returns the candidate that contrasts most with surface, pushed
+// to a readable contrast, or black or white when no candidate gets there
+func readableOn(surface RGB, candidates ...RGB) RGB {
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if GetContrastRatio(c, surface) > GetContrastRatio(best, surface) {
+			best = c
+		}
+	}
+	if best = EnsureContrast(best, surface, derivedTextContrast); GetContrastRatio(best, surface) >= derivedTextContrast {
+		return best
+	}
+	black, white := RGB{}, RGB{R: 255, G: 255, B: 255}
+	if GetContrastRatio(black, surface) > GetContrastRatio(white, surface) {
+		return black
+	}
+	return white
+}
+
+// insertIntoTable adds entries right after a table's header, or appends
+// the table when the file doesn't have it
+func insertIntoTable(lines []string, table string, entries []string) []string {
+	for i, line := range lines {
+		if strings.Trim(strings.TrimSpace(line), "[] ") == table {
+			rest := append(append([]string{}, entries...), lines[i+1:]...)
+			return append(lines[:i+1], rest...)
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	lines = append(lines, "", "["+table+"]")
+	return append(append(lines, entries...), "")
+}
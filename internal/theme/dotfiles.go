@@ -0,0 +1,81 @@
+package theme
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/config"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// DeployDotfiles hands the config edited in the dotfiles repository to
+// chezmoi or stow when the deployed copy no longer matches it. It does
+// nothing outside dotfiles mode, without a manager or with deploy off.
+func (m *Manager) DeployDotfiles() error {
+	d := m.config.Dotfiles
+	source, target := m.config.ConfigFile, m.config.DeployedConfigFile
+	if target == "" || d.Manager == "" || !d.Deploy {
+		return nil
+	}
+	want, err := os.ReadFile(source)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", source, err)
+	}
+	if got, err := os.ReadFile(target); err == nil && bytes.Equal(got, want) {
+		return nil
+	}
+
+	var cmd *exec.Cmd
+	switch d.Manager {
+	case config.DotfilesChezmoi:
+		cmd = exec.Command("chezmoi", "apply", target)
+	case config.DotfilesStow:
+		dir, pkg, home, err := stowPackage(source, target)
+		if err != nil {
+			return err
+		}
+		cmd = exec.Command("stow", "--dir", dir, "--target", home, "--restow", pkg)
+	}
+	m.logVerbose("Deploying dotfiles: %s", strings.Join(cmd.Args, " "))
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to deploy dotfiles with %s: %w", d.Manager, err)
+	}
+	ui.PrintInfo("Deployed %s with %s", target, d.Manager)
+	return nil
+}
+
+// stowPackage finds the stow directory and package holding source, which
+// stow links to target under the home directory
+func stowPackage(source, target string) (dir, pkg, home string, err error) {
+	home, err = os.UserHomeDir()
+	if err != nil {
+		return "", "", "", err
+	}
+	rel, err := filepath.Rel(home, target)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", "", "", fmt.Errorf("stow can only deploy %s from under %s", target, home)
+	}
+	suffix := string(filepath.Separator) + rel
+	if !strings.HasSuffix(source, suffix) {
+		return "", "", "", ui.WithHints(fmt.Errorf("cannot tell the stow package of %s", source),
+			fmt.Sprintf("Its path should end in %s, as stow links it to %s", rel, target))
+	}
+	root := strings.TrimSuffix(source, suffix)
+	return filepath.Dir(root), filepath.Base(root), home, nil
+}
+
+// deployedConfigFile is the Alacritty config that Alacritty reads, which
+// in dotfiles mode is not the file the tool edits
+func (m *Manager) deployedConfigFile() string {
+	if m.config.DeployedConfigFile != "" {
+		return m.config.DeployedConfigFile
+	}
+	return m.config.ConfigFile
+}
@@ -0,0 +1,214 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+	"github.com/vitruves/alacritty-colors/pkg/alacritty"
+)
+
+// OverridesFile remembers the config values in place before the tool first
+// changed them, so they can be put back
+const OverridesFile = "overrides.json"
+
+// Settings written by --opacity, --blur, --font and --font-size, as
+// "section.key"
+const (
+	keyOpacity    = "window.opacity"
+	keyBlur       = "window.blur"
+	keyFontSize   = "font.size"
+	keyFontFamily = "font.normal.family"
+	keyBoldBright = "colors." + alacritty.DrawBoldBrightKey
+)
+
+// ResetOptions choose which managed settings to restore
+type ResetOptions struct {
+	Opacity    bool
+	Blur       bool
+	Font       bool
+	BoldBright bool
+}
+
+// Any reports whether anything is to be reset
+func (o ResetOptions) Any() bool {
+	return o.Opacity || o.Blur || o.Font || o.BoldBright
+}
+
+func (o ResetOptions) keys() []string {
+	var keys []string
+	if o.Opacity {
+		keys = append(keys, keyOpacity)
+	}
+	if o.Blur {
+		keys = append(keys, keyBlur)
+	}
+	if o.Font {
+		keys = append(keys, keyFontSize, keyFontFamily)
+	}
+	if o.BoldBright {
+		keys = append(keys, keyBoldBright)
+	}
+	return keys
+}
+
+// baseValue is a setting as the user had it; Present is false when the
+// config didn't set it at all
+type baseValue struct {
+	Value   string `json:"value,omitempty"`
+	Present bool   `json:"present"`
+}
+
+// overrides maps config files to their remembered base values
+type overrides map[string]map[string]baseValue
+
+func (m *Manager) loadOverrides() overrides {
+	all := make(overrides)
+	data, err := os.ReadFile(filepath.Join(m.config.StateDir, OverridesFile))
+	if err == nil {
+		if err := json.Unmarshal(data, &all); err != nil {
+			m.logVerbose("Ignoring unreadable %s: %v", OverridesFile, err)
+		}
+	}
+	return all
+}
+
+func (m *Manager) saveOverrides(all overrides) error {
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.config.StateDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.config.StateDir, OverridesFile), data, 0644)
+}
+
+// splitKey turns "font.normal.family" into the "font.normal" table and
+// the "family" key
+func splitKey(key string) (string, string) {
+	i := strings.LastIndex(key, ".")
+	return key[:i], key[i+1:]
+}
+
+// findConfigValue returns the index of the line setting key in the given
+// table, and the raw value after '='
+func findConfigValue(lines []string, table, key string) (int, string, bool) {
+	current := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			current = strings.TrimSpace(strings.Trim(trimmed, "[]"))
+			continue
+		}
+		if current != table {
+			continue
+		}
+		name, value, ok := strings.Cut(trimmed, "=")
+		if ok && strings.TrimSpace(name) == key {
+			return i, strings.TrimSpace(value), true
+		}
+	}
+	return -1, "", false
+}
+
+// rememberBase records the current value of each key the first time the
+// tool is about to change it
+func (m *Manager) rememberBase(keys ...string) {
+	text, err := readConfigText(m.config.ConfigFile)
+	if err != nil {
+		return
+	}
+
+	all := m.loadOverrides()
+	saved := all[m.config.ConfigFile]
+	if saved == nil {
+		saved = make(map[string]baseValue)
+	}
+
+	changed := false
+	for _, key := range keys {
+		if _, ok := saved[key]; ok {
+			continue
+		}
+		table, name := splitKey(key)
+		_, value, found := findConfigValue(text.Lines, table, name)
+		saved[key] = baseValue{Value: value, Present: found}
+		changed = true
+	}
+	if !changed {
+		return
+	}
+
+	all[m.config.ConfigFile] = saved
+	if err := m.saveOverrides(all); err != nil {
+		m.logVerbose("Failed to remember config values: %v", err)
+	}
+}
+
+// ResetEffects puts the chosen settings back to the values they had before
+// the tool first changed them, removing them when the config had none
+func (m *Manager) ResetEffects(opts ResetOptions) error {
+	all := m.loadOverrides()
+	saved := all[m.config.ConfigFile]
+
+	text, err := readConfigText(m.config.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	lines := text.Lines
+
+	var restored []string
+	for _, key := range opts.keys() {
+		base, ok := saved[key]
+		if !ok {
+			m.logVerbose("%s was not changed by alacritty-colors", key)
+			continue
+		}
+
+		table, name := splitKey(key)
+		i, _, found := findConfigValue(lines, table, name)
+		switch {
+		case found && base.Present:
+			lines[i] = name + " = " + base.Value
+		case found:
+			lines = append(lines[:i], lines[i+1:]...)
+		case base.Present:
+			lines = insertConfigValue(lines, table, name+" = "+base.Value)
+		}
+		delete(saved, key)
+		restored = append(restored, key)
+	}
+
+	if len(restored) == 0 {
+		ui.PrintInfo("No window, font or color settings to reset")
+		return nil
+	}
+
+	if err := text.write(m.config.ConfigFile, lines); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if len(saved) == 0 {
+		delete(all, m.config.ConfigFile)
+	}
+	if err := m.saveOverrides(all); err != nil {
+		m.logVerbose("Failed to update %s: %v", OverridesFile, err)
+	}
+
+	ui.PrintSuccess("Reset %s", strings.Join(restored, ", "))
+	return nil
+}
+
+// insertConfigValue adds a line at the top of a table, creating the table
+// at the end of the file if needed
+func insertConfigValue(lines []string, table, line string) []string {
+	for i, l := range lines {
+		if strings.TrimSpace(l) == "["+table+"]" {
+			return append(lines[:i+1], append([]string{line}, lines[i+1:]...)...)
+		}
+	}
+	return append(lines, "", "["+table+"]", line)
+}
@@ -0,0 +1,94 @@
+package theme
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// matchesPatterns reports whether name matches one of the theme
+// patterns. Patterns are shell globs or /regex/, compared case-insensitively.
+func matchesPatterns(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matchesPattern(name, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// excludeThemes drops themes on the persisted exclusion list or matching
+// one of the extra patterns
+func (m *Manager) excludeThemes(themes []ThemeInfo, extra []string) []ThemeInfo {
+	patterns := append(append([]string{}, m.config.Random.Exclude...), extra...)
+	if len(patterns) == 0 {
+		return themes
+	}
+
+	var kept []ThemeInfo
+	for _, t := range themes {
+		if matchesPatterns(t.Name, patterns) {
+			continue
+		}
+		kept = append(kept, t)
+	}
+
+	if excluded := len(themes) - len(kept); excluded > 0 {
+		m.logVerbose("Excluded %d themes", excluded)
+	}
+	return kept
+}
+
+// AddExclusions adds themes or glob patterns to the persisted exclusion list
+func (m *Manager) AddExclusions(patterns []string) error {
+	for _, pattern := range patterns {
+		if !isThemePattern(pattern) {
+			if _, err := m.findTheme(pattern); err == nil {
+				continue
+			}
+			ui.PrintWarning("No installed theme named '%s'", pattern)
+		}
+	}
+
+	added, err := m.config.AddExclusions(patterns)
+	if err != nil {
+		return fmt.Errorf("failed to update exclusion list: %w", err)
+	}
+	if len(added) == 0 {
+		ui.PrintInfo("Already excluded")
+		return nil
+	}
+
+	ui.PrintSuccess("Excluded from random and slideshow: %s", strings.Join(added, ", "))
+	return nil
+}
+
+// RemoveExclusions removes entries from the persisted exclusion list
+func (m *Manager) RemoveExclusions(patterns []string) error {
+	removed, err := m.config.RemoveExclusions(patterns)
+	if err != nil {
+		return fmt.Errorf("failed to update exclusion list: %w", err)
+	}
+	if len(removed) == 0 {
+		return fmt.Errorf("not on the exclusion list: %s", strings.Join(patterns, ", "))
+	}
+
+	ui.PrintSuccess("No longer excluded: %s", strings.Join(removed, ", "))
+	return nil
+}
+
+// ListExclusions prints the persisted exclusion list
+func (m *Manager) ListExclusions() error {
+	if len(m.config.Random.Exclude) == 0 {
+		ui.PrintInfo("No themes are excluded")
+		ui.PrintInfo("Add one with: alacritty-colors exclude add <theme|pattern>")
+		return nil
+	}
+
+	ui.PrintHeader("Excluded Themes")
+	for _, pattern := range m.config.Random.Exclude {
+		fmt.Printf("  %s\n", pattern)
+	}
+	return nil
+}
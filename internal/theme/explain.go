@@ -0,0 +1,341 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// themeCharacter is what explain computes about a theme's palette
+type themeCharacter struct {
+	Theme       string   `json:"theme"`
+	Variant     string   `json:"variant"`
+	Saturation  float64  `json:"saturation"`
+	Intensity   string   `json:"intensity"`
+	Warmth      float64  `json:"warmth"`
+	Temperature string   `json:"temperature"`
+	Hue         string   `json:"dominant_hue"`
+	Contrast    float64  `json:"contrast"`
+	Grade       string   `json:"contrast_grade"`
+	Family      string   `json:"nearest_family,omitempty"`
+	FamilyTheme string   `json:"nearest_theme,omitempty"`
+	Tags        []string `json:"tags"`
+	// IdenticalBrights are the colors whose bright variant repeats the
+	// normal one
+	IdenticalBrights []string     `json:"identical_brights,omitempty"`
+	Quality          themeQuality `json:"quality"`
+
+	// tinted is set when the dominant hue comes from the background or
+	// foreground rather than the accents
+	tinted bool
+}
+
+// Thresholds for the words explain uses
+const (
+	mutedSaturation   = 0.35
+	vibrantSaturation = 0.7
+	pastelLightness   = 0.7
+	warmthThreshold   = 0.01
+	// tintedChroma is the chroma above which a background or foreground
+	// is tinted rather than gray
+	tintedChroma = 0.04
+	// dominantShare is how much of the accents' chroma one hue needs to
+	// dominate
+	dominantShare = 0.25
+)
+
+// accentSlots are the colors that carry a palette's hues, leaving out the
+// black and white slots
+var accentSlots = func() []string {
+	var slots []string
+	for _, table := range []string{"normal", "bright"} {
+		for _, name := range ansiColors[1:7] {
+			slots = append(slots, table+"_"+name)
+		}
+	}
+	return slots
+}()
+
+// knownFamilies are the scheme families explain compares themes with, by
+// the name prefix familyOf returns
+var knownFamilies = map[string]string{
+	"ayu":        "Ayu",
+	"catppuccin": "Catppuccin",
+	"dracula":    "Dracula",
+	"everforest": "Everforest",
+	"github":     "GitHub",
+	"gruvbox":    "Gruvbox",
+	"kanagawa":   "Kanagawa",
+	"material":   "Material",
+	"monokai":    "Monokai",
+	"nord":       "Nord",
+	"one":        "One Dark",
+	"rose":       "Rosé Pine",
+	"solarized":  "Solarized",
+	"tokyo":      "Tokyo Night",
+	"tomorrow":   "Tomorrow",
+	"zenburn":    "Zenburn",
+}
+
+// Explain describes a theme's character: variant, saturation, temperature,
+// dominant hue, contrast and the well-known scheme it is closest to
+func (m *Manager) Explain(themeName, format string) error {
+	var t ThemeInfo
+	if themeName == "current" {
+		_, path, err := m.resolveShowTarget(themeName)
+		if err != nil {
+			return err
+		}
+		if t, err = m.parseThemeFile(path); err != nil {
+			return fmt.Errorf("failed to read theme: %w", err)
+		}
+	} else {
+		var err error
+		if t, err = m.themeDetails(themeName); err != nil {
+			return err
+		}
+	}
+	if _, err := ParseColor(t.Colors["background"]); err != nil {
+		return fmt.Errorf("theme %s has no background color to analyze", t.Name)
+	}
+
+	c := characterOf(t)
+	c.IdenticalBrights = identicalBrights(t.FilePath)
+	c.Quality = qualityOf(t)
+	if themes, err := m.getThemeInfos(); err == nil {
+		c.Family, c.FamilyTheme = nearestFamily(t, themes)
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(c, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	case "", "table":
+	default:
+		return fmt.Errorf("unknown format: %s (use table|json)", format)
+	}
+
+	ui.PrintHeader(fmt.Sprintf("Theme: %s", t.Name))
+	ui.PrintKeyValue("Variant", c.Variant)
+	ui.PrintKeyValue("Saturation", fmt.Sprintf("%s (%.0f%% on average)", c.Intensity, c.Saturation*100))
+	ui.PrintKeyValue("Temperature", c.Temperature)
+	ui.PrintKeyValue("Dominant hue", c.Hue)
+	ui.PrintKeyValue("Contrast", fmt.Sprintf("%s (%s)", contrastBadge(c.Contrast), c.Grade))
+	if c.Family != "" {
+		ui.PrintKeyValue("Nearest family", fmt.Sprintf("%s (closest: %s)", knownFamilies[c.Family], c.FamilyTheme))
+	}
+	ui.PrintKeyValue("Tags", strings.Join(c.Tags, ", "))
+	if len(c.IdenticalBrights) > 0 {
+		ui.PrintKeyValue("Bright colors", "same as normal for "+strings.Join(c.IdenticalBrights, ", "))
+	}
+	ui.PrintKeyValue("Quality", scoreBadge(c.Quality.Score))
+	for _, issue := range c.Quality.issues() {
+		fmt.Printf("  - %s\n", issue)
+	}
+
+	fmt.Println()
+	ui.PrintInfo("%s", c.summary())
+	if len(c.IdenticalBrights) > 0 {
+		ui.PrintInfo("With draw_bold_text_with_bright_colors, bold text in those colors looks regular; apply.synthesize_brights gives them a brighter shade")
+	}
+	return nil
+}
+
+// summary puts the character into one sentence
+func (c themeCharacter) summary() string {
+	words := []string{c.Variant, c.Intensity}
+	if c.Temperature != "neutral" {
+		words = append(words, c.Temperature)
+	}
+	sentence := fmt.Sprintf("A %s theme", strings.Join(words, ", "))
+	if c.Hue != "neutral" {
+		sentence += " leaning " + c.Hue
+	}
+	sentence += fmt.Sprintf(", with %s contrast", c.Grade)
+	if c.Family != "" {
+		sentence += fmt.Sprintf("; closest to %s", knownFamilies[c.Family])
+	}
+	return sentence + "."
+}
+
+// characterOf computes everything explain reports except the nearest
+// family, which needs the rest of the collection
+func characterOf(t ThemeInfo) themeCharacter {
+	c := themeCharacter{Theme: t.Name, Variant: t.Variant}
+	bg, _ := ParseColor(t.Colors["background"])
+	// Names like rose_pine_dawn give a variant word; the background decides
+	if c.Variant != "dark" && c.Variant != "light" {
+		c.Variant = "dark"
+		if bg.ToHSL().L >= 0.5 {
+			c.Variant = "light"
+		}
+	}
+
+	var saturation, lightness float64
+	var accents []RGB
+	for _, slot := range accentSlots {
+		if rgb, err := ParseColor(t.Colors[slot]); err == nil {
+			hsl := rgb.ToHSL()
+			accents = append(accents, rgb)
+			saturation += hsl.S
+			lightness += hsl.L
+		}
+	}
+	if len(accents) > 0 {
+		saturation /= float64(len(accents))
+		lightness /= float64(len(accents))
+	}
+	c.Saturation = saturation
+	switch {
+	case len(accents) > 0 && saturation < neutralSat:
+		c.Intensity = "monochrome"
+	case saturation < mutedSaturation:
+		c.Intensity = "muted"
+	case lightness > pastelLightness:
+		c.Intensity = "pastel"
+	case saturation > vibrantSaturation:
+		c.Intensity = "vibrant"
+	default:
+		c.Intensity = "balanced"
+	}
+
+	// Temperature weighs each color by its chroma, so near-black and
+	// near-white colors count little. Background and foreground tint the
+	// whole screen; the accents together weigh as much as the foreground,
+	// since their hues mostly cancel out.
+	var warmth, total float64
+	tint := func(key string) (HSL, float64) {
+		rgb, err := ParseColor(t.Colors[key])
+		if err != nil {
+			return HSL{}, 0
+		}
+		return rgb.ToHSL(), chroma(rgb)
+	}
+	bgHSL, bgChroma := tint("background")
+	fgHSL, fgChroma := tint("foreground")
+	for _, w := range []struct {
+		hsl    HSL
+		chroma float64
+		weight float64
+	}{{bgHSL, bgChroma, 3}, {fgHSL, fgChroma, 2}} {
+		warmth += w.weight * w.chroma * hueWarmth(w.hsl.H)
+		total += w.weight
+	}
+	sectors := make([]float64, hueSectors+1)
+	var accentChroma float64
+	for _, rgb := range accents {
+		hsl := rgb.ToHSL()
+		weight := 2 / float64(len(accents))
+		warmth += weight * chroma(rgb) * hueWarmth(hsl.H)
+		total += weight
+		if hsl.S >= neutralSat {
+			sectors[heatmapCell(hsl)[1]] += chroma(rgb)
+			accentChroma += chroma(rgb)
+		}
+	}
+	if total > 0 {
+		c.Warmth = warmth / total
+	}
+	switch {
+	case c.Warmth > warmthThreshold:
+		c.Temperature = "warm"
+	case c.Warmth < -warmthThreshold:
+		c.Temperature = "cool"
+	default:
+		c.Temperature = "neutral"
+	}
+
+	// The dominant hue is the tint of the background, else of the
+	// foreground, else the hue most of the accents share
+	c.Hue = hueNames[0]
+	switch {
+	case bgChroma >= tintedChroma && bgHSL.S >= neutralSat:
+		c.Hue, c.tinted = hueNames[heatmapCell(bgHSL)[1]], true
+	case fgChroma >= tintedChroma && fgHSL.S >= neutralSat:
+		c.Hue, c.tinted = hueNames[heatmapCell(fgHSL)[1]], true
+	default:
+		best := 0
+		for col := 1; col <= hueSectors; col++ {
+			if sectors[col] > sectors[best] {
+				best = col
+			}
+		}
+		if best > 0 && sectors[best] >= dominantShare*accentChroma {
+			c.Hue = hueNames[best]
+		}
+	}
+
+	c.Contrast = themeContrast(t)
+	c.Grade = contrastGrade(c.Contrast)
+	c.Tags = paletteTags(c)
+	return c
+}
+
+// hueWarmth is 1 for orange, the warmest hue, and -1 for azure, the coolest
+func hueWarmth(h float64) float64 {
+	return math.Cos(2 * math.Pi * (h - 1.0/12))
+}
+
+// contrastGrade names the WCAG level a contrast ratio meets
+func contrastGrade(contrast float64) string {
+	switch {
+	case contrast >= 7:
+		return "AAA"
+	case contrast >= 4.5:
+		return "AA"
+	case contrast >= 3:
+		return "AA for large text"
+	default:
+		return "poor"
+	}
+}
+
+// nearestFamily finds the installed theme of a well-known family whose
+// colors are closest to t, and returns the family and that theme
+func nearestFamily(t ThemeInfo, themes []ThemeInfo) (string, string) {
+	type candidate struct {
+		family, theme string
+		distance      float64
+	}
+	// A member of a known family belongs to it, whatever its colors
+	own := familyOf(t.Name)
+	if _, ok := knownFamilies[own]; ok {
+		return own, t.Name
+	}
+
+	var candidates []candidate
+	for _, other := range themes {
+		family := familyOf(other.Name)
+		if _, ok := knownFamilies[family]; !ok {
+			continue
+		}
+		candidates = append(candidates, candidate{family, other.Name, themeDistance(t, other)})
+	}
+	if len(candidates) == 0 {
+		return "", ""
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+	return candidates[0].family, candidates[0].theme
+}
+
+// themeDistance is the weighted mean distance between the colors two
+// themes set for the same slots
+func themeDistance(a, b ThemeInfo) float64 {
+	target := make(map[string]RGB)
+	for key, value := range a.Colors {
+		if rgb, err := ParseColor(value); err == nil {
+			target[key] = rgb
+		}
+	}
+	return slotDistance(target, b)
+}
@@ -0,0 +1,163 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/targets"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// ExportTerminal renders a theme for another terminal emulator. Without an
+// output path the result goes to stdout so it can be piped or redirected; an
+// output directory gets the exporter's suggested file name. Several themes,
+// or glob and /regex/ patterns, are exported into an output directory; with
+// dryRun they are only listed.
+func (m *Manager) ExportTerminal(args []string, target, output string, dryRun bool) error {
+	exporter, ok := targets.GetExporter(target)
+	if !ok {
+		return ui.WithHints(fmt.Errorf("unknown terminal '%s' (available: %s)", target, strings.Join(targets.ExporterNames(), ", ")),
+			ui.DidYouMean(target, targets.ExporterNames()))
+	}
+	if len(args) > 1 || len(args) == 1 && isThemePattern(args[0]) {
+		return m.exportTerminalMany(exporter, args, target, output, dryRun)
+	}
+
+	themeName := ""
+	if len(args) > 0 {
+		themeName = args[0]
+	}
+	theme, colors, err := m.themeColors(themeName)
+	if err != nil {
+		return err
+	}
+	data := exporter.Render(theme, colors)
+
+	if output == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if info, err := os.Stat(output); err == nil && info.IsDir() {
+		output = filepath.Join(output, exporter.FileName(theme))
+	}
+	mode := os.FileMode(0644)
+	if strings.HasSuffix(output, ".sh") {
+		mode = 0755
+	}
+	if err := os.WriteFile(output, data, mode); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	ui.PrintSuccess("Exported '%s' for %s to %s", theme, target, output)
+	return nil
+}
+
+// exportTerminalMany exports every theme matching args into the output
+// directory
+func (m *Manager) exportTerminalMany(exporter targets.Exporter, args []string, target, output string, dryRun bool) error {
+	themes, err := m.selectThemes(args)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		printMatches("export", themes)
+		return nil
+	}
+	if len(themes) == 1 {
+		return m.ExportTerminal([]string{themes[0].Name}, target, output, false)
+	}
+	if info, err := os.Stat(output); output == "" || err != nil || !info.IsDir() {
+		return ui.WithHints(fmt.Errorf("exporting %d themes needs an output directory", len(themes)),
+			"Pass one with --output, e.g. -o ~/.local/share/konsole/")
+	}
+
+	exported := 0
+	for _, t := range themes {
+		theme, colors, err := m.themeColors(t.Name)
+		if err != nil {
+			ui.PrintWarning("Failed to read %s: %v", t.Name, err)
+			continue
+		}
+		path := filepath.Join(output, exporter.FileName(theme))
+		mode := os.FileMode(0644)
+		if strings.HasSuffix(path, ".sh") {
+			mode = 0755
+		}
+		if err := os.WriteFile(path, exporter.Render(theme, colors), mode); err != nil {
+			ui.PrintWarning("Failed to export %s: %v", t.Name, err)
+			continue
+		}
+		m.logVerbose("Exported %s to %s", theme, path)
+		exported++
+	}
+
+	ui.PrintSuccess("Exported %d theme(s) for %s to %s", exported, target, output)
+	return nil
+}
+
+// ExportSnippet prints the [colors] tables of a theme, ready to paste into
+// an Alacritty config that doesn't use the import line. Comments are kept
+// unless stripComments is set.
+func (m *Manager) ExportSnippet(themeName string, stripComments bool, output string) error {
+	if themeName == "" {
+		themeName = m.config.CurrentTheme
+	}
+	if themeName == "" {
+		return ui.WithHints(fmt.Errorf("no theme applied yet"),
+			"Name a theme: alacritty-colors export alacritty-snippet <theme>")
+	}
+
+	selected, err := m.findTheme(themeName)
+	if err != nil {
+		return err
+	}
+	text, err := readConfigText(selected.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read theme: %w", err)
+	}
+
+	var lines []string
+	if !stripComments {
+		lines = append(lines, fmt.Sprintf("# Colors from the '%s' theme, exported by alacritty-colors", selected.Name))
+	}
+	inColors := true
+	for _, line := range text.Lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			inColors = strings.HasPrefix(strings.TrimLeft(trimmed, "["), "colors")
+			if inColors && len(lines) > 0 && lines[len(lines)-1] != "" {
+				lines = append(lines, "")
+			}
+		}
+		if !inColors || (stripComments && strings.HasPrefix(trimmed, "#")) {
+			continue
+		}
+		// Collapse the blank lines left by skipped content
+		if trimmed == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	data := []byte(strings.Join(lines, "\n") + "\n")
+
+	if output == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if info, err := os.Stat(output); err == nil && info.IsDir() {
+		output = filepath.Join(output, selected.Name+"-colors.toml")
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	ui.PrintSuccess("Exported the colors of '%s' to %s", selected.Name, output)
+	return nil
+}
@@ -0,0 +1,192 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/fonts"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// FontListOptions filter and format the installed font list
+type FontListOptions struct {
+	Format    string
+	Ligatures bool
+	Nerd      bool
+}
+
+// fontSample exercises ligatures, Nerd Font icons, box drawing and the
+// characters that are easy to confuse
+var fontSample = []string{
+	"  -> => != === <= >= :: |> <| /* */ // www",
+	"  0O 1lI| {}[]() `'\" ;:., ~-_",
+	"  \uf113 \ue725 \uf07c \ue7a8 \uf489  (Nerd Font icons)",
+	"  ┌─┬─┐ │ │ │ └─┴─┘ ▁▂▃▄▅▆▇█",
+	"  func main() { fmt.Println(\"Hello, Alacritty\") }",
+}
+
+// ListFonts prints the installed monospace families, marking those with
+// programming ligatures and Nerd Font icons
+func (m *Manager) ListFonts(opts FontListOptions) error {
+	families, err := fonts.Monospace()
+	if err != nil {
+		return ui.WithHints(fmt.Errorf("failed to list installed fonts: %w", err),
+			"On Linux, install fontconfig so 'fc-list' is available")
+	}
+
+	var shown []fonts.Family
+	for _, f := range families {
+		if (opts.Ligatures && !f.Ligatures) || (opts.Nerd && !f.Nerd) {
+			continue
+		}
+		shown = append(shown, f)
+	}
+
+	switch opts.Format {
+	case "json":
+		if shown == nil {
+			shown = []fonts.Family{}
+		}
+		data, err := json.MarshalIndent(shown, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	case "", "list":
+	default:
+		return fmt.Errorf("unknown format: %s", opts.Format)
+	}
+
+	if len(shown) == 0 {
+		ui.PrintWarning("No matching monospace fonts found")
+		return nil
+	}
+
+	ui.PrintHeader(fmt.Sprintf("Monospace Fonts (%d)", len(shown)))
+	rows := make([][]string, 0, len(shown))
+	for _, f := range shown {
+		rows = append(rows, []string{f.Name, yesNo(f.Ligatures), yesNo(f.Nerd)})
+	}
+	ui.PrintTable([]string{"Family", "Ligatures", "Nerd Font"}, rows)
+	return nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "-"
+}
+
+// PreviewFont writes a font family into the config so Alacritty reloads
+// with it, shows a sample and puts the previous config back unless the user
+// keeps the font
+func (m *Manager) PreviewFont(family string, keep bool) error {
+	if installed, err := fonts.Installed(); err == nil && !installed.Has(family) {
+		names := installed.Families()
+		return ui.WithHints(fmt.Errorf("font '%s' is not installed", family),
+			ui.DidYouMean(family, names), "List fonts with: alacritty-colors fonts list")
+	}
+
+	original, err := os.ReadFile(m.config.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	// Remember the user's own font before the first change, in case it's kept
+	m.rememberBase(keyFontFamily)
+
+	m.logVerbose("Temporarily applying font for preview: %s", family)
+	if err := m.updateConfigFont(family, 0); err != nil {
+		return fmt.Errorf("failed to apply preview font: %w", err)
+	}
+
+	ui.PrintHeader(fmt.Sprintf("🔤 Font Preview: %s", family))
+	ui.PrintInfo("The font is now temporarily applied to your terminal!")
+	fmt.Println()
+	fmt.Println(strings.Join(fontSample, "\n"))
+	fmt.Println()
+
+	if keep || ui.PromptConfirm("Do you want to keep this font?") {
+		ui.PrintSuccess("Font set to %s", family)
+		return nil
+	}
+
+	if err := os.WriteFile(m.config.ConfigFile, original, 0644); err != nil {
+		return fmt.Errorf("failed to restore config: %w", err)
+	}
+	ui.PrintSuccess("Previous font restored")
+	return nil
+}
+
+// fontPairings merges the built-in pairings with the user's, which win
+func (m *Manager) fontPairings() map[string][]string {
+	pairings := make(map[string][]string, len(ThemeFonts)+len(m.config.FontPairings))
+	for key, families := range ThemeFonts {
+		pairings[key] = families
+	}
+	for key, families := range m.config.FontPairings {
+		pairings[key] = families
+	}
+	return pairings
+}
+
+// fontCandidates lists the fonts to try for a theme: a pairing for the
+// exact theme name, else for the longest scheme its name contains, then
+// the default pairing
+func (m *Manager) fontCandidates(themeName string) []string {
+	pairings := m.fontPairings()
+	themeKey := strings.ToLower(themeName)
+
+	var candidates []string
+	if families, ok := pairings[themeKey]; ok {
+		candidates = slices.Clone(families)
+	} else {
+		match := ""
+		for key := range pairings {
+			if key != "default" && strings.Contains(themeKey, key) && len(key) > len(match) {
+				match = key
+			}
+		}
+		if match != "" {
+			candidates = slices.Clone(pairings[match])
+		}
+	}
+
+	for _, font := range pairings["default"] {
+		if !slices.Contains(candidates, font) {
+			candidates = append(candidates, font)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = []string{"monospace"}
+	}
+	return candidates
+}
+
+// ListFontPairings prints the pairings in effect and where each comes from
+func (m *Manager) ListFontPairings() error {
+	pairings := m.fontPairings()
+	keys := make([]string, 0, len(pairings))
+	for key := range pairings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	ui.PrintHeader("Font Pairings")
+	rows := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		source := "built-in"
+		if _, ok := m.config.FontPairings[key]; ok {
+			source = "settings"
+		}
+		rows = append(rows, []string{key, strings.Join(pairings[key], ", "), source})
+	}
+	ui.PrintTable([]string{"Scheme or theme", "Fonts (in order)", "Source"}, rows)
+	ui.PrintInfo("Override them in the [font_pairings] table of %s", m.config.Path())
+	return nil
+}
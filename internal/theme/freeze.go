@@ -0,0 +1,221 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// FreezeOptions say where to read the live colors from and how to save them
+type FreezeOptions struct {
+	Name string
+	// Socket picks the Alacritty instance to ask; empty means the first found
+	Socket string
+	// ConfigOnly skips running instances and resolves the config files
+	ConfigOnly bool
+	Force      bool
+}
+
+// importRecursionLimit matches how deep Alacritty follows nested imports
+const importRecursionLimit = 5
+
+// importPathPattern pulls the quoted paths out of an import array
+var importPathPattern = regexp.MustCompile(`"([^"]*)"|'([^']*)'`)
+
+// FreezeTheme saves the colors on screen as a theme. A running Alacritty
+// instance is asked for its effective config, which includes runtime
+// overrides; without one, the config file and everything it imports are
+// merged the way Alacritty does.
+func (m *Manager) FreezeTheme(opts FreezeOptions) error {
+	if opts.Name == "" || strings.ContainsAny(opts.Name, `/\`) || opts.Name == "current" {
+		return ui.WithHints(fmt.Errorf("invalid theme name '%s'", opts.Name),
+			"Use a plain file name, e.g. alacritty-colors theme freeze my_tweaks")
+	}
+	themeFile := filepath.Join(m.config.ThemeSaveDir(), opts.Name+".toml")
+	if _, err := os.Stat(themeFile); err == nil && !opts.Force {
+		return ui.WithHints(fmt.Errorf("theme '%s' already exists", opts.Name),
+			"Choose another name, or pass --force to overwrite it.")
+	}
+
+	values, source, err := m.liveColors(opts)
+	if err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("no colors found in %s", source)
+	}
+
+	content := frozenThemeContent(opts.Name, source, values)
+	if err := os.WriteFile(themeFile, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to save theme: %w", err)
+	}
+
+	ui.PrintSuccess("Froze %d colors from %s as theme '%s' (%s)", len(values), source, opts.Name, themeFile)
+	ui.PrintInfo("Apply it with: alacritty-colors apply %s", opts.Name)
+	return nil
+}
+
+// liveColors returns the effective color settings as "table.key" values and
+// a description of where they came from
+func (m *Manager) liveColors(opts FreezeOptions) (map[string]string, string, error) {
+	if !opts.ConfigOnly {
+		if _, err := exec.LookPath("alacritty"); err == nil {
+			sockets := alacrittySockets()
+			if opts.Socket != "" {
+				sockets = []string{opts.Socket}
+			} else if len(sockets) > 1 {
+				ui.PrintInfo("%d Alacritty instances are running; using %s (pick another with --socket)", len(sockets), filepath.Base(sockets[0]))
+			}
+			for _, socket := range sockets {
+				values, err := runningColors(socket)
+				if err == nil {
+					return values, "running instance " + filepath.Base(socket), nil
+				}
+				if opts.Socket != "" {
+					return nil, "", fmt.Errorf("failed to read config from %s: %w", opts.Socket, err)
+				}
+				m.logVerbose("Could not ask %s for its config: %v", socket, err)
+			}
+		} else if opts.Socket != "" {
+			return nil, "", ui.WithHints(fmt.Errorf("the alacritty command is not on PATH"),
+				"Drop --socket to read the config files instead.")
+		}
+	}
+
+	values, files, err := resolvedConfigColors(m.config.ConfigFile, 0)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read config: %w", err)
+	}
+	m.logVerbose("Merged colors from %s", strings.Join(files, ", "))
+	source := filepath.Base(m.config.ConfigFile)
+	if len(files) > 1 {
+		source += fmt.Sprintf(" and %d import(s)", len(files)-1)
+	}
+	return values, source, nil
+}
+
+// runningColors flattens the colors section of a running instance's config
+func runningColors(socket string) (map[string]string, error) {
+	out, err := runningConfigJSON(socket)
+	if err != nil {
+		return nil, err
+	}
+	var cfg struct {
+		Colors map[string]interface{} `json:"colors"`
+	}
+	if err := json.Unmarshal(out, &cfg); err != nil {
+		return nil, fmt.Errorf("unexpected get-config output: %w", err)
+	}
+
+	values := make(map[string]string)
+	var walk func(prefix string, node map[string]interface{})
+	walk = func(prefix string, node map[string]interface{}) {
+		for key, value := range node {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			switch v := value.(type) {
+			case map[string]interface{}:
+				walk(path, v)
+			case string:
+				if isColorSlot(path) {
+					values[path] = normalizeHex(v)
+				}
+			}
+		}
+	}
+	walk("", cfg.Colors)
+	return values, nil
+}
+
+// resolvedConfigColors merges the colors of a config file over those of
+// its imports, in import order, and returns the files that were read
+func resolvedConfigColors(path string, depth int) (map[string]string, []string, error) {
+	text, err := readConfigText(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make(map[string]string)
+	var files []string
+	if depth < importRecursionLimit {
+		for _, imported := range configImports(text.Lines, filepath.Dir(path)) {
+			importedValues, importedFiles, err := resolvedConfigColors(imported, depth+1)
+			if err != nil {
+				// Alacritty skips imports it can't read, and so do we
+				continue
+			}
+			for key, value := range importedValues {
+				values[key] = value
+			}
+			files = append(files, importedFiles...)
+		}
+	}
+
+	own, _ := colorTables(text.Lines)
+	for key, value := range own {
+		values[key] = value
+	}
+	return values, append(files, path), nil
+}
+
+// configImports returns the files a config imports, resolved against dir.
+// The array may span several lines.
+func configImports(lines []string, dir string) []string {
+	for _, table := range []string{"general", ""} {
+		i, value, ok := findConfigValue(lines, table, "import")
+		if !ok {
+			continue
+		}
+		for j := i + 1; j < len(lines) && strings.Count(value, "[") > strings.Count(value, "]"); j++ {
+			if line := strings.TrimSpace(lines[j]); !strings.HasPrefix(line, "#") {
+				value += " " + line
+			}
+		}
+
+		var paths []string
+		for _, match := range importPathPattern.FindAllStringSubmatch(value, -1) {
+			path := match[1] + match[2]
+			if strings.HasPrefix(path, "~/") {
+				if home, err := os.UserHomeDir(); err == nil {
+					path = filepath.Join(home, path[2:])
+				}
+			}
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			paths = append(paths, path)
+		}
+		return paths
+	}
+	return nil
+}
+
+// frozenThemeContent writes color settings as a theme file, table by
+// table in the order of Alacritty's documentation
+func frozenThemeContent(name, source string, values map[string]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n# Frozen from %s at %s\n", name, source, time.Now().Format("2006-01-02 15:04:05"))
+
+	table := ""
+	for _, slot := range colorSlots {
+		value, ok := values[slot.Table+"."+slot.Key]
+		if !ok {
+			continue
+		}
+		if slot.Table != table {
+			table = slot.Table
+			fmt.Fprintf(&b, "\n[colors.%s]\n", table)
+		}
+		fmt.Fprintf(&b, "%s = %q\n", slot.Key, value)
+	}
+	return b.String()
+}
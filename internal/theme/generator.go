@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/vitruves/alacritty-colors/internal/ui"
@@ -62,7 +63,7 @@ func (m *Manager) GenerateTheme(scheme, name string, save bool) error {
 	themeContent := m.createThemeContent(colors, scheme, name)
 
 	// Always save generated themes
-	themeFile := filepath.Join(m.config.ThemesDir, name+".toml")
+	themeFile := filepath.Join(m.config.ThemeSaveDir(), name+".toml")
 	if err := os.WriteFile(themeFile, []byte(themeContent), 0644); err != nil {
 		return fmt.Errorf("failed to save theme: %w", err)
 	}
@@ -77,6 +78,9 @@ func (m *Manager) GenerateTheme(scheme, name string, save bool) error {
 	return nil
 }
 
+// GeneratorSchemes lists the schemes accepted by generate
+var GeneratorSchemes = []string{"random", "pastel", "neon", "mono", "warm", "cool", "nature", "cyberpunk", "dracula", "nord", "solarized", "gruvbox", SeasonalScheme}
+
 func (m *Manager) generateColorScheme(scheme string) (map[string]string, error) {
 	switch scheme {
 	case "random":
@@ -103,12 +107,18 @@ func (m *Manager) generateColorScheme(scheme string) (map[string]string, error)
 		return m.generateSolarizedColors(), nil
 	case "gruvbox":
 		return m.generateGruvboxColors(), nil
+	case SeasonalScheme:
+		colors, _ := m.seasonalColors(time.Now())
+		return colors, nil
 	default:
-		return nil, fmt.Errorf("unknown color scheme: %s", scheme)
+		return nil, ui.WithHints(fmt.Errorf("unknown color scheme: %s", scheme),
+			ui.DidYouMean(scheme, GeneratorSchemes),
+			"Available schemes: "+strings.Join(GeneratorSchemes, ", "))
 	}
 }
 
 func (m *Manager) createThemeContent(colors map[string]string, scheme, name string) string {
+	cursor := cursorColors(colors)
 	content := fmt.Sprintf(`# %s
 # Generated theme: %s
 # Scheme: %s
@@ -122,6 +132,10 @@ foreground = "%s"
 text = "%s"
 cursor = "%s"
 
+[colors.vi_mode_cursor]
+text = "%s"
+cursor = "%s"
+
 [colors.selection]
 text = "%s"
 background = "%s"
@@ -152,8 +166,10 @@ white = "%s"
 		time.Now().Format("2006-01-02 15:04:05"),
 		colors["background"],
 		colors["foreground"],
-		colors["background"],
-		colors["foreground"],
+		cursor["cursor.text"],
+		cursor["cursor.cursor"],
+		cursor["vi_mode_cursor.text"],
+		cursor["vi_mode_cursor.cursor"],
 		colors["foreground"],
 		colors["selection_background"],
 		colors["black"],
@@ -177,6 +193,57 @@ white = "%s"
 	return content
 }
 
+// cursorColors picks the cursors of a generated theme from its accents: the
+// most saturated one for the cursor, and the one furthest from it in hue
+// for the vi mode cursor, so it is clear which mode is on. Both are made to
+// stand out from the background, with readable text on them.
+func cursorColors(colors map[string]string) map[string]string {
+	bg, errBg := ParseColor(colors["background"])
+	fg, errFg := ParseColor(colors["foreground"])
+	if errBg != nil || errFg != nil {
+		return map[string]string{
+			"cursor.text": colors["background"], "cursor.cursor": colors["foreground"],
+			"vi_mode_cursor.text": colors["background"], "vi_mode_cursor.cursor": colors["foreground"],
+		}
+	}
+
+	type accent struct {
+		rgb RGB
+		c   oklch
+	}
+	var accents []accent
+	for _, name := range ansiColors[1:7] {
+		if rgb, err := ParseColor(colors[name]); err == nil && rgb.toOKLCH().C >= achromaticChroma {
+			accents = append(accents, accent{rgb, rgb.toOKLCH()})
+		}
+	}
+	cursor, viMode := fg, fg
+	if len(accents) > 0 {
+		first := accents[0]
+		for _, a := range accents[1:] {
+			if a.c.C > first.c.C {
+				first = a
+			}
+		}
+		second, distance := first, 0.0
+		for _, a := range accents {
+			if d := math.Abs(math.Remainder(a.c.H-first.c.H, 2*math.Pi)); d > distance {
+				second, distance = a, d
+			}
+		}
+		cursor, viMode = first.rgb, second.rgb
+	}
+
+	cursor = EnsureContrast(cursor, bg, derivedCursorContrast)
+	viMode = EnsureContrast(viMode, bg, derivedCursorContrast)
+	return map[string]string{
+		"cursor.text":           readableOn(cursor, bg, fg).ToHex(),
+		"cursor.cursor":         cursor.ToHex(),
+		"vi_mode_cursor.text":   readableOn(viMode, bg, fg).ToHex(),
+		"vi_mode_cursor.cursor": viMode.ToHex(),
+	}
+}
+
 func (m *Manager) generateColorSchemeWithVariant(scheme string, darkTheme, lightTheme bool) (map[string]string, error) {
 	colors, err := m.generateColorScheme(scheme)
 	if err != nil {
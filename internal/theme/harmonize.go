@@ -0,0 +1,256 @@
+package theme
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// achromaticChroma is the OKLCH chroma under which an accent is a gray with
+// no meaningful hue; harmonize only moves its lightness
+const achromaticChroma = 0.02
+
+// maxChroma bounds --chroma; no sRGB color goes much beyond it
+const maxChroma = 0.37
+
+// HarmonizeOptions set the OKLCH levels the normal accents are snapped to.
+// A zero target uses the median of the theme's own normal accents. Bright
+// accents keep the theme's offset from the normal ones.
+type HarmonizeOptions struct {
+	// Lightness is the target OKLCH lightness in percent
+	Lightness float64
+	// Chroma is the target OKLCH chroma, from 0 to about 0.37
+	Chroma float64
+	// Name of the new theme; defaults to <theme>_harmonized
+	Name   string
+	Force  bool
+	DryRun bool
+}
+
+// oklch is a color in the OKLCH space: perceived lightness from 0 to 1,
+// chroma, and hue in radians
+type oklch struct {
+	L, C, H float64
+}
+
+func srgbToLinear(c int) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) float64 {
+	if v <= 0.0031308 {
+		return 12.92 * v * 255
+	}
+	return (1.055*math.Pow(v, 1/2.4) - 0.055) * 255
+}
+
+func (rgb RGB) toOKLCH() oklch {
+	r, g, b := srgbToLinear(rgb.R), srgbToLinear(rgb.G), srgbToLinear(rgb.B)
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	L := 0.2104542553*l + 0.7936177850*m - 0.0040720468*s
+	A := 1.9779984951*l - 2.4285922050*m + 0.4505937099*s
+	B := 0.0259040371*l + 0.7827717662*m - 0.8086757660*s
+	return oklch{L: L, C: math.Hypot(A, B), H: math.Atan2(B, A)}
+}
+
+// linear returns the color in linear sRGB, outside 0..1 when it is out of
+// gamut
+func (c oklch) linear() (float64, float64, float64) {
+	A, B := c.C*math.Cos(c.H), c.C*math.Sin(c.H)
+	l := math.Pow(c.L+0.3963377774*A+0.2158037573*B, 3)
+	m := math.Pow(c.L-0.1055613458*A-0.0638541728*B, 3)
+	s := math.Pow(c.L-0.0894841775*A-1.2914855480*B, 3)
+	return 4.0767416621*l - 3.3077115913*m + 0.2309699292*s,
+		-1.2684380046*l + 2.6097574011*m - 0.3413193965*s,
+		-0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+}
+
+// toRGB converts back to sRGB, lowering the chroma until the color fits so
+// its lightness and hue stay as asked
+func (c oklch) toRGB() RGB {
+	inGamut := func(c oklch) bool {
+		r, g, b := c.linear()
+		const eps = 1e-6
+		return r >= -eps && r <= 1+eps && g >= -eps && g <= 1+eps && b >= -eps && b <= 1+eps
+	}
+	if !inGamut(c) {
+		low, high := 0.0, c.C
+		for i := 0; i < 24; i++ {
+			c.C = (low + high) / 2
+			if inGamut(c) {
+				low = c.C
+			} else {
+				high = c.C
+			}
+		}
+		c.C = low
+	}
+	r, g, b := c.linear()
+	channel := func(v float64) int {
+		return int(math.Round(math.Max(0, math.Min(255, linearToSRGB(math.Max(0, v))))))
+	}
+	return RGB{R: channel(r), G: channel(g), B: channel(b)}
+}
+
+// median returns the middle of values, or zero when there are none
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// accentLevels returns the median lightness and chroma of a group of
+// accents, leaving grays out of the chroma
+func accentLevels(colors map[string]oklch) (float64, float64) {
+	var lightness, chroma []float64
+	for _, c := range colors {
+		lightness = append(lightness, c.L)
+		if c.C >= achromaticChroma {
+			chroma = append(chroma, c.C)
+		}
+	}
+	return median(lightness), median(chroma)
+}
+
+// Harmonize snaps the six chromatic ANSI colors of a theme to the same
+// OKLCH lightness and chroma, keeping their hues, so no accent glares or
+// fades next to the others. The result is saved as a new theme.
+func (m *Manager) Harmonize(themeName string, opts HarmonizeOptions) error {
+	if opts.Lightness < 0 || opts.Lightness > 100 {
+		return fmt.Errorf("--lightness must be between 0 and 100")
+	}
+	if opts.Chroma < 0 || opts.Chroma > maxChroma {
+		return fmt.Errorf("--chroma must be between 0 and %.2f", maxChroma)
+	}
+
+	t, err := m.findTheme(themeName)
+	if err != nil {
+		return err
+	}
+	name := opts.Name
+	if name == "" {
+		name = t.Name + "_harmonized"
+	}
+	if strings.ContainsAny(name, `/\`) || name == "current" {
+		return ui.WithHints(fmt.Errorf("invalid theme name '%s'", name), "Use a plain file name, e.g. --name nord_even")
+	}
+	themeFile := filepath.Join(m.config.ThemeSaveDir(), name+".toml")
+	if _, err := os.Stat(themeFile); err == nil && !opts.Force && !opts.DryRun {
+		return ui.WithHints(fmt.Errorf("theme '%s' already exists", name),
+			"Choose another name with --name, or pass --force to overwrite it.")
+	}
+
+	text, err := readConfigText(t.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read theme: %w", err)
+	}
+	values, _ := colorTables(text.Lines)
+
+	groups := map[string]map[string]oklch{"normal": {}, "bright": {}}
+	for table, group := range groups {
+		for _, color := range ansiColors[1:7] {
+			if rgb, err := ParseColor(values[table+"."+color]); err == nil {
+				group[color] = rgb.toOKLCH()
+			}
+		}
+	}
+	if len(groups["normal"]) == 0 {
+		return fmt.Errorf("theme '%s' sets none of the normal ANSI accents", t.Name)
+	}
+
+	normalL, normalC := accentLevels(groups["normal"])
+	targetL, targetC := normalL, normalC
+	if opts.Lightness > 0 {
+		targetL = opts.Lightness / 100
+	}
+	if opts.Chroma > 0 {
+		targetC = opts.Chroma
+	}
+	targets := map[string]oklch{"normal": {L: targetL, C: targetC}}
+	if len(groups["bright"]) > 0 {
+		// Bright accents keep the theme's step up from the normal ones
+		brightL, brightC := accentLevels(groups["bright"])
+		targets["bright"] = oklch{
+			L: math.Max(0, math.Min(1, targetL+brightL-normalL)),
+			C: math.Max(0, targetC+brightC-normalC),
+		}
+	}
+
+	ui.PrintHeader(fmt.Sprintf("Harmonizing %s", t.Name))
+	ui.PrintKeyValue("Lightness", fmt.Sprintf("%.0f%% (theme median %.0f%%)", targetL*100, normalL*100))
+	ui.PrintKeyValue("Chroma", fmt.Sprintf("%.3f (theme median %.3f)", targetC, normalC))
+
+	lines := append([]string{}, text.Lines...)
+	var rows [][]string
+	for _, table := range []string{"normal", "bright"} {
+		target, ok := targets[table]
+		if !ok {
+			continue
+		}
+		for _, color := range ansiColors[1:7] {
+			c, ok := groups[table][color]
+			if !ok {
+				continue
+			}
+			snapped := oklch{L: target.L, C: target.C, H: c.H}
+			if c.C < achromaticChroma {
+				snapped.C = c.C
+			}
+			before := normalizeHex(values[table+"."+color])
+			rgb := snapped.toRGB()
+			after := rgb.ToHex()
+			if i, _, ok := findConfigValue(lines, "colors."+table, color); ok {
+				lines[i] = hexColorPattern.ReplaceAllStringFunc(lines[i], func(value string) string {
+					if strings.HasPrefix(value, "0x") {
+						return "0x" + strings.TrimPrefix(after, "#")
+					}
+					return after
+				})
+			}
+			// Out-of-gamut targets come out with less chroma; show what was written
+			got := rgb.toOKLCH()
+			rows = append(rows, []string{table + " " + color,
+				strings.TrimSpace(ui.Swatch(before) + " " + before),
+				strings.TrimSpace(ui.Swatch(after) + " " + after),
+				fmt.Sprintf("%+.0f%%", (got.L-c.L)*100),
+				fmt.Sprintf("%+.3f", got.C-c.C)})
+		}
+	}
+	ui.PrintTable([]string{"Color", "Before", "After", "Lightness", "Chroma"}, rows)
+
+	if opts.DryRun {
+		ui.PrintInfo("Dry run: '%s' was not saved", name)
+		return nil
+	}
+
+	header := fmt.Sprintf("# %s\n# Harmonized from %s at %s (OKLCH lightness %.0f%%, chroma %.3f)\n",
+		name, t.Name, time.Now().Format("2006-01-02 15:04:05"), targetL*100, targetC)
+	content := header + strings.Join(lines, "\n")
+	if err := os.WriteFile(themeFile, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to save theme: %w", err)
+	}
+
+	ui.PrintSuccess("Saved harmonized theme '%s' (%s)", name, themeFile)
+	ui.PrintInfo("Apply it with: alacritty-colors apply %s", name)
+	return nil
+}
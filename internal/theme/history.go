@@ -0,0 +1,156 @@
+package theme
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// HistoryFile records every applied theme, oldest first, one JSON object
+// per line
+const HistoryFile = "history.jsonl"
+
+// maxHistory bounds the history file; older entries are dropped
+const maxHistory = 1000
+
+// Sources of automatic switches in the history; themes applied by hand
+// have none
+const (
+	sourceScheduler = "scheduler"
+	sourceWorkspace = "workspace"
+	sourceWallpaper = "wallpaper"
+	sourceLocal     = "local"
+	sourceReplay    = "replay"
+)
+
+// HistoryEntry is one applied theme
+type HistoryEntry struct {
+	Theme     string    `json:"theme"`
+	AppliedAt time.Time `json:"applied_at"`
+	Source    string    `json:"source,omitempty"`
+}
+
+func (m *Manager) historyPath() string {
+	return filepath.Join(m.config.StateDir, HistoryFile)
+}
+
+// loadHistory returns the recorded history, oldest first. Unreadable lines
+// are skipped so a damaged file never blocks applying themes.
+func (m *Manager) loadHistory() ([]HistoryEntry, error) {
+	data, err := os.ReadFile(m.historyPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.Theme == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// recordHistory appends an applied theme to the history
+func (m *Manager) recordHistory(themeName string) error {
+	entries, err := m.loadHistory()
+	if err != nil {
+		return err
+	}
+
+	entries = append(entries, HistoryEntry{Theme: themeName, AppliedAt: time.Now(), Source: m.source})
+	if len(entries) > maxHistory {
+		entries = entries[len(entries)-maxHistory:]
+	}
+
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	if err := os.MkdirAll(m.config.StateDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(m.historyPath(), buf.Bytes(), 0644)
+}
+
+// applyAutomatic applies a theme for an automatic switch, recorded in the
+// history under source
+func (m *Manager) applyAutomatic(themeName, source string) error {
+	m.source = source
+	defer func() { m.source = "" }()
+	return m.applyTheme(themeName, false)
+}
+
+// recentThemes returns up to n distinct themes applied most recently by
+// hand. Automatic switches are left out, so a busy workspace watcher or
+// scheduler doesn't crowd out the themes random should avoid.
+func (m *Manager) recentThemes(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	entries, err := m.loadHistory()
+	if err != nil {
+		m.logVerbose("Failed to read history: %v", err)
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var recent []string
+	for i := len(entries) - 1; i >= 0 && len(recent) < n; i-- {
+		if entries[i].Source != "" {
+			continue
+		}
+		name := strings.ToLower(entries[i].Theme)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		recent = append(recent, entries[i].Theme)
+	}
+	return recent
+}
+
+// avoidRecent drops recently applied themes from the candidates. When that
+// would leave nothing to pick from, the candidates are returned unchanged.
+func (m *Manager) avoidRecent(themes []ThemeInfo) []ThemeInfo {
+	recent := m.recentThemes(m.config.Random.AvoidRecent)
+	if len(recent) == 0 {
+		return themes
+	}
+
+	skip := make(map[string]bool, len(recent))
+	for _, name := range recent {
+		skip[strings.ToLower(name)] = true
+	}
+
+	var kept []ThemeInfo
+	for _, t := range themes {
+		if !skip[strings.ToLower(t.Name)] {
+			kept = append(kept, t)
+		}
+	}
+
+	if len(kept) == 0 {
+		m.logVerbose("All candidates were applied recently; allowing repeats")
+		return themes
+	}
+	m.logVerbose("Skipping %d recently applied themes", len(themes)-len(kept))
+	return kept
+}
@@ -0,0 +1,109 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/notify"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// runHooks runs shell commands with the theme change in their environment:
+// ALACRITTY_COLORS_THEME is the new theme and ALACRITTY_COLORS_PREVIOUS the
+// one it replaces. ALACRITTY_COLORS_NO_INPUT keeps nested runs from
+// prompting.
+func (m *Manager) runHooks(stage string, commands []string, themeName, previous string) error {
+	for _, command := range commands {
+		m.logVerbose("Running %s hook: %s", stage, command)
+
+		cmd := shellCommand(command)
+		cmd.Env = append(os.Environ(),
+			"ALACRITTY_COLORS_THEME="+themeName,
+			"ALACRITTY_COLORS_PREVIOUS="+previous,
+			"ALACRITTY_COLORS_NO_INPUT=1",
+		)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s hook '%s' failed: %w", stage, command, err)
+		}
+	}
+	return nil
+}
+
+// shellCommand runs a command line through the platform's shell
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
+
+// notifyApplied sends the applied theme and its palette to the configured
+// webhooks and MQTT topics in parallel. Failures only warn.
+func (m *Manager) notifyApplied(themeName, previous, themePath string) {
+	hooks := m.config.Hooks
+	publishMQTT := hooks.MQTTBroker != "" && len(hooks.MQTTTopics) > 0
+	if len(hooks.Webhooks) == 0 && !publishMQTT {
+		return
+	}
+
+	info, err := m.parseThemeFile(themePath)
+	if err != nil {
+		ui.PrintWarning("Failed to read theme colors for notifications: %v", err)
+		return
+	}
+
+	// Notifications go through the same proxy and CA certificates as downloads
+	dl, err := m.newDownloader()
+	if err != nil {
+		ui.PrintWarning("Notifications not sent: %v", err)
+		return
+	}
+	transport := dl.Transport()
+
+	payload := notify.Payload{
+		Event:     "apply",
+		Theme:     themeName,
+		Previous:  previous,
+		AppliedAt: time.Now().UTC(),
+		Palette:   info.Colors,
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failures []string
+	report := func(target string, err error) {
+		if err != nil {
+			mu.Lock()
+			failures = append(failures, fmt.Sprintf("%s: %v", target, err))
+			mu.Unlock()
+		}
+	}
+
+	for _, url := range hooks.Webhooks {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			report(url, notify.Webhook(transport, url, payload))
+		}(url)
+	}
+	if publishMQTT {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report(hooks.MQTTBroker, notify.Publish(transport, hooks.MQTTBroker, hooks.MQTTTopics, payload))
+		}()
+	}
+	wg.Wait()
+
+	for _, failure := range failures {
+		ui.PrintWarning("Notification failed: %s", strings.TrimSpace(failure))
+	}
+}
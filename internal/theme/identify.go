@@ -0,0 +1,194 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// IdentifyOptions say what to match against the installed themes: a
+// terminal screenshot or a list of colors
+type IdentifyOptions struct {
+	Image  string
+	Colors []string
+	Limit  int
+	Format string
+}
+
+// identifyMatch is an installed theme and how close it is to the target
+type identifyMatch struct {
+	Theme      string  `json:"theme"`
+	Variant    string  `json:"variant,omitempty"`
+	Confidence float64 `json:"confidence"`
+	Distance   float64 `json:"distance"`
+}
+
+// identifyWeights favour the slots that cover most of a terminal
+var identifyWeights = map[string]float64{
+	"background": 3,
+	"foreground": 2,
+}
+
+// missingSlotDistance is charged when a theme doesn't set a slot at all
+const missingSlotDistance = 50.0
+
+// lowConfidence is the score below which the best match is probably not
+// the theme in the screenshot
+const lowConfidence = 40.0
+
+// Identify reports the installed themes closest to a screenshot or a
+// palette, with a confidence score for each
+func (m *Manager) Identify(opts IdentifyOptions) error {
+	var (
+		score  func(ThemeInfo) float64
+		source string
+	)
+	switch {
+	case opts.Image != "" && len(opts.Colors) > 0:
+		return fmt.Errorf("give either an image or --colors, not both")
+	case opts.Image != "":
+		img, err := decodeImage(opts.Image)
+		if err != nil {
+			return err
+		}
+		colors, detected := extractTerminalColors(img)
+		target := make(map[string]RGB)
+		for slot := range detected {
+			rgb, err := ParseColor(colors[slot])
+			if err != nil {
+				continue
+			}
+			if slot != "background" && slot != "foreground" {
+				slot = "normal_" + slot
+			}
+			target[slot] = rgb
+		}
+		m.logVerbose("Matching %d colors found in the screenshot", len(target))
+		score = func(t ThemeInfo) float64 { return slotDistance(target, t) }
+		source = filepath.Base(opts.Image)
+	case len(opts.Colors) > 0:
+		var target []RGB
+		for _, value := range opts.Colors {
+			rgb, err := ParseColor(value)
+			if err != nil {
+				return err
+			}
+			target = append(target, rgb)
+		}
+		score = func(t ThemeInfo) float64 { return paletteDistance(target, t) }
+		source = fmt.Sprintf("%d colors", len(target))
+	default:
+		return ui.WithHints(fmt.Errorf("nothing to identify"),
+			"Pass a screenshot: alacritty-colors identify shot.png",
+			"or colors: alacritty-colors identify --colors '#282a36,#f8f8f2,#ff5555'")
+	}
+
+	themes, err := m.getThemeInfos()
+	if err != nil {
+		return err
+	}
+
+	matches := make([]identifyMatch, 0, len(themes))
+	for _, t := range themes {
+		d := score(t)
+		matches = append(matches, identifyMatch{
+			Theme:      t.Name,
+			Variant:    t.Variant,
+			Confidence: math.Round(100*math.Exp(-d/12)*10) / 10,
+			Distance:   math.Round(d*10) / 10,
+		})
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Distance < matches[j].Distance })
+	if opts.Limit > 0 && len(matches) > opts.Limit {
+		matches = matches[:opts.Limit]
+	}
+
+	switch opts.Format {
+	case "json":
+		data, err := json.MarshalIndent(matches, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	case "", "table":
+	default:
+		return fmt.Errorf("unknown format: %s", opts.Format)
+	}
+
+	if len(matches) == 0 {
+		ui.PrintWarning("No themes installed to compare against")
+		return nil
+	}
+
+	ui.PrintHeader(fmt.Sprintf("Closest themes to %s", source))
+	rows := make([][]string, 0, len(matches))
+	for _, match := range matches {
+		rows = append(rows, []string{
+			match.Theme,
+			match.Variant,
+			strconv.FormatFloat(match.Confidence, 'f', 0, 64) + "%",
+			strconv.FormatFloat(match.Distance, 'f', 1, 64),
+		})
+	}
+	ui.PrintTable([]string{"Theme", "Variant", "Confidence", "Distance"}, rows)
+
+	if matches[0].Confidence < lowConfidence {
+		ui.PrintWarning("No close match; the theme may not be installed")
+		ui.PrintInfo("Fetch more themes with 'alacritty-colors update', or recreate it with 'alacritty-colors generate --from-screenshot'")
+		return nil
+	}
+	ui.PrintInfo("Apply the best match: alacritty-colors apply %s", matches[0].Theme)
+	return nil
+}
+
+// slotDistance is the weighted mean distance between the colors found in a
+// screenshot and the same slots of a theme
+func slotDistance(target map[string]RGB, t ThemeInfo) float64 {
+	var total, weights float64
+	for slot, want := range target {
+		weight := identifyWeights[slot]
+		if weight == 0 {
+			weight = 1
+		}
+		d := missingSlotDistance
+		if rgb, err := ParseColor(t.Colors[slot]); err == nil {
+			d = ColorDistance(want, rgb)
+		}
+		total += weight * d
+		weights += weight
+	}
+	if weights == 0 {
+		return missingSlotDistance
+	}
+	return total / weights
+}
+
+// paletteDistance is the mean distance from each color to the nearest
+// color of a theme, whatever slot it is in
+func paletteDistance(target []RGB, t ThemeInfo) float64 {
+	var palette []RGB
+	for _, value := range t.Colors {
+		if rgb, err := ParseColor(value); err == nil {
+			palette = append(palette, rgb)
+		}
+	}
+	if len(palette) == 0 {
+		return missingSlotDistance
+	}
+
+	var total float64
+	for _, want := range target {
+		best := math.Inf(1)
+		for _, rgb := range palette {
+			best = math.Min(best, ColorDistance(want, rgb))
+		}
+		total += best
+	}
+	return total / float64(len(target))
+}
@@ -0,0 +1,177 @@
+package theme
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// imageSamples is roughly how many pixels are sampled from an image
+const imageSamples = 160 * 160
+
+// hueBins splits the color wheel when looking for an image's hues
+const hueBins = 36
+
+// ansiHues are the hues the six chromatic terminal colors aim for
+var ansiHues = []struct {
+	name string
+	hue  float64
+}{
+	{"red", 0}, {"yellow", 1.0 / 6}, {"green", 1.0 / 3},
+	{"cyan", 0.5}, {"blue", 2.0 / 3}, {"magenta", 5.0 / 6},
+}
+
+// imagePalette summarizes the colors of an image
+type imagePalette struct {
+	bins      [hueBins]struct{ weight, hue, sat float64 }
+	avgSat    float64
+	avgLight  float64
+	dominant  float64
+	maxWeight float64
+}
+
+// decodeImage opens and decodes a PNG, JPEG or GIF file
+func decodeImage(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image %s (PNG, JPEG and GIF are supported): %w", filepath.Base(path), err)
+	}
+	return img, nil
+}
+
+// generateImageColors derives a terminal palette from an image: background
+// and accents follow the image's dominant hue, and each ANSI color takes
+// the image's closest hue so the result still reads as red, green, etc.
+func (m *Manager) generateImageColors(path string) (map[string]string, error) {
+	img, err := decodeImage(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p := analyzeImage(img)
+	light := p.avgLight > 0.6
+
+	colors := make(map[string]string)
+	bgSat := math.Min(p.avgSat, 0.3) * 0.6
+	if light {
+		colors["background"] = HSL{H: p.dominant, S: bgSat, L: 0.94}.ToRGB().ToHex()
+		colors["foreground"] = HSL{H: p.dominant, S: 0.1, L: 0.15}.ToRGB().ToHex()
+		colors["selection_background"] = HSL{H: p.dominant, S: 0.35, L: 0.8}.ToRGB().ToHex()
+	} else {
+		colors["background"] = HSL{H: p.dominant, S: bgSat, L: 0.08}.ToRGB().ToHex()
+		colors["foreground"] = HSL{H: p.dominant, S: 0.1, L: 0.88}.ToRGB().ToHex()
+		colors["selection_background"] = HSL{H: p.dominant, S: 0.35, L: 0.25}.ToRGB().ToHex()
+	}
+	background, _ := HexToRGB(colors["background"])
+
+	accentLight, brightStep := 0.6, 0.12
+	if light {
+		accentLight, brightStep = 0.4, -0.1
+	}
+
+	for _, target := range ansiHues {
+		hue, sat := p.closestHue(target.hue)
+		sat = math.Max(0.45, math.Min(0.9, sat))
+
+		normal := EnsureContrast(HSL{H: hue, S: sat, L: accentLight}.ToRGB(), background, 3.0)
+		bright := EnsureContrast(HSL{H: hue, S: math.Min(1, sat+0.05), L: accentLight + brightStep}.ToRGB(), background, 3.0)
+		colors[target.name] = normal.ToHex()
+		colors["bright_"+target.name] = bright.ToHex()
+	}
+
+	grays := []float64{0.2, 0.35, 0.75, 0.9} // black, bright black, white, bright white
+	if light {
+		grays = []float64{0.15, 0.4, 0.8, 0.95}
+	}
+	colors["black"] = HSL{H: p.dominant, S: 0.1, L: grays[0]}.ToRGB().ToHex()
+	colors["bright_black"] = HSL{H: p.dominant, S: 0.1, L: grays[1]}.ToRGB().ToHex()
+	colors["white"] = HSL{H: p.dominant, S: 0.08, L: grays[2]}.ToRGB().ToHex()
+	colors["bright_white"] = HSL{H: p.dominant, S: 0.05, L: grays[3]}.ToRGB().ToHex()
+
+	return colors, nil
+}
+
+func analyzeImage(img image.Image) imagePalette {
+	var p imagePalette
+	bounds := img.Bounds()
+	step := int(math.Max(1, math.Sqrt(float64(bounds.Dx()*bounds.Dy())/imageSamples)))
+
+	count := 0.0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			r, g, b, _ := img.At(x, y).RGBA()
+			hsl := RGB{R: int(r >> 8), G: int(g >> 8), B: int(b >> 8)}.ToHSL()
+			p.avgSat += hsl.S
+			p.avgLight += hsl.L
+			count++
+
+			// Grays and near-black or near-white pixels carry no usable hue
+			if hsl.S < 0.2 || hsl.L < 0.12 || hsl.L > 0.88 {
+				continue
+			}
+			bin := &p.bins[int(hsl.H*hueBins)%hueBins]
+			bin.weight += hsl.S
+			bin.hue += hsl.H * hsl.S
+			bin.sat += hsl.S * hsl.S
+		}
+	}
+
+	if count > 0 {
+		p.avgSat /= count
+		p.avgLight /= count
+	}
+
+	for i := range p.bins {
+		bin := &p.bins[i]
+		if bin.weight > 0 {
+			bin.hue /= bin.weight
+			bin.sat /= bin.weight
+		}
+		if bin.weight > p.maxWeight {
+			p.maxWeight = bin.weight
+			p.dominant = bin.hue
+		}
+	}
+
+	return p
+}
+
+// closestHue returns the strongest image hue within 30° of target, or the
+// target itself, in a saturation based on the image's, when there is none
+func (p imagePalette) closestHue(target float64) (float64, float64) {
+	best, bestWeight := -1, 0.0
+	for i, bin := range p.bins {
+		if bin.weight == 0 || bin.weight < p.maxWeight*0.05 {
+			continue
+		}
+		distance := math.Abs(bin.hue - target)
+		distance = math.Min(distance, 1-distance)
+		if distance <= 1.0/12 && bin.weight > bestWeight {
+			best, bestWeight = i, bin.weight
+		}
+	}
+
+	if best < 0 {
+		return target, math.Min(0.6, p.avgSat+0.2)
+	}
+
+	// Meet the target halfway so the color still reads as its name
+	d := p.bins[best].hue - target
+	if d > 0.5 {
+		d--
+	} else if d < -0.5 {
+		d++
+	}
+	return math.Mod(target+d/2+1, 1), p.bins[best].sat
+}
@@ -0,0 +1,36 @@
+package theme
+
+import (
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// initReport numbers and times the steps of init, and sums them up at the end
+type initReport struct {
+	total int
+	rows  [][]string
+	start time.Time
+}
+
+func newInitReport(total int) *initReport {
+	return &initReport{total: total, start: time.Now()}
+}
+
+// step runs one step, recording its outcome and duration. A failed step
+// is recorded before its error is returned.
+func (r *initReport) step(name string, fn func() (string, error)) error {
+	ui.PrintStep(len(r.rows)+1, r.total, name)
+	start := time.Now()
+	result, err := fn()
+	if err != nil {
+		result = "failed"
+	}
+	r.rows = append(r.rows, []string{name, result, formatDuration(time.Since(start))})
+	return err
+}
+
+func (r *initReport) print() {
+	r.rows = append(r.rows, []string{"Total", "", formatDuration(time.Since(r.start))})
+	ui.PrintTable([]string{"Step", "Result", "Time"}, r.rows)
+}
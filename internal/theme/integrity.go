@@ -0,0 +1,139 @@
+package theme
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// AppliedStateFile records the hash of current.toml when a theme is applied
+const AppliedStateFile = "applied.json"
+
+// AppliedState is what current.toml held right after the last apply
+type AppliedState struct {
+	Theme     string    `json:"theme"`
+	SHA256    string    `json:"sha256"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+func (m *Manager) appliedStatePath() string {
+	return filepath.Join(m.config.StateDir, AppliedStateFile)
+}
+
+func fileSHA256(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// recordApplied stores the hash of current.toml for the applied theme
+func (m *Manager) recordApplied(themeName string) error {
+	sum, err := fileSHA256(m.currentThemeFile())
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(AppliedState{
+		Theme:     themeName,
+		SHA256:    sum,
+		AppliedAt: time.Now(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.config.StateDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(m.appliedStatePath(), data, 0644)
+}
+
+// themeDrift returns the recorded state when current.toml no longer matches
+// it. A symlinked current.toml follows its theme on purpose and never
+// drifts; nothing is reported while no hash has been recorded.
+func (m *Manager) themeDrift() (*AppliedState, bool) {
+	if _, linked := m.linkedTheme(); linked {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(m.appliedStatePath())
+	if err != nil {
+		return nil, false
+	}
+	var state AppliedState
+	if err := json.Unmarshal(data, &state); err != nil || state.SHA256 == "" {
+		return nil, false
+	}
+
+	sum, err := fileSHA256(m.currentThemeFile())
+	if err != nil || sum == state.SHA256 {
+		return nil, false
+	}
+	return &state, true
+}
+
+// driftError refuses to overwrite a current.toml edited since it was applied
+func (m *Manager) driftError(state *AppliedState) error {
+	return ui.WithHints(
+		fmt.Errorf("current.toml was modified after '%s' was applied on %s", state.Theme, state.AppliedAt.Format("2006-01-02 15:04")),
+		"Keep the changes as a new theme with 'alacritty-colors theme adopt <name>',",
+		"or discard them with 'alacritty-colors apply <theme> --force'.")
+}
+
+// warnDrift prints a warning when current.toml no longer matches the
+// applied theme
+func (m *Manager) warnDrift() {
+	if state, drifted := m.themeDrift(); drifted {
+		ui.PrintWarning("current.toml was modified after '%s' was applied", state.Theme)
+		ui.PrintInfo("  Save it with 'alacritty-colors theme adopt <name>' or reset it with 'alacritty-colors apply %s --force'", state.Theme)
+	}
+}
+
+// AdoptCurrent saves the content of current.toml as a new theme and makes
+// it the applied one
+func (m *Manager) AdoptCurrent(name string) error {
+	state, drifted := m.themeDrift()
+	if name == "" {
+		base := m.GetCurrentTheme()
+		if state != nil {
+			base = state.Theme
+		}
+		if base == "" {
+			base = "custom"
+		}
+		name = base + "_modified"
+	}
+	if !drifted {
+		ui.PrintInfo("current.toml matches the applied theme; saving a copy anyway")
+	}
+
+	themeFile := filepath.Join(m.config.ThemeSaveDir(), name+".toml")
+	if _, err := os.Stat(themeFile); err == nil {
+		return ui.WithHints(fmt.Errorf("theme '%s' already exists", name), "Choose another name: alacritty-colors theme adopt <name>")
+	}
+
+	if err := m.copyFile(m.currentThemeFile(), themeFile); err != nil {
+		return fmt.Errorf("failed to save theme: %w", err)
+	}
+	if err := m.installCurrent(themeFile); err != nil {
+		return fmt.Errorf("failed to apply theme: %w", err)
+	}
+	if err := m.config.SetCurrentTheme(name); err != nil {
+		ui.PrintWarning("Failed to update theme tracking: %v", err)
+	}
+	if err := m.recordApplied(name); err != nil {
+		m.logVerbose("Failed to record applied theme: %v", err)
+	}
+
+	ui.PrintSuccess("Saved current.toml as theme '%s' (%s)", name, themeFile)
+	return nil
+}
@@ -0,0 +1,219 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// windowsPipeDir is where Windows lists named pipes
+const windowsPipeDir = `\\.\pipe\`
+
+// alacrittySockets finds the IPC endpoints of running Alacritty instances:
+// $ALACRITTY_SOCKET first, then the Alacritty-*.sock sockets Alacritty
+// creates in the runtime and temp directories, or on Windows the named
+// pipes starting with Alacritty-
+func alacrittySockets() []string {
+	seen := make(map[string]bool)
+	var sockets []string
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			sockets = append(sockets, path)
+		}
+	}
+
+	if socket := os.Getenv("ALACRITTY_SOCKET"); socket != "" {
+		if _, err := os.Stat(socket); err == nil {
+			add(socket)
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		// Named pipes can't be globbed, but the pipe directory can be listed
+		entries, err := os.ReadDir(windowsPipeDir)
+		if err != nil {
+			return sockets
+		}
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		for _, pipe := range alacrittyPipes(names) {
+			add(pipe)
+		}
+		return sockets
+	}
+
+	for _, dir := range socketDirs(os.Getenv("XDG_RUNTIME_DIR"), os.TempDir()) {
+		matches, _ := filepath.Glob(filepath.Join(dir, "Alacritty-*.sock"))
+		sort.Strings(matches)
+		for _, match := range matches {
+			add(match)
+		}
+	}
+	return sockets
+}
+
+// alacrittyPipes picks the pipes Alacritty created out of the names listed
+// in the Windows pipe directory
+func alacrittyPipes(names []string) []string {
+	var pipes []string
+	for _, name := range names {
+		if strings.HasPrefix(name, "Alacritty-") {
+			pipes = append(pipes, windowsPipeDir+name)
+		}
+	}
+	return pipes
+}
+
+// socketDirs lists the directories Alacritty creates its sockets in
+func socketDirs(runtimeDir, tempDir string) []string {
+	var dirs []string
+	if runtimeDir != "" {
+		dirs = append(dirs, runtimeDir, filepath.Join(runtimeDir, "alacritty"))
+	}
+	return append(dirs, tempDir)
+}
+
+// liveReloadEnabled reports whether the Alacritty config leaves live
+// config reload on. It is on unless [general] live_config_reload, or the
+// top-level key older releases read, says false.
+func (m *Manager) liveReloadEnabled() bool {
+	text, err := readConfigText(m.config.ConfigFile)
+	if err != nil {
+		return true
+	}
+	for _, table := range []string{"general", ""} {
+		if _, value, ok := findConfigValue(text.Lines, table, "live_config_reload"); ok {
+			return !strings.HasPrefix(value, "false")
+		}
+	}
+	return true
+}
+
+// warnLiveReloadOff tells the user a theme won't show up in open windows
+func (m *Manager) warnLiveReloadOff() {
+	if m.liveReloadEnabled() {
+		return
+	}
+	ui.PrintWarning("live_config_reload is off in %s; open windows keep their colors", m.config.ConfigFile)
+	ui.PrintInfo("Set live_config_reload = true under [general], or open a new window")
+}
+
+// runningConfig is the part of 'alacritty msg get-config' output that
+// shows which theme a window uses
+type runningConfig struct {
+	Colors struct {
+		Primary struct {
+			Background string `json:"background"`
+		} `json:"primary"`
+	} `json:"colors"`
+}
+
+// runningConfigJSON asks an Alacritty instance for its effective config,
+// including overrides set with 'alacritty msg config'
+func runningConfigJSON(socket string) ([]byte, error) {
+	out, err := exec.Command("alacritty", "msg", "--socket", socket, "get-config").Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// runningBackground asks an Alacritty instance for its background color
+func runningBackground(socket string) (string, error) {
+	out, err := runningConfigJSON(socket)
+	if err != nil {
+		return "", err
+	}
+	var cfg runningConfig
+	if err := json.Unmarshal(out, &cfg); err != nil {
+		return "", fmt.Errorf("unexpected get-config output: %w", err)
+	}
+	return strings.ToLower(cfg.Colors.Primary.Background), nil
+}
+
+// CheckLiveReload reports whether live reload is on and, through Alacritty's
+// IPC, whether running instances show the applied theme
+func (m *Manager) CheckLiveReload() error {
+	ui.PrintSubHeader("Live Reload")
+
+	enabled := m.liveReloadEnabled()
+	if enabled {
+		ui.PrintKeyValue("Live reload", "on")
+	} else {
+		ui.PrintKeyValue("Live reload", "off")
+	}
+	ui.PrintKeyValue("Config File", m.config.ConfigFile)
+
+	if _, err := exec.LookPath("alacritty"); err != nil {
+		ui.PrintInfo("The alacritty command is not on PATH, so running windows can't be checked")
+		return nil
+	}
+
+	sockets := alacrittySockets()
+	if len(sockets) == 0 {
+		if runtime.GOOS == "windows" {
+			ui.PrintInfo("No Alacritty named pipes found; Alacritty on Windows may not offer IPC, so only live reload applies themes to open windows")
+		} else {
+			ui.PrintInfo("No running Alacritty instances found (set ALACRITTY_SOCKET if yours uses another path)")
+		}
+		return nil
+	}
+
+	expected := ""
+	if colors, err := renderColors(m.currentThemeFile()); err == nil {
+		expected = colors["primary.background"].ToHex()
+	}
+
+	stale := 0
+	var rows [][]string
+	for _, socket := range sockets {
+		background, err := runningBackground(socket)
+		state := ""
+		switch {
+		case err != nil:
+			state = "unknown: " + err.Error()
+		case expected == "" || background == "":
+			state = "background " + background
+		case hexDistance(background, expected) < 1:
+			state = "up to date"
+		default:
+			state = fmt.Sprintf("showing %s, expected %s", background, expected)
+			stale++
+		}
+		rows = append(rows, []string{filepath.Base(socket), state})
+	}
+	ui.PrintTable([]string{"Instance", "Theme"}, rows)
+
+	if stale > 0 {
+		if enabled {
+			ui.PrintWarning("%d instance(s) did not pick up the theme; they may use another config file (alacritty --config-file)", stale)
+		} else {
+			ui.PrintWarning("%d instance(s) still show the previous theme because live reload is off", stale)
+		}
+	}
+	return nil
+}
+
+// hexDistance is ColorDistance for color strings; unparsable colors are
+// infinitely far apart
+func hexDistance(a, b string) float64 {
+	ca, errA := ParseColor(a)
+	cb, errB := ParseColor(b)
+	if errA != nil || errB != nil {
+		return 1e9
+	}
+	return ColorDistance(ca, cb)
+}
@@ -0,0 +1,64 @@
+package theme
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAlacrittyPipes(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{name: "no pipes", names: nil, want: nil},
+		{
+			name:  "only Alacritty pipes",
+			names: []string{"Alacritty-1234", "Alacritty-5678"},
+			want:  []string{`\\.\pipe\Alacritty-1234`, `\\.\pipe\Alacritty-5678`},
+		},
+		{
+			name:  "other pipes are skipped",
+			names: []string{"InitShutdown", "Alacritty-42", "lsass", "alacritty-7", "openssh-ssh-agent"},
+			want:  []string{`\\.\pipe\Alacritty-42`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := alacrittyPipes(tt.names); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("alacrittyPipes(%q) = %q, want %q", tt.names, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSocketDirs(t *testing.T) {
+	tests := []struct {
+		name       string
+		runtimeDir string
+		tempDir    string
+		want       []string
+	}{
+		{
+			name:    "temp directory only",
+			tempDir: "/tmp",
+			want:    []string{"/tmp"},
+		},
+		{
+			name:       "runtime directory first",
+			runtimeDir: "/run/user/1000",
+			tempDir:    "/tmp",
+			want:       []string{"/run/user/1000", filepath.Join("/run/user/1000", "alacritty"), "/tmp"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := socketDirs(tt.runtimeDir, tt.tempDir); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("socketDirs(%q, %q) = %q, want %q", tt.runtimeDir, tt.tempDir, got, tt.want)
+			}
+		})
+	}
+}
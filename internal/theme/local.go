@@ -0,0 +1,183 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+const (
+	// LocalThemeFile records the theme of a directory and its subdirectories
+	LocalThemeFile = ".alacritty-theme"
+	localStateFile = "local.json"
+)
+
+// localState remembers the theme to go back to when leaving a project
+type localState struct {
+	GlobalTheme string `json:"global_theme"`
+	ActiveFile  string `json:"active_file"`
+}
+
+// SetLocalTheme records a theme for dir
+func (m *Manager) SetLocalTheme(dir, themeName string) error {
+	selected, err := m.findTheme(themeName)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, LocalThemeFile)
+	if err := os.WriteFile(path, []byte(selected.Name+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", LocalThemeFile, err)
+	}
+
+	ui.PrintSuccess("Theme for %s set to '%s'", dir, selected.Name)
+	return nil
+}
+
+// UnsetLocalTheme removes the theme recorded for dir
+func (m *Manager) UnsetLocalTheme(dir string) error {
+	path := filepath.Join(dir, LocalThemeFile)
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no local theme set in %s", dir)
+		}
+		return fmt.Errorf("failed to remove %s: %w", LocalThemeFile, err)
+	}
+
+	ui.PrintSuccess("Removed local theme from %s", dir)
+	return nil
+}
+
+// FindLocalTheme returns the theme recorded in dir or its nearest parent and
+// the file it was read from
+func FindLocalTheme(dir string) (string, string) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", ""
+	}
+
+	for {
+		path := filepath.Join(dir, LocalThemeFile)
+		if data, err := os.ReadFile(path); err == nil {
+			if name := strings.TrimSpace(string(data)); name != "" {
+				return name, path
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		dir = parent
+	}
+}
+
+// ShowLocalTheme prints the theme that applies to dir
+func (m *Manager) ShowLocalTheme(dir string) error {
+	name, path := FindLocalTheme(dir)
+	if name == "" {
+		ui.PrintInfo("No local theme for %s", dir)
+		return nil
+	}
+
+	ui.PrintKeyValue("Local Theme", name)
+	ui.PrintKeyValue("Set In", path)
+	return nil
+}
+
+// ApplyLocalTheme applies the theme recorded for dir. Outside of any project
+// the theme that was active before entering one is restored. Nothing is
+// written when the right theme is already applied, so it is cheap to call
+// from a shell hook.
+func (m *Manager) ApplyLocalTheme(dir string) error {
+	state := m.loadLocalState()
+	name, path := FindLocalTheme(dir)
+
+	if name == "" {
+		if state.ActiveFile == "" {
+			return nil
+		}
+		global := state.GlobalTheme
+		if err := m.saveLocalState(localState{}); err != nil {
+			return err
+		}
+		if global == "" || global == m.config.CurrentTheme {
+			return nil
+		}
+		return m.applyAutomatic(global, sourceLocal)
+	}
+
+	if state.ActiveFile == "" {
+		state.GlobalTheme = m.config.CurrentTheme
+	}
+	state.ActiveFile = path
+	if err := m.saveLocalState(state); err != nil {
+		return err
+	}
+
+	if strings.EqualFold(name, m.config.CurrentTheme) {
+		return nil
+	}
+	return m.applyAutomatic(name, sourceLocal)
+}
+
+func (m *Manager) loadLocalState() localState {
+	var state localState
+	data, err := os.ReadFile(filepath.Join(m.config.StateDir, localStateFile))
+	if err == nil {
+		json.Unmarshal(data, &state)
+	}
+	return state
+}
+
+func (m *Manager) saveLocalState(state localState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(m.config.StateDir, localStateFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to save local theme state: %w", err)
+	}
+	return nil
+}
+
+// ShellHook returns shell code that applies local themes on every directory
+// change
+func ShellHook(shell, executable string) (string, error) {
+	cmd := fmt.Sprintf("%q local apply >/dev/null 2>&1", executable)
+
+	switch shell {
+	case "bash":
+		return fmt.Sprintf(`_alacritty_colors_hook() {
+  if [ "$PWD" != "${_ALACRITTY_COLORS_PWD:-}" ]; then
+    _ALACRITTY_COLORS_PWD="$PWD"
+    %s
+  fi
+}
+case ";${PROMPT_COMMAND:-};" in
+  *";_alacritty_colors_hook;"*) ;;
+  *) PROMPT_COMMAND="_alacritty_colors_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}" ;;
+esac
+`, cmd), nil
+	case "zsh":
+		return fmt.Sprintf(`_alacritty_colors_hook() {
+  %s
+}
+autoload -Uz add-zsh-hook
+add-zsh-hook chpwd _alacritty_colors_hook
+_alacritty_colors_hook
+`, cmd), nil
+	case "fish":
+		return fmt.Sprintf(`function _alacritty_colors_hook --on-variable PWD
+    %s
+end
+_alacritty_colors_hook
+`, cmd), nil
+	default:
+		return "", fmt.Errorf("unsupported shell: %s (use bash, zsh or fish)", shell)
+	}
+}
@@ -0,0 +1,140 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// themeLockFile, in the state directory, holds the lock while it is on
+const themeLockFile = "theme-lock.json"
+
+// themeLock keeps the applied theme in place. A zero Until means it lasts
+// until 'unlock'.
+type themeLock struct {
+	LockedAt time.Time `json:"locked_at"`
+	Theme    string    `json:"theme,omitempty"`
+	Until    time.Time `json:"until,omitempty"`
+}
+
+// describe says how long the lock lasts
+func (l themeLock) describe() string {
+	if l.Until.IsZero() {
+		return "until 'alacritty-colors unlock'"
+	}
+	if l.Until.Format("2006-01-02") != time.Now().Format("2006-01-02") {
+		return "until " + l.Until.Format("Mon 15:04")
+	}
+	return "until " + l.Until.Format("15:04")
+}
+
+func (m *Manager) themeLockPath() string {
+	return filepath.Join(m.config.StateDir, themeLockFile)
+}
+
+// activeLock returns the lock in place, removing one that has expired
+func (m *Manager) activeLock() (themeLock, bool) {
+	var l themeLock
+	data, err := os.ReadFile(m.themeLockPath())
+	if err != nil || json.Unmarshal(data, &l) != nil {
+		return l, false
+	}
+	if !l.Until.IsZero() && time.Now().After(l.Until) {
+		os.Remove(m.themeLockPath())
+		return l, false
+	}
+	return l, true
+}
+
+// lockedError refuses a theme change while the lock is on
+func lockedError(l themeLock) error {
+	return ui.WithHints(fmt.Errorf("theme changes are locked %s", l.describe()),
+		"Apply a theme anyway with: alacritty-colors apply <theme> --force",
+		"Remove the lock with: alacritty-colors unlock")
+}
+
+// checkLock fails while theme changes are locked, unless forced
+func (m *Manager) checkLock() error {
+	if l, locked := m.activeLock(); locked && !m.force {
+		return lockedError(l)
+	}
+	return nil
+}
+
+// parseLockUntil reads --until as a clock time, the next time it comes
+// around, or as a duration from now
+func parseLockUntil(until string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(until); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("--until must be in the future")
+		}
+		return now.Add(d), nil
+	}
+	minute, err := minuteOfDay(until)
+	if err != nil {
+		return time.Time{}, ui.WithHints(fmt.Errorf("invalid --until %q", until),
+			"Use a time like 18:00 or a duration like 2h30m.")
+	}
+	t := time.Date(now.Year(), now.Month(), now.Day(), minute/60, minute%60, 0, 0, now.Location())
+	if !t.After(now) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// Lock keeps the applied theme until the given time, or until Unlock when
+// until is empty. The scheduler, the daemon, slideshows, random and other
+// theme changes are refused meanwhile; 'apply --force' still goes through.
+func (m *Manager) Lock(until string) error {
+	l := themeLock{LockedAt: time.Now(), Theme: m.GetCurrentTheme()}
+	if until != "" {
+		t, err := parseLockUntil(until, l.LockedAt)
+		if err != nil {
+			return err
+		}
+		l.Until = t
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.config.StateDir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(m.themeLockPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to lock theme: %w", err)
+	}
+
+	if l.Theme != "" {
+		ui.PrintSuccess("Locked theme '%s' %s", l.Theme, l.describe())
+	} else {
+		ui.PrintSuccess("Locked theme changes %s", l.describe())
+	}
+	ui.PrintInfo("The scheduler, daemon, slideshows and random won't change it; 'apply --force' still does")
+	return nil
+}
+
+// Unlock lets theme changes through again
+func (m *Manager) Unlock() error {
+	if _, locked := m.activeLock(); !locked {
+		ui.PrintInfo("Theme changes are not locked")
+		return nil
+	}
+	if err := os.Remove(m.themeLockPath()); err != nil {
+		return fmt.Errorf("failed to unlock theme: %w", err)
+	}
+	ui.PrintSuccess("Theme changes unlocked")
+	return nil
+}
+
+// printLockStatus shows the lock in 'status'
+func (m *Manager) printLockStatus() {
+	if l, locked := m.activeLock(); locked {
+		ui.PrintKeyValue("Locked", l.describe())
+	}
+}
@@ -8,12 +8,16 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/vitruves/alacritty-colors/internal/config"
 	"github.com/vitruves/alacritty-colors/internal/downloader"
+	"github.com/vitruves/alacritty-colors/internal/fonts"
+	"github.com/vitruves/alacritty-colors/internal/i18n"
 	"github.com/vitruves/alacritty-colors/internal/ui"
 )
 
@@ -28,6 +32,14 @@ type ApplyOptions struct {
 	Blur       float64
 	FontSize   float64
 	FontFamily string
+	Reset      ResetOptions
+	Transform  ColorTransform
+	// RevertAfter puts the previous theme back unless the new one is kept
+	// within this time
+	RevertAfter time.Duration
+	// Transition fades from the current colors to the theme's over this
+	// time
+	Transition time.Duration
 }
 
 type ListOptions struct {
@@ -35,15 +47,36 @@ type ListOptions struct {
 	ShowColors bool
 	DarkOnly   bool
 	LightOnly  bool
+	Sort       string
+	Collection string
+	// New and Updated keep the themes added or changed by the latest update
+	New     bool
+	Updated bool
+	// MinContrast drops themes whose foreground/background contrast ratio
+	// is lower
+	MinContrast float64
+	// MinScore drops themes with a lower quality score
+	MinScore int
 }
 
 type RandomOptions struct {
-	DarkOnly  bool
-	LightOnly bool
-	WithFont  bool
-	Opacity   float64
-	Blur      float64
-	Scheme    string
+	DarkOnly    bool
+	LightOnly   bool
+	WithFont    bool
+	Opacity     float64
+	Blur        float64
+	Scheme      string
+	Exclude     []string
+	AllowRepeat bool
+	Collection  string
+	Tags        []string
+	// Schedule "daily" derives the pick from the date instead of chance
+	Schedule string
+	// Seed namespaces the daily pick; empty uses random.seed from settings
+	Seed            string
+	PreviewTomorrow bool
+	// MinScore skips themes with a lower quality score
+	MinScore int
 }
 
 type GenerateOptions struct {
@@ -55,16 +88,32 @@ type GenerateOptions struct {
 	WithFont   bool
 	Opacity    float64
 	Blur       float64
+	// FromScreenshot reads the colors from an image instead of a scheme,
+	// the way ScreenshotMode says
+	FromScreenshot string
+	ScreenshotMode string
+	// Date picks the palette of the seasonal scheme; zero is today
+	Date time.Time
 }
 
 type SearchOptions struct {
 	Format     string
 	ShowColors bool
+	// Color finds themes with a color within Tolerance of it, optionally
+	// only in Slot
+	Color     string
+	Tolerance float64
+	Slot      string
 }
 
 type PreviewOptions struct {
 	AutoApply bool
 	ShowHex   bool
+	// Showcase draws color ramps, text styles and sample UIs in the theme
+	Showcase bool
+	// OSC previews with escape sequences instead of writing current.toml,
+	// for terminals that don't reload it
+	OSC bool
 }
 
 type SlideshowOptions struct {
@@ -74,25 +123,60 @@ type SlideshowOptions struct {
 	Randomize  bool
 	Loop       bool
 	Categories []string
+	Exclude    []string
+	Collection string
+	// Tournament pits the themes against each other in pairs instead of
+	// cycling through them
+	Tournament bool
 }
 
 type BackupOptions struct {
 	Name        string
 	Description string
+	// Slot saves to a named slot, replacing its previous contents
+	Slot string
 }
 
 type RestoreOptions struct {
 	Interactive bool
+	// ConfigOnly restores alacritty.toml but leaves current.toml alone
+	ConfigOnly bool
+	// Nth picks the nth most recent backup, 1 being the latest
+	Nth int
+	// Slot restores a named slot saved with 'backup --slot'
+	Slot string
 }
 
 type UpdateOptions struct {
-	Force bool
-	Check bool
+	Force              bool
+	Check              bool
+	Incremental        bool
+	Prune              string
+	InsecureSkipVerify bool
+	// Source refreshes only the named source, "official" or one of the
+	// configured [[sources]]
+	Source string
+}
+
+type InitOptions struct {
+	Offline            bool
+	InsecureSkipVerify bool
+	// SkipDownload only wires up the config, installing no themes
+	SkipDownload bool
 }
 
 type Manager struct {
 	config  *config.Config
 	verbose bool
+	// force lets apply overwrite a current.toml edited by hand
+	force bool
+	// transform changes the colors of the next theme written to current.toml
+	transform ColorTransform
+	// transition fades current.toml into the next theme over this time
+	// instead of switching at once
+	transition time.Duration
+	// source tags the history entry of an automatic switch
+	source string
 }
 
 type ThemeInfo struct {
@@ -100,13 +184,26 @@ type ThemeInfo struct {
 	FilePath    string
 	Description string
 	Author      string
-	Tags        []string
-	Colors      map[string]string
-	IsDark      bool
-	IsLight     bool
-}
-
-// Font definitions for automatic pairing
+	// Title, Variant, Upstream and Source come from the file's comments and
+	// the metadata index built during downloads
+	Title      string
+	Variant    string
+	Upstream   string
+	Source     string
+	Tags       []string
+	Colors     map[string]string
+	IsDark     bool
+	IsLight    bool
+	Popularity downloader.Popularity
+	// Contrast is the foreground/background ratio, set when listing by it
+	Contrast float64
+	// Score is the palette's quality score, set when listing or picking
+	// by it
+	Score int
+}
+
+// Built-in font pairings, keyed by a color scheme found in theme names.
+// Entries in the [font_pairings] settings table take precedence.
 var ThemeFonts = map[string][]string{
 	"cyberpunk": {"JetBrains Mono", "Fira Code", "Source Code Pro"},
 	"dracula":   {"Fira Code", "JetBrains Mono", "Cascadia Code"},
@@ -124,6 +221,11 @@ func (m *Manager) SetVerbose(verbose bool) {
 	m.verbose = verbose
 }
 
+// SetForce lets apply discard manual edits to current.toml
+func (m *Manager) SetForce(force bool) {
+	m.force = force
+}
+
 func (m *Manager) logVerbose(format string, args ...interface{}) {
 	if m.verbose {
 		ui.PrintVerbose(format, args...)
@@ -131,29 +233,48 @@ func (m *Manager) logVerbose(format string, args ...interface{}) {
 }
 
 func (m *Manager) Initialize() error {
+	return m.InitializeWithOptions(&InitOptions{})
+}
+
+func (m *Manager) InitializeWithOptions(opts *InitOptions) error {
+	report := newInitReport(6)
+
 	ui.PrintSubHeader("Setting up configuration")
 
 	// Create config file if it doesn't exist
-	if _, err := os.Stat(m.config.ConfigFile); os.IsNotExist(err) {
-		ui.PrintInfo("Creating default alacritty.toml")
+	err := report.step("Alacritty config", func() (string, error) {
+		if _, err := os.Stat(m.config.ConfigFile); !os.IsNotExist(err) {
+			return "kept", nil
+		}
 		if err := m.createDefaultConfig(); err != nil {
-			return fmt.Errorf("failed to create config file: %w", err)
+			return "", fmt.Errorf("failed to create config file: %w", err)
 		}
-		ui.PrintSuccess("Created alacritty.toml")
+		return "created", nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Check if import line exists
-	if !m.hasImportLine() {
-		ui.PrintInfo("Adding theme import line")
+	err = report.step("Theme import line", func() (string, error) {
+		if m.hasImportLine() {
+			return "found", nil
+		}
 		if err := m.addImportLine(); err != nil {
-			return fmt.Errorf("failed to add import line: %w", err)
+			return "", fmt.Errorf("failed to add import line: %w", err)
 		}
-		ui.PrintSuccess("Added theme import line")
+		return "added", nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Create current.toml (empty initially)
-	currentThemePath := filepath.Join(m.config.ThemesDir, "current.toml")
-	if _, err := os.Stat(currentThemePath); os.IsNotExist(err) {
+	err = report.step("current.toml", func() (string, error) {
+		currentThemePath := filepath.Join(m.config.ThemesDir, "current.toml")
+		if _, err := os.Stat(currentThemePath); !os.IsNotExist(err) {
+			return "kept", nil
+		}
 		defaultTheme := `# No theme applied
 # Run 'alacritty-colors apply <theme-name>' to apply a theme
 
@@ -162,33 +283,77 @@ background = "#1e1e1e"
 foreground = "#ffffff"
 `
 		if err := os.WriteFile(currentThemePath, []byte(defaultTheme), 0644); err != nil {
-			return fmt.Errorf("failed to create current theme file: %w", err)
+			return "", fmt.Errorf("failed to create current theme file: %w", err)
 		}
+		return "created", nil
+	})
+	if err != nil {
+		return err
 	}
 
-	// Download themes
-	ui.PrintSubHeader("Downloading themes")
-	dl := downloader.New(m.config.ThemesDir)
-	count, err := dl.DownloadOfficialThemes()
+	// Install the embedded themes first so init works without network
+	err = report.step("Bundled themes", func() (string, error) {
+		if opts.SkipDownload {
+			return "skipped (--skip-download)", nil
+		}
+		dl := downloader.New(m.config.ThemesDir, m.config.StateDir)
+		dl.SetAnalyzer(m.analyzeThemeFile)
+		bundled, err := dl.InstallBundledThemes()
+		if err != nil {
+			return "", fmt.Errorf("failed to install bundled themes: %w", err)
+		}
+		return fmt.Sprintf("%d installed", bundled), nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to download themes: %w", err)
+		return err
 	}
 
-	ui.PrintSuccess("Downloaded %d themes", count)
+	// Download themes; a failure leaves the bundled themes in place
+	report.step("Theme download", func() (string, error) {
+		switch {
+		case opts.SkipDownload:
+			return "skipped (--skip-download)", nil
+		case opts.Offline:
+			return "skipped (--offline)", nil
+		}
+		count, err := m.downloadThemes(opts.InsecureSkipVerify)
+		if err != nil {
+			ui.PrintWarning("Failed to download themes: %v", err)
+			return "failed, using bundled themes", nil
+		}
+		return fmt.Sprintf("%d downloaded", count), nil
+	})
+
+	report.step("Theme index", func() (string, error) {
+		themes, err := m.getThemeInfos()
+		if err != nil {
+			return "not built", nil
+		}
+		return fmt.Sprintf("%d themes", len(themes)), nil
+	})
+
 	ui.PrintSubHeader("Configuration complete")
+	report.print()
 	ui.PrintInfo("Config file: %s", m.config.ConfigFile)
 	ui.PrintInfo("Themes directory: %s", m.config.ThemesDir)
 	ui.PrintInfo("Backups directory: %s", m.config.BackupDir)
+	switch {
+	case opts.SkipDownload:
+		ui.PrintInfo("Run 'alacritty-colors update' to download themes")
+	case opts.Offline:
+		ui.PrintInfo("Run 'alacritty-colors update' once online to get the full collection")
+	}
 
 	return nil
 }
 
 func (m *Manager) createDefaultConfig() error {
+	importPath := m.themeImportPath()
 	defaultConfig := `# Alacritty Configuration
-# Managed by alacritty-colors - theme imported from themes/current.toml
+# Managed by alacritty-colors - theme imported from ` + importPath + `
 
 [general]
-import = ["themes/current.toml"]
+import = [` + strconv.Quote(importPath) + `]
 
 # Personal configuration below - will be preserved when switching themes
 
@@ -235,30 +400,37 @@ save_to_clipboard = true
 }
 
 func (m *Manager) hasImportLine() bool {
-	file, err := os.Open(m.config.ConfigFile)
+	text, err := readConfigText(m.config.ConfigFile)
 	if err != nil {
 		return false
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if strings.Contains(line, "themes/current.toml") {
+	for _, line := range text.Lines {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, "themes/current.toml") || strings.Contains(line, m.themeImportPath()) {
 			return true
 		}
 	}
 	return false
 }
 
+// themeImportPath returns how the config file refers to current.toml: relative
+// when the themes directory sits next to it, absolute otherwise
+func (m *Manager) themeImportPath() string {
+	if filepath.Clean(m.config.ThemesDir) == filepath.Join(filepath.Dir(m.deployedConfigFile()), "themes") {
+		return "themes/current.toml"
+	}
+	return filepath.ToSlash(filepath.Join(m.config.ThemesDir, "current.toml"))
+}
+
 func (m *Manager) addImportLine() error {
-	data, err := os.ReadFile(m.config.ConfigFile)
+	text, err := readConfigText(m.config.ConfigFile)
 	if err != nil {
 		return err
 	}
 
 	// Add the import line at the beginning after any initial comments
-	lines := strings.Split(string(data), "\n")
+	lines := text.Lines
 	var newLines []string
 
 	// Keep initial comments
@@ -271,44 +443,102 @@ func (m *Manager) addImportLine() error {
 	// Add [general] section and import line
 	newLines = append(newLines, "")
 	newLines = append(newLines, "[general]")
-	newLines = append(newLines, "import = [\"themes/current.toml\"]")
+	newLines = append(newLines, "import = ["+strconv.Quote(m.themeImportPath())+"]")
 	newLines = append(newLines, "")
 
 	// Add rest of config
 	newLines = append(newLines, lines[i:]...)
 
-	return os.WriteFile(m.config.ConfigFile, []byte(strings.Join(newLines, "\n")), 0644)
+	return text.write(m.config.ConfigFile, newLines)
 }
 
 func (m *Manager) ApplyTheme(themeName string) error {
+	return m.applyTheme(themeName, true)
+}
+
+// findTheme looks a theme up by file name first and falls back to a
+// case-insensitive match
+func (m *Manager) findTheme(themeName string) (*ThemeInfo, error) {
+	if themeName != "current" && !strings.ContainsAny(themeName, `/\`) {
+		path := m.config.GetThemePath(themeName)
+		if _, err := os.Stat(path); err == nil {
+			return &ThemeInfo{Name: themeName, FilePath: path}, nil
+		}
+	}
+
 	themes, err := m.getThemeInfos()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var selectedTheme *ThemeInfo
 	for _, theme := range themes {
 		if strings.EqualFold(theme.Name, themeName) {
-			selectedTheme = &theme
-			break
+			return &theme, nil
 		}
 	}
 
-	if selectedTheme == nil {
-		return fmt.Errorf("theme '%s' not found", themeName)
+	return nil, themeNotFound(themeName, themes)
+}
+
+// themeNotFound reports a missing theme with the closest installed names
+func themeNotFound(themeName string, themes []ThemeInfo) error {
+	err := i18n.Errorf("theme '%s' not found", themeName)
+	if len(themes) == 0 {
+		return ui.WithHints(err, "No themes are installed. Run 'alacritty-colors init' to download them.")
+	}
+
+	names := make([]string, len(themes))
+	for i, theme := range themes {
+		names[i] = theme.Name
+	}
+	return ui.WithHints(err,
+		ui.DidYouMean(themeName, names),
+		i18n.Sprintf("Search installed themes with: %s", "alacritty-colors search "+themeName))
+}
+
+func (m *Manager) applyTheme(themeName string, backup bool) error {
+	selectedTheme, err := m.findTheme(themeName)
+	if err != nil {
+		return err
+	}
+	if err := m.checkLock(); err != nil {
+		return err
+	}
+
+	if state, drifted := m.themeDrift(); drifted && !m.force {
+		return m.driftError(state)
 	}
 
 	ui.PrintInfo("Applying theme: %s", selectedTheme.Name)
 
+	previous := m.config.CurrentTheme
+	if err := m.runHooks("pre-apply", m.config.Hooks.PreApply, selectedTheme.Name, previous); err != nil {
+		return err
+	}
+
 	// Create backup
-	if err := m.CreateBackup(); err != nil {
-		ui.PrintWarning("Failed to create backup: %v", err)
+	if backup && m.config.Backup.OnApply {
+		if err := m.CreateBackup(); err != nil {
+			ui.PrintWarning("Failed to create backup: %v", err)
+		}
+	}
+
+	unlock, err := m.lockCurrent()
+	if err != nil {
+		return i18n.Errorf("failed to apply theme: %w", err)
+	}
+
+	if m.transition > 0 {
+		if err := m.fadeCurrent(selectedTheme.FilePath); err != nil {
+			m.logVerbose("Skipping the transition: %v", err)
+		}
 	}
 
 	// Copy theme to current.toml
-	currentThemePath := filepath.Join(m.config.ThemesDir, "current.toml")
-	if err := m.copyFile(selectedTheme.FilePath, currentThemePath); err != nil {
-		return fmt.Errorf("failed to apply theme: %w", err)
+	currentThemePath := m.currentThemeFile()
+	if err := m.installCurrent(selectedTheme.FilePath); err != nil {
+		unlock()
+		return i18n.Errorf("failed to apply theme: %w", err)
 	}
 
 	// Update config to track current theme
@@ -316,7 +546,29 @@ func (m *Manager) ApplyTheme(themeName string) error {
 		ui.PrintWarning("Failed to update theme tracking: %v", err)
 	}
 
+	if err := m.writePromptCache(selectedTheme.Name, currentThemePath); err != nil {
+		m.logVerbose("Failed to update prompt cache: %v", err)
+	}
+	if err := m.recordHistory(selectedTheme.Name); err != nil {
+		m.logVerbose("Failed to record history: %v", err)
+	}
+	if err := m.recordApplied(selectedTheme.Name); err != nil {
+		m.logVerbose("Failed to record applied theme: %v", err)
+	}
+	unlock()
+
+	m.syncTargets(selectedTheme.Name)
+	if err := m.DeployDotfiles(); err != nil {
+		ui.PrintWarning("%v", err)
+	}
+
 	ui.PrintSuccess("Applied theme '%s'", selectedTheme.Name)
+	m.warnLiveReloadOff()
+
+	if err := m.runHooks("post-apply", m.config.Hooks.PostApply, selectedTheme.Name, previous); err != nil {
+		ui.PrintWarning("%v", err)
+	}
+	m.notifyApplied(selectedTheme.Name, previous, currentThemePath)
 	return nil
 }
 
@@ -326,6 +578,7 @@ func (m *Manager) RandomTheme() error {
 		return err
 	}
 
+	themes = m.excludeThemes(themes, nil)
 	if len(themes) == 0 {
 		return fmt.Errorf("no themes available")
 	}
@@ -400,78 +653,53 @@ func (m *Manager) PreviewTheme(themeName string) error {
 	opts := &PreviewOptions{
 		AutoApply: false,
 		ShowHex:   false,
+		Showcase:  true,
 	}
 	return m.PreviewThemeWithOptions(themeName, opts)
 }
 
 func (m *Manager) CreateBackup() error {
 	timestamp := time.Now().Format("2006-01-02_15-04-05")
-	backupFile := filepath.Join(m.config.BackupDir, fmt.Sprintf("alacritty_%s.toml", timestamp))
-
-	src, err := os.Open(m.config.ConfigFile)
-	if err != nil {
-		return fmt.Errorf("failed to open config file: %w", err)
-	}
-	defer src.Close()
-
-	dst, err := os.Create(backupFile)
-	if err != nil {
-		return fmt.Errorf("failed to create backup file: %w", err)
-	}
-	defer dst.Close()
+	backupFile := filepath.Join(m.config.BackupDir, fmt.Sprintf("%s%s.toml", autoBackupPrefix, timestamp))
 
-	_, err = io.Copy(dst, src)
-	if err != nil {
-		return fmt.Errorf("failed to copy config: %w", err)
+	if err := m.writeBackup(backupFile, ""); err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
 	}
 
 	ui.PrintSuccess("Backup created: %s", filepath.Base(backupFile))
+	m.pruneBackups()
 	return nil
 }
 
-func (m *Manager) RestoreBackup(backupFile string) error {
-	if backupFile == "" {
-		// List available backups and let user choose
-		return m.interactiveRestore()
-	}
-
-	// If backupFile is just a filename, look in backup directory
-	if !filepath.IsAbs(backupFile) {
-		backupFile = filepath.Join(m.config.BackupDir, backupFile)
-	}
-
-	if _, err := os.Stat(backupFile); os.IsNotExist(err) {
-		return fmt.Errorf("backup file not found: %s", backupFile)
-	}
-
-	ui.PrintInfo("Restoring from backup: %s", filepath.Base(backupFile))
+// backupNotFound reports a missing backup with the closest existing names
+func (m *Manager) backupNotFound(backupFile string) error {
+	err := i18n.Errorf("backup file not found: %s", backupFile)
 
-	src, err := os.Open(backupFile)
-	if err != nil {
-		return fmt.Errorf("failed to open backup file: %w", err)
+	var names []string
+	if entries, readErr := os.ReadDir(m.config.BackupDir); readErr == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				names = append(names, entry.Name())
+			}
+		}
 	}
-	defer src.Close()
-
-	dst, err := os.Create(m.config.ConfigFile)
-	if err != nil {
-		return fmt.Errorf("failed to create config file: %w", err)
+	if len(names) == 0 {
+		return ui.WithHints(err, "No backups exist yet in "+m.config.BackupDir+". Create one with 'alacritty-colors backup'.")
 	}
-	defer dst.Close()
 
-	_, err = io.Copy(dst, src)
-	if err != nil {
-		return fmt.Errorf("failed to restore config: %w", err)
-	}
+	return ui.WithHints(err,
+		ui.DidYouMean(filepath.Base(backupFile), names),
+		"List backups with 'alacritty-colors restore --list', or run 'alacritty-colors restore' to pick one.")
+}
 
-	ui.PrintSuccess("Configuration restored from backup")
-	return nil
+func (m *Manager) RestoreBackup(backupFile string) error {
+	return m.RestoreBackupWithOptions(backupFile, &RestoreOptions{})
 }
 
 func (m *Manager) UpdateThemes() error {
 	ui.PrintSubHeader("Updating theme database")
 
-	dl := downloader.New(m.config.ThemesDir)
-	count, err := dl.DownloadOfficialThemes()
+	count, err := m.downloadThemes(false)
 	if err != nil {
 		return fmt.Errorf("failed to update themes: %w", err)
 	}
@@ -480,7 +708,12 @@ func (m *Manager) UpdateThemes() error {
 	return nil
 }
 
+// GetCurrentTheme returns the applied theme, read from the link target when
+// current.toml is a symlink
 func (m *Manager) GetCurrentTheme() string {
+	if name, ok := m.linkedTheme(); ok {
+		return name
+	}
 	return m.config.CurrentTheme
 }
 
@@ -490,6 +723,12 @@ func (m *Manager) ShowCurrentTheme() error {
 		ui.PrintInfo("No theme currently applied")
 	} else {
 		ui.PrintSuccess("Current theme: %s", currentTheme)
+		if info, err := m.themeDetails(currentTheme); err == nil {
+			m.printThemeMetadata(info)
+		}
+		ui.PrintKeyValue("current.toml", m.describeCurrentFile())
+		m.printLockStatus()
+		m.warnDrift()
 	}
 	return nil
 }
@@ -501,6 +740,13 @@ func (m *Manager) copyFile(src, dst string) error {
 	}
 	defer sourceFile.Close()
 
+	// Replace a symlink rather than writing through it into a theme file
+	if info, err := os.Lstat(dst); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(dst); err != nil {
+			return err
+		}
+	}
+
 	destFile, err := os.Create(dst)
 	if err != nil {
 		return err
@@ -517,6 +763,8 @@ func (m *Manager) getThemeInfos() ([]ThemeInfo, error) {
 		return nil, err
 	}
 
+	index := downloader.New(m.config.ThemesDir, m.config.StateDir).LoadMetadata()
+
 	var themes []ThemeInfo
 	for _, file := range files {
 		// Skip current.toml as it's not a real theme
@@ -529,6 +777,7 @@ func (m *Manager) getThemeInfos() ([]ThemeInfo, error) {
 			ui.PrintWarning("Failed to parse theme %s: %v", filepath.Base(file), err)
 			continue
 		}
+		m.enrichTheme(&info, index[info.Name])
 		themes = append(themes, info)
 	}
 
@@ -540,20 +789,33 @@ func (m *Manager) getThemeInfos() ([]ThemeInfo, error) {
 	return themes, nil
 }
 
+// getThemeFiles lists theme files across the themes directories. A theme
+// found in an earlier directory hides one of the same name in a later one.
 func (m *Manager) getThemeFiles() ([]string, error) {
 	if _, err := os.Stat(m.config.ThemesDir); os.IsNotExist(err) {
-		return nil, fmt.Errorf("themes directory not found: %s", m.config.ThemesDir)
-	}
-
-	files, err := os.ReadDir(m.config.ThemesDir)
-	if err != nil {
-		return nil, err
+		return nil, ui.WithHints(i18n.Errorf("themes directory not found: %s", m.config.ThemesDir),
+			"Run 'alacritty-colors init' to create it and download themes,",
+			"or point to an existing one with 'alacritty-colors config set-path --themes-dir <dir>'.")
 	}
 
+	seen := make(map[string]bool)
 	var themes []string
-	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".toml") {
-			themes = append(themes, filepath.Join(m.config.ThemesDir, file.Name()))
+	for i, dir := range m.config.ThemeDirs() {
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			if i > 0 && os.IsNotExist(err) {
+				m.logVerbose("Skipping missing themes directory: %s", dir)
+				continue
+			}
+			return nil, err
+		}
+
+		for _, file := range files {
+			if file.IsDir() || !strings.HasSuffix(file.Name(), ".toml") || seen[file.Name()] {
+				continue
+			}
+			seen[file.Name()] = true
+			themes = append(themes, filepath.Join(dir, file.Name()))
 		}
 	}
 
@@ -577,6 +839,7 @@ func (m *Manager) parseThemeFile(filePath string) (ThemeInfo, error) {
 	scanner := bufio.NewScanner(file)
 	inColors := false
 	currentSection := ""
+	var meta downloader.ThemeMetadata
 
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -584,10 +847,10 @@ func (m *Manager) parseThemeFile(filePath string) (ThemeInfo, error) {
 		// Skip comments and empty lines
 		if strings.HasPrefix(line, "#") || line == "" {
 			// Extract metadata from comments
-			if strings.HasPrefix(line, "# Author:") {
-				info.Author = strings.TrimSpace(strings.TrimPrefix(line, "# Author:"))
-			} else if strings.HasPrefix(line, "# Description:") {
+			if strings.HasPrefix(line, "# Description:") {
 				info.Description = strings.TrimSpace(strings.TrimPrefix(line, "# Description:"))
+			} else if line != "" {
+				downloader.ParseHeaderComment(&meta, line)
 			}
 			continue
 		}
@@ -629,6 +892,11 @@ func (m *Manager) parseThemeFile(filePath string) (ThemeInfo, error) {
 		}
 	}
 
+	info.Author = meta.Author
+	info.Title = meta.Title
+	info.Variant = meta.Variant
+	info.Upstream = meta.Upstream
+
 	return info, scanner.Err()
 }
 
@@ -656,7 +924,7 @@ func (m *Manager) printThemeGrid(themes []ThemeInfo) {
 		for i, theme := range grouped[key] {
 			names[i] = theme.Name
 		}
-		ui.PrintThemeGrid(names, 3)
+		ui.PrintThemeGrid(names, 0)
 	}
 }
 
@@ -664,9 +932,15 @@ func (m *Manager) printThemeList(themes []ThemeInfo) {
 	ui.PrintHeader(fmt.Sprintf("Available Themes (%d)", len(themes)))
 
 	for _, theme := range themes {
-		description := theme.Description
-		if description == "" && theme.Author != "" {
-			description = fmt.Sprintf("by %s", theme.Author)
+		description := themeSummary(theme)
+		if badge := popularityBadge(theme.Popularity); badge != "" {
+			description = strings.TrimSpace(badge + "  " + description)
+		}
+		if badge := contrastBadge(theme.Contrast); badge != "" {
+			description = strings.TrimSpace(badge + "  " + description)
+		}
+		if badge := scoreBadge(theme.Score); badge != "" {
+			description = strings.TrimSpace(badge + "  " + description)
 		}
 		ui.PrintTheme(theme.Name, description)
 	}
@@ -691,17 +965,10 @@ func (m *Manager) printThemeJSON(themes []ThemeInfo) {
 	fmt.Println("]")
 }
 
-func (m *Manager) interactiveRestore() error {
-	files, err := os.ReadDir(m.config.BackupDir)
+func (m *Manager) interactiveRestore(opts *RestoreOptions) error {
+	backups, err := m.backupFiles()
 	if err != nil {
-		return fmt.Errorf("failed to read backup directory: %w", err)
-	}
-
-	var backups []string
-	for _, file := range files {
-		if strings.HasSuffix(file.Name(), ".toml") {
-			backups = append(backups, file.Name())
-		}
+		return err
 	}
 
 	if len(backups) == 0 {
@@ -709,36 +976,62 @@ func (m *Manager) interactiveRestore() error {
 		return nil
 	}
 
-	ui.PrintHeader("Available Backups")
+	// Newest first, with the theme each one holds
+	options := make([]string, len(backups))
 	for i, backup := range backups {
-		ui.PrintInfo("%d. %s", i+1, backup)
-	}
-
-	fmt.Print("\nSelect backup to restore (number): ")
-	var choice int
-	if _, err := fmt.Scanln(&choice); err != nil {
-		return fmt.Errorf("invalid input")
+		options[i] = backup
+		info := readBackupInfo(filepath.Join(m.config.BackupDir, backup))
+		var details []string
+		for _, detail := range []string{info["Theme"], info["Description"]} {
+			if detail != "" {
+				details = append(details, detail)
+			}
+		}
+		if len(details) > 0 {
+			options[i] += "  (" + strings.Join(details, ", ") + ")"
+		}
 	}
 
-	if choice < 1 || choice > len(backups) {
-		return fmt.Errorf("invalid selection")
+	choice := ui.PromptSelect("Select backup to restore", options)
+	if choice < 0 {
+		ui.PrintInfo("Restore cancelled")
+		return nil
 	}
 
-	selectedBackup := backups[choice-1]
+	selectedBackup := backups[choice]
 	if !ui.PromptConfirm(fmt.Sprintf("Restore from '%s'?", selectedBackup)) {
 		ui.PrintInfo("Restore cancelled")
 		return nil
 	}
 
-	return m.RestoreBackup(selectedBackup)
+	return m.restoreBackup(selectedBackup, opts)
 }
 
 func (m *Manager) ApplyThemeWithOptions(themeName string, opts *ApplyOptions) error {
 	m.logVerbose("Applying theme %s with options", themeName)
 
+	if opts != nil && opts.RevertAfter > 0 {
+		return m.applyWithRevert(themeName, opts)
+	}
+
+	if opts != nil && opts.Transform.Any() {
+		if err := opts.Transform.Validate(); err != nil {
+			return err
+		}
+		m.transform = opts.Transform
+		defer func() { m.transform = ColorTransform{} }()
+	}
+	if opts != nil && opts.Transition > 0 {
+		m.transition = opts.Transition
+		defer func() { m.transition = 0 }()
+	}
+
 	if err := m.ApplyTheme(themeName); err != nil {
 		return err
 	}
+	if m.transform.Any() {
+		ui.PrintInfo("Colors adjusted: %s (the theme file is unchanged)", m.transform)
+	}
 
 	// Apply additional options
 	if opts != nil {
@@ -753,6 +1046,12 @@ func (m *Manager) ApplyThemeWithOptions(themeName string, opts *ApplyOptions) er
 				ui.PrintWarning("Failed to apply visual effects: %v", err)
 			}
 		}
+
+		if opts.Reset.Any() {
+			if err := m.ResetEffects(opts.Reset); err != nil {
+				ui.PrintWarning("Failed to reset settings: %v", err)
+			}
+		}
 	}
 
 	return nil
@@ -763,6 +1062,15 @@ func (m *Manager) ListThemesWithOptions(opts *ListOptions) error {
 	if err != nil {
 		return err
 	}
+	if opts.Format != "json" {
+		m.warnDrift()
+	}
+	if themes, err = m.filterCollection(themes, opts.Collection); err != nil {
+		return err
+	}
+	if themes, err = m.filterArrivals(themes, opts.New, opts.Updated); err != nil {
+		return err
+	}
 
 	// Apply filters
 	if opts.DarkOnly {
@@ -771,9 +1079,30 @@ func (m *Manager) ListThemesWithOptions(opts *ListOptions) error {
 		themes = m.filterLightThemes(themes)
 	}
 
+	if opts.Sort == SortContrast || opts.MinContrast > 0 {
+		themes = m.filterContrast(m.withContrast(themes), opts.MinContrast)
+	}
+	if opts.Sort == SortScore || opts.MinScore > 0 {
+		themes = m.filterScore(m.withScore(themes), opts.MinScore)
+	}
+
 	m.logVerbose("Found %d themes after filtering", len(themes))
 
-	switch opts.Format {
+	if opts.Sort == SortPopular {
+		themes = m.withPopularity(themes)
+	}
+	if err := sortThemes(themes, opts.Sort); err != nil {
+		return err
+	}
+
+	// The grid groups themes alphabetically, so show a ranked list instead
+	format := opts.Format
+	ranked := opts.Sort == SortPopular || opts.Sort == SortContrast || opts.Sort == SortScore
+	if ranked && (format == "" || format == "grid") {
+		format = "list"
+	}
+
+	switch format {
 	case "grid":
 		m.printThemeGrid(themes)
 	case "list":
@@ -792,9 +1121,27 @@ func (m *Manager) ListThemesWithOptions(opts *ListOptions) error {
 func (m *Manager) RandomThemeWithOptions(opts *RandomOptions) error {
 	m.logVerbose("Selecting random theme with constraints")
 
-	// If scheme is specified, generate new theme instead
+	switch {
+	case opts.Schedule != "" && opts.Schedule != "daily":
+		return ui.WithHints(fmt.Errorf("unknown schedule: %s", opts.Schedule),
+			"The only schedule is daily: alacritty-colors random --schedule daily")
+	case opts.Schedule == "" && (opts.PreviewTomorrow || opts.Seed != ""):
+		return ui.WithHints(fmt.Errorf("--preview-tomorrow and --seed need a schedule"),
+			"Add --schedule daily.")
+	case opts.Schedule != "" && opts.Scheme != "":
+		return fmt.Errorf("--schedule picks an installed theme and can't be combined with --scheme")
+	}
+	if !opts.PreviewTomorrow {
+		if err := m.checkLock(); err != nil {
+			return err
+		}
+	}
+
+	// If scheme is specified, generate new theme instead. It is saved like
+	// 'generate' does by default, since it is applied by name.
 	if opts.Scheme != "" {
 		genOpts := &GenerateOptions{
+			Save:       true,
 			Scheme:     opts.Scheme,
 			DarkTheme:  opts.DarkOnly,
 			LightTheme: opts.LightOnly,
@@ -811,15 +1158,33 @@ func (m *Manager) RandomThemeWithOptions(opts *RandomOptions) error {
 	}
 
 	// Apply filters
+	if themes, err = m.filterCollection(themes, opts.Collection); err != nil {
+		return err
+	}
 	if opts.DarkOnly {
 		themes = m.filterDarkThemes(themes)
 	} else if opts.LightOnly {
 		themes = m.filterLightThemes(themes)
 	}
+	themes = m.excludeThemes(themes, opts.Exclude)
+	if themes, err = filterTags(themes, opts.Tags); err != nil {
+		return err
+	}
+	// Structurally broken themes are skipped unless the minimum is 0
+	if opts.MinScore > 0 {
+		themes = m.filterScore(m.withScore(themes), opts.MinScore)
+	}
 
 	if len(themes) == 0 {
 		return fmt.Errorf("no themes found matching criteria")
 	}
+	// The daily pick ignores recent history, which differs between machines
+	if opts.Schedule == "daily" {
+		return m.randomDaily(themes, opts)
+	}
+	if !opts.AllowRepeat {
+		themes = m.avoidRecent(themes)
+	}
 
 	// Select random theme
 	rand.Seed(time.Now().UnixNano())
@@ -837,6 +1202,13 @@ func (m *Manager) RandomThemeWithOptions(opts *RandomOptions) error {
 }
 
 func (m *Manager) GenerateThemeWithOptions(opts *GenerateOptions) error {
+	if opts.FromScreenshot != "" {
+		return m.generateFromScreenshot(opts)
+	}
+	if opts.Scheme == SeasonalScheme {
+		return m.generateSeasonal(opts)
+	}
+
 	colors, err := m.generateColorSchemeWithVariant(opts.Scheme, opts.DarkTheme, opts.LightTheme)
 	if err != nil {
 		return fmt.Errorf("failed to generate colors: %w", err)
@@ -853,10 +1225,16 @@ func (m *Manager) GenerateThemeWithOptions(opts *GenerateOptions) error {
 		name = generateRandomName(opts.Scheme + variant)
 	}
 
-	themeContent := m.createThemeContent(colors, opts.Scheme, name)
+	return m.saveGeneratedTheme(colors, opts.Scheme, name, opts)
+}
+
+// saveGeneratedTheme writes generated colors as a theme, applies it and
+// adds the requested font and window effects
+func (m *Manager) saveGeneratedTheme(colors map[string]string, scheme, name string, opts *GenerateOptions) error {
+	themeContent := m.createThemeContent(colors, scheme, name)
 
 	if opts.Save {
-		themeFile := filepath.Join(m.config.ThemesDir, name+".toml")
+		themeFile := filepath.Join(m.config.ThemeSaveDir(), name+".toml")
 		if err := os.WriteFile(themeFile, []byte(themeContent), 0644); err != nil {
 			return fmt.Errorf("failed to save theme: %w", err)
 		}
@@ -870,7 +1248,7 @@ func (m *Manager) GenerateThemeWithOptions(opts *GenerateOptions) error {
 
 	// Apply additional options
 	if opts.WithFont {
-		if err := m.applyThemeFont(opts.Scheme, "", 0); err != nil {
+		if err := m.applyThemeFont(scheme, "", 0); err != nil {
 			ui.PrintWarning("Failed to set font: %v", err)
 		}
 	}
@@ -902,6 +1280,26 @@ func (m *Manager) SearchThemesWithOptions(query string, opts *SearchOptions) err
 
 	m.logVerbose("Found %d themes matching '%s'", len(matches), query)
 
+	if opts.Color != "" {
+		target, err := ParseColor(opts.Color)
+		if err != nil {
+			return err
+		}
+		colorMatches := m.findByColor(matches, target, opts.Tolerance, opts.Slot)
+		if len(colorMatches) == 0 {
+			ui.PrintWarning("No themes have a color within %.1f of %s", opts.Tolerance, target.ToHex())
+			return nil
+		}
+		if opts.Format != "colors" {
+			m.printColorMatches(target, colorMatches)
+			return nil
+		}
+		matches = matches[:0]
+		for _, match := range colorMatches {
+			matches = append(matches, match.Theme)
+		}
+	}
+
 	if len(matches) == 0 {
 		ui.PrintWarning("No themes found matching '%s'", query)
 		return nil
@@ -910,12 +1308,10 @@ func (m *Manager) SearchThemesWithOptions(query string, opts *SearchOptions) err
 	switch opts.Format {
 	case "grid":
 		m.printThemeGrid(matches)
-	case "list":
-		m.printThemeList(matches)
 	case "colors":
 		m.printThemeColors(matches)
 	default:
-		m.printThemeList(matches)
+		m.printThemeList(m.withPopularity(matches))
 	}
 
 	return nil
@@ -936,28 +1332,44 @@ func (m *Manager) PreviewThemeWithOptions(themeName string, opts *PreviewOptions
 	}
 
 	if selectedTheme == nil {
-		return fmt.Errorf("theme '%s' not found", themeName)
+		return themeNotFound(themeName, themes)
 	}
 
 	// Save current theme state for restoration
 	currentThemePath := filepath.Join(m.config.ThemesDir, "current.toml")
 	backupThemePath := filepath.Join(m.config.ThemesDir, "preview_backup.toml")
 
-	// Create backup of current theme
-	if _, err := os.Stat(currentThemePath); err == nil {
-		if err := m.copyFile(currentThemePath, backupThemePath); err != nil {
-			return fmt.Errorf("failed to backup current theme: %w", err)
+	usingOSC := opts.OSC
+	if opts.OSC {
+		if err := m.sendOSCPreview(*selectedTheme); err != nil {
+			return err
+		}
+	} else {
+		// Create backup of current theme
+		if _, err := os.Stat(currentThemePath); err == nil {
+			if err := m.saveCurrent(backupThemePath); err != nil {
+				return fmt.Errorf("failed to backup current theme: %w", err)
+			}
 		}
-	}
 
-	// Temporarily apply the preview theme
-	m.logVerbose("Temporarily applying theme for preview: %s", selectedTheme.Name)
-	if err := m.copyFile(selectedTheme.FilePath, currentThemePath); err != nil {
-		return fmt.Errorf("failed to apply preview theme: %w", err)
+		// Temporarily apply the preview theme
+		m.logVerbose("Temporarily applying theme for preview: %s", selectedTheme.Name)
+		if err := m.showCurrent(selectedTheme.FilePath); err != nil {
+			return fmt.Errorf("failed to apply preview theme: %w", err)
+		}
 	}
 
 	// Show theme information
 	ui.PrintHeader(fmt.Sprintf("🎨 Theme Preview: %s", selectedTheme.Name))
+	if !opts.OSC {
+		if reasons := m.previewMismatch(normalizeHex(selectedTheme.Colors["background"])); len(reasons) > 0 {
+			usingOSC = m.offerOSCPreview(*selectedTheme, reasons, !opts.AutoApply)
+		}
+	}
+	if usingOSC {
+		// Hand the terminal its own colors back whatever the user decides
+		defer writeTTY(oscReset)
+	}
 	ui.PrintInfo("The theme is now temporarily applied to your terminal!")
 
 	if selectedTheme.Description != "" {
@@ -971,6 +1383,9 @@ func (m *Manager) PreviewThemeWithOptions(themeName string, opts *PreviewOptions
 	if opts.ShowHex {
 		m.printThemePreview(*selectedTheme, true)
 	}
+	if opts.Showcase {
+		printShowcase(*selectedTheme)
+	}
 
 	var keepTheme bool
 
@@ -988,9 +1403,15 @@ func (m *Manager) PreviewThemeWithOptions(themeName string, opts *PreviewOptions
 
 	if keepTheme {
 		// User wants to keep the theme - update tracking
+		if err := m.installCurrent(selectedTheme.FilePath); err != nil {
+			ui.PrintWarning("Failed to apply theme: %v", err)
+		}
 		if err := m.config.SetCurrentTheme(selectedTheme.Name); err != nil {
 			ui.PrintWarning("Failed to update theme tracking: %v", err)
 		}
+		if err := m.recordApplied(selectedTheme.Name); err != nil {
+			m.logVerbose("Failed to record applied theme: %v", err)
+		}
 		ui.PrintSuccess("Applied theme: %s", selectedTheme.Name)
 
 		// Clean up backup
@@ -999,12 +1420,13 @@ func (m *Manager) PreviewThemeWithOptions(themeName string, opts *PreviewOptions
 		// User wants to restore previous theme
 		ui.PrintInfo("Restoring previous theme...")
 
-		if _, err := os.Stat(backupThemePath); err == nil {
-			if err := m.copyFile(backupThemePath, currentThemePath); err != nil {
+		if opts.OSC {
+			ui.PrintSuccess("Previous theme restored")
+		} else if _, err := os.Lstat(backupThemePath); err == nil {
+			if err := m.restoreCurrent(backupThemePath); err != nil {
 				ui.PrintError("Failed to restore previous theme: %v", err)
 				return err
 			}
-			os.Remove(backupThemePath)
 			ui.PrintSuccess("Previous theme restored")
 		} else {
 			// No backup exists, create empty current.toml
@@ -1015,7 +1437,7 @@ func (m *Manager) PreviewThemeWithOptions(themeName string, opts *PreviewOptions
 background = "#1e1e1e"
 foreground = "#ffffff"
 `
-			os.WriteFile(currentThemePath, []byte(defaultTheme), 0644)
+			m.replaceCurrent([]byte(defaultTheme))
 			ui.PrintSuccess("Reset to default theme")
 		}
 	}
@@ -1024,6 +1446,10 @@ foreground = "#ffffff"
 }
 
 func (m *Manager) ThemeSlideshow(opts *SlideshowOptions) error {
+	if err := m.checkLock(); err != nil {
+		return err
+	}
+
 	// Import required packages for keyboard input
 	themes, err := m.getThemeInfos()
 	if err != nil {
@@ -1031,11 +1457,15 @@ func (m *Manager) ThemeSlideshow(opts *SlideshowOptions) error {
 	}
 
 	// Filter themes based on options
+	if themes, err = m.filterCollection(themes, opts.Collection); err != nil {
+		return err
+	}
 	if opts.DarkOnly {
 		themes = m.filterDarkThemes(themes)
 	} else if opts.LightOnly {
 		themes = m.filterLightThemes(themes)
 	}
+	themes = m.excludeThemes(themes, opts.Exclude)
 
 	if len(themes) == 0 {
 		return fmt.Errorf("no themes available for slideshow")
@@ -1050,13 +1480,17 @@ func (m *Manager) ThemeSlideshow(opts *SlideshowOptions) error {
 		}
 	}
 
+	if opts.Tournament {
+		return m.runTournament(themes)
+	}
+
 	// Save current theme for restoration
 	currentThemePath := filepath.Join(m.config.ThemesDir, "current.toml")
 	backupThemePath := filepath.Join(m.config.ThemesDir, "slideshow_backup.toml")
 
 	// Create backup of current theme
 	if _, err := os.Stat(currentThemePath); err == nil {
-		if err := m.copyFile(currentThemePath, backupThemePath); err != nil {
+		if err := m.saveCurrent(backupThemePath); err != nil {
 			return fmt.Errorf("failed to backup current theme: %w", err)
 		}
 	}
@@ -1087,13 +1521,24 @@ func (m *Manager) ThemeSlideshow(opts *SlideshowOptions) error {
 
 	for {
 		select {
-		case key := <-keyboardInput:
+		case key, ok := <-keyboardInput:
+			if !ok {
+				// Input ended; keep cycling on the timer
+				keyboardInput = nil
+				continue
+			}
 			switch key {
 			case ' ', '\r', '\n': // Space or Enter - select current theme
 				ui.PrintSuccess("Selected theme: %s", themes[currentIndex].Name)
+				if err := m.installCurrent(themes[currentIndex].FilePath); err != nil {
+					ui.PrintWarning("Failed to apply theme: %v", err)
+				}
 				if err := m.config.SetCurrentTheme(themes[currentIndex].Name); err != nil {
 					ui.PrintWarning("Failed to update theme tracking: %v", err)
 				}
+				if err := m.recordApplied(themes[currentIndex].Name); err != nil {
+					m.logVerbose("Failed to record applied theme: %v", err)
+				}
 				os.Remove(backupThemePath)
 				return nil
 
@@ -1161,9 +1606,7 @@ func (m *Manager) ThemeSlideshow(opts *SlideshowOptions) error {
 }
 
 func (m *Manager) applyThemeForSlideshow(theme ThemeInfo, current, total int) error {
-	currentThemePath := filepath.Join(m.config.ThemesDir, "current.toml")
-
-	if err := m.copyFile(theme.FilePath, currentThemePath); err != nil {
+	if err := m.showCurrent(theme.FilePath); err != nil {
 		return err
 	}
 
@@ -1225,7 +1668,8 @@ func (m *Manager) captureKeyboardInput(input chan rune) {
 		}
 	}
 
-	// Read characters
+	// Read characters until input ends, which closes the channel
+	defer close(input)
 	reader := bufio.NewReader(os.Stdin)
 	for {
 		char, _, err := reader.ReadRune()
@@ -1240,11 +1684,10 @@ func (m *Manager) captureKeyboardInput(input chan rune) {
 }
 
 func (m *Manager) restoreFromBackup(currentThemePath, backupThemePath string) error {
-	if _, err := os.Stat(backupThemePath); err == nil {
-		if err := m.copyFile(backupThemePath, currentThemePath); err != nil {
+	if _, err := os.Lstat(backupThemePath); err == nil {
+		if err := m.restoreCurrent(backupThemePath); err != nil {
 			return err
 		}
-		os.Remove(backupThemePath)
 	} else {
 		// No backup exists, create empty current.toml
 		defaultTheme := `# No theme applied
@@ -1254,115 +1697,378 @@ func (m *Manager) restoreFromBackup(currentThemePath, backupThemePath string) er
 background = "#1e1e1e"
 foreground = "#ffffff"
 `
-		os.WriteFile(currentThemePath, []byte(defaultTheme), 0644)
+		m.replaceCurrent([]byte(defaultTheme))
 	}
 	return nil
 }
 
 func (m *Manager) CreateBackupWithOptions(opts *BackupOptions) error {
+	if opts.Slot != "" {
+		return m.saveSlot(opts.Slot, opts.Description)
+	}
+
 	timestamp := time.Now().Format("2006-01-02_15-04-05")
 
 	var backupName string
 	if opts.Name != "" {
 		backupName = fmt.Sprintf("%s_%s.toml", opts.Name, timestamp)
 	} else {
-		backupName = fmt.Sprintf("alacritty_%s.toml", timestamp)
+		backupName = fmt.Sprintf("%s%s.toml", autoBackupPrefix, timestamp)
 	}
 
 	backupPath := filepath.Join(m.config.BackupDir, backupName)
 
 	m.logVerbose("Creating backup: %s", backupPath)
 
-	if err := m.copyFile(m.config.ConfigFile, backupPath); err != nil {
+	if err := m.writeBackup(backupPath, opts.Description); err != nil {
 		return fmt.Errorf("failed to create backup: %w", err)
 	}
 
 	ui.PrintSuccess("Backup created: %s", backupName)
-
-	if opts.Description != "" {
-		// Create a companion .info file with description
-		infoPath := strings.TrimSuffix(backupPath, ".toml") + ".info"
-		infoContent := fmt.Sprintf("Description: %s\nCreated: %s\n", opts.Description, time.Now().Format("2006-01-02 15:04:05"))
-		os.WriteFile(infoPath, []byte(infoContent), 0644)
-	}
-
+	m.pruneBackups()
 	return nil
 }
 
 func (m *Manager) RestoreBackupWithOptions(backupFile string, opts *RestoreOptions) error {
-	if opts.Interactive || backupFile == "" {
-		return m.interactiveRestore()
+	if opts.Slot != "" {
+		return m.restoreSlot(opts.Slot, opts.ConfigOnly)
+	}
+	if opts.Nth > 0 {
+		name, err := m.nthBackup(opts.Nth)
+		if err != nil {
+			return err
+		}
+		backupFile = name
+	} else if opts.Interactive || backupFile == "" {
+		return m.interactiveRestore(opts)
 	}
 
 	m.logVerbose("Restoring from backup: %s", backupFile)
-	return m.RestoreBackup(backupFile)
+	return m.restoreBackup(backupFile, opts)
+}
+
+// restoreBackup restores a backup given by file name, path or unique
+// name prefix
+func (m *Manager) restoreBackup(backupFile string, opts *RestoreOptions) error {
+	backupFile, err := m.resolveBackup(backupFile)
+	if err != nil {
+		return err
+	}
+
+	ui.PrintInfo("Restoring from backup: %s", filepath.Base(backupFile))
+	return m.restoreBackupFiles(backupFile, opts.ConfigOnly)
 }
 
 func (m *Manager) UpdateThemesWithOptions(opts *UpdateOptions) error {
 	if opts.Check {
 		ui.PrintInfo("Checking for theme updates...")
-		// This would check remote repository for updates
-		ui.PrintInfo("Update check functionality not yet implemented")
+
+		dl, err := m.newDownloader()
+		if err != nil {
+			return err
+		}
+		result, err := dl.SyncOfficialThemes(true)
+		if err != nil {
+			return fmt.Errorf("failed to check for updates: %w", err)
+		}
+
+		m.printSyncResult(result, true)
 		return nil
 	}
 
-	m.logVerbose("Updating themes (force: %v)", opts.Force)
+	m.logVerbose("Updating themes (force: %v, incremental: %v)", opts.Force, opts.Incremental)
+
+	before := m.loadManifest()
+
+	if opts.Source != "" {
+		if err := m.updateSource(opts); err != nil {
+			return err
+		}
+		after := m.loadManifest()
+		m.recordUpdateChanges(before, after)
+		return m.pruneThemes(m.removedThemes(before, after), opts.Prune)
+	}
 
-	dl := downloader.New(m.config.ThemesDir)
+	if opts.Incremental && !opts.Force {
+		if err := m.syncThemes(opts.InsecureSkipVerify); err != nil {
+			return err
+		}
+		after := m.loadManifest()
+		m.recordUpdateChanges(before, after)
+		return m.pruneThemes(m.removedThemes(before, after), opts.Prune)
+	}
 
 	if opts.Force {
 		// Remove existing themes before downloading
 		ui.PrintInfo("Force update: removing existing themes")
 		files, _ := filepath.Glob(filepath.Join(m.config.ThemesDir, "*.toml"))
+		var kept []string
 		for _, file := range files {
-			if !strings.HasSuffix(file, "current.toml") {
-				os.Remove(file)
+			if strings.HasSuffix(file, "current.toml") {
+				continue
+			}
+			if m.IsProtected(file) {
+				kept = append(kept, themeNameOf(file))
+				continue
 			}
+			os.Remove(file)
+		}
+		if len(kept) > 0 {
+			ui.PrintInfo("Keeping %d protected themes: %s", len(kept), strings.Join(kept, ", "))
 		}
 	}
 
-	count, err := dl.DownloadOfficialThemes()
+	count, err := m.downloadThemes(opts.InsecureSkipVerify)
 	if err != nil {
 		return fmt.Errorf("failed to update themes: %w", err)
 	}
 
 	ui.PrintSuccess("Updated %d themes", count)
+	after := m.loadManifest()
+	m.recordUpdateChanges(before, after)
+	return m.pruneThemes(m.removedThemes(before, after), opts.Prune)
+}
+
+// downloadThemes fetches the official collection followed by any configured sources
+func (m *Manager) downloadThemes(skipVerify bool) (int, error) {
+	dl, err := m.newDownloader()
+	if err != nil {
+		return 0, err
+	}
+	dl.SetSkipVerify(skipVerify)
+
+	official := downloader.OfficialSource
+	official.Mirrors = m.config.OfficialMirrors
+
+	if len(m.config.Sources) == 0 {
+		ui.PrintInfo("Downloading from official repository...")
+		return dl.DownloadSource(official)
+	}
+
+	ui.PrintInfo("Downloading from official repository and %d more sources...", len(m.config.Sources))
+	results := dl.DownloadSources(append([]downloader.Source{official}, m.extraSources()...))
+	count := m.countDownloaded(results[1:])
+	if results[0].Err != nil {
+		return 0, results[0].Err
+	}
+	return results[0].Count + count, nil
+}
+
+// updateSource refreshes a single source; with --incremental the official
+// collection is synced file by file
+func (m *Manager) updateSource(opts *UpdateOptions) error {
+	src := downloader.OfficialSource
+	src.Mirrors = m.config.OfficialMirrors
+	if opts.Source != src.Name {
+		names := []string{src.Name}
+		found := false
+		for _, extra := range m.extraSources() {
+			names = append(names, extra.Name)
+			if extra.Name == opts.Source {
+				src, found = extra, true
+			}
+		}
+		if !found {
+			return ui.WithHints(fmt.Errorf("no source named '%s'", opts.Source),
+				ui.DidYouMean(opts.Source, names),
+				"Sources: "+strings.Join(names, ", "))
+		}
+		if opts.Incremental {
+			return ui.WithHints(fmt.Errorf("--incremental only works with the official source"),
+				fmt.Sprintf("Run 'alacritty-colors update --source %s' to download it again", opts.Source))
+		}
+	}
+
+	dl, err := m.newDownloader()
+	if err != nil {
+		return err
+	}
+	dl.SetSkipVerify(opts.InsecureSkipVerify)
+
+	if src.Official {
+		if opts.Incremental {
+			ui.PrintInfo("Syncing with official repository...")
+			result, err := dl.SyncOfficialThemes(false)
+			if err != nil {
+				return fmt.Errorf("failed to sync themes: %w", err)
+			}
+			m.printSyncResult(result, false)
+			return nil
+		}
+		ui.PrintInfo("Downloading from official repository...")
+	}
+
+	count, err := dl.DownloadSource(src)
+	if err != nil {
+		return fmt.Errorf("failed to update source '%s': %w", src.Name, err)
+	}
+	ui.PrintSuccess("Updated %d themes from '%s'", count, src.Name)
+	return nil
+}
+
+// syncThemes updates the official collection file by file through the
+// GitHub API and reports exactly what changed
+func (m *Manager) syncThemes(skipVerify bool) error {
+	dl, err := m.newDownloader()
+	if err != nil {
+		return err
+	}
+	dl.SetSkipVerify(skipVerify)
+
+	ui.PrintInfo("Syncing with official repository...")
+	result, err := dl.SyncOfficialThemes(false)
+	if err != nil {
+		return fmt.Errorf("failed to sync themes: %w", err)
+	}
+	m.printSyncResult(result, false)
+
+	if count := m.downloadExtraSources(dl); count > 0 {
+		ui.PrintSuccess("Updated %d themes from extra sources", count)
+	}
+
 	return nil
 }
 
+func (m *Manager) printSyncResult(result *downloader.SyncResult, pending bool) {
+	if len(result.Added) == 0 && len(result.Updated) == 0 && len(result.Removed) == 0 {
+		ui.PrintSuccess("Themes are up to date (%d unchanged)", result.Unchanged)
+	} else {
+		if len(result.Added) > 0 {
+			ui.PrintSubHeader(fmt.Sprintf("New themes (%d)", len(result.Added)))
+			ui.PrintList(result.Added)
+		}
+		if len(result.Updated) > 0 {
+			ui.PrintSubHeader(fmt.Sprintf("Updated themes (%d)", len(result.Updated)))
+			ui.PrintList(result.Updated)
+		}
+		fmt.Println()
+
+		if pending && len(result.Removed) > 0 {
+			ui.PrintSubHeader(fmt.Sprintf("Removed upstream (%d)", len(result.Removed)))
+			ui.PrintList(result.Removed)
+		}
+		if pending {
+			ui.PrintInfo("Run 'alacritty-colors update --incremental' to fetch these changes")
+		} else {
+			ui.PrintSuccess("Synced %d new and %d updated themes", len(result.Added), len(result.Updated))
+		}
+	}
+
+	if len(result.Skipped) > 0 {
+		ui.PrintWarning("Kept %d locally modified or protected themes: %s", len(result.Skipped), strings.Join(result.Skipped, ", "))
+	}
+}
+
+// downloadExtraSources downloads every configured source, several at once
+// when there are more than one, and returns the number of themes
+// extracted; failing sources are reported and skipped
+func (m *Manager) downloadExtraSources(dl *downloader.Downloader) int {
+	sources := m.extraSources()
+	if len(sources) > 1 {
+		return m.countDownloaded(dl.DownloadSources(sources))
+	}
+
+	var results []downloader.SourceResult
+	for _, src := range sources {
+		n, err := dl.DownloadSource(src)
+		results = append(results, downloader.SourceResult{Source: src.Name, Count: n, Err: err})
+	}
+	return m.countDownloaded(results)
+}
+
+// extraSources returns the configured [[sources]] as downloads
+func (m *Manager) extraSources() []downloader.Source {
+	sources := make([]downloader.Source, len(m.config.Sources))
+	for i, src := range m.config.Sources {
+		sources[i] = downloader.Source{
+			Name:        src.Name,
+			URL:         src.URL,
+			Mirrors:     src.Mirrors,
+			ChecksumURL: src.ChecksumURL,
+		}
+	}
+	return sources
+}
+
+// countDownloaded adds up the themes extracted from sources, reporting the
+// ones that failed
+func (m *Manager) countDownloaded(results []downloader.SourceResult) int {
+	count := 0
+	for _, result := range results {
+		if result.Err != nil {
+			ui.PrintWarning("Failed to download source '%s': %v", result.Source, result.Err)
+			continue
+		}
+		count += result.Count
+	}
+	return count
+}
+
+// newDownloader creates a downloader using the configured proxy and CA certificates
+func (m *Manager) newDownloader() (*downloader.Downloader, error) {
+	dl := downloader.New(m.config.ThemesDir, m.config.StateDir)
+	if err := dl.ConfigureTransport(m.config.ProxyURL, m.config.CACerts); err != nil {
+		return nil, fmt.Errorf("failed to configure network: %w", err)
+	}
+	dl.SetGitHubToken(m.config.GitHubAPIToken())
+	dl.SetProtected(m.protectedFile)
+	dl.SetAnalyzer(m.analyzeThemeFile)
+	return dl, nil
+}
+
 func (m *Manager) ListBackups() error {
 	files, err := filepath.Glob(filepath.Join(m.config.BackupDir, "*.toml"))
 	if err != nil {
 		return err
 	}
 
-	if len(files) == 0 {
+	slots := m.backupSlots()
+	if len(files) == 0 && len(slots) == 0 {
 		ui.PrintInfo("No backups found")
 		return nil
 	}
 
+	if len(slots) > 0 {
+		ui.PrintHeader("Slots")
+		var rows [][]string
+		for _, slot := range slots {
+			info := readBackupInfo(m.slotPath(slot))
+			rows = append(rows, []string{slot, info["Created"], info["Theme"], info["Description"]})
+		}
+		ui.PrintTable([]string{"Slot", "Saved", "Theme", "Description"}, rows)
+		fmt.Println()
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
 	ui.PrintHeader("Available Backups")
 	for i, file := range files {
 		name := filepath.Base(file)
 		stat, _ := os.Stat(file)
 
-		// Check for description file
-		infoFile := strings.TrimSuffix(file, ".toml") + ".info"
-		description := ""
-		if content, err := os.ReadFile(infoFile); err == nil {
-			lines := strings.Split(string(content), "\n")
-			for _, line := range lines {
-				if strings.HasPrefix(line, "Description: ") {
-					description = strings.TrimPrefix(line, "Description: ")
-					break
-				}
-			}
-		}
+		info := readBackupInfo(file)
 
 		ui.PrintInfo("[%d] %s", i+1, name)
 		ui.PrintInfo("    Created: %s", stat.ModTime().Format("2006-01-02 15:04:05"))
-		if description != "" {
-			ui.PrintInfo("    Description: %s", description)
+		if info["Theme"] != "" {
+			ui.PrintInfo("    Theme: %s", info["Theme"])
+		}
+		if info["Description"] != "" {
+			ui.PrintInfo("    Description: %s", info["Description"])
+		}
+		if info["Effects"] != "" {
+			ui.PrintInfo("    Effects: %s", info["Effects"])
+		}
+		var versions []string
+		if info["Alacritty"] != "" {
+			versions = append(versions, "Alacritty "+info["Alacritty"])
+		}
+		if info["Tool"] != "" {
+			versions = append(versions, "alacritty-colors "+info["Tool"])
+		}
+		if len(versions) > 0 {
+			ui.PrintInfo("    Made with: %s", strings.Join(versions, ", "))
 		}
 		fmt.Println()
 	}
@@ -1373,17 +2079,52 @@ func (m *Manager) ListBackups() error {
 func (m *Manager) ShowConfig() error {
 	ui.PrintHeader("Alacritty Colors Configuration")
 
+	if m.config.Profile != "" {
+		ui.PrintKeyValue("Profile", m.config.Profile)
+	}
 	ui.PrintKeyValue("Config File", m.config.ConfigFile)
+	if m.config.DeployedConfigFile != "" {
+		deployed := m.config.DeployedConfigFile
+		if m.config.Dotfiles.Manager != "" {
+			deployed += " (by " + m.config.Dotfiles.Manager + ")"
+		}
+		ui.PrintKeyValue("Deployed To", deployed)
+	}
 	ui.PrintKeyValue("Themes Dir", m.config.ThemesDir)
+	for _, dir := range m.config.ExtraThemesDirs {
+		ui.PrintKeyValue("Also Searched", dir)
+	}
+	if m.config.SaveDir != "" {
+		ui.PrintKeyValue("Save Dir", m.config.SaveDir)
+	}
 	ui.PrintKeyValue("Backup Dir", m.config.BackupDir)
+	ui.PrintKeyValue("Settings", fmt.Sprintf("%s (schema v%d)", m.config.Path(), config.SchemaVersion))
+	for _, migration := range m.config.Migrations {
+		ui.PrintInfo("Migrated settings %s", migration)
+	}
+	ui.PrintKeyValue("State Dir", m.config.StateDir)
+	if m.config.ProxyURL != "" {
+		ui.PrintKeyValue("Proxy", m.config.ProxyURL)
+	}
+	if len(m.config.CACerts) > 0 {
+		ui.PrintKeyValue("CA Certs", strings.Join(m.config.CACerts, ", "))
+	}
+	if m.config.GitHubAPIToken() != "" {
+		ui.PrintKeyValue("GitHub Token", "set")
+	}
 
 	// Show current theme
 	current := m.GetCurrentTheme()
 	if current != "" {
 		ui.PrintKeyValue("Current Theme", current)
 	} else {
-		ui.PrintKeyValue("Current Theme", "None")
+		ui.PrintKeyValue("Current Theme", i18n.T("None"))
 	}
+	ui.PrintKeyValue("current.toml", m.describeCurrentFile())
+	if d := m.config.Display; d.Calibrated() {
+		ui.PrintKeyValue("Calibration", fmt.Sprintf("gamma %g, brightness offset %+g", d.Gamma, d.BrightnessOffset))
+	}
+	m.warnDrift()
 
 	// Show statistics
 	themes, _ := m.getThemeInfos()
@@ -1391,6 +2132,9 @@ func (m *Manager) ShowConfig() error {
 
 	backups, _ := filepath.Glob(filepath.Join(m.config.BackupDir, "*.toml"))
 	ui.PrintKeyValue("Backups", fmt.Sprintf("%d", len(backups)))
+	if slots := m.backupSlots(); len(slots) > 0 {
+		ui.PrintKeyValue("Slots", strings.Join(slots, ", "))
+	}
 
 	return nil
 }
@@ -1405,29 +2149,59 @@ func (m *Manager) applyThemeFont(themeName, fontFamily string, fontSize float64)
 	// Determine font based on theme name or use provided fontFamily
 	if fontFamily != "" {
 		selectedFont = fontFamily
+		if installed, err := fonts.Installed(); err == nil && !installed.Has(fontFamily) {
+			ui.PrintWarning("Font '%s' doesn't seem to be installed; Alacritty will fall back to its default", fontFamily)
+		}
 	} else {
 		// Auto-select font based on theme
-		themeKey := strings.ToLower(themeName)
-		for key, fonts := range ThemeFonts {
-			if strings.Contains(themeKey, key) {
-				selectedFont = fonts[0] // Use first font in the list
-				break
-			}
-		}
-		if selectedFont == "" {
-			selectedFont = ThemeFonts["default"][0]
-		}
+		candidates := m.fontCandidates(themeName)
+		selectedFont = m.pickInstalledFont(candidates)
 	}
 
 	m.logVerbose("Selected font: %s", selectedFont)
 
+	keys := []string{keyFontFamily}
+	if fontSize > 0 {
+		keys = append(keys, keyFontSize)
+	}
+	m.rememberBase(keys...)
+
 	// Update Alacritty config with font settings
 	return m.updateConfigFont(selectedFont, fontSize)
 }
 
+// pickInstalledFont returns the first candidate installed on this system,
+// or "monospace" when none is. If the installed fonts can't be listed the
+// first candidate is used as-is.
+func (m *Manager) pickInstalledFont(candidates []string) string {
+	installed, err := fonts.Installed()
+	if err != nil {
+		m.logVerbose("Could not list installed fonts (%v), using %s", err, candidates[0])
+		return candidates[0]
+	}
+	font, ok := installed.FirstInstalled(candidates)
+	if !ok {
+		font = "monospace"
+	}
+	if font == "monospace" {
+		missing := slices.DeleteFunc(slices.Clone(candidates), func(c string) bool { return c == "monospace" })
+		ui.PrintWarning("None of %s is installed; using the generic monospace font", strings.Join(missing, ", "))
+	}
+	return font
+}
+
 func (m *Manager) applyVisualEffects(opacity, blur float64) error {
 	m.logVerbose("Applying visual effects: opacity=%.2f, blur=%.2f", opacity, blur)
 
+	var keys []string
+	if opacity > 0 {
+		keys = append(keys, keyOpacity)
+	}
+	if blur > 0 {
+		keys = append(keys, keyBlur)
+	}
+	m.rememberBase(keys...)
+
 	// Update Alacritty config with visual effects
 	return m.updateConfigVisualEffects(opacity, blur)
 }
@@ -1626,12 +2400,12 @@ func (m *Manager) matchesQuery(theme ThemeInfo, query string) bool {
 
 func (m *Manager) updateConfigFont(fontFamily string, fontSize float64) error {
 	// Read current config
-	content, err := os.ReadFile(m.config.ConfigFile)
+	text, err := readConfigText(m.config.ConfigFile)
 	if err != nil {
 		return err
 	}
 
-	lines := strings.Split(string(content), "\n")
+	lines := text.Lines
 	var newLines []string
 	inFontSection := false
 	inFontNormalSection := false
@@ -1720,17 +2494,17 @@ func (m *Manager) updateConfigFont(fontFamily string, fontSize float64) error {
 		}
 	}
 
-	return os.WriteFile(m.config.ConfigFile, []byte(strings.Join(newLines, "\n")), 0644)
+	return text.write(m.config.ConfigFile, newLines)
 }
 
 func (m *Manager) updateConfigVisualEffects(opacity, blur float64) error {
 	// Read current config
-	content, err := os.ReadFile(m.config.ConfigFile)
+	text, err := readConfigText(m.config.ConfigFile)
 	if err != nil {
 		return err
 	}
 
-	lines := strings.Split(string(content), "\n")
+	lines := text.Lines
 	var newLines []string
 	inWindowSection := false
 	windowSectionAdded := false
@@ -1801,7 +2575,7 @@ func (m *Manager) updateConfigVisualEffects(opacity, blur float64) error {
 		}
 	}
 
-	return os.WriteFile(m.config.ConfigFile, []byte(strings.Join(newLines, "\n")), 0644)
+	return text.write(m.config.ConfigFile, newLines)
 }
 
 // Utility functions for color conversion
@@ -0,0 +1,105 @@
+package theme
+
+import (
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/downloader"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// enrichTheme fills metadata the theme file leaves out from the index built
+// during downloads, then guesses the variant from the name or colors
+func (m *Manager) enrichTheme(info *ThemeInfo, indexed downloader.ThemeMetadata) {
+	fill := func(field *string, value string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+	fill(&info.Title, indexed.Title)
+	fill(&info.Author, indexed.Author)
+	fill(&info.Variant, indexed.Variant)
+	fill(&info.Upstream, indexed.Upstream)
+	fill(&info.Source, indexed.Source)
+
+	if info.Variant == "" {
+		info.Variant = downloader.VariantFromName(info.Name)
+	}
+	if info.Variant == "" && info.Colors["background"] != "" {
+		if m.isThemeDark(*info) {
+			info.Variant = "dark"
+		} else {
+			info.Variant = "light"
+		}
+	}
+
+	// Themes indexed before tagging, or never downloaded, are tagged on
+	// the fly
+	info.Tags = indexed.Tags
+	if len(info.Tags) == 0 && info.Colors["background"] != "" {
+		info.Tags = characterOf(*info).Tags
+	}
+}
+
+// themeDetails finds a theme and reads its colors and metadata
+func (m *Manager) themeDetails(name string) (ThemeInfo, error) {
+	found, err := m.findTheme(name)
+	if err != nil {
+		return ThemeInfo{}, err
+	}
+	info, err := m.parseThemeFile(found.FilePath)
+	if err != nil {
+		return info, err
+	}
+	index := downloader.New(m.config.ThemesDir, m.config.StateDir).LoadMetadata()
+	m.enrichTheme(&info, index[info.Name])
+	return info, nil
+}
+
+// themeSummary is the one-line description shown in theme lists
+func themeSummary(t ThemeInfo) string {
+	var parts []string
+	if t.Description != "" {
+		parts = append(parts, t.Description)
+	} else if t.Title != "" && !strings.EqualFold(normalizeTitle(t.Title), normalizeTitle(t.Name)) {
+		parts = append(parts, t.Title)
+	}
+	if t.Author != "" {
+		parts = append(parts, "by "+t.Author)
+	}
+	if t.Variant != "" {
+		parts = append(parts, t.Variant)
+	}
+	if t.Source != "" && t.Source != downloader.OfficialSource.Name && t.Source != downloader.BundledSource {
+		parts = append(parts, "from "+t.Source)
+	}
+
+	separator := " · "
+	if !ui.SupportsUnicode() {
+		separator = " - "
+	}
+	return strings.Join(parts, separator)
+}
+
+// normalizeTitle lets "Gruvbox dark" match the gruvbox_dark file name
+func normalizeTitle(s string) string {
+	return strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(s))
+}
+
+// printThemeMetadata shows what is known about a theme's origin
+func (m *Manager) printThemeMetadata(t ThemeInfo) {
+	if t.Title != "" {
+		ui.PrintKeyValue("Title", t.Title)
+	}
+	if t.Author != "" {
+		ui.PrintKeyValue("Author", t.Author)
+	}
+	if t.Variant != "" {
+		ui.PrintKeyValue("Variant", t.Variant)
+	}
+	if t.Source != "" {
+		ui.PrintKeyValue("Source", t.Source)
+	}
+	if t.Upstream != "" {
+		ui.PrintKeyValue("Upstream", t.Upstream)
+	}
+}
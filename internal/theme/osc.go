@@ -0,0 +1,100 @@
+package theme
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/targets"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// oscColors maps OSC 10/11/12 to the theme's special colors
+var oscColors = []struct {
+	code int
+	keys []string
+}{
+	{10, []string{"foreground"}},
+	{11, []string{"background"}},
+	{12, []string{"cursor", "foreground"}},
+}
+
+// ApplyOSC recolors the controlling terminal with OSC escape sequences. It
+// works in any xterm-compatible terminal, including over SSH, and changes
+// no files; the colors last until the terminal is reset or closed.
+func (m *Manager) ApplyOSC(themeName string) error {
+	theme, colors, err := m.themeColors(themeName)
+	if err != nil {
+		return err
+	}
+	if err := writeTTY(oscSequence(colors)); err != nil {
+		return err
+	}
+
+	ui.PrintSuccess("Applied '%s' to this terminal", theme)
+	return nil
+}
+
+// oscSequence sets the terminal's palette and special colors to a theme's
+func oscSequence(colors targets.Colors) string {
+	var seq strings.Builder
+	for i, color := range colors.Palette() {
+		if rgb := oscRGB(color); rgb != "" {
+			seq.WriteString(osc(fmt.Sprintf("4;%d;%s", i, rgb)))
+		}
+	}
+	for _, c := range oscColors {
+		if rgb := oscRGB(colors.Get(c.keys[0], c.keys[1:]...)); rgb != "" {
+			seq.WriteString(osc(fmt.Sprintf("%d;%s", c.code, rgb)))
+		}
+	}
+	return seq.String()
+}
+
+// oscReset is the sequence that restores the terminal's own colors
+var oscReset = osc("104") + osc("110") + osc("111") + osc("112")
+
+// ResetOSC restores the terminal's own palette and colors
+func (m *Manager) ResetOSC() error {
+	if err := writeTTY(oscReset); err != nil {
+		return err
+	}
+
+	ui.PrintSuccess("Reset this terminal's colors")
+	return nil
+}
+
+// osc wraps an OSC command, passing it through tmux when running inside it
+func osc(command string) string {
+	seq := "\033]" + command + "\033\\"
+	if os.Getenv("TMUX") != "" {
+		return "\033Ptmux;" + strings.ReplaceAll(seq, "\033", "\033\033") + "\033\\"
+	}
+	return seq
+}
+
+// oscRGB converts "#rrggbb" to the X11 "rgb:rr/gg/bb" form every terminal
+// that supports OSC 4 understands
+func oscRGB(hex string) string {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return ""
+	}
+	return fmt.Sprintf("rgb:%s/%s/%s", hex[0:2], hex[2:4], hex[4:6])
+}
+
+// writeTTY writes to the controlling terminal so redirected output does not
+// swallow the sequences, falling back to stdout without one
+func writeTTY(seq string) error {
+	var w io.Writer = os.Stdout
+	if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+		defer tty.Close()
+		w = tty
+	}
+
+	if _, err := io.WriteString(w, seq); err != nil {
+		return fmt.Errorf("failed to write to terminal: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// OverridesDir holds the user's corrections to themes. overrides/<theme>.toml
+// applies to one theme; a glob such as overrides/gruvbox*.toml applies to
+// every theme it matches.
+func (m *Manager) OverridesDir() string {
+	return filepath.Join(m.config.DataDir, "overrides")
+}
+
+// themeOverrides returns the override files for a theme in the order they
+// are merged: glob files sorted by name, then the theme's own file
+func (m *Manager) themeOverrides(themeName string) []string {
+	files, _ := filepath.Glob(filepath.Join(m.OverridesDir(), "*.toml"))
+	sort.Strings(files)
+
+	var matched []string
+	exact := ""
+	for _, file := range files {
+		switch overrideMatch(file, themeName) {
+		case overrideExact:
+			exact = file
+		case overrideGlob:
+			matched = append(matched, file)
+		}
+	}
+	if exact != "" {
+		matched = append(matched, exact)
+	}
+	return matched
+}
+
+// How an override file applies to a theme
+const (
+	overrideNone = iota
+	overrideGlob
+	overrideExact
+)
+
+// overrideMatch tells whether an override file is named after a theme or
+// is a glob matching it
+func overrideMatch(file, themeName string) int {
+	pattern := strings.ToLower(strings.TrimSuffix(filepath.Base(file), ".toml"))
+	name := strings.ToLower(themeName)
+	if pattern == name {
+		return overrideExact
+	}
+	if ok, _ := path.Match(pattern, name); ok && strings.ContainsAny(pattern, "*?[") {
+		return overrideGlob
+	}
+	return overrideNone
+}
+
+// overrideEntry is a key = value line of an override file
+type overrideEntry struct {
+	table, key, line string
+}
+
+// parseOverride reads the key = value lines of an override file along with
+// the table each belongs to
+func parseOverride(data []byte) []overrideEntry {
+	var entries []overrideEntry
+	table := ""
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+		case strings.HasPrefix(trimmed, "["):
+			table = tableName(trimmed)
+		case strings.Contains(trimmed, "="):
+			key := strings.Trim(strings.TrimSpace(strings.SplitN(trimmed, "=", 2)[0]), `"'`)
+			entries = append(entries, overrideEntry{table: table, key: key, line: trimmed})
+		}
+	}
+	return entries
+}
+
+// tableName returns the name of a [table] header line
+func tableName(header string) string {
+	if i := strings.Index(header, "#"); i >= 0 {
+		header = header[:i]
+	}
+	return strings.TrimSpace(strings.Trim(strings.TrimSpace(header), "[]"))
+}
+
+// mergeOverride replaces the keys of a theme that an override sets and adds
+// the ones it lacks, at the end of their table or in a new table
+func mergeOverride(theme []byte, entries []overrideEntry) []byte {
+	pending := make(map[string][]overrideEntry)
+	var order []string
+	for _, e := range entries {
+		if _, ok := pending[e.table]; !ok {
+			order = append(order, e.table)
+		}
+		pending[e.table] = append(pending[e.table], e)
+	}
+
+	var out []string
+	// flush adds the keys of a table the theme didn't set, before the
+	// blank lines that separate it from the next table
+	flush := func(table string) {
+		end := len(out)
+		for end > 0 && strings.TrimSpace(out[end-1]) == "" {
+			end--
+		}
+		var added []string
+		for _, e := range pending[table] {
+			added = append(added, e.line)
+		}
+		delete(pending, table)
+		out = append(out[:end], append(added, out[end:]...)...)
+	}
+
+	table := ""
+	for _, line := range strings.Split(string(theme), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			flush(table)
+			table = tableName(trimmed)
+			out = append(out, line)
+			continue
+		}
+		if strings.Contains(trimmed, "=") && !strings.HasPrefix(trimmed, "#") {
+			key := strings.Trim(strings.TrimSpace(strings.SplitN(trimmed, "=", 2)[0]), `"'`)
+			if i := indexOverride(pending[table], key); i >= 0 {
+				out = append(out, pending[table][i].line)
+				pending[table] = append(pending[table][:i], pending[table][i+1:]...)
+				continue
+			}
+		}
+		out = append(out, line)
+	}
+	flush(table)
+
+	for _, name := range order {
+		if len(pending[name]) == 0 {
+			continue
+		}
+		for len(out) > 0 && strings.TrimSpace(out[len(out)-1]) == "" {
+			out = out[:len(out)-1]
+		}
+		out = append(out, "", "["+name+"]")
+		for _, e := range pending[name] {
+			out = append(out, e.line)
+		}
+		out = append(out, "")
+	}
+	return []byte(strings.Join(out, "\n"))
+}
+
+func indexOverride(entries []overrideEntry, key string) int {
+	for i, e := range entries {
+		if e.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyOverrides merges the override files of a theme into its content
+func (m *Manager) applyOverrides(themeName string, data []byte) ([]byte, error) {
+	for _, file := range m.themeOverrides(themeName) {
+		override, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read override: %w", err)
+		}
+		m.logVerbose("Applying override %s", filepath.Base(file))
+		data = mergeOverride(data, parseOverride(override))
+	}
+	return data, nil
+}
+
+// ListOverrides shows each override file and the installed themes it
+// applies to
+func (m *Manager) ListOverrides() error {
+	files, _ := filepath.Glob(filepath.Join(m.OverridesDir(), "*.toml"))
+	if len(files) == 0 {
+		ui.PrintInfo("No overrides yet")
+		ui.PrintInfo("Add <theme>.toml, or a glob such as gruvbox*.toml, to %s", m.OverridesDir())
+		return nil
+	}
+	sort.Strings(files)
+
+	themes, err := m.getThemeInfos()
+	if err != nil {
+		return err
+	}
+
+	ui.PrintHeader(fmt.Sprintf("Theme Overrides (%d)", len(files)))
+	rows := make([][]string, 0, len(files))
+	for _, file := range files {
+		var matched []string
+		for _, t := range themes {
+			if overrideMatch(file, t.Name) != overrideNone {
+				matched = append(matched, t.Name)
+			}
+		}
+		applies := strings.Join(matched, ", ")
+		switch {
+		case len(matched) == 0:
+			applies = "(no installed theme)"
+		case len(matched) > 3:
+			applies = fmt.Sprintf("%s and %d more", strings.Join(matched[:3], ", "), len(matched)-3)
+		}
+		data, _ := os.ReadFile(file)
+		rows = append(rows, []string{filepath.Base(file), fmt.Sprintf("%d", len(parseOverride(data))), applies})
+	}
+	ui.PrintTable([]string{"File", "Keys", "Applies To"}, rows)
+	ui.PrintInfo("Overrides are merged into current.toml when a theme is applied")
+	return nil
+}
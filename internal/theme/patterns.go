@@ -0,0 +1,167 @@
+package theme
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/downloader"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// regexPattern compiles a /regex/ pattern, matched case-insensitively. It
+// reports false for patterns that aren't between slashes.
+func regexPattern(pattern string) (*regexp.Regexp, bool, error) {
+	if len(pattern) < 2 || !strings.HasPrefix(pattern, "/") || !strings.HasSuffix(pattern, "/") {
+		return nil, false, nil
+	}
+	expr := pattern[1 : len(pattern)-1]
+	if _, err := regexp.Compile(expr); err != nil {
+		return nil, true, err
+	}
+	return regexp.MustCompile("(?i)" + expr), true, nil
+}
+
+// isThemePattern reports whether s is a glob or a /regex/ rather than a
+// theme name
+func isThemePattern(s string) bool {
+	if _, ok, _ := regexPattern(s); ok {
+		return true
+	}
+	return strings.ContainsAny(s, "*?[")
+}
+
+// matchesPattern reports whether a theme name matches a shell glob or a
+// /regex/, ignoring case
+func matchesPattern(name, pattern string) bool {
+	if re, ok, err := regexPattern(pattern); ok {
+		return err == nil && re.MatchString(name)
+	}
+	ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+	return ok
+}
+
+// selectThemes resolves theme names and patterns to installed themes, in
+// name order without duplicates. A name that isn't installed, or a pattern
+// that matches nothing, is an error.
+func (m *Manager) selectThemes(args []string) ([]ThemeInfo, error) {
+	themes, err := m.getThemeInfos()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]bool)
+	for _, arg := range args {
+		if !isThemePattern(arg) {
+			t, err := m.findTheme(arg)
+			if err != nil {
+				return nil, err
+			}
+			selected[strings.ToLower(t.Name)] = true
+			continue
+		}
+		if _, _, err := regexPattern(arg); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", arg, err)
+		}
+		matched := false
+		for _, t := range themes {
+			if matchesPattern(t.Name, arg) {
+				selected[strings.ToLower(t.Name)] = true
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, ui.WithHints(fmt.Errorf("no themes match '%s'", arg),
+				"Globs use * and ?, e.g. 'gruvbox*'; regular expressions go between slashes, e.g. '/^nord|_night$/'.")
+		}
+	}
+
+	var result []ThemeInfo
+	for _, t := range themes {
+		if selected[strings.ToLower(t.Name)] {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+// printMatches lists the themes a command would act on
+func printMatches(action string, themes []ThemeInfo) {
+	ui.PrintInfo("Would %s %d theme(s):", action, len(themes))
+	for _, t := range themes {
+		fmt.Printf("  %s\n", t.Name)
+	}
+}
+
+// RemoveThemes moves the themes matching names or patterns to the trash.
+// The applied theme and protected themes are kept. With dryRun the themes
+// are only listed.
+func (m *Manager) RemoveThemes(args []string, dryRun bool) error {
+	themes, err := m.selectThemes(args)
+	if err != nil {
+		return err
+	}
+
+	var removable []ThemeInfo
+	for _, t := range themes {
+		switch {
+		case strings.EqualFold(t.Name, m.GetCurrentTheme()):
+			ui.PrintWarning("Keeping %s: it is the applied theme", t.Name)
+		case m.IsProtected(t.FilePath):
+			ui.PrintWarning("Keeping %s: it is protected", t.Name)
+		default:
+			removable = append(removable, t)
+		}
+	}
+	if len(removable) == 0 {
+		ui.PrintInfo("No themes to remove")
+		return nil
+	}
+	if dryRun {
+		printMatches("remove", removable)
+		return nil
+	}
+
+	removed := 0
+	for _, t := range removable {
+		if err := m.MoveToTrash(t.FilePath, TrashTheme); err != nil {
+			ui.PrintWarning("Failed to remove %s: %v", t.Name, err)
+			continue
+		}
+		m.logVerbose("Removed %s", t.Name)
+		removed++
+	}
+
+	ui.PrintSuccess("Removed %d theme(s)", removed)
+	ui.PrintInfo("Removed files stay in the trash for %d days: alacritty-colors trash list", int(TrashExpiry.Hours()/24))
+	return nil
+}
+
+// ValidateThemes checks that the themes matching names or patterns parse
+// and define the colors every theme needs; no arguments checks them all
+func (m *Manager) ValidateThemes(args []string) error {
+	if len(args) == 0 {
+		args = []string{"*"}
+	}
+	themes, err := m.selectThemes(args)
+	if err != nil {
+		return err
+	}
+
+	invalid := 0
+	for _, t := range themes {
+		if err := downloader.ValidateThemeFile(t.FilePath); err != nil {
+			ui.PrintError("%s: %v", t.Name, err)
+			invalid++
+			continue
+		}
+		m.logVerbose("%s is valid", t.Name)
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d theme(s) are invalid", invalid, len(themes))
+	}
+	ui.PrintSuccess("All %d theme(s) are valid", len(themes))
+	return nil
+}
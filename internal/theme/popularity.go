@@ -0,0 +1,88 @@
+package theme
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/downloader"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// Sort orders for theme listings
+const (
+	SortName     = "name"
+	SortPopular  = "popular"
+	SortContrast = "contrast"
+	SortScore    = "score"
+)
+
+// withPopularity attaches popularity data to each theme
+func (m *Manager) withPopularity(themes []ThemeInfo) []ThemeInfo {
+	popularity := downloader.New(m.config.ThemesDir, m.config.StateDir).Popularity()
+	for i := range themes {
+		themes[i].Popularity = popularity[themes[i].Name]
+	}
+	return themes
+}
+
+// sortThemes orders themes by name, or by popularity, contrast or quality
+// score, highest first
+func sortThemes(themes []ThemeInfo, order string) error {
+	switch order {
+	case "", SortName:
+		sort.Slice(themes, func(i, j int) bool {
+			return themes[i].Name < themes[j].Name
+		})
+	case SortPopular:
+		sort.SliceStable(themes, func(i, j int) bool {
+			a, b := themes[i].Popularity, themes[j].Popularity
+			if a.Score != b.Score {
+				return a.Score > b.Score
+			}
+			if a.Stars != b.Stars {
+				return a.Stars > b.Stars
+			}
+			return themes[i].Name < themes[j].Name
+		})
+	case SortContrast:
+		sort.SliceStable(themes, func(i, j int) bool {
+			if themes[i].Contrast != themes[j].Contrast {
+				return themes[i].Contrast > themes[j].Contrast
+			}
+			return themes[i].Name < themes[j].Name
+		})
+	case SortScore:
+		sort.SliceStable(themes, func(i, j int) bool {
+			if themes[i].Score != themes[j].Score {
+				return themes[i].Score > themes[j].Score
+			}
+			return themes[i].Name < themes[j].Name
+		})
+	default:
+		return fmt.Errorf("unknown sort order: %s (use name|popular|contrast|score)", order)
+	}
+	return nil
+}
+
+// popularityBadge renders a short badge such as "★★★★☆" or "★ 1.2k"
+func popularityBadge(p downloader.Popularity) string {
+	star, empty := "★", "☆"
+	if !ui.SupportsUnicode() {
+		star, empty = "*", "."
+	}
+
+	if p.Stars > 0 {
+		if p.Stars >= 1000 {
+			return fmt.Sprintf("%s %.1fk", star, float64(p.Stars)/1000)
+		}
+		return fmt.Sprintf("%s %d", star, p.Stars)
+	}
+
+	if p.Score == 0 {
+		return ""
+	}
+
+	filled := (p.Score + 19) / 20
+	return strings.Repeat(star, filled) + strings.Repeat(empty, 5-filled)
+}
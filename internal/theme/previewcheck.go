@@ -0,0 +1,112 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+const (
+	// reloadWait is how long a preview waits for this window to reload
+	// current.toml before calling it a mismatch
+	reloadWait = 1500 * time.Millisecond
+	// reloadPoll is how often the window is asked for its colors meanwhile
+	reloadPoll = 150 * time.Millisecond
+)
+
+// previewMismatch lists the reasons a theme written to current.toml won't
+// show up in this terminal: it isn't Alacritty, it shows a remote session,
+// the config doesn't import current.toml, live reload is off, or, asked
+// through IPC, this window kept its previous colors. background is the
+// previewed theme's.
+func (m *Manager) previewMismatch(background string) []string {
+	var reasons []string
+	if os.Getenv("ALACRITTY_WINDOW_ID") == "" {
+		if program := os.Getenv("TERM_PROGRAM"); program != "" {
+			reasons = append(reasons, fmt.Sprintf("this terminal is %s, not Alacritty", program))
+		} else {
+			reasons = append(reasons, "this terminal doesn't look like Alacritty (ALACRITTY_WINDOW_ID is not set)")
+		}
+	}
+	if os.Getenv("SSH_CONNECTION") != "" {
+		reasons = append(reasons, "this is an SSH session; the Alacritty showing it reads its config on the other machine")
+	}
+	if !m.hasImportLine() {
+		reasons = append(reasons, fmt.Sprintf("%s doesn't import current.toml", m.config.ConfigFile))
+	}
+	if !m.liveReloadEnabled() {
+		reasons = append(reasons, fmt.Sprintf("live_config_reload is off in %s", m.config.ConfigFile))
+	}
+	if len(reasons) > 0 {
+		return reasons
+	}
+
+	if shown, ok := m.windowBackground(background); ok && hexDistance(shown, background) >= 1 {
+		reasons = append(reasons, fmt.Sprintf("this window still shows background %s instead of %s; it may use another config file (alacritty --config-file)", shown, background))
+	}
+	return reasons
+}
+
+// windowBackground waits for this window to pick up the expected
+// background and returns the one it shows. It reports false when the
+// window can't be asked.
+func (m *Manager) windowBackground(expected string) (string, bool) {
+	socket := os.Getenv("ALACRITTY_SOCKET")
+	if socket == "" || expected == "" {
+		return "", false
+	}
+	if _, err := exec.LookPath("alacritty"); err != nil {
+		return "", false
+	}
+
+	deadline := time.Now().Add(reloadWait)
+	for {
+		shown, err := runningBackground(socket)
+		if err != nil {
+			m.logVerbose("Could not ask %s for its colors: %v", filepath.Base(socket), err)
+			return "", false
+		}
+		if hexDistance(shown, expected) < 1 || time.Now().After(deadline) {
+			return shown, true
+		}
+		time.Sleep(reloadPoll)
+	}
+}
+
+// offerOSCPreview explains why a preview isn't visible and asks whether to
+// show it with escape sequences instead. It reports whether the theme was
+// sent to the terminal.
+func (m *Manager) offerOSCPreview(t ThemeInfo, reasons []string, ask bool) bool {
+	ui.PrintWarning("The preview may not show in this terminal:")
+	for _, reason := range reasons {
+		ui.PrintInfo("  • %s", reason)
+	}
+	if !ask || !ui.IsTerminal() {
+		ui.PrintInfo("Preview with escape sequences instead: alacritty-colors preview %s --osc", t.Name)
+		return false
+	}
+	if !ui.PromptConfirm("Preview with escape sequences in this terminal instead?") {
+		return false
+	}
+	if err := m.sendOSCPreview(t); err != nil {
+		ui.PrintWarning("%v", err)
+		return false
+	}
+	return true
+}
+
+// sendOSCPreview recolors this terminal with the theme, leaving files alone
+func (m *Manager) sendOSCPreview(t ThemeInfo) error {
+	_, colors, err := m.themeColors(t.Name)
+	if err != nil {
+		return err
+	}
+	if err := writeTTY(oscSequence(colors)); err != nil {
+		return fmt.Errorf("failed to preview with escape sequences: %w", err)
+	}
+	return nil
+}
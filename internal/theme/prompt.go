@@ -0,0 +1,42 @@
+package theme
+
+import (
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/prompt"
+)
+
+// promptSwatchKeys are the colors shown next to the theme name
+var promptSwatchKeys = []string{"normal_red", "normal_green", "normal_yellow", "normal_blue", "normal_magenta", "normal_cyan"}
+
+// writePromptCache records the applied theme for the prompt helper
+func (m *Manager) writePromptCache(themeName, themePath string) error {
+	info, err := m.parseThemeFile(themePath)
+	if err != nil {
+		return err
+	}
+
+	entry := prompt.Entry{Theme: themeName}
+	for _, key := range promptSwatchKeys {
+		if c := info.Colors[key]; strings.HasPrefix(c, "#") && len(c) == 7 {
+			entry.Colors = append(entry.Colors, c)
+		}
+	}
+	return prompt.WriteCache(m.config.StateDir, entry)
+}
+
+// RefreshPromptCache rebuilds the prompt cache from the current theme. With
+// no theme applied yet it returns an empty entry.
+func (m *Manager) RefreshPromptCache() (prompt.Entry, error) {
+	if m.config.CurrentTheme == "" {
+		return prompt.Entry{}, nil
+	}
+	selected, err := m.findTheme(m.config.CurrentTheme)
+	if err != nil {
+		return prompt.Entry{}, err
+	}
+	if err := m.writePromptCache(selected.Name, selected.FilePath); err != nil {
+		return prompt.Entry{}, err
+	}
+	return prompt.ReadCache(m.config.StateDir)
+}
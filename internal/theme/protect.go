@@ -0,0 +1,120 @@
+package theme
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// KeepMarker in a comment near the top of a theme file protects it like an
+// entry on the protected list
+const KeepMarker = "alacritty-colors: keep"
+
+// keepMarkerLines is how far into a theme file the marker is looked for
+const keepMarkerLines = 10
+
+// IsProtected reports whether a theme file must survive updates and clean
+// commands, either because it is on the protected list or because it
+// carries the keep marker
+func (m *Manager) IsProtected(path string) bool {
+	if matchesPatterns(themeNameOf(path), m.config.Protected) {
+		return true
+	}
+	return hasKeepMarker(path)
+}
+
+func hasKeepMarker(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for i := 0; i < keepMarkerLines && scanner.Scan(); i++ {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") && strings.Contains(line, KeepMarker) {
+			return true
+		}
+	}
+	return false
+}
+
+// protectedFile adapts IsProtected to the file names downloads work with
+func (m *Manager) protectedFile(filename string) bool {
+	return m.IsProtected(filepath.Join(m.config.ThemesDir, filename))
+}
+
+// Protect adds themes or glob patterns to the protected list
+func (m *Manager) Protect(patterns []string) error {
+	for _, pattern := range patterns {
+		if !isThemePattern(pattern) {
+			if _, err := m.findTheme(pattern); err == nil {
+				continue
+			}
+			ui.PrintWarning("No installed theme named '%s'", pattern)
+		}
+	}
+
+	added, err := m.config.AddProtected(patterns)
+	if err != nil {
+		return fmt.Errorf("failed to update protected list: %w", err)
+	}
+	if len(added) == 0 {
+		ui.PrintInfo("Already protected")
+		return nil
+	}
+
+	ui.PrintSuccess("Protected from updates and clean-up: %s", strings.Join(added, ", "))
+	return nil
+}
+
+// Unprotect removes entries from the protected list
+func (m *Manager) Unprotect(patterns []string) error {
+	removed, err := m.config.RemoveProtected(patterns)
+	if err != nil {
+		return fmt.Errorf("failed to update protected list: %w", err)
+	}
+	if len(removed) == 0 {
+		return ui.WithHints(fmt.Errorf("not on the protected list: %s", strings.Join(patterns, ", ")),
+			fmt.Sprintf("Themes protected with a '# %s' comment stay protected until the comment is removed", KeepMarker))
+	}
+
+	ui.PrintSuccess("No longer protected: %s", strings.Join(removed, ", "))
+	return nil
+}
+
+// ListProtected prints the protected list and the themes carrying the keep
+// marker
+func (m *Manager) ListProtected() error {
+	var marked []string
+	if files, err := m.getThemeFiles(); err == nil {
+		for _, file := range files {
+			if filepath.Base(file) != "current.toml" && hasKeepMarker(file) {
+				marked = append(marked, themeNameOf(file))
+			}
+		}
+	}
+
+	if len(m.config.Protected) == 0 && len(marked) == 0 {
+		ui.PrintInfo("No themes are protected")
+		ui.PrintInfo("Add one with: alacritty-colors protect add <theme|pattern>")
+		return nil
+	}
+
+	if len(m.config.Protected) > 0 {
+		ui.PrintHeader("Protected Themes")
+		for _, pattern := range m.config.Protected {
+			fmt.Printf("  %s\n", pattern)
+		}
+	}
+	if len(marked) > 0 {
+		ui.PrintSubHeader(fmt.Sprintf("Marked '%s' (%d)", KeepMarker, len(marked)))
+		ui.PrintList(marked)
+	}
+	return nil
+}
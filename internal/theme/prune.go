@@ -0,0 +1,146 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/vitruves/alacritty-colors/internal/downloader"
+	"github.com/vitruves/alacritty-colors/internal/i18n"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// Actions for themes that were removed upstream
+const (
+	PruneAsk     = "ask"
+	PruneArchive = "archive"
+	PruneDelete  = "delete"
+	PruneKeep    = "keep"
+)
+
+// ArchiveDir receives pruned themes when they are archived instead of deleted
+const ArchiveDir = ".archive"
+
+func (m *Manager) loadManifest() map[string]downloader.SourceRecord {
+	records, err := downloader.New(m.config.ThemesDir, m.config.StateDir).LoadManifest()
+	if err != nil {
+		m.logVerbose("Failed to read manifest: %v", err)
+		return map[string]downloader.SourceRecord{}
+	}
+	return records
+}
+
+// removedThemes compares manifests from before and after an update and returns
+// the downloaded themes no source provides anymore. Themes that never came
+// from a source, like generated or imported ones, are never listed.
+func (m *Manager) removedThemes(before, after map[string]downloader.SourceRecord) []string {
+	provided := make(map[string]bool)
+	for _, record := range after {
+		for name := range record.Files {
+			provided[name] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	var removed []string
+	for source, record := range before {
+		if _, ok := after[source]; !ok {
+			continue
+		}
+		for name := range record.Files {
+			if provided[name] || seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			if _, err := os.Stat(filepath.Join(m.config.ThemesDir, name)); err == nil {
+				removed = append(removed, name)
+			}
+		}
+	}
+
+	sort.Strings(removed)
+	return removed
+}
+
+// pruneThemes archives, deletes or keeps themes removed upstream
+func (m *Manager) pruneThemes(removed []string, action string) error {
+	var candidates []string
+	for _, name := range removed {
+		if themeNameFromFile(name) == m.config.CurrentTheme {
+			ui.PrintInfo("Keeping current theme %s although it was removed upstream", name)
+			continue
+		}
+		if m.protectedFile(name) {
+			ui.PrintInfo("Keeping protected theme %s although it was removed upstream", name)
+			continue
+		}
+		candidates = append(candidates, name)
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	ui.PrintSubHeader(i18n.Sprintf("Removed upstream (%d)", len(candidates)))
+	ui.PrintList(candidates)
+
+	if action == "" || action == PruneAsk {
+		options := []string{i18n.Sprintf("Archive to themes/%s", ArchiveDir), i18n.T("Delete"), i18n.T("Keep")}
+		// Keeping is the default, so unattended updates never move themes
+		switch ui.PromptSelectDefault("What should happen to these themes?", options, 2) {
+		case 0:
+			action = PruneArchive
+		case 1:
+			action = PruneDelete
+		default:
+			action = PruneKeep
+		}
+	}
+
+	switch action {
+	case PruneKeep:
+		ui.PrintInfo("Kept %d themes", len(candidates))
+		return nil
+	case PruneArchive, PruneDelete:
+	default:
+		return fmt.Errorf("unknown prune action: %s (use ask|archive|delete|keep)", action)
+	}
+
+	archiveDir := filepath.Join(m.config.ThemesDir, ArchiveDir)
+	if action == PruneArchive {
+		if err := os.MkdirAll(archiveDir, 0755); err != nil {
+			return fmt.Errorf("failed to create archive directory: %w", err)
+		}
+	}
+
+	pruned := 0
+	for _, name := range candidates {
+		path := filepath.Join(m.config.ThemesDir, name)
+
+		var err error
+		if action == PruneArchive {
+			err = os.Rename(path, filepath.Join(archiveDir, name))
+		} else {
+			err = m.MoveToTrash(path, TrashTheme)
+		}
+		if err != nil {
+			ui.PrintWarning("Failed to prune %s: %v", name, err)
+			continue
+		}
+		pruned++
+	}
+
+	if action == PruneArchive {
+		ui.PrintSuccess("Archived %d themes to %s", pruned, archiveDir)
+	} else {
+		ui.PrintSuccess("Deleted %d themes (recoverable for %d days with 'alacritty-colors trash restore')", pruned, int(TrashExpiry.Hours()/24))
+	}
+
+	return nil
+}
+
+func themeNameFromFile(filename string) string {
+	return filename[:len(filename)-len(filepath.Ext(filename))]
+}
@@ -0,0 +1,180 @@
+package theme
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/downloader"
+)
+
+// Quality score weights, out of 100
+const (
+	qualityTextWeight       = 20
+	qualityAccentWeight     = 20
+	qualityBrightWeight     = 20
+	qualityCompleteWeight   = 25
+	qualityDuplicatesWeight = 15
+	// qualityDuplicatePenalty is taken off the duplicates share for each
+	// repeated color
+	qualityDuplicatePenalty = 5
+)
+
+// Thresholds the quality score checks colors against
+const (
+	// accentContrast is the WCAG ratio for large text and UI parts, which
+	// accents used for highlights should meet on the background
+	accentContrast = 3
+	// distinctBright is the CIE76 distance from which a bright color
+	// looks different from its normal one
+	distinctBright = 5
+	// duplicateDistance is the CIE76 distance under which two slots show
+	// the same color
+	duplicateDistance = 2
+)
+
+// paletteKeys are the colors a complete theme sets
+var paletteKeys = func() []string {
+	keys := []string{"background", "foreground"}
+	for _, table := range []string{"normal", "bright"} {
+		for _, name := range ansiColors {
+			keys = append(keys, table+"_"+name)
+		}
+	}
+	return keys
+}()
+
+// themeQuality rates how well a theme's palette is built, so broken
+// community themes can be told apart from deliberate low-key ones
+type themeQuality struct {
+	Score int `json:"score"`
+	// Missing are palette colors the theme leaves out
+	Missing []string `json:"missing,omitempty"`
+	// LowContrast are accents hard to make out on the background
+	LowContrast []string `json:"low_contrast,omitempty"`
+	// SameBrights are colors whose bright variant looks like the normal one
+	SameBrights []string `json:"same_brights,omitempty"`
+	// Duplicates are palette colors that repeat another slot's
+	Duplicates []string `json:"duplicates,omitempty"`
+}
+
+// qualityOf scores a theme on text contrast, accent contrast, distinct
+// bright colors, completeness and repeated colors
+func qualityOf(t ThemeInfo) themeQuality {
+	var q themeQuality
+	colors := make(map[string]RGB)
+	for _, key := range paletteKeys {
+		if rgb, err := ParseColor(t.Colors[key]); err == nil {
+			colors[key] = rgb
+		} else {
+			q.Missing = append(q.Missing, key)
+		}
+	}
+
+	score := float64(qualityCompleteWeight) * float64(len(paletteKeys)-len(q.Missing)) / float64(len(paletteKeys))
+
+	bg, hasBg := colors["background"]
+	if fg, ok := colors["foreground"]; ok && hasBg {
+		score += qualityTextWeight * math.Min(GetContrastRatio(fg, bg)/4.5, 1)
+	}
+
+	readable := 0
+	for _, slot := range accentSlots {
+		rgb, ok := colors[slot]
+		if ok && hasBg && GetContrastRatio(rgb, bg) >= accentContrast {
+			readable++
+		} else if ok {
+			q.LowContrast = append(q.LowContrast, slot)
+		}
+	}
+	score += qualityAccentWeight * float64(readable) / float64(len(accentSlots))
+
+	distinct := 0
+	for _, name := range ansiColors {
+		normal, okNormal := colors["normal_"+name]
+		bright, okBright := colors["bright_"+name]
+		if okNormal && okBright && ColorDistance(normal, bright) >= distinctBright {
+			distinct++
+		} else if okNormal && okBright {
+			q.SameBrights = append(q.SameBrights, name)
+		}
+	}
+	score += qualityBrightWeight * float64(distinct) / float64(len(ansiColors))
+
+	// A bright color repeating its normal one is counted above; any other
+	// pair of slots showing the same color is a duplicate
+	keys := paletteKeys[2:]
+	for i, key := range keys {
+		rgb, ok := colors[key]
+		if !ok {
+			continue
+		}
+		for _, earlier := range keys[:i] {
+			other, ok := colors[earlier]
+			if ok && slotName(earlier) != slotName(key) && ColorDistance(rgb, other) < duplicateDistance {
+				q.Duplicates = append(q.Duplicates, fmt.Sprintf("%s = %s", key, earlier))
+				break
+			}
+		}
+	}
+	score += float64(max(0, qualityDuplicatesWeight-qualityDuplicatePenalty*len(q.Duplicates)))
+
+	q.Score = int(score + 0.5)
+	return q
+}
+
+// slotName is the color of a key such as "bright_red"
+func slotName(key string) string {
+	_, name, _ := strings.Cut(key, "_")
+	return name
+}
+
+// withScore attaches the quality score to each theme, from the metadata
+// index when it has one
+func (m *Manager) withScore(themes []ThemeInfo) []ThemeInfo {
+	index := downloader.New(m.config.ThemesDir, m.config.StateDir).LoadMetadata()
+	for i := range themes {
+		themes[i].Score = index[themes[i].Name].Score
+		if themes[i].Score == 0 {
+			themes[i].Score = qualityOf(themes[i]).Score
+		}
+	}
+	return themes
+}
+
+// filterScore keeps the themes scoring at least min
+func (m *Manager) filterScore(themes []ThemeInfo, min int) []ThemeInfo {
+	var kept []ThemeInfo
+	for _, t := range themes {
+		if t.Score >= min {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// scoreBadge renders a score such as "82/100"
+func scoreBadge(score int) string {
+	if score <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/100", score)
+}
+
+// issues lists what costs the theme points, for explain
+func (q themeQuality) issues() []string {
+	var issues []string
+	if len(q.Missing) > 0 {
+		issues = append(issues, "missing "+strings.Join(q.Missing, ", "))
+	}
+	if len(q.LowContrast) > 0 {
+		issues = append(issues, "hard to read on the background: "+strings.Join(q.LowContrast, ", "))
+	}
+	if len(q.SameBrights) > 0 {
+		issues = append(issues, "bright same as normal: "+strings.Join(q.SameBrights, ", "))
+	}
+	if len(q.Duplicates) > 0 {
+		issues = append(issues, "repeated colors: "+strings.Join(q.Duplicates, ", "))
+	}
+	return issues
+}
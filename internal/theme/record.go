@@ -0,0 +1,196 @@
+package theme
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// RecordingsDir, inside the data directory, holds saved recordings
+const RecordingsDir = "recordings"
+
+// recordingFile marks a recording in progress in the state directory
+const recordingFile = "recording.json"
+
+// recording is the marker 'record start' leaves for 'record stop'
+type recording struct {
+	StartedAt time.Time `json:"started_at"`
+	// Theme is the theme applied when recording started, the session's
+	// first step
+	Theme string `json:"theme,omitempty"`
+}
+
+func (m *Manager) recordingPath() string {
+	return filepath.Join(m.config.StateDir, recordingFile)
+}
+
+func (m *Manager) recordingsDir() string {
+	return filepath.Join(m.config.DataDir, RecordingsDir)
+}
+
+// StartRecording starts capturing applied themes. Nothing runs in the
+// background: 'record stop' reads what was applied since from the history.
+func (m *Manager) StartRecording() error {
+	if data, err := os.ReadFile(m.recordingPath()); err == nil {
+		var r recording
+		if json.Unmarshal(data, &r) == nil {
+			return ui.WithHints(fmt.Errorf("already recording since %s", r.StartedAt.Format("15:04:05")),
+				"Save it with: alacritty-colors record stop")
+		}
+	}
+
+	data, err := json.MarshalIndent(recording{StartedAt: time.Now(), Theme: m.GetCurrentTheme()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.config.StateDir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(m.recordingPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to start recording: %w", err)
+	}
+
+	ui.PrintSuccess("Recording theme changes")
+	ui.PrintInfo("Apply themes as usual, then save the session with: alacritty-colors record stop")
+	return nil
+}
+
+// StopRecording saves the themes applied since StartRecording, with their
+// times, as a session file. output defaults to a timestamped file in the
+// recordings directory; a bare name goes there too.
+func (m *Manager) StopRecording(output string) error {
+	data, err := os.ReadFile(m.recordingPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ui.WithHints(fmt.Errorf("not recording"), "Start with: alacritty-colors record start")
+		}
+		return err
+	}
+	var r recording
+	if err := json.Unmarshal(data, &r); err != nil {
+		return fmt.Errorf("failed to read recording state: %w", err)
+	}
+
+	history, err := m.loadHistory()
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+	var session []HistoryEntry
+	if r.Theme != "" {
+		session = append(session, HistoryEntry{Theme: r.Theme, AppliedAt: r.StartedAt})
+	}
+	for _, entry := range history {
+		if entry.AppliedAt.After(r.StartedAt) {
+			session = append(session, entry)
+		}
+	}
+
+	path := m.recordingOutput(output, r.StartedAt)
+	var buf bytes.Buffer
+	for _, entry := range session {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to save recording: %w", err)
+	}
+	os.Remove(m.recordingPath())
+
+	ui.PrintSuccess("Recorded %d theme change(s) over %s to %s", len(session), time.Since(r.StartedAt).Round(time.Second), path)
+	name := path
+	if filepath.Dir(path) == m.recordingsDir() {
+		name = strings.TrimSuffix(filepath.Base(path), ".jsonl")
+	}
+	ui.PrintInfo("Replay it with: alacritty-colors replay %s", name)
+	return nil
+}
+
+// recordingOutput resolves where 'record stop' saves a session
+func (m *Manager) recordingOutput(output string, startedAt time.Time) string {
+	if output == "" {
+		output = "session-" + startedAt.Format("2006-01-02_15-04-05")
+	}
+	if !strings.ContainsAny(output, `/\`) {
+		output = filepath.Join(m.recordingsDir(), output)
+	}
+	if filepath.Ext(output) == "" {
+		output += ".jsonl"
+	}
+	return output
+}
+
+// loadRecording reads a session file, looked up in the recordings
+// directory when file is a bare name
+func (m *Manager) loadRecording(file string) ([]HistoryEntry, string, error) {
+	path := file
+	if _, err := os.Stat(path); err != nil {
+		path = m.recordingOutput(file, time.Time{})
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", ui.WithHints(fmt.Errorf("recording not found: %s", file),
+				"Recordings are saved in "+m.recordingsDir())
+		}
+		return nil, "", err
+	}
+	defer f.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.Theme == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, path, scanner.Err()
+}
+
+// Replay applies the themes of a recorded session in order. The recorded
+// gaps between changes are kept unless interval is set, which replaces them.
+func (m *Manager) Replay(file string, interval time.Duration) error {
+	if err := m.checkLock(); err != nil {
+		return err
+	}
+	entries, path, err := m.loadRecording(file)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("recording %s has no theme changes", path)
+	}
+
+	ui.PrintHeader(fmt.Sprintf("Replaying %s", filepath.Base(path)))
+	for i, entry := range entries {
+		if i > 0 {
+			wait := interval
+			if wait <= 0 {
+				wait = entry.AppliedAt.Sub(entries[i-1].AppliedAt)
+			}
+			time.Sleep(wait)
+		}
+		ui.PrintStep(i+1, len(entries), entry.Theme)
+		if err := m.applyAutomatic(entry.Theme, sourceReplay); err != nil {
+			ui.PrintWarning("Skipping %s: %v", entry.Theme, err)
+		}
+	}
+
+	ui.PrintSuccess("Replayed %d theme change(s)", len(entries))
+	return nil
+}
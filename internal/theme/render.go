@@ -0,0 +1,321 @@
+package theme
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// RenderOptions say which themes to draw and where the image goes
+type RenderOptions struct {
+	Themes     []string
+	Collection string
+	Output     string
+	// Animate writes a GIF with one frame per theme
+	Animate bool
+	Delay   time.Duration
+	Scale   int
+}
+
+// renderSpan is a run of text in the mock terminal. Colors name a slot as
+// "table.key"; an empty background leaves the terminal background.
+type renderSpan struct {
+	text, fg, bg string
+}
+
+// Mock terminal geometry, in cells and pixels at scale 1
+const (
+	renderCols    = 48
+	renderPadding = 8
+	cellWidth     = glyphWidth + 1
+	cellHeight    = glyphHeight + 4
+)
+
+// renderPrompt starts each command line of the mock terminal
+var renderPrompt = []renderSpan{{"~/dotfiles", "normal.green", ""}, {" $ ", "primary.foreground", ""}}
+
+// renderLines is the mock terminal session: a listing, git status, some
+// code and the palette
+var renderLines = [][]renderSpan{
+	append(renderPrompt, renderSpan{"ls", "primary.foreground", ""}),
+	{{"config/", "normal.blue", ""}, {"  ", "", ""}, {"themes/", "normal.blue", ""}, {"  ", "", ""},
+		{"install.sh", "normal.green", ""}, {"  ", "", ""}, {"alacritty.toml", "normal.cyan", ""}},
+	append(renderPrompt, renderSpan{"git status -s", "primary.foreground", ""}),
+	{{" M", "normal.red", ""}, {" README.md", "primary.foreground", ""}},
+	{{"A ", "normal.green", ""}, {" themes/nord.toml", "primary.foreground", ""}},
+	append(renderPrompt, renderSpan{"cat hello.go", "primary.foreground", ""}),
+	{{"func", "normal.magenta", ""}, {" ", "", ""}, {"main", "normal.blue", ""}, {"() {", "primary.foreground", ""}},
+	{{"    fmt.Println(", "primary.foreground", ""}, {`"hello, world"`, "normal.yellow", ""}, {")", "primary.foreground", ""}},
+	{{"    // warning: ", "bright.black", ""}, {"todo", "bright.yellow", ""}},
+	{{"}", "primary.foreground", ""}},
+	renderPalette("normal"),
+	renderPalette("bright"),
+	append(renderPrompt, renderSpan{" ", "", "cursor.cursor"}),
+}
+
+// renderPalette is a row of blocks in the eight colors of a table
+func renderPalette(table string) []renderSpan {
+	spans := make([]renderSpan, 0, len(ansiColors))
+	for _, name := range ansiColors {
+		spans = append(spans, renderSpan{"     ", "", table + "." + name}, renderSpan{" ", "", ""})
+	}
+	return spans
+}
+
+// Render draws themes over a mock terminal, to a PNG for a single theme or
+// to an animated GIF cycling through them
+func (m *Manager) Render(opts RenderOptions) error {
+	themes, err := m.renderThemes(opts)
+	if err != nil {
+		return err
+	}
+	if opts.Scale < 1 || opts.Scale > 8 {
+		return fmt.Errorf("scale must be between 1 and 8, got %d", opts.Scale)
+	}
+	if opts.Animate && opts.Delay < 100*time.Millisecond {
+		return fmt.Errorf("delay must be at least 100ms, got %s", opts.Delay)
+	}
+
+	output := opts.Output
+	if output == "" {
+		output = themes[0].Name + ".png"
+		if opts.Animate {
+			output = "themes.gif"
+		}
+	}
+	ext := strings.ToLower(filepath.Ext(output))
+	if ext != ".png" && ext != ".gif" {
+		return ui.WithHints(fmt.Errorf("unsupported image format '%s'", ext),
+			"Write a .png for one theme or a .gif for an animation.")
+	}
+	if opts.Animate && ext != ".gif" {
+		return ui.WithHints(fmt.Errorf("animations can only be written as GIF"),
+			fmt.Sprintf("Use an output name ending in .gif, e.g. -o %s.gif", strings.TrimSuffix(output, filepath.Ext(output))))
+	}
+	if !opts.Animate && len(themes) > 1 {
+		return ui.WithHints(fmt.Errorf("%d themes given without --animate", len(themes)),
+			"Add --animate to cycle through them in a GIF.")
+	}
+
+	frames := make([]*image.Paletted, 0, len(themes))
+	for _, t := range themes {
+		colors, err := renderColors(t.FilePath)
+		if err != nil {
+			return fmt.Errorf("failed to read theme %s: %w", t.Name, err)
+		}
+		frames = append(frames, renderFrame(t.Name, colors, opts.Scale))
+		m.logVerbose("Rendered %s", t.Name)
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create image: %w", err)
+	}
+	defer f.Close()
+
+	if ext == ".png" {
+		err = png.Encode(f, frames[0])
+	} else {
+		err = gif.EncodeAll(f, animation(frames, opts.Delay))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+
+	if len(frames) > 1 {
+		ui.PrintSuccess("Rendered %d themes to %s", len(frames), output)
+	} else {
+		ui.PrintSuccess("Rendered %s to %s", themes[0].Name, output)
+	}
+	return nil
+}
+
+// renderThemes resolves the named themes, or the themes of a collection
+func (m *Manager) renderThemes(opts RenderOptions) ([]ThemeInfo, error) {
+	if opts.Collection != "" {
+		if len(opts.Themes) > 0 {
+			return nil, fmt.Errorf("give either theme names or --collection, not both")
+		}
+		all, err := m.getThemeInfos()
+		if err != nil {
+			return nil, err
+		}
+		themes, err := m.filterCollection(all, opts.Collection)
+		if err != nil {
+			return nil, err
+		}
+		if len(themes) == 0 {
+			return nil, fmt.Errorf("collection '%s' has no installed themes", opts.Collection)
+		}
+		return themes, nil
+	}
+
+	if len(opts.Themes) == 0 {
+		return nil, ui.WithHints(fmt.Errorf("no themes to render"),
+			"Name one or more themes, or pick a collection with --collection.")
+	}
+	themes := make([]ThemeInfo, 0, len(opts.Themes))
+	for _, name := range opts.Themes {
+		t, err := m.findTheme(name)
+		if err != nil {
+			return nil, err
+		}
+		themes = append(themes, *t)
+	}
+	return themes, nil
+}
+
+// renderColors reads every color slot of a theme, with Alacritty's
+// defaults for those it leaves out
+func renderColors(path string) (map[string]RGB, error) {
+	text, err := readConfigText(path)
+	if err != nil {
+		return nil, err
+	}
+	return paletteOf(text.Lines), nil
+}
+
+// paletteOf is renderColors for a theme already read into lines
+func paletteOf(lines []string) map[string]RGB {
+	values, _ := colorTables(lines)
+
+	colors := make(map[string]RGB)
+	for _, slot := range colorSlots {
+		key := slot.Table + "." + slot.Key
+		value, ok := values[key]
+		if !ok {
+			value = resolveDefault(slot, values)
+		}
+		if rgb, err := ParseColor(value); err == nil {
+			colors[key] = rgb
+		}
+	}
+	// Cell colors such as CellForeground have no fixed value
+	if _, ok := colors["cursor.cursor"]; !ok {
+		colors["cursor.cursor"] = colors["primary.foreground"]
+	}
+	return colors
+}
+
+// renderFrame draws the mock terminal in a theme's colors, with the theme
+// name in the title bar
+func renderFrame(name string, colors map[string]RGB, scale int) *image.Paletted {
+	titleHeight := cellHeight + renderPadding
+	width := renderCols*cellWidth + 2*renderPadding
+	height := titleHeight + len(renderLines)*cellHeight + 2*renderPadding
+
+	bg := colors["primary.background"]
+	fg := colors["primary.foreground"]
+	bar := mix(bg, fg, 0.12)
+
+	var palette color.Palette
+	index := make(map[RGB]uint8)
+	paletteIndex := func(c RGB) uint8 {
+		if i, ok := index[c]; ok {
+			return i
+		}
+		index[c] = uint8(len(palette))
+		palette = append(palette, color.RGBA{uint8(c.R), uint8(c.G), uint8(c.B), 255})
+		return index[c]
+	}
+	// A theme has at most a few dozen distinct colors, well within the
+	// 256 a GIF frame allows
+	for _, c := range []RGB{bg, fg, bar} {
+		paletteIndex(c)
+	}
+	for _, slot := range colorSlots {
+		if c, ok := colors[slot.Table+"."+slot.Key]; ok {
+			paletteIndex(c)
+		}
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, width*scale, height*scale), palette)
+	fill := func(x, y, w, h int, c RGB) {
+		i := paletteIndex(c)
+		for py := y * scale; py < (y+h)*scale; py++ {
+			for px := x * scale; px < (x+w)*scale; px++ {
+				img.SetColorIndex(px, py, i)
+			}
+		}
+	}
+	drawText := func(x, y int, text string, c RGB) {
+		for _, r := range text {
+			glyph := glyphFor(r)
+			for gy, row := range glyph {
+				for gx, bit := range row {
+					if bit == '#' {
+						fill(x+gx, y+gy, 1, 1, c)
+					}
+				}
+			}
+			x += cellWidth
+		}
+	}
+
+	fill(0, 0, width, height, bg)
+
+	// Title bar with window buttons and the theme name centered
+	fill(0, 0, width, titleHeight, bar)
+	for i, button := range []string{"normal.red", "normal.yellow", "normal.green"} {
+		drawDot(fill, renderPadding+3+i*10, titleHeight/2, 3, colors[button])
+	}
+	title := []rune(name)
+	if len(title) > renderCols-8 {
+		title = append(title[:renderCols-9], '.')
+	}
+	drawText((width-len(title)*cellWidth)/2, (titleHeight-glyphHeight)/2, string(title), fg)
+
+	for row, line := range renderLines {
+		x := renderPadding
+		y := titleHeight + renderPadding + row*cellHeight
+		for _, span := range line {
+			spanWidth := len([]rune(span.text)) * cellWidth
+			if c, ok := colors[span.bg]; ok {
+				fill(x, y, spanWidth, cellHeight-2, c)
+			}
+			if c, ok := colors[span.fg]; ok {
+				drawText(x, y+2, span.text, c)
+			}
+			x += spanWidth
+		}
+	}
+	return img
+}
+
+// drawDot fills a circle around a center point
+func drawDot(fill func(x, y, w, h int, c RGB), cx, cy, radius int, c RGB) {
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			if dx*dx+dy*dy <= radius*radius {
+				fill(cx+dx, cy+dy, 1, 1, c)
+			}
+		}
+	}
+}
+
+// mix blends b into a by the given amount
+func mix(a, b RGB, amount float64) RGB {
+	blend := func(x, y int) int {
+		return int(float64(x) + (float64(y)-float64(x))*amount + 0.5)
+	}
+	return RGB{R: blend(a.R, b.R), G: blend(a.G, b.G), B: blend(a.B, b.B)}
+}
+
+// animation turns frames into a looping GIF that shows each for delay
+func animation(frames []*image.Paletted, delay time.Duration) *gif.GIF {
+	anim := &gif.GIF{LoopCount: 0}
+	for _, frame := range frames {
+		anim.Image = append(anim.Image, frame)
+		// GIF delays are in hundredths of a second
+		anim.Delay = append(anim.Delay, int(delay/(10*time.Millisecond)))
+	}
+	return anim
+}
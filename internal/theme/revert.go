@@ -0,0 +1,96 @@
+package theme
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// revertBackupFile, in the state directory, keeps current.toml while a
+// safe-mode apply waits for an answer
+const revertBackupFile = "revert_backup.toml"
+
+// applyWithRevert applies a theme like ApplyThemeWithOptions, then puts the
+// previous theme and config back unless the user keeps the new one within
+// opts.RevertAfter. Nothing typed in time, e.g. because the theme made the
+// terminal unreadable, means revert, as with a display resolution change.
+func (m *Manager) applyWithRevert(themeName string, opts *ApplyOptions) error {
+	previous := m.GetCurrentTheme()
+	if previous == "" {
+		return ui.WithHints(fmt.Errorf("--revert-after needs a theme to go back to"),
+			"Apply one first: alacritty-colors apply <theme>")
+	}
+
+	if err := os.MkdirAll(m.config.StateDir, 0755); err != nil {
+		return err
+	}
+	backupPath := filepath.Join(m.config.StateDir, revertBackupFile)
+	if err := m.saveCurrent(backupPath); err != nil {
+		return fmt.Errorf("failed to save the current theme: %w", err)
+	}
+	configData, err := os.ReadFile(m.config.ConfigFile)
+	if err != nil {
+		os.Remove(backupPath)
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	applyOpts := *opts
+	applyOpts.RevertAfter = 0
+	if err := m.ApplyThemeWithOptions(themeName, &applyOpts); err != nil {
+		os.Remove(backupPath)
+		return err
+	}
+	applied := m.GetCurrentTheme()
+
+	fmt.Println()
+	keep, answered := ui.PromptConfirmTimeout(
+		fmt.Sprintf("Keep '%s'? Reverting to '%s' in %s.", applied, previous, opts.RevertAfter), opts.RevertAfter)
+	if keep {
+		os.Remove(backupPath)
+		ui.PrintSuccess("Keeping theme '%s'", applied)
+		return nil
+	}
+	if !answered {
+		ui.PrintWarning("No answer within %s", opts.RevertAfter)
+	}
+	return m.revertApply(previous, backupPath, configData)
+}
+
+// revertApply puts back the theme and config saved before a safe-mode apply
+func (m *Manager) revertApply(previous, backupPath string, configData []byte) error {
+	unlock, err := m.lockCurrent()
+	if err != nil {
+		return fmt.Errorf("failed to revert theme: %w", err)
+	}
+	defer unlock()
+
+	if err := m.restoreCurrent(backupPath); err != nil {
+		return fmt.Errorf("failed to revert theme: %w", err)
+	}
+	// Font and effect options change alacritty.toml too
+	if data, err := os.ReadFile(m.config.ConfigFile); err != nil || !bytes.Equal(data, configData) {
+		if err := os.WriteFile(m.config.ConfigFile, configData, 0644); err != nil {
+			ui.PrintWarning("Failed to restore %s: %v", m.config.ConfigFile, err)
+		}
+	}
+
+	if err := m.config.SetCurrentTheme(previous); err != nil {
+		ui.PrintWarning("Failed to update theme tracking: %v", err)
+	}
+	if err := m.writePromptCache(previous, m.currentThemeFile()); err != nil {
+		m.logVerbose("Failed to update prompt cache: %v", err)
+	}
+	if err := m.recordHistory(previous); err != nil {
+		m.logVerbose("Failed to record history: %v", err)
+	}
+	if err := m.recordApplied(previous); err != nil {
+		m.logVerbose("Failed to record applied theme: %v", err)
+	}
+	m.syncTargets(previous)
+
+	ui.PrintSuccess("Reverted to theme '%s'", previous)
+	return nil
+}
@@ -0,0 +1,181 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// Scheduler periods
+const (
+	PeriodLight = "light"
+	PeriodDark  = "dark"
+)
+
+// schedulerInterval is how often the daemon checks the clock
+const schedulerInterval = time.Minute
+
+// settingsPollInterval is how often the daemon looks for settings changes
+const settingsPollInterval = 2 * time.Second
+
+// SchedulePeriod returns whether the light or dark theme is due at t
+func (m *Manager) SchedulePeriod(t time.Time) (string, error) {
+	s := m.config.Scheduler
+	lightAt, err := minuteOfDay(s.LightAt)
+	if err != nil {
+		return "", fmt.Errorf("invalid scheduler.light_at: %w", err)
+	}
+	darkAt, err := minuteOfDay(s.DarkAt)
+	if err != nil {
+		return "", fmt.Errorf("invalid scheduler.dark_at: %w", err)
+	}
+
+	now := t.Hour()*60 + t.Minute()
+	light := now >= lightAt && now < darkAt
+	if lightAt > darkAt {
+		// The light period wraps around midnight
+		light = now >= lightAt || now < darkAt
+	}
+
+	if light {
+		return PeriodLight, nil
+	}
+	return PeriodDark, nil
+}
+
+// scheduledTheme returns the period and theme due at t
+func (m *Manager) scheduledTheme(t time.Time) (string, string, error) {
+	s := m.config.Scheduler
+	if s.LightTheme == "" || s.DarkTheme == "" {
+		return "", "", fmt.Errorf("set scheduler.light_theme and scheduler.dark_theme in %s", m.config.Path())
+	}
+
+	period, err := m.SchedulePeriod(t)
+	if err != nil {
+		return "", "", err
+	}
+	if period == PeriodLight {
+		return period, s.LightTheme, nil
+	}
+	return period, s.DarkTheme, nil
+}
+
+// RunSchedule applies the theme due now, unless it is already applied. Runs
+// from a timer, so a disabled scheduler is not an error.
+func (m *Manager) RunSchedule() error {
+	if !m.config.Scheduler.Enabled {
+		ui.PrintInfo("Scheduler is disabled; set scheduler.enabled = true in %s", m.config.Path())
+		return nil
+	}
+
+	period, name, err := m.scheduledTheme(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if m.config.CurrentTheme == name {
+		m.logVerbose("Theme '%s' for the %s period is already applied", name, period)
+		return nil
+	}
+	if l, locked := m.activeLock(); locked {
+		ui.PrintInfo("Theme changes are locked %s; keeping '%s'", l.describe(), m.config.CurrentTheme)
+		return nil
+	}
+
+	ui.PrintInfo("Switching to the %s theme", period)
+	return m.applyScheduled(name)
+}
+
+// applyScheduled applies a theme for the scheduler, fading into it over
+// scheduler.transition
+func (m *Manager) applyScheduled(name string) error {
+	m.transition = m.config.Scheduler.TransitionDuration()
+	defer func() { m.transition = 0 }()
+	return m.applyAutomatic(name, sourceScheduler)
+}
+
+// RunDaemon keeps the scheduled theme applied until a signal arrives. It
+// only switches when the period changes, so a theme applied by hand stays
+// until the next transition.
+func (m *Manager) RunDaemon(stop <-chan os.Signal) error {
+	if !m.config.Scheduler.Enabled {
+		return fmt.Errorf("scheduler is disabled; set scheduler.enabled = true in %s", m.config.Path())
+	}
+
+	ui.PrintInfo("Scheduler running: light at %s, dark at %s", m.config.Scheduler.LightAt, m.config.Scheduler.DarkAt)
+
+	lastPeriod := ""
+	check := func() {
+		period, name, err := m.scheduledTheme(time.Now())
+		if err != nil {
+			ui.PrintWarning("%v", err)
+			return
+		}
+		if period == lastPeriod {
+			return
+		}
+		// A locked theme stays; the period is switched once the lock ends
+		if _, locked := m.activeLock(); locked {
+			m.logVerbose("Theme changes are locked; not switching to the %s theme yet", period)
+			return
+		}
+		lastPeriod = period
+
+		if m.config.CurrentTheme == name {
+			return
+		}
+		ui.PrintInfo("Switching to the %s theme", period)
+		if err := m.applyScheduled(name); err != nil {
+			ui.PrintWarning("Failed to apply scheduled theme: %v", err)
+		}
+	}
+
+	check()
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+
+	// Settings changed by other commands, such as a theme applied by hand
+	// or new switch times, are picked up without a restart
+	done := make(chan struct{})
+	defer close(done)
+	settingsChanged := m.config.Watch(settingsPollInterval, done)
+
+	for {
+		select {
+		case <-ticker.C:
+			if m.config.Scheduler.Enabled {
+				check()
+			}
+		case <-settingsChanged:
+			before := m.config.Scheduler
+			if err := m.config.Reload(); err != nil {
+				ui.PrintWarning("Failed to reload settings: %v", err)
+				continue
+			}
+			m.logVerbose("Reloaded settings")
+			if m.config.Scheduler == before {
+				continue
+			}
+			if !m.config.Scheduler.Enabled {
+				ui.PrintInfo("Scheduler disabled in settings; waiting until it is enabled again")
+				continue
+			}
+			ui.PrintInfo("Schedule changed: light at %s, dark at %s", m.config.Scheduler.LightAt, m.config.Scheduler.DarkAt)
+			lastPeriod = ""
+			check()
+		case <-stop:
+			ui.PrintInfo("Scheduler stopped")
+			return nil
+		}
+	}
+}
+
+func minuteOfDay(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
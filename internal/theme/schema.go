@@ -0,0 +1,119 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/vitruves/alacritty-colors/internal/config"
+	"github.com/vitruves/alacritty-colors/internal/schema"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+	"github.com/vitruves/alacritty-colors/pkg/alacritty"
+)
+
+// Documents 'export json-schema' writes
+const (
+	SchemaTheme    = "theme"
+	SchemaSettings = "settings"
+)
+
+// SchemaKinds lists the documents 'export json-schema' writes
+var SchemaKinds = []string{SchemaTheme, SchemaSettings}
+
+// schemaFileNames are the names used when --output is a directory
+var schemaFileNames = map[string]string{
+	SchemaTheme:    "alacritty-theme.schema.json",
+	SchemaSettings: "alacritty-colors.schema.json",
+}
+
+// Colors as Alacritty reads them; cursor and selection colors may also take
+// the color of the cell they cover
+const (
+	colorPattern     = `^(#|0x)[0-9a-fA-F]{6}$`
+	cellColorPattern = `^((#|0x)[0-9a-fA-F]{6}|CellForeground|CellBackground)$`
+)
+
+// cellColorTables are the [colors] tables whose colors may be cell colors
+var cellColorTables = map[string]bool{"cursor": true, "vi_mode_cursor": true, "selection": true}
+
+// ThemeSchema describes a theme file as a JSON Schema, built from the
+// color tables the parser reads. Other Alacritty settings are allowed.
+func ThemeSchema() schema.Schema {
+	colors := schema.Of(reflect.TypeOf(alacritty.ColorScheme{}), "toml")
+	for name, value := range colors["properties"].(schema.Schema) {
+		table := value.(schema.Schema)
+		pattern := colorPattern
+		if cellColorTables[name] {
+			pattern = cellColorPattern
+		}
+
+		switch {
+		case name == "indexed_colors":
+			// Alacritty lists these as { index, color } tables
+			table = schema.Schema{"type": "array", "items": schema.Schema{
+				"type": "object",
+				"properties": schema.Schema{
+					"index": schema.Schema{"type": "integer", "minimum": 16, "maximum": 255},
+					"color": schema.Schema{"type": "string", "pattern": colorPattern},
+				},
+				"required": []string{"index", "color"},
+			}}
+		case table["additionalProperties"] != nil:
+			// normal, bright and dim hold the eight ANSI colors
+			properties := schema.Schema{}
+			for _, color := range alacritty.ANSIColors {
+				properties[color] = schema.Schema{"type": "string", "pattern": pattern}
+			}
+			table = schema.Schema{"type": "object", "properties": properties, "additionalProperties": false}
+		case table["properties"] != nil:
+			for key := range table["properties"].(schema.Schema) {
+				table["properties"].(schema.Schema)[key] = schema.Schema{"type": "string", "pattern": pattern}
+			}
+		}
+		colors["properties"].(schema.Schema)[name] = table
+	}
+
+	return schema.Document("Alacritty theme", schema.Schema{
+		"type":       "object",
+		"properties": schema.Schema{"colors": colors},
+		"required":   []string{"colors"},
+	})
+}
+
+// ExportSchema writes the JSON Schema of theme files or of the settings
+// file to stdout, or to output. A directory gets the document under its
+// usual name.
+func (m *Manager) ExportSchema(kind, output string) error {
+	var doc schema.Schema
+	switch kind {
+	case SchemaTheme:
+		doc = ThemeSchema()
+	case SchemaSettings:
+		doc = config.SettingsSchema()
+	default:
+		return ui.WithHints(fmt.Errorf("unknown schema: %s", kind),
+			"Use one of: theme, settings")
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode schema: %w", err)
+	}
+	data = append(data, '\n')
+
+	if output == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if info, err := os.Stat(output); err == nil && info.IsDir() {
+		output = filepath.Join(output, schemaFileNames[kind])
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+
+	ui.PrintSuccess("Exported the %s schema to %s", kind, output)
+	return nil
+}
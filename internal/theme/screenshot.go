@@ -0,0 +1,222 @@
+package theme
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// Screenshot extraction modes
+const (
+	// ScreenshotTerminal reads the colors of a terminal screenshot
+	ScreenshotTerminal = "terminal"
+	// ScreenshotWallpaper derives a palette from the image's mood, as
+	// watch-wallpaper does
+	ScreenshotWallpaper = "wallpaper"
+)
+
+// ScreenshotModes lists the accepted --mode values
+var ScreenshotModes = []string{ScreenshotTerminal, ScreenshotWallpaper}
+
+// colorBucket is a group of nearly identical pixels: 5 bits per channel
+type colorBucket struct {
+	count   int
+	r, g, b int // channel sums
+}
+
+func (c colorBucket) color() RGB {
+	return RGB{R: c.r / c.count, G: c.g / c.count, B: c.b / c.count}
+}
+
+// generateFromScreenshot builds a theme from the colors of an image
+func (m *Manager) generateFromScreenshot(opts *GenerateOptions) error {
+	colors, err := m.screenshotColors(opts.FromScreenshot, opts.ScreenshotMode)
+	if err != nil {
+		return err
+	}
+
+	base := strings.TrimSuffix(filepath.Base(opts.FromScreenshot), filepath.Ext(opts.FromScreenshot))
+	name := opts.Name
+	if name == "" {
+		name = "screenshot_" + strings.ReplaceAll(strings.ToLower(base), " ", "_")
+	}
+	return m.saveGeneratedTheme(colors, "screenshot:"+filepath.Base(opts.FromScreenshot), name, opts)
+}
+
+// screenshotColors reads a palette from an image, either from the colors a
+// terminal screenshot shows or from the mood of any picture
+func (m *Manager) screenshotColors(path, mode string) (map[string]string, error) {
+	switch mode {
+	case ScreenshotWallpaper:
+		return m.generateImageColors(path)
+	case ScreenshotTerminal, "":
+	default:
+		return nil, fmt.Errorf("unknown mode '%s' (use %s)", mode, strings.Join(ScreenshotModes, " or "))
+	}
+
+	img, err := decodeImage(path)
+	if err != nil {
+		return nil, err
+	}
+
+	colors, detected := extractTerminalColors(img)
+	m.logVerbose("Detected %d of %d colors in the screenshot", len(detected), len(colors))
+	var guessed []string
+	for _, slot := range append([]string{"foreground"}, ansiColors...) {
+		if !detected[slot] {
+			guessed = append(guessed, slot)
+		}
+	}
+	if len(guessed) > 0 {
+		ui.PrintInfo("Not found in the screenshot, derived instead: %s", strings.Join(guessed, ", "))
+	}
+	return colors, nil
+}
+
+// extractTerminalColors takes the dominant flat color as the background,
+// the most common neutral color that stands out from it as the foreground,
+// and sorts the remaining colors into ANSI slots by hue. Slots with no
+// matching pixels are derived from the others. The second result tells
+// which slots were found in the image.
+func extractTerminalColors(img image.Image) (map[string]string, map[string]bool) {
+	buckets := bucketPixels(img)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].count > buckets[j].count })
+
+	colors := make(map[string]string)
+	detected := make(map[string]bool)
+	if len(buckets) == 0 {
+		return colors, detected
+	}
+
+	background := buckets[0].color()
+	bgHSL := background.ToHSL()
+	light := bgHSL.L > 0.5
+	colors["background"] = background.ToHex()
+	detected["background"] = true
+
+	// Text antialiasing leaves blends of every color with the background;
+	// only colors clearly apart from it count
+	var distinct []colorBucket
+	for _, b := range buckets[1:] {
+		if ColorDistance(b.color(), background) > 18 {
+			distinct = append(distinct, b)
+		}
+	}
+
+	foreground := HSL{H: bgHSL.H, S: 0.05, L: 0.85}.ToRGB()
+	if light {
+		foreground = HSL{H: bgHSL.H, S: 0.05, L: 0.2}.ToRGB()
+	}
+	for _, b := range distinct {
+		c := b.color()
+		if chroma(c) < 0.15 && GetContrastRatio(c, background) >= 3 {
+			foreground = c
+			detected["foreground"] = true
+			break
+		}
+	}
+	colors["foreground"] = foreground.ToHex()
+
+	// The most common color near each ANSI hue becomes the normal color; a
+	// lighter one of the same hue, if any, the bright color
+	accentLight := 0.6
+	if light {
+		accentLight = 0.4
+	}
+	for _, target := range ansiHues {
+		var found []RGB
+		for _, b := range distinct {
+			c := b.color()
+			hsl := c.ToHSL()
+			distance := math.Abs(hsl.H - target.hue)
+			distance = math.Min(distance, 1-distance)
+			if chroma(c) >= 0.15 && distance <= 1.0/12 && ColorDistance(c, foreground) > 12 {
+				found = append(found, c)
+			}
+		}
+
+		var normal, bright RGB
+		switch {
+		case len(found) == 0:
+			normal = EnsureContrast(HSL{H: target.hue, S: 0.6, L: accentLight}.ToRGB(), background, 3.0)
+		default:
+			normal = found[0]
+			detected[target.name] = true
+		}
+		bright = lighten(normal, 0.1)
+		for _, c := range found[min(1, len(found)):] {
+			if c.ToHSL().L > normal.ToHSL().L+0.05 && ColorDistance(c, normal) > 8 {
+				bright = c
+				break
+			}
+		}
+		colors[target.name] = normal.ToHex()
+		colors["bright_"+target.name] = bright.ToHex()
+	}
+
+	// Grays sit between the background and foreground
+	fgHSL := foreground.ToHSL()
+	mix := func(t float64) string {
+		return HSL{H: bgHSL.H, S: math.Min(bgHSL.S, 0.15), L: bgHSL.L + (fgHSL.L-bgHSL.L)*t}.ToRGB().ToHex()
+	}
+	colors["black"] = mix(0.15)
+	colors["bright_black"] = mix(0.45)
+	colors["white"] = mix(0.85)
+	colors["bright_white"] = lighten(foreground, 0.08).ToHex()
+	if light {
+		colors["black"], colors["white"] = colors["white"], colors["black"]
+		colors["bright_white"] = mix(0.05)
+		colors["bright_black"] = mix(0.6)
+	}
+	colors["selection_background"] = mix(0.3)
+
+	return colors, detected
+}
+
+// chroma is how far a color is from gray. Unlike HSL saturation it stays
+// low for near-whites and near-blacks.
+func chroma(c RGB) float64 {
+	return float64(max(c.R, max(c.G, c.B))-min(c.R, min(c.G, c.B))) / 255
+}
+
+// bucketPixels groups sampled pixels into buckets of similar colors
+func bucketPixels(img image.Image) []colorBucket {
+	bounds := img.Bounds()
+	step := int(math.Max(1, math.Sqrt(float64(bounds.Dx()*bounds.Dy())/imageSamples)))
+
+	index := make(map[int]*colorBucket)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			r, g, b, _ := img.At(x, y).RGBA()
+			r, g, b = r>>8, g>>8, b>>8
+			key := int(r>>3)<<10 | int(g>>3)<<5 | int(b>>3)
+			bucket := index[key]
+			if bucket == nil {
+				bucket = &colorBucket{}
+				index[key] = bucket
+			}
+			bucket.count++
+			bucket.r += int(r)
+			bucket.g += int(g)
+			bucket.b += int(b)
+		}
+	}
+
+	buckets := make([]colorBucket, 0, len(index))
+	for _, b := range index {
+		buckets = append(buckets, *b)
+	}
+	return buckets
+}
+
+// lighten raises a color's lightness, capped at white
+func lighten(c RGB, amount float64) RGB {
+	hsl := c.ToHSL()
+	hsl.L = math.Min(1, hsl.L+amount)
+	return hsl.ToRGB()
+}
@@ -0,0 +1,207 @@
+package theme
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/config"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// SeasonalScheme is the generator scheme whose palette follows the date
+const SeasonalScheme = "seasonal"
+
+// seasonalPalette is the look of a season or holiday. Accents keep their
+// usual hues, so red still reads as an error, but lean towards hue.
+type seasonalPalette struct {
+	name  string
+	title string
+	hue   float64
+	pull  float64
+	// saturation and lightness are those of the normal accents
+	saturation float64
+	lightness  float64
+	background HSL
+	// accents gives some colors a hue of their own
+	accents map[string]float64
+}
+
+var seasonPalettes = map[string]seasonalPalette{
+	"winter": {
+		name: "winter", title: "winter blues",
+		hue: 0.58, pull: 0.35, saturation: 0.45, lightness: 0.64,
+		background: HSL{H: 0.61, S: 0.3, L: 0.09},
+	},
+	"spring": {
+		name: "spring", title: "spring greens",
+		hue: 0.3, pull: 0.3, saturation: 0.5, lightness: 0.62,
+		background: HSL{H: 0.32, S: 0.18, L: 0.1},
+	},
+	"summer": {
+		name: "summer", title: "summer sun and sea",
+		hue: 0.13, pull: 0.2, saturation: 0.75, lightness: 0.58,
+		background: HSL{H: 0.55, S: 0.35, L: 0.1},
+	},
+	"autumn": {
+		name: "autumn", title: "autumn ambers",
+		hue: 0.08, pull: 0.35, saturation: 0.65, lightness: 0.55,
+		background: HSL{H: 0.06, S: 0.3, L: 0.08},
+	},
+}
+
+// seasonalHoliday is a holiday palette and the days it covers, which may
+// wrap around the new year
+type seasonalHoliday struct {
+	from, to [2]int
+	palette  seasonalPalette
+}
+
+var seasonalHolidays = []seasonalHoliday{
+	{[2]int{12, 31}, [2]int{1, 2}, seasonalPalette{
+		name: "new_year", title: "New Year gold",
+		hue: 0.13, pull: 0.3, saturation: 0.6, lightness: 0.6,
+		background: HSL{H: 0.63, S: 0.3, L: 0.07},
+		accents:    map[string]float64{"yellow": 0.13},
+	}},
+	{[2]int{2, 12}, [2]int{2, 14}, seasonalPalette{
+		name: "valentine", title: "Valentine's pinks",
+		hue: 0.95, pull: 0.3, saturation: 0.6, lightness: 0.64,
+		background: HSL{H: 0.95, S: 0.2, L: 0.09},
+		accents:    map[string]float64{"magenta": 0.92},
+	}},
+	{[2]int{3, 15}, [2]int{3, 17}, seasonalPalette{
+		name: "st_patrick", title: "St. Patrick's greens",
+		hue: 0.33, pull: 0.35, saturation: 0.6, lightness: 0.58,
+		background: HSL{H: 0.36, S: 0.25, L: 0.07},
+		accents:    map[string]float64{"yellow": 0.14},
+	}},
+	{[2]int{10, 24}, [2]int{10, 31}, seasonalPalette{
+		name: "halloween", title: "Halloween orange and purple",
+		hue: 0.07, pull: 0.3, saturation: 0.75, lightness: 0.56,
+		background: HSL{H: 0.75, S: 0.25, L: 0.07},
+		accents:    map[string]float64{"yellow": 0.09, "blue": 0.75, "magenta": 0.78},
+	}},
+	{[2]int{12, 20}, [2]int{12, 26}, seasonalPalette{
+		name: "christmas", title: "Christmas red and green",
+		hue: 0.0, pull: 0.2, saturation: 0.6, lightness: 0.52,
+		background: HSL{H: 0.38, S: 0.3, L: 0.08},
+		accents:    map[string]float64{"green": 0.36, "yellow": 0.13},
+	}},
+}
+
+// seasonalHues are the usual hues of the accent colors
+var seasonalHues = map[string]float64{
+	"red": 0, "green": 0.33, "yellow": 0.14, "blue": 0.6, "magenta": 0.83, "cyan": 0.5,
+}
+
+// seasonalPaletteFor returns the holiday palette covering date or else the
+// palette of its season, in the configured hemisphere
+func (m *Manager) seasonalPaletteFor(date time.Time) seasonalPalette {
+	day := [2]int{int(date.Month()), date.Day()}
+	if m.config.Seasonal.Holidays {
+		for _, h := range seasonalHolidays {
+			if inDayRange(day, h.from, h.to) {
+				return h.palette
+			}
+		}
+	}
+
+	month := int(date.Month())
+	if m.config.Seasonal.Hemisphere == config.HemisphereSouth {
+		month = (month+5)%12 + 1
+	}
+	switch {
+	case month == 12 || month <= 2:
+		return seasonPalettes["winter"]
+	case month <= 5:
+		return seasonPalettes["spring"]
+	case month <= 8:
+		return seasonPalettes["summer"]
+	default:
+		return seasonPalettes["autumn"]
+	}
+}
+
+func inDayRange(day, from, to [2]int) bool {
+	after := day[0] > from[0] || day[0] == from[0] && day[1] >= from[1]
+	before := day[0] < to[0] || day[0] == to[0] && day[1] <= to[1]
+	if from[0] > to[0] {
+		return after || before
+	}
+	return after && before
+}
+
+// seasonalColors builds the palette for date. The variations come from
+// the date, so every run on the same day gives the same colors.
+func (m *Manager) seasonalColors(date time.Time) (map[string]string, seasonalPalette) {
+	p := m.seasonalPaletteFor(date)
+	rng := rand.New(rand.NewSource(int64(date.Year()*10000 + int(date.Month())*100 + date.Day())))
+	jitter := func(spread float64) float64 { return (rng.Float64()*2 - 1) * spread }
+
+	colors := make(map[string]string)
+	bg := p.background
+	colors["background"] = bg.ToRGB().ToHex()
+	colors["foreground"] = HSL{H: p.hue, S: 0.25, L: 0.86}.ToRGB().ToHex()
+	colors["selection_background"] = HSL{H: bg.H, S: bg.S, L: bg.L + 0.14}.ToRGB().ToHex()
+	colors["black"] = HSL{H: bg.H, S: bg.S, L: bg.L + 0.07}.ToRGB().ToHex()
+	colors["bright_black"] = HSL{H: bg.H, S: bg.S * 0.6, L: bg.L + 0.3}.ToRGB().ToHex()
+	colors["white"] = HSL{H: p.hue, S: 0.15, L: 0.78}.ToRGB().ToHex()
+	colors["bright_white"] = HSL{H: p.hue, S: 0.1, L: 0.94}.ToRGB().ToHex()
+
+	for _, name := range ansiColors[1:7] {
+		hue, ok := p.accents[name]
+		if !ok {
+			hue = leanHue(seasonalHues[name], p.hue, p.pull)
+		}
+		hue = math.Mod(hue+jitter(0.015)+1, 1)
+		sat := math.Max(0, math.Min(1, p.saturation+jitter(0.06)))
+		light := p.lightness + jitter(0.04)
+
+		colors[name] = HSL{H: hue, S: sat, L: light}.ToRGB().ToHex()
+		colors["bright_"+name] = HSL{H: hue, S: math.Min(1, sat+0.1), L: math.Min(0.88, light+0.12)}.ToRGB().ToHex()
+	}
+
+	return colors, p
+}
+
+// maxLean is the furthest an accent leans, so cyan never turns green
+const maxLean = 0.05
+
+// leanHue moves hue the given fraction of the way towards target, around
+// the shorter side of the color wheel, and by maxLean at most
+func leanHue(hue, target, fraction float64) float64 {
+	shift := math.Remainder(target-hue, 1) * fraction
+	shift = math.Max(-maxLean, math.Min(maxLean, shift))
+	return math.Mod(hue+shift+1, 1)
+}
+
+// generateSeasonal generates the seasonal theme for opts.Date, named after
+// its season or holiday unless opts.Name is set, so a daily run replaces
+// the same theme
+func (m *Manager) generateSeasonal(opts *GenerateOptions) error {
+	date := opts.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	colors, p := m.seasonalColors(date)
+	ui.PrintInfo("Seasonal palette for %s: %s", date.Format("2006-01-02"), p.title)
+
+	name := opts.Name
+	if name == "" {
+		name = SeasonalScheme + "_" + p.name
+	}
+	if opts.DarkTheme {
+		colors = m.convertToDarkVariant(colors)
+		if opts.Name == "" {
+			name += "_dark"
+		}
+	} else if opts.LightTheme {
+		colors = m.convertToLightVariant(colors)
+		if opts.Name == "" {
+			name += "_light"
+		}
+	}
+
+	return m.saveGeneratedTheme(colors, SeasonalScheme, name, opts)
+}
@@ -0,0 +1,254 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// colorSlot is one setting of Alacritty's [colors] tables and what
+// Alacritty uses when a theme leaves it out
+type colorSlot struct {
+	Table, Key, Default string
+}
+
+// colorSlots lists the color settings Alacritty reads, in the order of its
+// documentation. Defaults in capitals are not colors but cell colors
+// picked at render time; empty ones are derived from other settings.
+var colorSlots = func() []colorSlot {
+	slots := []colorSlot{
+		{"primary", "foreground", "#d8d8d8"},
+		{"primary", "background", "#181818"},
+		{"primary", "dim_foreground", ""},
+		{"primary", "bright_foreground", ""},
+		{"cursor", "text", "CellBackground"},
+		{"cursor", "cursor", "CellForeground"},
+		{"vi_mode_cursor", "text", "CellBackground"},
+		{"vi_mode_cursor", "cursor", "CellForeground"},
+		{"search.matches", "foreground", "#181818"},
+		{"search.matches", "background", "#ac4242"},
+		{"search.focused_match", "foreground", "#181818"},
+		{"search.focused_match", "background", "#f4bf75"},
+		{"hints.start", "foreground", "#181818"},
+		{"hints.start", "background", "#f4bf75"},
+		{"hints.end", "foreground", "#181818"},
+		{"hints.end", "background", "#ac4242"},
+		{"line_indicator", "foreground", "None"},
+		{"line_indicator", "background", "None"},
+		{"footer_bar", "foreground", "#181818"},
+		{"footer_bar", "background", "#d8d8d8"},
+		{"selection", "text", "CellBackground"},
+		{"selection", "background", "CellForeground"},
+	}
+	normal := []string{"#181818", "#ac4242", "#90a959", "#f4bf75", "#6a9fb5", "#aa759f", "#75b5aa", "#d8d8d8"}
+	bright := []string{"#6b6b6b", "#c55555", "#aac474", "#feca88", "#82b8c8", "#c28cb8", "#93d3c3", "#f8f8f8"}
+	for i, name := range ansiColors {
+		slots = append(slots, colorSlot{"normal", name, normal[i]})
+	}
+	for i, name := range ansiColors {
+		slots = append(slots, colorSlot{"bright", name, bright[i]})
+	}
+	for _, name := range ansiColors {
+		slots = append(slots, colorSlot{"dim", name, ""})
+	}
+	return slots
+}()
+
+var ansiColors = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// ShowTheme prints every color setting of a theme, filling in what
+// Alacritty would use for those it leaves out. "current" shows current.toml
+// as it is on disk. With raw set, the file is printed unchanged.
+func (m *Manager) ShowTheme(themeName string, raw bool) error {
+	name, path, err := m.resolveShowTarget(themeName)
+	if err != nil {
+		return err
+	}
+
+	if raw {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read theme: %w", err)
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	text, err := readConfigText(path)
+	if err != nil {
+		return fmt.Errorf("failed to read theme: %w", err)
+	}
+	values, extra := colorTables(text.Lines)
+
+	ui.PrintHeader(fmt.Sprintf("Theme: %s", name))
+	ui.PrintKeyValue("File", path)
+
+	fromTheme := 0
+	table := ""
+	for _, slot := range colorSlots {
+		if slot.Table != table {
+			table = slot.Table
+			ui.PrintSubHeader("colors." + table)
+		}
+		value, ok := values[slot.Table+"."+slot.Key]
+		source := ""
+		if ok {
+			fromTheme++
+		} else {
+			value, source = resolveDefault(slot, values), "(default)"
+		}
+		if ui.Accessible() {
+			// The color name goes last so the columns stay aligned
+			fmt.Printf("  %-18s %-18s %s\n", slot.Key, value, strings.TrimSpace(ui.ColorName(value)+" "+source))
+			continue
+		}
+		fmt.Printf("  %-2s %-18s %-18s %s\n", ui.Swatch(value), slot.Key, value, source)
+	}
+
+	if len(extra) > 0 {
+		ui.PrintSubHeader("other settings")
+		for _, line := range extra {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+
+	fmt.Println()
+	ui.PrintInfo("%d of %d color settings come from the theme; the rest are Alacritty defaults", fromTheme, len(colorSlots))
+	return nil
+}
+
+// dimFactor is how much Alacritty darkens colors it derives for dim text
+const dimFactor = 0.66
+
+// resolveDefault returns the value Alacritty uses for a slot the theme
+// leaves out, deriving dim colors from the resolved normal ones
+func resolveDefault(slot colorSlot, values map[string]string) string {
+	lookup := func(table, key string) string {
+		if v, ok := values[table+"."+key]; ok {
+			return v
+		}
+		for _, s := range colorSlots {
+			if s.Table == table && s.Key == key {
+				return s.Default
+			}
+		}
+		return ""
+	}
+
+	switch {
+	case slot.Table == "primary" && slot.Key == "bright_foreground":
+		return lookup("primary", "foreground")
+	case slot.Table == "primary" && slot.Key == "dim_foreground":
+		return dimmed(lookup("primary", "foreground"))
+	case slot.Table == "dim":
+		return dimmed(lookup("normal", slot.Key))
+	}
+	return slot.Default
+}
+
+func dimmed(value string) string {
+	rgb, err := ParseColor(value)
+	if err != nil {
+		return value
+	}
+	return RGB{
+		R: int(float64(rgb.R) * dimFactor),
+		G: int(float64(rgb.G) * dimFactor),
+		B: int(float64(rgb.B) * dimFactor),
+	}.ToHex()
+}
+
+// resolveShowTarget finds the file to show; "current" and an empty name
+// mean current.toml
+func (m *Manager) resolveShowTarget(themeName string) (string, string, error) {
+	if themeName == "" || themeName == "current" {
+		path := m.currentThemeFile()
+		if _, err := os.Stat(path); err != nil {
+			return "", "", ui.WithHints(fmt.Errorf("no current.toml in %s", m.config.ThemesDir),
+				"Apply a theme first: alacritty-colors apply <theme>")
+		}
+		name := "current"
+		if applied := m.GetCurrentTheme(); applied != "" {
+			name = fmt.Sprintf("current (%s)", applied)
+		}
+		return name, path, nil
+	}
+
+	selected, err := m.findTheme(themeName)
+	if err != nil {
+		return "", "", err
+	}
+	return selected.Name, selected.FilePath, nil
+}
+
+// colorTables reads the [colors.*] tables into "table.key" values, with
+// color values normalized to lowercase #rrggbb. Settings outside the known
+// slots, like indexed_colors, are returned as lines.
+func colorTables(lines []string) (map[string]string, []string) {
+	values := make(map[string]string)
+	var extra []string
+	table := ""
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") {
+			header := strings.Trim(trimmed, "[] ")
+			if header == "colors" || strings.HasPrefix(header, "colors.") {
+				table = strings.TrimPrefix(strings.TrimPrefix(header, "colors"), ".")
+				if strings.HasPrefix(trimmed, "[[") {
+					extra = append(extra, trimmed)
+				}
+			} else {
+				table = "-"
+			}
+			continue
+		}
+		if table == "-" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		if table != "" {
+			key = table + "." + key
+		}
+
+		// Keys at the top of [colors], e.g. transparent_background_colors,
+		// have no table
+		if !strings.Contains(key, ".") || !isColorSlot(key) {
+			extra = append(extra, key+" = "+value)
+			continue
+		}
+		values[key] = normalizeHex(strings.Trim(value, `"'`))
+	}
+	return values, extra
+}
+
+func isColorSlot(key string) bool {
+	for _, slot := range colorSlots {
+		if slot.Table+"."+slot.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeHex turns "0xRRGGBB" and "#RGB" into lowercase "#rrggbb" and
+// leaves anything else, like "CellForeground", alone
+func normalizeHex(value string) string {
+	if rgb, err := ParseColor(value); err == nil {
+		return rgb.ToHex()
+	}
+	return value
+}
@@ -0,0 +1,252 @@
+package theme
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+const (
+	// showcasePane is the width of each of the two side-by-side panes
+	showcasePane = 38
+	// rampWidth is the number of cells in the truecolor ramps
+	rampWidth = 64
+	// invisibleContrast is the contrast with the background under which a
+	// palette color is reported as nearly invisible
+	invisibleContrast = 1.5
+)
+
+// showcaseLine is one line of sample output, built from SGR-styled spans
+// while keeping track of its width in cells
+type showcaseLine struct {
+	b     strings.Builder
+	width int
+}
+
+// add appends text in the given SGR style; an empty style is plain text
+func (l *showcaseLine) add(sgr, text string) *showcaseLine {
+	if sgr == "" {
+		l.b.WriteString(text)
+	} else {
+		fmt.Fprintf(&l.b, "\033[%sm%s\033[0m", sgr, text)
+	}
+	l.width += ui.DisplayWidth(text)
+	return l
+}
+
+// padded returns the line padded with spaces to width cells
+func (l *showcaseLine) padded(width int) string {
+	return l.b.String() + strings.Repeat(" ", max(0, width-l.width))
+}
+
+// printShowcase draws sample output in the palette the terminal is showing:
+// the ANSI colors, the 256-color cube, truecolor ramps, text styles, a diff
+// and a small TUI, followed by warnings about colors hard to see on the
+// background. It is meant to run while the theme is applied.
+func printShowcase(t ThemeInfo) {
+	if !ui.ColorEnabled() || ui.Accessible() {
+		return
+	}
+
+	ui.PrintSubHeader("Showcase")
+	printANSIColors()
+	print256Colors()
+	printTruecolorRamps(t)
+	printTextStyles()
+	fmt.Println()
+	printPanes(diffPane(), tuiPane())
+
+	for _, warning := range paletteWarnings(t) {
+		ui.PrintWarning("%s", warning)
+	}
+}
+
+// printANSIColors shows the 16 colors as blocks and as text on the
+// background, where a color too close to it disappears
+func printANSIColors() {
+	for _, bright := range []bool{false, true} {
+		var blocks, text showcaseLine
+		for i, name := range ansiColors {
+			code := 40 + i
+			if bright {
+				code = 100 + i
+			}
+			blocks.add(fmt.Sprint(code), "        ")
+			text.add(fmt.Sprint(code-10), fmt.Sprintf(" %-7s", name))
+		}
+		fmt.Println(blocks.padded(0))
+		fmt.Println(text.padded(0))
+	}
+	fmt.Println()
+}
+
+// print256Colors shows the 6×6×6 color cube and the grayscale ramp of the
+// 256-color palette
+func print256Colors() {
+	for row := 0; row < 6; row++ {
+		var line showcaseLine
+		for col := 0; col < 36; col++ {
+			// Each row shows one green level across the six red planes
+			index := 16 + (col/6)*36 + row*6 + col%6
+			line.add(fmt.Sprintf("48;5;%d", index), " ")
+		}
+		fmt.Println(line.padded(0))
+	}
+	var gray showcaseLine
+	for index := 232; index < 256; index++ {
+		gray.add(fmt.Sprintf("48;5;%d", index), " ")
+	}
+	fmt.Println(gray.padded(0))
+	fmt.Println()
+}
+
+// printTruecolorRamps shows a smooth gradient from the theme's background
+// to its foreground and a hue rainbow; banding means the terminal is not
+// rendering 24-bit color
+func printTruecolorRamps(t ThemeInfo) {
+	bg, errBg := ParseColor(t.Colors["background"])
+	fg, errFg := ParseColor(t.Colors["foreground"])
+	if errBg == nil && errFg == nil {
+		var ramp showcaseLine
+		for i := 0; i < rampWidth; i++ {
+			c := mix(bg, fg, float64(i)/float64(rampWidth-1))
+			ramp.add(fmt.Sprintf("48;2;%d;%d;%d", c.R, c.G, c.B), " ")
+		}
+		fmt.Println(ramp.padded(0))
+	}
+
+	var rainbow showcaseLine
+	for i := 0; i < rampWidth; i++ {
+		c := HSL{H: float64(i) / rampWidth, S: 0.8, L: 0.55}.ToRGB()
+		rainbow.add(fmt.Sprintf("48;2;%d;%d;%d", c.R, c.G, c.B), " ")
+	}
+	fmt.Println(rainbow.padded(0))
+	fmt.Println()
+}
+
+// printTextStyles shows the attributes programs use on top of colors
+func printTextStyles() {
+	var line showcaseLine
+	for _, style := range []struct{ sgr, text string }{
+		{"1", "bold"}, {"2", "dim"}, {"3", "italic"}, {"4", "underline"},
+		{"4:3", "undercurl"}, {"9", "strikethrough"}, {"7", "reverse"},
+		{"1;91", "bold red"}, {"3;90", "italic comment"},
+	} {
+		line.add(style.sgr, style.text).add("", " ")
+	}
+	fmt.Println(line.padded(0))
+}
+
+// diffPane is a diff and a shell session as a version-control tool and a
+// prompt would color them
+func diffPane() []*showcaseLine {
+	lines := []struct{ sgr, text string }{
+		{"1", "diff --git a/main.go b/main.go"},
+		{"36", "@@ -12,6 +12,8 @@ func main() {"},
+		{"", "     cfg := load()"},
+		{"31", "-    run(cfg)"},
+		{"32", "+    if err := run(cfg); err != nil {"},
+		{"32", "+        log.Fatal(err)"},
+		{"32", "+    }"},
+		{"", " }"},
+		{"", ""},
+		{"90", "# tests, with bright black comments"},
+	}
+	var pane []*showcaseLine
+	for _, l := range lines {
+		pane = append(pane, new(showcaseLine).add(l.sgr, l.text))
+	}
+	pane = append(pane,
+		new(showcaseLine).add("34", "~/src").add("", " ").add("35", "main*").add("", " ").add("1;32", "$").add("", " go test ./..."),
+		new(showcaseLine).add("32", "ok  ").add("", "  app/config   0.21s"),
+		new(showcaseLine).add("1;31", "FAIL").add("", "  app/theme    0.08s"),
+		new(showcaseLine).add("33", "warning:").add("", " 1 test skipped"),
+	)
+	return pane
+}
+
+// tuiPane is a file browser frame with a selection, a status line, a
+// search match and a mode line
+func tuiPane() []*showcaseLine {
+	h, v, tl, tr, bl, br, ml, mr := "─", "│", "┌", "┐", "└", "┘", "├", "┤"
+	if !ui.SupportsUnicode() {
+		h, v, tl, tr, bl, br, ml, mr = "-", "|", "+", "+", "+", "+", "+", "+"
+	}
+	inner := showcasePane - 2
+
+	row := func(content *showcaseLine) *showcaseLine {
+		line := new(showcaseLine).add("90", v)
+		line.b.WriteString(content.padded(inner))
+		line.width += inner
+		return line.add("90", v)
+	}
+	rule := func(left, right string) *showcaseLine {
+		return new(showcaseLine).add("90", left+strings.Repeat(h, inner)+right)
+	}
+	title := new(showcaseLine).add("90", tl+h+" ").add("1", "Files").add("", " ")
+	title.add("90", strings.Repeat(h, showcasePane-title.width-1)+tr)
+
+	return []*showcaseLine{
+		title,
+		row(new(showcaseLine).add("1;34", " cmd/")),
+		row(new(showcaseLine).add("1;34", " internal/")),
+		row(new(showcaseLine).add("7", fmt.Sprintf(" %-25s%8s ", "main.go", "2.1 KB"))),
+		row(new(showcaseLine).add("", fmt.Sprintf(" %-25s%8s", "README.md", "4.3 KB"))),
+		row(new(showcaseLine).add("90", fmt.Sprintf(" %-25s%8s", ".gitignore", "36 B"))),
+		rule(ml, mr),
+		row(new(showcaseLine).add("31", " 3 errors").add("", "  ").add("33", "1 warning").add("", "  ").add("32", "build ok")),
+		row(new(showcaseLine).add("", " search: the").add("30;43", "me").add("", " [1/4] ").add("30;42", "theme")),
+		rule(bl, br),
+		new(showcaseLine).add("1;30;44", " NORMAL ").add("30;47", " main.go ").add("97;100", fmt.Sprintf(" %*s ", showcasePane-19, "utf-8  42:7")),
+	}
+}
+
+// printPanes prints two panes side by side, or one under the other when
+// the terminal is too narrow
+func printPanes(left, right []*showcaseLine) {
+	if ui.TerminalWidth() < 2*showcasePane+3 {
+		for _, pane := range [][]*showcaseLine{left, right} {
+			for _, line := range pane {
+				fmt.Println(line.padded(0))
+			}
+			fmt.Println()
+		}
+		return
+	}
+	for i := 0; i < len(left) || i < len(right); i++ {
+		var l, r string
+		if i < len(left) {
+			l = left[i].padded(showcasePane)
+		} else {
+			l = strings.Repeat(" ", showcasePane)
+		}
+		if i < len(right) {
+			r = right[i].padded(0)
+		}
+		fmt.Printf("%s   %s\n", l, r)
+	}
+	fmt.Println()
+}
+
+// paletteWarnings lists the accent colors, and bright black, which
+// programs use for comments and suggestions, that barely show on the
+// theme's background
+func paletteWarnings(t ThemeInfo) []string {
+	bg, err := ParseColor(t.Colors["background"])
+	if err != nil {
+		return nil
+	}
+	var warnings []string
+	for _, slot := range append(append([]string{}, accentSlots...), "bright_black") {
+		c, err := ParseColor(t.Colors[slot])
+		if err != nil {
+			continue
+		}
+		if ratio := GetContrastRatio(c, bg); ratio < invisibleContrast {
+			warnings = append(warnings, fmt.Sprintf("%s (%s) is nearly invisible on the background (%s)",
+				strings.Replace(slot, "_", " ", 1), c.ToHex(), contrastBadge(ratio)))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,147 @@
+package theme
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// sshArgOptions are the ssh options that take an argument, as in ssh's
+// getopt string
+const sshArgOptions = "BbcDEeFIiJLlmOoPpQRSWw"
+
+// ApplySSHTheme recolors the terminal with the theme configured for the
+// host in an ssh command line and returns its name. Hosts without a theme
+// return an empty name and leave the terminal alone.
+func (m *Manager) ApplySSHTheme(args []string) (string, error) {
+	host := sshHost(sshDestination(args))
+	if host == "" {
+		return "", nil
+	}
+	name := m.sshTheme(host)
+	if name == "" {
+		m.logVerbose("No SSH theme for %s", host)
+		return "", nil
+	}
+
+	theme, colors, err := m.themeColors(name)
+	if err != nil {
+		return "", err
+	}
+	if err := writeTTY(oscSequence(colors)); err != nil {
+		return "", err
+	}
+	m.logVerbose("Applied '%s' for %s", theme, host)
+	return theme, nil
+}
+
+// sshTheme returns the theme for host: an exact entry in ssh.hosts, else
+// the longest matching pattern, else ssh.theme
+func (m *Manager) sshTheme(host string) string {
+	hosts := m.config.SSH.Hosts
+	if name, ok := hosts[host]; ok {
+		return name
+	}
+
+	patterns := make([]string, 0, len(hosts))
+	for pattern := range hosts {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	best := ""
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, host); ok && len(pattern) > len(best) {
+			best = pattern
+		}
+	}
+	if best != "" {
+		return hosts[best]
+	}
+	return m.config.SSH.Theme
+}
+
+// sshDestination returns the first argument of an ssh command line that is
+// neither an option nor an option's value
+func sshDestination(args []string) string {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			if i+1 < len(args) {
+				return args[i+1]
+			}
+			return ""
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return arg
+		}
+		// Options combine, as in -vp 22; one taking a value ends the group
+		// and uses the rest of it, or the next argument
+		for j := 1; j < len(arg); j++ {
+			if strings.IndexByte(sshArgOptions, arg[j]) >= 0 {
+				if j == len(arg)-1 {
+					i++
+				}
+				break
+			}
+		}
+	}
+	return ""
+}
+
+// sshHost extracts the host from [user@]host or ssh://[user@]host[:port]
+func sshHost(dest string) string {
+	rest, isURI := strings.CutPrefix(dest, "ssh://")
+	if isURI {
+		rest, _, _ = strings.Cut(rest, "/")
+	}
+	if i := strings.LastIndex(rest, "@"); i >= 0 {
+		rest = rest[i+1:]
+	}
+	if isURI {
+		if i := strings.LastIndex(rest, ":"); i > strings.LastIndex(rest, "]") {
+			rest = rest[:i]
+		}
+	}
+	return strings.ToLower(strings.Trim(rest, "[]"))
+}
+
+// SSHHook returns shell code wrapping ssh so sessions to hosts with a
+// theme recolor the terminal, which is reset when ssh exits
+func SSHHook(shell, executable string) (string, error) {
+	apply := fmt.Sprintf("%q osc ssh -- \"$@\" 2>/dev/null", executable)
+	reset := fmt.Sprintf("%q osc apply --reset >/dev/null 2>&1", executable)
+
+	switch shell {
+	case "bash", "zsh":
+		return fmt.Sprintf(`ssh() {
+  local _ac_theme="" _ac_rc
+  if [ -t 1 ]; then
+    _ac_theme=$(%s)
+  fi
+  command ssh "$@"
+  _ac_rc=$?
+  if [ -n "$_ac_theme" ]; then
+    %s
+  fi
+  return $_ac_rc
+}
+`, apply, reset), nil
+	case "fish":
+		return fmt.Sprintf(`function ssh --wraps ssh
+    set -l _ac_theme
+    if isatty stdout
+        set _ac_theme (%s)
+    end
+    command ssh $argv
+    set -l _ac_rc $status
+    if test -n "$_ac_theme"
+        %s
+    end
+    return $_ac_rc
+end
+`, strings.ReplaceAll(apply, `"$@"`, "$argv"), reset), nil
+	default:
+		return "", fmt.Errorf("unsupported shell: %s (use bash, zsh or fish)", shell)
+	}
+}
@@ -0,0 +1,322 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// StatsOptions select the themes to analyze and how to report them
+type StatsOptions struct {
+	Collection string
+	Heatmap    bool
+	Format     string
+}
+
+// Heatmap layout: a column for neutral backgrounds followed by twelve hue
+// sectors of 30 degrees, and ten lightness bands
+const (
+	hueSectors       = 12
+	lightnessBands   = 10
+	neutralSat       = 0.12
+	statsTopFamilies = 10
+)
+
+// hueLabels name the heatmap columns, neutral first
+var hueLabels = []string{"gry", "red", "ora", "yel", "lim", "grn", "tea", "cya", "azu", "blu", "vio", "mag", "ros"}
+
+// hueNames are the column names spelled out for accessibility mode
+var hueNames = []string{"neutral", "red", "orange", "yellow", "lime", "green", "teal", "cyan", "azure", "blue", "violet", "magenta", "rose"}
+
+// collectionStats summarizes a set of themes
+type collectionStats struct {
+	Themes   int            `json:"themes"`
+	Variants map[string]int `json:"variants"`
+	Families []familyCount  `json:"families"`
+	Applied  []appliedCount `json:"most_applied,omitempty"`
+	// Heatmap counts backgrounds by lightness band (darkest first) and
+	// hue column (neutral first)
+	Heatmap [lightnessBands][hueSectors + 1]int `json:"heatmap"`
+
+	applied map[[2]int]bool
+}
+
+// familyCount is a scheme family, the name up to the first separator, and
+// its variants
+type familyCount struct {
+	Family string `json:"family"`
+	Themes int    `json:"themes"`
+	Dark   int    `json:"dark"`
+	Light  int    `json:"light"`
+}
+
+// appliedCount is how often a theme was applied
+type appliedCount struct {
+	Theme string `json:"theme"`
+	Count int    `json:"count"`
+}
+
+// Stats reports how the installed themes spread over variants and scheme
+// families and, with a heatmap, over background hue and lightness
+func (m *Manager) Stats(opts StatsOptions) error {
+	themes, err := m.getThemeInfos()
+	if err != nil {
+		return err
+	}
+	themes, err = m.filterCollection(themes, opts.Collection)
+	if err != nil {
+		return err
+	}
+	if len(themes) == 0 {
+		ui.PrintInfo("No themes to analyze")
+		return nil
+	}
+
+	stats := m.collectStats(themes)
+
+	switch opts.Format {
+	case "json":
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	case "", "table":
+	default:
+		return fmt.Errorf("unknown format: %s (use table|json)", opts.Format)
+	}
+
+	ui.PrintHeader("Theme Collection Stats")
+	ui.PrintKeyValue("Themes", strconv.Itoa(stats.Themes))
+	for _, variant := range []string{"dark", "light"} {
+		ui.PrintKeyValue(strings.Title(variant), fmt.Sprintf("%d (%.0f%%)", stats.Variants[variant], percentOf(stats.Variants[variant], stats.Themes)))
+	}
+
+	ui.PrintSubHeader(fmt.Sprintf("Scheme Families (%d)", len(stats.Families)))
+	var rows [][]string
+	for i, f := range stats.Families {
+		if i == statsTopFamilies {
+			break
+		}
+		rows = append(rows, []string{f.Family, strconv.Itoa(f.Themes), strconv.Itoa(f.Dark), strconv.Itoa(f.Light)})
+	}
+	ui.PrintTable([]string{"Family", "Themes", "Dark", "Light"}, rows)
+	if len(stats.Families) > statsTopFamilies {
+		ui.PrintInfo("%d smaller families not shown", len(stats.Families)-statsTopFamilies)
+	}
+
+	if len(stats.Applied) > 0 {
+		ui.PrintSubHeader("Most Applied")
+		var items []string
+		for _, a := range stats.Applied {
+			items = append(items, fmt.Sprintf("%s (%d)", a.Theme, a.Count))
+		}
+		ui.PrintList(items)
+	}
+
+	if opts.Heatmap {
+		printHeatmap(stats)
+	}
+	return nil
+}
+
+// collectStats counts variants, families, the most applied themes and the
+// background heatmap
+func (m *Manager) collectStats(themes []ThemeInfo) collectionStats {
+	stats := collectionStats{
+		Themes:   len(themes),
+		Variants: make(map[string]int),
+		applied:  make(map[[2]int]bool),
+	}
+
+	appliedCounts := make(map[string]int)
+	if entries, err := m.loadHistory(); err == nil {
+		for _, entry := range entries {
+			appliedCounts[strings.ToLower(entry.Theme)]++
+		}
+	}
+
+	families := make(map[string]*familyCount)
+	for _, t := range themes {
+		variant := t.Variant
+		if variant == "" {
+			variant = "unknown"
+		}
+		stats.Variants[variant]++
+
+		name := familyOf(t.Name)
+		f, ok := families[name]
+		if !ok {
+			f = &familyCount{Family: name}
+			families[name] = f
+		}
+		f.Themes++
+		switch t.Variant {
+		case "dark":
+			f.Dark++
+		case "light":
+			f.Light++
+		}
+
+		if count := appliedCounts[strings.ToLower(t.Name)]; count > 0 {
+			stats.Applied = append(stats.Applied, appliedCount{Theme: t.Name, Count: count})
+		}
+
+		bg, err := ParseColor(t.Colors["background"])
+		if err != nil {
+			continue
+		}
+		cell := heatmapCell(bg.ToHSL())
+		stats.Heatmap[cell[0]][cell[1]]++
+		if appliedCounts[strings.ToLower(t.Name)] > 0 {
+			stats.applied[cell] = true
+		}
+	}
+
+	for _, f := range families {
+		stats.Families = append(stats.Families, *f)
+	}
+	sort.Slice(stats.Families, func(i, j int) bool {
+		a, b := stats.Families[i], stats.Families[j]
+		if a.Themes != b.Themes {
+			return a.Themes > b.Themes
+		}
+		return a.Family < b.Family
+	})
+
+	sort.Slice(stats.Applied, func(i, j int) bool {
+		a, b := stats.Applied[i], stats.Applied[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Theme < b.Theme
+	})
+	if len(stats.Applied) > 5 {
+		stats.Applied = stats.Applied[:5]
+	}
+	return stats
+}
+
+// familyOf groups variants of a scheme, e.g. "gruvbox" for
+// "gruvbox_material_dark"
+func familyOf(name string) string {
+	if i := strings.IndexAny(name, "_-"); i > 0 {
+		return strings.ToLower(name[:i])
+	}
+	return strings.ToLower(name)
+}
+
+// heatmapCell returns the lightness band and hue column of a color
+func heatmapCell(hsl HSL) [2]int {
+	band := min(int(hsl.L*lightnessBands), lightnessBands-1)
+	if hsl.S < neutralSat {
+		return [2]int{band, 0}
+	}
+	// Sectors are centered on their hue, so red spans 345 to 15 degrees
+	sector := int(math.Floor(hsl.H*hueSectors+0.5)) % hueSectors
+	return [2]int{band, sector + 1}
+}
+
+// printHeatmap draws background lightness against hue, lightest at the
+// top, shading each cell by how many themes fall in it
+func printHeatmap(stats collectionStats) {
+	ui.PrintSubHeader("Background Hue and Lightness")
+
+	if ui.Accessible() {
+		printHeatmapText(stats)
+		return
+	}
+
+	busiest := 0
+	for _, band := range stats.Heatmap {
+		for _, count := range band {
+			busiest = max(busiest, count)
+		}
+	}
+
+	shades := []string{"·", "░", "▒", "▓", "█"}
+	if !ui.SupportsUnicode() {
+		shades = []string{".", ":", "+", "#", "@"}
+	}
+
+	fmt.Print("         ")
+	for _, label := range hueLabels {
+		fmt.Printf("%-4s", label)
+	}
+	fmt.Println()
+
+	for band := lightnessBands - 1; band >= 0; band-- {
+		fmt.Printf("  %3d%%   ", (band+1)*100/lightnessBands)
+		for col, count := range stats.Heatmap[band] {
+			cell := " " + shades[0] + " "
+			if count > 0 {
+				shade := shades[1+min(3, (count*4-1)/busiest)]
+				cell = ui.Tint(heatmapColor(band, col), shade+shade+shade)
+			}
+			marker := " "
+			if stats.applied[[2]int{band, col}] {
+				marker = "*"
+			}
+			fmt.Print(cell + marker)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s %s %s %s  up to 25/50/75/100%% of the busiest cell (%d themes)\n", shades[1], shades[2], shades[3], shades[4], busiest)
+	if len(stats.applied) > 0 {
+		fmt.Println("  *        holds a theme you have applied")
+	}
+}
+
+// printHeatmapText lists the filled heatmap cells, busiest first, for
+// screen readers
+func printHeatmapText(stats collectionStats) {
+	type cell struct {
+		band, col, count int
+	}
+	var cells []cell
+	for band, row := range stats.Heatmap {
+		for col, count := range row {
+			if count > 0 {
+				cells = append(cells, cell{band, col, count})
+			}
+		}
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		return cells[i].count > cells[j].count
+	})
+
+	for _, c := range cells {
+		line := fmt.Sprintf("%s, %d to %d%% lightness: %d themes", hueNames[c.col],
+			c.band*100/lightnessBands, (c.band+1)*100/lightnessBands, c.count)
+		if stats.applied[[2]int{c.band, c.col}] {
+			line += ", including themes you have applied"
+		}
+		fmt.Printf("  %s\n", line)
+	}
+}
+
+// heatmapColor is the color at the center of a heatmap cell, kept light
+// enough to stand out on a dark terminal
+func heatmapColor(band, col int) string {
+	hsl := HSL{L: math.Max(0.3, (float64(band)+0.5)/lightnessBands)}
+	if col > 0 {
+		hsl.H = float64(col-1) / hueSectors
+		hsl.S = 0.65
+	}
+	return hsl.ToRGB().ToHex()
+}
+
+func percentOf(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
@@ -0,0 +1,101 @@
+package theme
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/vitruves/alacritty-colors/internal/targets"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// syncTargets regenerates the color snippets of every enabled sync target
+// for the applied theme. Failures are reported but never undo the apply.
+func (m *Manager) syncTargets(themeName string) {
+	if len(m.config.Sync.Targets) == 0 {
+		return
+	}
+	if err := m.SyncTargets(themeName, m.config.Sync.Targets); err != nil {
+		ui.PrintWarning("%v", err)
+	}
+}
+
+// SyncTargets writes the colors of a theme to the given targets. An empty
+// theme name uses the current theme.
+func (m *Manager) SyncTargets(themeName string, names []string) error {
+	theme, colors, err := m.themeColors(themeName)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, name := range names {
+		opts := m.config.SyncTarget(name)
+		path, err := targets.Sync(name, theme, colors, targets.Options{
+			Path:   opts.Path,
+			Reload: opts.Reload,
+		})
+		if err != nil {
+			ui.PrintWarning("Sync %s: %v", name, err)
+			for _, hint := range ui.Hints(err) {
+				ui.PrintInfo("  %s", hint)
+			}
+			failed = append(failed, name)
+			continue
+		}
+		m.logVerbose("Synced %s colors to %s", name, path)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to sync %d of %d targets", len(failed), len(names))
+	}
+	return nil
+}
+
+// themeColors returns a theme's name and colors. An empty name uses the
+// current theme.
+func (m *Manager) themeColors(themeName string) (string, targets.Colors, error) {
+	if themeName == "" {
+		themeName = m.config.CurrentTheme
+	}
+	if themeName == "" {
+		return "", nil, fmt.Errorf("no theme applied yet")
+	}
+
+	selected, err := m.findTheme(themeName)
+	if err != nil {
+		return "", nil, err
+	}
+	info, err := m.parseThemeFile(selected.FilePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read theme colors: %w", err)
+	}
+
+	return selected.Name, targets.Colors(info.Colors), nil
+}
+
+// ListSyncTargets shows the available targets and where each one writes
+func (m *Manager) ListSyncTargets() {
+	enabled := make(map[string]bool)
+	for _, name := range m.config.Sync.Targets {
+		enabled[name] = true
+	}
+
+	ui.PrintHeader("Sync Targets")
+	for _, name := range targets.Names() {
+		t, _ := targets.Get(name)
+		opts := m.config.SyncTarget(name)
+		path := opts.Path
+		if path == "" {
+			path = t.DefaultPath()
+		}
+
+		status := "disabled"
+		if enabled[name] {
+			status = "enabled"
+		}
+		if opts.Reload {
+			status += ", reload"
+		}
+		fmt.Printf("  %-10s %-18s %s\n", name, status, filepath.Clean(path))
+	}
+}
@@ -0,0 +1,81 @@
+package theme
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/downloader"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// paletteTags turns a theme's character into search tags, e.g. "dark",
+// "warm", "pastel", "high-contrast" or "blue-tinted"
+func paletteTags(c themeCharacter) []string {
+	tags := []string{c.Variant}
+	if c.Intensity != "balanced" {
+		tags = append(tags, c.Intensity)
+	}
+	if c.Temperature != "neutral" {
+		tags = append(tags, c.Temperature)
+	}
+	switch {
+	case c.Contrast >= 7:
+		tags = append(tags, "high-contrast")
+	case c.Contrast > 0 && c.Contrast < 4.5:
+		tags = append(tags, "low-contrast")
+	}
+	if c.tinted {
+		tags = append(tags, c.Hue+"-tinted")
+	}
+	return tags
+}
+
+// tagVocabulary lists every tag paletteTags can produce
+func tagVocabulary() []string {
+	tags := []string{"dark", "light", "monochrome", "muted", "pastel", "vibrant", "warm", "cool", "high-contrast", "low-contrast"}
+	for _, hue := range hueNames[1:] {
+		tags = append(tags, hue+"-tinted")
+	}
+	return tags
+}
+
+// analyzeThemeFile tags and scores a theme file for the metadata index
+func (m *Manager) analyzeThemeFile(path string, meta *downloader.ThemeMetadata) {
+	info, err := m.parseThemeFile(path)
+	if err != nil || info.Colors["background"] == "" {
+		return
+	}
+	meta.Tags = characterOf(info).Tags
+	meta.Score = qualityOf(info).Score
+}
+
+// filterTags keeps the themes carrying every one of the tags
+func filterTags(themes []ThemeInfo, tags []string) ([]ThemeInfo, error) {
+	if len(tags) == 0 {
+		return themes, nil
+	}
+	vocabulary := tagVocabulary()
+	for _, tag := range tags {
+		if !slices.Contains(vocabulary, strings.ToLower(tag)) {
+			return nil, ui.WithHints(fmt.Errorf("unknown tag: %s", tag),
+				ui.DidYouMean(tag, vocabulary),
+				"Tags: "+strings.Join(vocabulary, ", "))
+		}
+	}
+
+	var kept []ThemeInfo
+	for _, t := range themes {
+		matches := true
+		for _, tag := range tags {
+			if !slices.Contains(t.Tags, strings.ToLower(tag)) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			kept = append(kept, t)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,105 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/targets"
+	"github.com/vitruves/alacritty-colors/internal/templates"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// TemplatesDir holds user templates, which override built-ins of the same name
+func (m *Manager) TemplatesDir() string {
+	return filepath.Join(m.config.DataDir, "templates")
+}
+
+// ListTemplates shows every template and where its output goes
+func (m *Manager) ListTemplates() error {
+	list, err := templates.List(m.TemplatesDir())
+	if err != nil {
+		return err
+	}
+
+	ui.PrintHeader("Templates")
+	for _, t := range list {
+		output := t.Output
+		if output == "" {
+			output = "(stdout)"
+		}
+		fmt.Printf("  %-12s %-10s %s\n", t.Name, sourceLabel(t), output)
+	}
+	fmt.Println()
+	ui.PrintInfo("Add your own as <name>%s in %s", templates.Extension, m.TemplatesDir())
+	return nil
+}
+
+// ShowTemplate prints a template's source, e.g. to start a custom copy
+func (m *Manager) ShowTemplate(name string) error {
+	t, err := templates.Find(m.TemplatesDir(), name)
+	if err != nil {
+		return err
+	}
+	fmt.Print(t.Text())
+	return nil
+}
+
+// ApplyTemplate renders a template with a theme's colors and writes it to
+// output, the template's own output path, or stdout, in that order. The
+// template's reload command runs after writing a file, when installed.
+func (m *Manager) ApplyTemplate(name, themeName, output string) error {
+	t, err := templates.Find(m.TemplatesDir(), name)
+	if err != nil {
+		return err
+	}
+
+	theme, colors, err := m.themeColors(themeName)
+	if err != nil {
+		return err
+	}
+
+	data, err := t.Render(theme, colors)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		output = t.Output
+	}
+	if output == "" || output == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	output = targets.ExpandHome(output)
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write template output: %w", err)
+	}
+	ui.PrintSuccess("Rendered template '%s' for '%s' to %s", t.Name, theme, output)
+
+	if t.Reload != "" {
+		fields := strings.Fields(t.Reload)
+		if _, err := exec.LookPath(fields[0]); err != nil {
+			m.logVerbose("Skipping reload, %s is not installed", fields[0])
+			return nil
+		}
+		if out, err := shellCommand(t.Reload).CombinedOutput(); err != nil {
+			ui.PrintWarning("Reload command failed: %v %s", err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	return nil
+}
+
+func sourceLabel(t templates.Template) string {
+	if t.Builtin {
+		return "built-in"
+	}
+	return "custom"
+}
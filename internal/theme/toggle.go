@@ -0,0 +1,112 @@
+package theme
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// counterpartTokens are swapped in a theme name to find its other variant,
+// e.g. gruvbox_dark and gruvbox_light
+var counterpartTokens = [][2]string{
+	{"dark", "light"},
+	{"night", "day"},
+	{"moon", "dawn"},
+	{"black", "white"},
+}
+
+// counterpart returns the light or dark variant of a theme. Pairs from the
+// settings file come first, then the scheduler's light and dark themes, then
+// installed themes whose name swaps one of counterpartTokens.
+func (m *Manager) counterpart(name string, themes []ThemeInfo) (string, bool) {
+	for a, b := range m.config.Pairs {
+		if strings.EqualFold(a, name) {
+			return b, true
+		}
+		if strings.EqualFold(b, name) {
+			return a, true
+		}
+	}
+
+	s := m.config.Scheduler
+	if s.LightTheme != "" && s.DarkTheme != "" {
+		if strings.EqualFold(s.LightTheme, name) {
+			return s.DarkTheme, true
+		}
+		if strings.EqualFold(s.DarkTheme, name) {
+			return s.LightTheme, true
+		}
+	}
+
+	installed := make(map[string]string, len(themes))
+	for _, t := range themes {
+		installed[strings.ToLower(t.Name)] = t.Name
+	}
+
+	lower := strings.ToLower(name)
+	for _, pair := range counterpartTokens {
+		for _, swap := range [][2]string{pair, {pair[1], pair[0]}} {
+			if !strings.Contains(lower, swap[0]) {
+				continue
+			}
+			if found, ok := installed[strings.ReplaceAll(lower, swap[0], swap[1])]; ok {
+				return found, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Toggle applies the light or dark counterpart of the current theme
+func (m *Manager) Toggle() error {
+	current := m.GetCurrentTheme()
+	if current == "" {
+		return ui.WithHints(fmt.Errorf("no theme applied yet"),
+			"Apply one first: alacritty-colors apply <theme>")
+	}
+
+	themes, err := m.getThemeInfos()
+	if err != nil {
+		return err
+	}
+
+	other, ok := m.counterpart(current, themes)
+	if !ok {
+		return ui.WithHints(fmt.Errorf("no light/dark counterpart found for '%s'", current),
+			fmt.Sprintf(`Pair it under [pairs] in the settings file: %q = "<other theme>"`, current))
+	}
+
+	m.logVerbose("Toggling %s -> %s", current, other)
+	return m.ApplyTheme(other)
+}
+
+// ListPairs shows every installed theme that has a counterpart
+func (m *Manager) ListPairs() error {
+	themes, err := m.getThemeInfos()
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	var rows [][]string
+	for _, t := range themes {
+		other, ok := m.counterpart(t.Name, themes)
+		if !ok || seen[strings.ToLower(t.Name)] {
+			continue
+		}
+		seen[strings.ToLower(t.Name)] = true
+		seen[strings.ToLower(other)] = true
+		rows = append(rows, []string{t.Name, other})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i][0] < rows[j][0] })
+
+	ui.PrintHeader(fmt.Sprintf("Theme Pairs (%d)", len(rows)))
+	if len(rows) == 0 {
+		ui.PrintInfo("No pairs found. Define some under [pairs] in the settings file.")
+		return nil
+	}
+	ui.PrintTable([]string{"Theme", "Counterpart"}, rows)
+	return nil
+}
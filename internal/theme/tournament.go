@@ -0,0 +1,148 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// tournamentRounds is the number of rounds a bracket of n themes needs
+func tournamentRounds(n int) int {
+	rounds := 0
+	for size := 1; size < n; size *= 2 {
+		rounds++
+	}
+	return rounds
+}
+
+// runTournament plays the themes off in head-to-head matches until one is
+// left, then applies it like 'apply' does. Each round pairs the survivors
+// in order; an odd theme out gets a bye into the next round.
+func (m *Manager) runTournament(themes []ThemeInfo) error {
+	if len(themes) < 2 {
+		return ui.WithHints(fmt.Errorf("a tournament needs at least two themes, found %d", len(themes)),
+			"Loosen the filters, or apply the theme directly.")
+	}
+	if !ui.Interactive() {
+		return ui.WithHints(fmt.Errorf("a tournament needs you to pick the winners, but input is not interactive"),
+			"Run it in a terminal, without --yes or --no-input.")
+	}
+	// The champion is applied like any theme, so refuse up front rather
+	// than after every match
+	if state, drifted := m.themeDrift(); drifted && !m.force {
+		return m.driftError(state)
+	}
+
+	currentThemePath := m.currentThemeFile()
+	backupThemePath := filepath.Join(m.config.ThemesDir, "slideshow_backup.toml")
+	if _, err := os.Stat(currentThemePath); err == nil {
+		if err := m.saveCurrent(backupThemePath); err != nil {
+			return fmt.Errorf("failed to backup current theme: %w", err)
+		}
+	}
+	defer os.Remove(backupThemePath)
+
+	keys := make(chan rune, 1)
+	go m.captureKeyboardInput(keys)
+
+	rounds := tournamentRounds(len(themes))
+	matches := len(themes) - 1
+	played := 0
+	var runnerUp ThemeInfo
+
+	round := 1
+	for len(themes) > 1 {
+		var next []ThemeInfo
+		for i := 0; i+1 < len(themes); i += 2 {
+			played++
+			title := fmt.Sprintf("Round %d of %d, match %d of %d", round, rounds, played, matches)
+			winner, loser, ok := m.playMatch(themes[i], themes[i+1], title, keys)
+			if !ok {
+				ui.PrintInfo("Tournament abandoned, restoring original theme...")
+				if err := m.restoreFromBackup(currentThemePath, backupThemePath); err != nil {
+					return fmt.Errorf("failed to restore original theme: %w", err)
+				}
+				ui.PrintSuccess("Original theme restored")
+				return nil
+			}
+			next = append(next, winner)
+			runnerUp = loser
+		}
+		if len(themes)%2 == 1 {
+			next = append(next, themes[len(themes)-1])
+		}
+		themes = next
+		round++
+	}
+
+	champion := themes[0]
+	fmt.Print("\033[2J\033[H")
+	ui.PrintHeader("🏆 Tournament Winner")
+	ui.PrintKeyValue("Champion", champion.Name)
+	ui.PrintKeyValue("Runner-up", runnerUp.Name)
+	ui.PrintKeyValue("Matches", fmt.Sprintf("%d over %d rounds", matches, rounds))
+
+	// Put the original back first, so the apply backs it up and hooks see
+	// the theme it replaces
+	if err := m.restoreFromBackup(currentThemePath, backupThemePath); err != nil {
+		return fmt.Errorf("failed to restore original theme: %w", err)
+	}
+	return m.applyTheme(champion.Name, true)
+}
+
+// playMatch shows two themes in turn until the user picks one. It reports
+// false when the user quits.
+func (m *Manager) playMatch(a, b ThemeInfo, title string, keys <-chan rune) (ThemeInfo, ThemeInfo, bool) {
+	contenders := [2]ThemeInfo{a, b}
+	shown := 0
+	m.showContender(contenders, shown, title)
+
+	for key := range keys {
+		switch key {
+		case 'a', 'A', '1':
+			shown = 0
+		case 'b', 'B', '2':
+			shown = 1
+		case '\t', 't':
+			shown = 1 - shown
+		case ' ', '\r', '\n':
+			return contenders[shown], contenders[1-shown], true
+		case 'q', '\x1b':
+			return ThemeInfo{}, ThemeInfo{}, false
+		default:
+			continue
+		}
+		m.showContender(contenders, shown, title)
+	}
+	return ThemeInfo{}, ThemeInfo{}, false
+}
+
+// showContender applies one side of a match and redraws the match screen
+func (m *Manager) showContender(contenders [2]ThemeInfo, shown int, title string) {
+	theme := contenders[shown]
+	if err := m.showCurrent(theme.FilePath); err != nil {
+		ui.PrintError("Failed to apply theme: %v", err)
+	}
+
+	fmt.Print("\033[2J\033[H")
+	ui.PrintHeader("🏆 " + title)
+	for i, t := range contenders {
+		marker := "  "
+		if i == shown {
+			marker = "▶ "
+		}
+		fmt.Printf("%s%c: %s\n", marker, 'A'+i, t.Name)
+	}
+	fmt.Println()
+
+	if bg := theme.Colors["background"]; bg != "" {
+		ui.PrintColorPreview("Background", bg)
+	}
+	if fg := theme.Colors["foreground"]; fg != "" {
+		ui.PrintColorPreview("Foreground", fg)
+	}
+
+	ui.PrintInfo("\n⌨️  Controls: a/b or TAB=switch | SPACE/ENTER=pick the one shown | q=quit")
+}
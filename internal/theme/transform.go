@@ -0,0 +1,128 @@
+package theme
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ColorTransform is a one-off change to a theme's colors made while it is
+// written to current.toml; the theme file itself is left alone
+type ColorTransform struct {
+	// Desaturate lowers saturation by a percentage, 0 to 100
+	Desaturate float64
+	// DarkenBg darkens the background by a percentage; negative values
+	// lighten it
+	DarkenBg float64
+	// HueShift rotates every hue by degrees
+	HueShift float64
+	// RotateAccents passes each chromatic ANSI color the hue of the one this
+	// many steps further around the color wheel
+	RotateAccents int
+	// ShuffleAccents, when not zero, seeds a random reordering of the
+	// chromatic ANSI hues
+	ShuffleAccents int64
+}
+
+// Any reports whether the transform changes anything
+func (t ColorTransform) Any() bool {
+	return t.Desaturate != 0 || t.DarkenBg != 0 || t.HueShift != 0 || t.movesAccents()
+}
+
+// movesAccents reports whether the transform reorders the accent hues
+func (t ColorTransform) movesAccents() bool {
+	return t.RotateAccents%len(accentWheel) != 0 || t.ShuffleAccents != 0
+}
+
+// Validate checks the transform amounts are in range
+func (t ColorTransform) Validate() error {
+	switch {
+	case t.Desaturate < 0 || t.Desaturate > 100:
+		return fmt.Errorf("--desaturate must be between 0 and 100, got %g", t.Desaturate)
+	case t.DarkenBg < -100 || t.DarkenBg > 100:
+		return fmt.Errorf("--darken-bg must be between -100 and 100, got %g", t.DarkenBg)
+	case t.HueShift < -360 || t.HueShift > 360:
+		return fmt.Errorf("--hue-shift must be between -360 and 360, got %g", t.HueShift)
+	case t.RotateAccents != 0 && t.ShuffleAccents != 0:
+		return fmt.Errorf("--rotate-accents and --shuffle-accents cannot be used together")
+	}
+	return nil
+}
+
+// String describes the transform, e.g. "desaturate 20%, hue shift 30°"
+func (t ColorTransform) String() string {
+	var parts []string
+	if t.Desaturate != 0 {
+		parts = append(parts, fmt.Sprintf("desaturate %g%%", t.Desaturate))
+	}
+	if t.DarkenBg > 0 {
+		parts = append(parts, fmt.Sprintf("darken background %g%%", t.DarkenBg))
+	} else if t.DarkenBg < 0 {
+		parts = append(parts, fmt.Sprintf("lighten background %g%%", -t.DarkenBg))
+	}
+	if t.HueShift != 0 {
+		parts = append(parts, fmt.Sprintf("hue shift %g°", t.HueShift))
+	}
+	if t.RotateAccents%len(accentWheel) != 0 {
+		parts = append(parts, fmt.Sprintf("accents rotated by %d", t.RotateAccents))
+	}
+	if t.ShuffleAccents != 0 {
+		parts = append(parts, "accents shuffled")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// apply transforms one color; background says whether it is the primary
+// background
+func (t ColorTransform) apply(c RGB, background bool) RGB {
+	hsl := c.ToHSL()
+	if t.HueShift != 0 {
+		hsl.H = math.Mod(hsl.H+t.HueShift/360+1, 1)
+	}
+	if t.Desaturate != 0 {
+		hsl.S *= 1 - t.Desaturate/100
+	}
+	if background && t.DarkenBg > 0 {
+		hsl.L *= 1 - t.DarkenBg/100
+	} else if background && t.DarkenBg < 0 {
+		hsl.L += (1 - hsl.L) * -t.DarkenBg / 100
+	}
+	return hsl.ToRGB()
+}
+
+// transformTheme rewrites every color of the [colors] tables of a theme
+// with the transform, keeping the rest of the file as is
+func transformTheme(data []byte, t ColorTransform) []byte {
+	lines := strings.Split(string(data), "\n")
+	if t.movesAccents() {
+		lines = moveAccents(lines, t)
+		if t.Desaturate == 0 && t.DarkenBg == 0 && t.HueShift == 0 {
+			return []byte(strings.Join(lines, "\n"))
+		}
+	}
+	table := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			table = strings.Trim(trimmed, "[] ")
+			continue
+		}
+		if table != "colors" && !strings.HasPrefix(table, "colors.") || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		key, _, _ := strings.Cut(trimmed, "=")
+		background := table == "colors.primary" && strings.TrimSpace(key) == "background"
+		lines[i] = hexColorPattern.ReplaceAllStringFunc(line, func(value string) string {
+			rgb, err := ParseColor(value)
+			if err != nil {
+				return value
+			}
+			hex := t.apply(rgb, background).ToHex()
+			if strings.HasPrefix(value, "0x") {
+				return "0x" + strings.TrimPrefix(hex, "#")
+			}
+			return hex
+		})
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
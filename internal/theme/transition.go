@@ -0,0 +1,78 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// transitionFrame is how long each step of a transition shows, about as
+// fast as Alacritty reloads its config
+const transitionFrame = 80 * time.Millisecond
+
+// maxTransitionSteps bounds the writes of a long transition
+const maxTransitionSteps = 100
+
+// fadeCurrent eases current.toml from the colors it shows to those of
+// themePath over m.transition, one blended palette per step, leaving the
+// last step to installCurrent. Slots either theme leaves to a cell color
+// such as CellForeground switch at the end.
+func (m *Manager) fadeCurrent(themePath string) error {
+	from, err := renderColors(m.currentThemeFile())
+	if err != nil {
+		return err
+	}
+	read := os.ReadFile
+	if m.adjustsCurrent(themePath) {
+		read = m.adjustedTheme
+	}
+	data, err := read(themePath)
+	if err != nil {
+		return err
+	}
+	to := paletteOf(strings.Split(string(data), "\n"))
+
+	steps := int(m.transition / transitionFrame)
+	if steps < 2 {
+		steps = 2
+	}
+	if steps > maxTransitionSteps {
+		steps = maxTransitionSteps
+	}
+	delay := m.transition / time.Duration(steps)
+	m.logVerbose("Fading to %s in %d steps", themeNameOf(themePath), steps)
+
+	for step := 1; step < steps; step++ {
+		// Smoothstep: the change starts and ends gently
+		t := float64(step) / float64(steps)
+		t = t * t * (3 - 2*t)
+		if err := m.replaceCurrent(blendedTheme(from, to, t)); err != nil {
+			return err
+		}
+		time.Sleep(delay)
+	}
+	return nil
+}
+
+// blendedTheme writes the colors both palettes have, blended by t from
+// one to the other, as a theme
+func blendedTheme(from, to map[string]RGB, t float64) []byte {
+	var out strings.Builder
+	out.WriteString("# Transition step written by alacritty-colors\n")
+	table := ""
+	for _, slot := range colorSlots {
+		key := slot.Table + "." + slot.Key
+		a, okFrom := from[key]
+		b, okTo := to[key]
+		if !okFrom || !okTo {
+			continue
+		}
+		if slot.Table != table {
+			table = slot.Table
+			fmt.Fprintf(&out, "\n[colors.%s]\n", table)
+		}
+		fmt.Fprintf(&out, "%s = %q\n", slot.Key, mix(a, b, t).ToHex())
+	}
+	return []byte(out.String())
+}
@@ -0,0 +1,211 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// TrashDir, inside the data directory, holds files removed by clean
+// commands until they expire
+const (
+	TrashDir    = ".trash"
+	trashIndex  = "index.json"
+	TrashExpiry = 30 * 24 * time.Hour
+)
+
+// Kinds of trashed files
+const (
+	TrashTheme  = "theme"
+	TrashBackup = "backup"
+)
+
+// TrashEntry is a file in the trash and where it came from
+type TrashEntry struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Kind      string    `json:"kind"`
+	Original  string    `json:"original"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+func (m *Manager) trashDir() string {
+	return filepath.Join(m.config.DataDir, TrashDir)
+}
+
+func (m *Manager) loadTrash() []TrashEntry {
+	var entries []TrashEntry
+	data, err := os.ReadFile(filepath.Join(m.trashDir(), trashIndex))
+	if err == nil {
+		if err := json.Unmarshal(data, &entries); err != nil {
+			m.logVerbose("Ignoring unreadable trash index: %v", err)
+		}
+	}
+	return entries
+}
+
+func (m *Manager) saveTrash(entries []TrashEntry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.trashDir(), trashIndex), data, 0644)
+}
+
+// MoveToTrash removes a file by moving it to the trash, from where
+// 'trash restore' can put it back
+func (m *Manager) MoveToTrash(path, kind string) error {
+	if err := os.MkdirAll(m.trashDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create trash: %w", err)
+	}
+	entries := m.expireTrash(m.loadTrash())
+
+	now := time.Now()
+	entry := TrashEntry{
+		ID:        fmt.Sprintf("%s-%d", now.Format("20060102-150405"), len(entries)+1),
+		Name:      filepath.Base(path),
+		Kind:      kind,
+		DeletedAt: now,
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	entry.Original = path
+
+	if err := moveFile(path, filepath.Join(m.trashDir(), entry.ID)); err != nil {
+		return err
+	}
+	return m.saveTrash(append(entries, entry))
+}
+
+// expireTrash deletes entries older than TrashExpiry
+func (m *Manager) expireTrash(entries []TrashEntry) []TrashEntry {
+	var kept []TrashEntry
+	for _, e := range entries {
+		if time.Since(e.DeletedAt) > TrashExpiry {
+			os.Remove(filepath.Join(m.trashDir(), e.ID))
+			m.logVerbose("Expired %s from the trash", e.Name)
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept
+}
+
+// moveFile renames a file, copying it when the rename crosses file systems
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(dst, data, 0644); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
+
+// ListTrash prints the trashed files, newest first
+func (m *Manager) ListTrash() error {
+	entries := m.expireTrash(m.loadTrash())
+	if len(entries) == 0 {
+		ui.PrintInfo("The trash is empty")
+		return nil
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].DeletedAt.After(entries[j].DeletedAt)
+	})
+
+	ui.PrintHeader(fmt.Sprintf("Trash (%d)", len(entries)))
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		expires := e.DeletedAt.Add(TrashExpiry)
+		rows = append(rows, []string{
+			e.ID, e.Name, e.Kind,
+			e.DeletedAt.Format("2006-01-02 15:04"),
+			fmt.Sprintf("%dd", int(math.Ceil(time.Until(expires).Hours()/24))),
+		})
+	}
+	ui.PrintTable([]string{"ID", "Name", "Kind", "Deleted", "Expires in"}, rows)
+	ui.PrintInfo("Restore with: alacritty-colors trash restore <id|name>")
+	return nil
+}
+
+// RestoreTrash moves trashed files back to where they were. Each selector
+// is an ID or a file name, with or without .toml; a name matching several
+// entries restores the most recent.
+func (m *Manager) RestoreTrash(selectors []string) error {
+	entries := m.expireTrash(m.loadTrash())
+
+	restored := 0
+	for _, sel := range selectors {
+		i := findTrashEntry(entries, sel)
+		if i < 0 {
+			names := make([]string, 0, len(entries))
+			for _, e := range entries {
+				names = append(names, e.Name)
+			}
+			return ui.WithHints(fmt.Errorf("'%s' is not in the trash", sel),
+				ui.DidYouMean(sel, names), "List the trash with: alacritty-colors trash list")
+		}
+
+		e := entries[i]
+		if _, err := os.Stat(e.Original); err == nil {
+			return ui.WithHints(fmt.Errorf("%s already exists", e.Original),
+				"Move or rename it first, then restore again")
+		}
+		if err := os.MkdirAll(filepath.Dir(e.Original), 0755); err != nil {
+			return err
+		}
+		if err := moveFile(filepath.Join(m.trashDir(), e.ID), e.Original); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", e.Name, err)
+		}
+		entries = append(entries[:i], entries[i+1:]...)
+		ui.PrintSuccess("Restored %s to %s", e.Name, filepath.Dir(e.Original))
+		restored++
+	}
+
+	if restored > 0 {
+		return m.saveTrash(entries)
+	}
+	return nil
+}
+
+func findTrashEntry(entries []TrashEntry, sel string) int {
+	found := -1
+	for i, e := range entries {
+		if e.ID == sel {
+			return i
+		}
+		if e.Name == sel || strings.TrimSuffix(e.Name, ".toml") == sel {
+			if found < 0 || e.DeletedAt.After(entries[found].DeletedAt) {
+				found = i
+			}
+		}
+	}
+	return found
+}
+
+// EmptyTrash deletes everything in the trash for good
+func (m *Manager) EmptyTrash() error {
+	entries := m.loadTrash()
+	if len(entries) == 0 {
+		ui.PrintInfo("The trash is empty")
+		return nil
+	}
+	if err := os.RemoveAll(m.trashDir()); err != nil {
+		return fmt.Errorf("failed to empty trash: %w", err)
+	}
+	ui.PrintSuccess("Deleted %d files from the trash", len(entries))
+	return nil
+}
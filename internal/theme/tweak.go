@@ -0,0 +1,127 @@
+package theme
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// TweakOptions say how tweak varies the current theme
+type TweakOptions struct {
+	RotateAccents  int
+	ShuffleAccents bool
+	// Seed makes the shuffle repeatable; empty means a new order every time
+	Seed string
+}
+
+// accentWheel lists the chromatic ANSI colors in hue order, the order
+// --rotate-accents moves along
+var accentWheel = []string{"red", "yellow", "green", "cyan", "blue", "magenta"}
+
+// moveAccents gives the chromatic ANSI colors each other's hues, rotated or
+// shuffled, while each keeps its own OKLCH lightness and chroma, so the
+// theme reads the same. Normal and bright colors move together; grays
+// among them have no hue to give and stay put.
+func moveAccents(lines []string, t ColorTransform) []string {
+	values, _ := colorTables(lines)
+
+	// Positions on the wheel whose normal color has a hue
+	var chromatic []int
+	for i, color := range accentWheel {
+		if rgb, err := ParseColor(values["normal."+color]); err == nil && rgb.toOKLCH().C >= achromaticChroma {
+			chromatic = append(chromatic, i)
+		}
+	}
+	if len(chromatic) < 2 {
+		return lines
+	}
+
+	// order[i] is the position whose hue position chromatic[i] takes
+	order := make([]int, len(chromatic))
+	if t.ShuffleAccents != 0 {
+		r := rand.New(rand.NewSource(t.ShuffleAccents))
+		for {
+			perm := r.Perm(len(chromatic))
+			identity := true
+			for i, p := range perm {
+				order[i] = chromatic[p]
+				identity = identity && p == i
+			}
+			if !identity {
+				break
+			}
+		}
+	} else {
+		n := len(chromatic)
+		for i := range chromatic {
+			order[i] = chromatic[((i+t.RotateAccents)%n+n)%n]
+		}
+	}
+
+	lines = append([]string{}, lines...)
+	for _, table := range []string{"normal", "bright"} {
+		hues := make(map[int]float64)
+		for _, pos := range chromatic {
+			if rgb, err := ParseColor(values[table+"."+accentWheel[pos]]); err == nil {
+				hues[pos] = rgb.toOKLCH().H
+			}
+		}
+		for i, pos := range chromatic {
+			hue, ok := hues[order[i]]
+			if !ok {
+				continue
+			}
+			color := accentWheel[pos]
+			rgb, err := ParseColor(values[table+"."+color])
+			if err != nil {
+				continue
+			}
+			c := rgb.toOKLCH()
+			c.H = hue
+			moved := c.toRGB().ToHex()
+			if line, _, ok := findConfigValue(lines, "colors."+table, color); ok {
+				lines[line] = hexColorPattern.ReplaceAllStringFunc(lines[line], func(value string) string {
+					if strings.HasPrefix(value, "0x") {
+						return "0x" + strings.TrimPrefix(moved, "#")
+					}
+					return moved
+				})
+			}
+		}
+	}
+	return lines
+}
+
+// Tweak re-applies the current theme with its accent hues rotated or
+// shuffled, leaving the background, the foreground and the theme file as
+// they are
+func (m *Manager) Tweak(opts TweakOptions) error {
+	if opts.Seed != "" && !opts.ShuffleAccents {
+		return fmt.Errorf("--seed only applies to --shuffle-accents")
+	}
+	t := ColorTransform{RotateAccents: opts.RotateAccents}
+	if opts.ShuffleAccents {
+		t.ShuffleAccents = time.Now().UnixNano()
+		if opts.Seed != "" {
+			h := fnv.New64a()
+			h.Write([]byte(opts.Seed))
+			// Zero would mean no shuffle
+			t.ShuffleAccents = int64(h.Sum64() | 1)
+		}
+	}
+	if !t.Any() {
+		return ui.WithHints(fmt.Errorf("nothing to tweak"),
+			"Pass --rotate-accents or --shuffle-accents.")
+	}
+
+	current := m.GetCurrentTheme()
+	if current == "" {
+		return ui.WithHints(fmt.Errorf("no theme applied yet"),
+			"Apply one first: alacritty-colors apply <theme>")
+	}
+	return m.ApplyThemeWithOptions(current, &ApplyOptions{Transform: t})
+}
@@ -0,0 +1,59 @@
+package theme
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/downloader"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// The opt-in version check asks GitHub at most once a day and gives up
+// quickly so it never holds up a command
+const (
+	versionCheckInterval = 24 * time.Hour
+	versionCheckTimeout  = 2 * time.Second
+)
+
+// VersionNotice prints a one-line notice when a newer release exists. It
+// runs only with network.version_check on and output going to a terminal.
+func (m *Manager) VersionNotice(current string) {
+	if !m.config.VersionCheck || !ui.IsTerminal() {
+		return
+	}
+	dl, err := m.newDownloader()
+	if err != nil {
+		return
+	}
+	release, err := dl.CachedRelease(versionCheckInterval, versionCheckTimeout)
+	if err != nil {
+		m.logVerbose("Version check failed: %v", err)
+	}
+	if release.Version != "" && downloader.NewerVersion(current, release.Version) {
+		ui.PrintInfo("alacritty-colors %s is available (you have %s): %s", release.Version, current, release.URL)
+	}
+}
+
+// CheckVersion looks up the latest release now and compares it with the
+// running version
+func (m *Manager) CheckVersion(current string) error {
+	dl, err := m.newDownloader()
+	if err != nil {
+		return err
+	}
+	release, err := dl.LatestRelease(downloader.Timeout)
+	if err != nil {
+		return ui.WithHints(fmt.Errorf("failed to check the latest release: %w", err),
+			"Releases are listed at https://github.com/vitruves/alacritty-colors/releases")
+	}
+
+	ui.PrintKeyValue("Current", current)
+	ui.PrintKeyValue("Latest", release.Version)
+	ui.PrintKeyValue("Changelog", release.URL)
+	if downloader.NewerVersion(current, release.Version) {
+		ui.PrintWarning("A newer release is available")
+	} else {
+		ui.PrintSuccess("Up to date")
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package theme
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/vitruves/alacritty-colors/internal/targets"
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// WallpaperTheme is the theme regenerated from the wallpaper
+const WallpaperTheme = "wallpaper"
+
+// WallpaperOptions configures WatchWallpaper
+type WallpaperOptions struct {
+	// File is watched instead of detecting the desktop's wallpaper
+	File     string
+	Interval time.Duration
+	// Once applies the current wallpaper's theme and returns
+	Once bool
+}
+
+// wallpaperState identifies a wallpaper so edits to the same file count as
+// a change
+type wallpaperState struct {
+	path    string
+	modTime time.Time
+}
+
+// WatchWallpaper regenerates and applies a theme derived from the desktop
+// wallpaper whenever it changes, until a signal arrives
+func (m *Manager) WatchWallpaper(opts WallpaperOptions, stop <-chan os.Signal) error {
+	var last wallpaperState
+
+	check := func() error {
+		path := opts.File
+		if path == "" {
+			detected, err := DetectWallpaper()
+			if err != nil {
+				return err
+			}
+			path = detected
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to read wallpaper: %w", err)
+		}
+		current := wallpaperState{path: path, modTime: info.ModTime()}
+		if current == last {
+			return nil
+		}
+		last = current
+
+		ui.PrintInfo("Wallpaper: %s", path)
+		return m.ApplyImageTheme(path, WallpaperTheme)
+	}
+
+	if err := check(); err != nil {
+		if opts.Once {
+			return err
+		}
+		ui.PrintWarning("%v", err)
+	}
+	if opts.Once {
+		return nil
+	}
+
+	ui.PrintInfo("Watching the wallpaper every %s (Ctrl+C to stop)", opts.Interval)
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := check(); err != nil {
+				m.logVerbose("Wallpaper check failed: %v", err)
+			}
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+// ApplyImageTheme generates a theme from an image, saves it under name and
+// applies it without a backup, since it is regenerated often
+func (m *Manager) ApplyImageTheme(path, name string) error {
+	colors, err := m.generateImageColors(path)
+	if err != nil {
+		return err
+	}
+
+	content := m.createThemeContent(colors, "image:"+filepath.Base(path), name)
+	themeFile := filepath.Join(m.config.ThemeSaveDir(), name+".toml")
+	if err := os.WriteFile(themeFile, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to save theme: %w", err)
+	}
+
+	return m.applyAutomatic(name, sourceWallpaper)
+}
+
+// DetectWallpaper returns the current desktop wallpaper. It understands
+// swaybg, GNOME, feh and macOS.
+func DetectWallpaper() (string, error) {
+	if runtime.GOOS == "darwin" {
+		out, err := exec.Command("osascript", "-e", `tell application "System Events" to get picture of current desktop`).Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to query the desktop picture: %w", err)
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	for _, detect := range []func() string{swaybgWallpaper, gnomeWallpaper, fehWallpaper} {
+		if path := detect(); path != "" {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("could not detect the wallpaper (supported: swaybg, GNOME, feh, macOS); pass --file instead")
+}
+
+// swaybgWallpaper reads the image argument of a running swaybg
+func swaybgWallpaper() string {
+	procs, _ := filepath.Glob("/proc/[0-9]*/cmdline")
+	for _, proc := range procs {
+		data, err := os.ReadFile(proc)
+		if err != nil {
+			continue
+		}
+		args := strings.Split(strings.TrimRight(string(data), "\x00"), "\x00")
+		if len(args) == 0 || filepath.Base(args[0]) != "swaybg" {
+			continue
+		}
+		for i, arg := range args {
+			if (arg == "-i" || arg == "--image") && i+1 < len(args) {
+				return args[i+1]
+			}
+		}
+	}
+	return ""
+}
+
+// gnomeWallpaper reads the background URI, using the dark variant when the
+// desktop prefers dark
+func gnomeWallpaper() string {
+	if _, err := exec.LookPath("gsettings"); err != nil {
+		return ""
+	}
+
+	key := "picture-uri"
+	if out, err := exec.Command("gsettings", "get", "org.gnome.desktop.interface", "color-scheme").Output(); err == nil &&
+		strings.Contains(string(out), "prefer-dark") {
+		key = "picture-uri-dark"
+	}
+
+	out, err := exec.Command("gsettings", "get", "org.gnome.desktop.background", key).Output()
+	if err != nil {
+		return ""
+	}
+	uri := strings.Trim(strings.TrimSpace(string(out)), "'")
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	return u.Path
+}
+
+// fehWallpaper reads the last image in ~/.fehbg
+func fehWallpaper() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".fehbg"))
+	if err != nil {
+		return ""
+	}
+
+	path := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.Contains(line, "feh") {
+			continue
+		}
+		for _, field := range strings.Fields(line) {
+			field = strings.Trim(field, `'"`)
+			if filepath.IsAbs(field) || strings.HasPrefix(field, "~/") {
+				path = targets.ExpandHome(field)
+			}
+		}
+	}
+	return path
+}
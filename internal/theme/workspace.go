@@ -0,0 +1,479 @@
+package theme
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/ui"
+)
+
+// Window managers WatchWorkspaces talks to
+const (
+	WMSway     = "sway"
+	WMI3       = "i3"
+	WMHyprland = "hyprland"
+)
+
+// WindowManagers lists the window managers WatchWorkspaces understands
+var WindowManagers = []string{WMSway, WMI3, WMHyprland}
+
+// WorkspaceOptions configures WatchWorkspaces
+type WorkspaceOptions struct {
+	// WM is one of WindowManagers, or empty to detect the running one
+	WM string
+	// Once applies the theme of the focused workspace and returns
+	Once bool
+}
+
+// wmFocus is what has focus in the window manager
+type wmFocus struct {
+	Workspace string
+	// App is the Wayland app_id or X11 window class of the focused window
+	App string
+}
+
+// wmConn is a connection to the window manager's IPC
+type wmConn interface {
+	// focus queries what has focus now
+	focus() (wmFocus, error)
+	// listen passes every focus change, starting from state, to send
+	// until the connection closes or send returns false
+	listen(state wmFocus, send func(wmFocus) bool) error
+	Close() error
+}
+
+// WatchWorkspaces applies the themes mapped to workspaces and focused
+// applications as the focus moves, until a signal arrives. Unmapped
+// workspaces get the theme that was applied when the watch started, or
+// the last one applied by hand since.
+func (m *Manager) WatchWorkspaces(opts WorkspaceOptions, stop <-chan os.Signal) error {
+	if len(m.config.Workspaces) == 0 && len(m.config.WorkspaceApps) == 0 {
+		return ui.WithHints(fmt.Errorf("no workspaces are mapped to themes"),
+			fmt.Sprintf("Map them in the [workspaces] and [workspace_apps] tables of %s", m.config.Path()))
+	}
+
+	wm := opts.WM
+	if wm == "" {
+		detected, err := detectWM()
+		if err != nil {
+			return err
+		}
+		wm = detected
+	}
+	conn, err := dialWM(wm)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	state, err := conn.focus()
+	if err != nil {
+		return fmt.Errorf("failed to query %s: %w", wm, err)
+	}
+
+	// base is the theme unmapped workspaces return to and applied the last
+	// theme this watcher switched to
+	base := m.config.CurrentTheme
+	applied := base
+
+	switchTo := func(f wmFocus) error {
+		name, reason := m.workspaceTheme(f)
+		if name == "" {
+			name, reason = base, fmt.Sprintf("Workspace %s", f.Workspace)
+		}
+		if name == "" || name == m.config.CurrentTheme {
+			return nil
+		}
+		if l, locked := m.activeLock(); locked {
+			m.logVerbose("Theme changes are locked %s; not switching to '%s'", l.describe(), name)
+			return nil
+		}
+		m.logVerbose("%s has focus", reason)
+		if err := m.applyAutomatic(name, sourceWorkspace); err != nil {
+			return err
+		}
+		applied = name
+		return nil
+	}
+
+	if err := switchTo(state); err != nil || opts.Once {
+		return err
+	}
+
+	ui.PrintInfo("Watching %s workspaces (Ctrl+C to stop)", wm)
+
+	done := make(chan struct{})
+	defer close(done)
+	events := make(chan wmFocus)
+	failed := make(chan error, 1)
+	send := func(f wmFocus) bool {
+		select {
+		case events <- f:
+			return true
+		case <-done:
+			return false
+		}
+	}
+	go func() { failed <- conn.listen(state, send) }()
+
+	// Themes applied by hand and new mappings are picked up without a
+	// restart
+	settingsChanged := m.config.Watch(settingsPollInterval, done)
+
+	for {
+		select {
+		case state = <-events:
+			if err := switchTo(state); err != nil {
+				ui.PrintWarning("Failed to apply workspace theme: %v", err)
+			}
+		case err := <-failed:
+			return fmt.Errorf("lost the connection to %s: %w", wm, err)
+		case <-settingsChanged:
+			workspaces, apps := m.config.Workspaces, m.config.WorkspaceApps
+			if err := m.config.Reload(); err != nil {
+				ui.PrintWarning("Failed to reload settings: %v", err)
+				continue
+			}
+			if m.config.CurrentTheme != applied {
+				base, applied = m.config.CurrentTheme, m.config.CurrentTheme
+				m.logVerbose("Unmapped workspaces now use '%s'", base)
+			}
+			if !maps.Equal(workspaces, m.config.Workspaces) || !maps.Equal(apps, m.config.WorkspaceApps) {
+				m.logVerbose("Workspace mappings changed")
+				if err := switchTo(state); err != nil {
+					ui.PrintWarning("Failed to apply workspace theme: %v", err)
+				}
+			}
+		case <-stop:
+			// Leave the terminal as it was before the watch
+			if base != "" && m.config.CurrentTheme == applied && applied != base {
+				if _, locked := m.activeLock(); !locked {
+					if err := m.applyAutomatic(base, sourceWorkspace); err != nil {
+						ui.PrintWarning("Failed to restore '%s': %v", base, err)
+					}
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// workspaceTheme returns the theme mapped to the focused application or,
+// failing that, to the workspace by name or by the number in names such
+// as "2: web"
+func (m *Manager) workspaceTheme(f wmFocus) (name, reason string) {
+	if f.App != "" {
+		if name := m.config.WorkspaceApps[strings.ToLower(f.App)]; name != "" {
+			return name, fmt.Sprintf("App %s", f.App)
+		}
+	}
+	if f.Workspace == "" {
+		return "", ""
+	}
+	if name := m.config.Workspaces[f.Workspace]; name != "" {
+		return name, fmt.Sprintf("Workspace %s", f.Workspace)
+	}
+	if num, _, ok := strings.Cut(f.Workspace, ":"); ok {
+		if name := m.config.Workspaces[strings.TrimSpace(num)]; name != "" {
+			return name, fmt.Sprintf("Workspace %s", f.Workspace)
+		}
+	}
+	return "", ""
+}
+
+// detectWM finds the running window manager from the sockets it exports
+func detectWM() (string, error) {
+	switch {
+	case os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "":
+		return WMHyprland, nil
+	case os.Getenv("SWAYSOCK") != "":
+		return WMSway, nil
+	case os.Getenv("I3SOCK") != "":
+		return WMI3, nil
+	}
+	if _, err := i3SocketPath(WMI3); err == nil {
+		return WMI3, nil
+	}
+	return "", ui.WithHints(fmt.Errorf("could not detect a running window manager"),
+		fmt.Sprintf("Supported: %s", strings.Join(WindowManagers, ", ")),
+		"Name it with: alacritty-colors watch-workspaces --wm <name>")
+}
+
+func dialWM(wm string) (wmConn, error) {
+	switch wm {
+	case WMSway, WMI3:
+		path, err := i3SocketPath(wm)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := net.Dial("unix", path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to %s: %w", wm, err)
+		}
+		return i3Conn{conn}, nil
+	case WMHyprland:
+		if os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") == "" {
+			return nil, fmt.Errorf("HYPRLAND_INSTANCE_SIGNATURE is not set; is Hyprland running?")
+		}
+		conn, err := net.Dial("unix", hyprlandSocket(".socket2.sock"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to hyprland: %w", err)
+		}
+		return hyprlandConn{conn}, nil
+	}
+	return nil, fmt.Errorf("unknown window manager %q (supported: %s)", wm, strings.Join(WindowManagers, ", "))
+}
+
+// i3 and sway share the i3 IPC protocol
+const (
+	i3GetWorkspaces  = 1
+	i3Subscribe      = 2
+	i3GetTree        = 4
+	i3EventWorkspace = 0x80000000
+	i3EventWindow    = 0x80000003
+)
+
+var i3Magic = []byte("i3-ipc")
+
+func i3SocketPath(wm string) (string, error) {
+	env := "I3SOCK"
+	if wm == WMSway {
+		env = "SWAYSOCK"
+	}
+	if path := os.Getenv(env); path != "" {
+		return path, nil
+	}
+	out, err := exec.Command(wm, "--get-socketpath").Output()
+	if err != nil || len(bytes.TrimSpace(out)) == 0 {
+		return "", fmt.Errorf("could not find the %s IPC socket; is %s running? (%s is not set)", wm, wm, env)
+	}
+	return string(bytes.TrimSpace(out)), nil
+}
+
+type i3Conn struct {
+	net.Conn
+}
+
+// i3Node is the part of a container in the layout tree used here
+type i3Node struct {
+	Focused          bool   `json:"focused"`
+	AppID            string `json:"app_id"`
+	WindowProperties struct {
+		Class string `json:"class"`
+	} `json:"window_properties"`
+	Nodes         []i3Node `json:"nodes"`
+	FloatingNodes []i3Node `json:"floating_nodes"`
+}
+
+// app is the sway app_id or, for X11 windows, the window class
+func (n i3Node) app() string {
+	if n.AppID != "" {
+		return n.AppID
+	}
+	return n.WindowProperties.Class
+}
+
+func (n i3Node) focusedApp() (string, bool) {
+	if n.Focused {
+		return n.app(), true
+	}
+	for _, children := range [][]i3Node{n.Nodes, n.FloatingNodes} {
+		for _, child := range children {
+			if app, ok := child.focusedApp(); ok {
+				return app, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (c i3Conn) send(kind uint32, payload []byte) error {
+	msg := append([]byte{}, i3Magic...)
+	msg = binary.NativeEndian.AppendUint32(msg, uint32(len(payload)))
+	msg = binary.NativeEndian.AppendUint32(msg, kind)
+	_, err := c.Write(append(msg, payload...))
+	return err
+}
+
+func (c i3Conn) read() (uint32, []byte, error) {
+	header := make([]byte, len(i3Magic)+8)
+	if _, err := io.ReadFull(c, header); err != nil {
+		return 0, nil, err
+	}
+	if !bytes.HasPrefix(header, i3Magic) {
+		return 0, nil, fmt.Errorf("unexpected IPC reply")
+	}
+	size := binary.NativeEndian.Uint32(header[len(i3Magic):])
+	kind := binary.NativeEndian.Uint32(header[len(i3Magic)+4:])
+	payload := make([]byte, size)
+	_, err := io.ReadFull(c, payload)
+	return kind, payload, err
+}
+
+// query sends a request and decodes its reply
+func (c i3Conn) query(kind uint32, payload []byte, v any) error {
+	if err := c.send(kind, payload); err != nil {
+		return err
+	}
+	_, reply, err := c.read()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(reply, v)
+}
+
+func (c i3Conn) focus() (wmFocus, error) {
+	var f wmFocus
+	var workspaces []struct {
+		Name    string `json:"name"`
+		Focused bool   `json:"focused"`
+	}
+	if err := c.query(i3GetWorkspaces, nil, &workspaces); err != nil {
+		return f, err
+	}
+	for _, ws := range workspaces {
+		if ws.Focused {
+			f.Workspace = ws.Name
+		}
+	}
+	var tree i3Node
+	if err := c.query(i3GetTree, nil, &tree); err != nil {
+		return f, err
+	}
+	f.App, _ = tree.focusedApp()
+	return f, nil
+}
+
+func (c i3Conn) listen(state wmFocus, send func(wmFocus) bool) error {
+	var reply struct {
+		Success bool `json:"success"`
+	}
+	if err := c.query(i3Subscribe, []byte(`["workspace","window"]`), &reply); err != nil {
+		return err
+	}
+	if !reply.Success {
+		return fmt.Errorf("the window manager refused the event subscription")
+	}
+
+	for {
+		kind, payload, err := c.read()
+		if err != nil {
+			return err
+		}
+		var event struct {
+			Change  string `json:"change"`
+			Current struct {
+				Name string `json:"name"`
+			} `json:"current"`
+			Container i3Node `json:"container"`
+		}
+		if err := json.Unmarshal(payload, &event); err != nil {
+			continue
+		}
+
+		switch {
+		case kind == i3EventWorkspace && event.Change == "focus":
+			// A window on the new workspace reports its focus next; an
+			// empty workspace has none
+			state = wmFocus{Workspace: event.Current.Name}
+		case kind == i3EventWindow && event.Change == "focus":
+			state.App = event.Container.app()
+		case kind == i3EventWindow && event.Change == "close" && event.Container.app() == state.App:
+			state.App = ""
+		default:
+			continue
+		}
+		if !send(state) {
+			return nil
+		}
+	}
+}
+
+// hyprlandSocket returns the path of one of Hyprland's sockets, which
+// moved from /tmp to the runtime directory in version 0.40
+func hyprlandSocket(name string) string {
+	sig := os.Getenv("HYPRLAND_INSTANCE_SIGNATURE")
+	if runtime := os.Getenv("XDG_RUNTIME_DIR"); runtime != "" {
+		path := filepath.Join(runtime, "hypr", sig, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join("/tmp/hypr", sig, name)
+}
+
+// hyprlandConn reads events from Hyprland's socket2; queries go to a
+// fresh connection on its request socket each time
+type hyprlandConn struct {
+	net.Conn
+}
+
+func hyprctl(request string, v any) error {
+	conn, err := net.Dial("unix", hyprlandSocket(".socket.sock"))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(request)); err != nil {
+		return err
+	}
+	reply, err := io.ReadAll(conn)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(reply, v)
+}
+
+func (c hyprlandConn) focus() (wmFocus, error) {
+	var workspace struct {
+		Name string `json:"name"`
+	}
+	if err := hyprctl("j/activeworkspace", &workspace); err != nil {
+		return wmFocus{}, err
+	}
+	var window struct {
+		Class string `json:"class"`
+	}
+	// Without a focused window the reply is an empty object
+	if err := hyprctl("j/activewindow", &window); err != nil {
+		return wmFocus{}, err
+	}
+	return wmFocus{Workspace: workspace.Name, App: window.Class}, nil
+}
+
+func (c hyprlandConn) listen(state wmFocus, send func(wmFocus) bool) error {
+	scanner := bufio.NewScanner(c)
+	for scanner.Scan() {
+		event, data, ok := strings.Cut(scanner.Text(), ">>")
+		if !ok {
+			continue
+		}
+		switch event {
+		case "workspace":
+			state.Workspace = data
+		case "focusedmon":
+			_, state.Workspace, _ = strings.Cut(data, ",")
+		case "activewindow":
+			state.App, _, _ = strings.Cut(data, ",")
+		default:
+			continue
+		}
+		if !send(state) {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.EOF
+}
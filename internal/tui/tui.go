@@ -10,6 +10,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/vitruves/alacritty-colors/internal/config"
+	"github.com/vitruves/alacritty-colors/internal/i18n"
 	"github.com/vitruves/alacritty-colors/internal/theme"
 	"github.com/vitruves/alacritty-colors/pkg/alacritty"
 )
@@ -30,6 +31,9 @@ type ColorEditor struct {
 	// Color editing state
 	colorValues map[string]string
 	colorKeys   []string
+	// unsetColors are shown with Alacritty's default but not saved unless
+	// edited
+	unsetColors map[string]bool
 	isDirty     bool
 }
 
@@ -42,6 +46,7 @@ func NewColorEditor(cfg *config.Config) *ColorEditor {
 		themeManager: tm,
 		colorValues:  make(map[string]string),
 		colorKeys:    make([]string, 0),
+		unsetColors:  make(map[string]bool),
 	}
 
 	// Theme will be applied in setupUI()
@@ -95,7 +100,7 @@ func (ce *ColorEditor) setupUI() {
 
 	// Status bar at bottom
 	ce.statusBar = tview.NewTextView()
-	ce.statusBar.SetText("Tab: switch panels | ↑↓: navigate | ←→: adjust RGB values | Enter: edit | q: quit | s: save | r: reset")
+	ce.statusBar.SetText(i18n.T("Tab: switch panels | ↑↓: navigate | ←→: adjust RGB values | Enter: edit | q: quit | s: save | r: reset"))
 	ce.statusBar.SetTextColor(tcell.ColorYellow)
 
 	// Layout - just use theme list as left panel
@@ -123,7 +128,7 @@ func (ce *ColorEditor) loadThemes() {
 	// Get theme files directly
 	themeFiles, err := ce.getThemeFiles()
 	if err != nil {
-		ce.setStatus("Error loading themes: " + err.Error())
+		ce.setStatus(i18n.Sprintf("Error loading themes: %v", err))
 		return
 	}
 
@@ -175,7 +180,7 @@ func (ce *ColorEditor) loadTheme(themeName string) {
 
 	config, err := parser.ParseFile(themeFile)
 	if err != nil {
-		ce.setStatus(fmt.Sprintf("Error loading theme %s: %v", themeName, err))
+		ce.setStatus(i18n.Sprintf("Error loading theme %s: %v", themeName, err))
 		return
 	}
 
@@ -187,6 +192,7 @@ func (ce *ColorEditor) loadTheme(themeName string) {
 func (ce *ColorEditor) extractColors() {
 	ce.colorValues = make(map[string]string)
 	ce.colorKeys = make([]string, 0)
+	ce.unsetColors = make(map[string]bool)
 
 	if ce.currentTheme == nil {
 		return
@@ -196,13 +202,13 @@ func (ce *ColorEditor) extractColors() {
 	ce.addColor("primary.background", ce.currentTheme.Colors.Primary.Background)
 	ce.addColor("primary.foreground", ce.currentTheme.Colors.Primary.Foreground)
 
-	// Cursor colors
-	if ce.currentTheme.Colors.Cursor.Text != "" {
-		ce.addColor("cursor.text", ce.currentTheme.Colors.Cursor.Text)
-	}
-	if ce.currentTheme.Colors.Cursor.Cursor != "" {
-		ce.addColor("cursor.cursor", ce.currentTheme.Colors.Cursor.Cursor)
-	}
+	// Cursor colors; unset ones start as the inverted cell Alacritty draws
+	background := ce.currentTheme.Colors.Primary.Background
+	foreground := ce.currentTheme.Colors.Primary.Foreground
+	ce.addColorOrDefault("cursor.text", ce.currentTheme.Colors.Cursor.Text, background)
+	ce.addColorOrDefault("cursor.cursor", ce.currentTheme.Colors.Cursor.Cursor, foreground)
+	ce.addColorOrDefault("vi_mode_cursor.text", ce.currentTheme.Colors.ViModeCursor.Text, background)
+	ce.addColorOrDefault("vi_mode_cursor.cursor", ce.currentTheme.Colors.ViModeCursor.Cursor, foreground)
 
 	// Selection colors
 	if ce.currentTheme.Colors.Selection.Text != "" {
@@ -235,21 +241,32 @@ func (ce *ColorEditor) addColor(key, value string) {
 	}
 }
 
+// addColorOrDefault adds a color the theme may leave unset, showing
+// fallback in its place until it is edited
+func (ce *ColorEditor) addColorOrDefault(key, value, fallback string) {
+	if value == "" {
+		ce.unsetColors[key] = true
+		value = fallback
+	}
+	ce.addColor(key, value)
+}
+
 func (ce *ColorEditor) buildColorPanel() {
 	ce.colorPanel.Clear()
 
 	if ce.currentTheme == nil {
-		ce.colorPanel.AddItem("Select a theme to start editing", "", 0, nil)
+		ce.colorPanel.AddItem(i18n.T("Select a theme to start editing"), "", 0, nil)
 		return
 	}
 
 	sections := map[string][]string{
-		"Primary":   {"primary.background", "primary.foreground"},
-		"Cursor":    {"cursor.text", "cursor.cursor"},
-		"Selection": {"selection.text", "selection.background"},
-		"Normal":    {},
-		"Bright":    {},
-		"Dim":       {},
+		"Primary":        {"primary.background", "primary.foreground"},
+		"Cursor":         {"cursor.text", "cursor.cursor"},
+		"Vi Mode Cursor": {"vi_mode_cursor.text", "vi_mode_cursor.cursor"},
+		"Selection":      {"selection.text", "selection.background"},
+		"Normal":         {},
+		"Bright":         {},
+		"Dim":            {},
 	}
 
 	// Populate normal, bright, and dim sections
@@ -261,7 +278,10 @@ func (ce *ColorEditor) buildColorPanel() {
 	}
 
 	// Define order to ensure consistent display
-	sectionOrder := []string{"Primary", "Cursor", "Selection", "Normal", "Bright", "Dim"}
+	sectionOrder := []string{"Primary", "Cursor", "Vi Mode Cursor", "Selection", "Normal", "Bright", "Dim"}
+
+	// Keys are listed in display order so list items map back to them
+	ce.colorKeys = make([]string, 0, len(ce.colorValues))
 
 	for _, sectionName := range sectionOrder {
 		keys := sections[sectionName]
@@ -283,7 +303,7 @@ func (ce *ColorEditor) buildColorPanel() {
 		}
 
 		// Add section header
-		ce.colorPanel.AddItem(fmt.Sprintf("[cyan::b]%s[-]", sectionName), "", 0, nil)
+		ce.colorPanel.AddItem(fmt.Sprintf("[cyan::b]%s[-]", i18n.T(sectionName)), "", 0, nil)
 
 		for _, key := range keys {
 			if value, exists := ce.colorValues[key]; exists {
@@ -301,10 +321,15 @@ func (ce *ColorEditor) buildColorPanel() {
 					rgbDisplay = colorValue
 				}
 
+				if ce.unsetColors[key] {
+					rgbDisplay += " " + i18n.T("(default)")
+				}
+
 				displayName := strings.Replace(key, ".", " ", -1)
-				text := fmt.Sprintf("  [%s]██[-] %-20s %s", colorValue, displayName, rgbDisplay)
+				text := fmt.Sprintf("  [%s]██[-] %-22s %s", colorValue, displayName, rgbDisplay)
 
 				ce.colorPanel.AddItem(text, "", 0, nil)
+				ce.colorKeys = append(ce.colorKeys, key)
 			}
 		}
 	}
@@ -316,7 +341,7 @@ func (ce *ColorEditor) handleThemeListKeys(event *tcell.EventKey) *tcell.EventKe
 		ce.app.SetFocus(ce.colorPanel)
 		ce.colorPanel.SetBorderColor(tcell.ColorYellow)
 		ce.themeList.SetBorderColor(tcell.ColorDefault)
-		ce.setStatus("Focus: Color Panel | Use arrow keys to navigate, Enter to edit")
+		ce.setStatus(i18n.T("Focus: Color Panel | Use arrow keys to navigate, Enter to edit"))
 		return nil
 	case tcell.KeyEnter:
 		// Select current theme
@@ -366,7 +391,7 @@ func (ce *ColorEditor) handleColorPanelKeys(event *tcell.EventKey) *tcell.EventK
 		ce.app.SetFocus(ce.themeList)
 		ce.themeList.SetBorderColor(tcell.ColorYellow)
 		ce.colorPanel.SetBorderColor(tcell.ColorDefault)
-		ce.setStatus("Focus: Theme List | Use arrow keys to navigate, Enter to select")
+		ce.setStatus(i18n.T("Focus: Theme List | Use arrow keys to navigate, Enter to select"))
 		return nil
 	case tcell.KeyEnter:
 		index := ce.colorPanel.GetCurrentItem()
@@ -494,8 +519,8 @@ func (ce *ColorEditor) confirmQuit() {
 	ce.resetTUITheme()
 
 	modal := tview.NewModal()
-	modal.SetText("You have unsaved changes. Are you sure you want to quit?")
-	modal.AddButtons([]string{"Save & Quit", "Quit", "Cancel"})
+	modal.SetText(i18n.T("You have unsaved changes. Are you sure you want to quit?"))
+	modal.AddButtons([]string{i18n.T("Save & Quit"), i18n.T("Quit"), i18n.T("Cancel")})
 
 	// Style the modal with high contrast colors
 	modal.SetBackgroundColor(tcell.ColorBlack)
@@ -524,7 +549,7 @@ func (ce *ColorEditor) confirmQuit() {
 
 func (ce *ColorEditor) saveTheme() {
 	if ce.currentTheme == nil || ce.themeName == "" {
-		ce.setStatus("No theme selected to save")
+		ce.setStatus(i18n.T("No theme selected to save"))
 		return
 	}
 
@@ -534,12 +559,12 @@ func (ce *ColorEditor) saveTheme() {
 	// Save to file
 	err := ce.saveThemeToFile()
 	if err != nil {
-		ce.setStatus(fmt.Sprintf("Error saving theme: %v", err))
+		ce.setStatus(i18n.Sprintf("Error saving theme: %v", err))
 		return
 	}
 
 	ce.isDirty = false
-	ce.setStatus(fmt.Sprintf("Theme '%s' saved successfully", ce.themeName))
+	ce.setStatus(i18n.Sprintf("Theme '%s' saved successfully", ce.themeName))
 }
 
 func (ce *ColorEditor) saveThemeToFile() error {
@@ -575,16 +600,20 @@ func (ce *ColorEditor) generateTOMLContent() string {
 	content.WriteString(fmt.Sprintf("foreground = \"%s\"\n", ce.colorValues["primary.foreground"]))
 	content.WriteString("\n")
 
-	// Cursor colors
-	if ce.colorValues["cursor.text"] != "" || ce.colorValues["cursor.cursor"] != "" {
-		content.WriteString("[colors.cursor]\n")
-		if ce.colorValues["cursor.text"] != "" {
-			content.WriteString(fmt.Sprintf("text = \"%s\"\n", ce.colorValues["cursor.text"]))
+	// Cursor colors, leaving out the ones still unset
+	for _, table := range []string{"cursor", "vi_mode_cursor"} {
+		var entries []string
+		for _, key := range []string{"text", "cursor"} {
+			value := ce.colorValues[table+"."+key]
+			if value != "" && !ce.unsetColors[table+"."+key] {
+				entries = append(entries, fmt.Sprintf("%s = \"%s\"\n", key, value))
+			}
 		}
-		if ce.colorValues["cursor.cursor"] != "" {
-			content.WriteString(fmt.Sprintf("cursor = \"%s\"\n", ce.colorValues["cursor.cursor"]))
+		if len(entries) > 0 {
+			content.WriteString("[colors." + table + "]\n")
+			content.WriteString(strings.Join(entries, ""))
+			content.WriteString("\n")
 		}
-		content.WriteString("\n")
 	}
 
 	// Selection colors
@@ -646,11 +675,17 @@ func (ce *ColorEditor) updateThemeConfig() {
 	ce.currentTheme.Colors.Primary.Foreground = ce.colorValues["primary.foreground"]
 
 	// Update cursor colors
-	if ce.colorValues["cursor.text"] != "" {
-		ce.currentTheme.Colors.Cursor.Text = ce.colorValues["cursor.text"]
+	cursors := map[string]*alacritty.CursorColors{
+		"cursor":         &ce.currentTheme.Colors.Cursor,
+		"vi_mode_cursor": &ce.currentTheme.Colors.ViModeCursor,
 	}
-	if ce.colorValues["cursor.cursor"] != "" {
-		ce.currentTheme.Colors.Cursor.Cursor = ce.colorValues["cursor.cursor"]
+	for table, cursor := range cursors {
+		if !ce.unsetColors[table+".text"] {
+			cursor.Text = ce.colorValues[table+".text"]
+		}
+		if !ce.unsetColors[table+".cursor"] {
+			cursor.Cursor = ce.colorValues[table+".cursor"]
+		}
 	}
 
 	// Update selection colors
@@ -694,7 +729,7 @@ func (ce *ColorEditor) resetTheme() {
 	if len(ce.colorKeys) > 0 {
 		ce.colorPanel.SetCurrentItem(0)
 	}
-	ce.setStatus("Theme reset to original values")
+	ce.setStatus(i18n.T("Theme reset to original values"))
 }
 
 func (ce *ColorEditor) setStatus(message string) {
@@ -746,6 +781,7 @@ func (ce *ColorEditor) adjustColorWithArrows(colorKey string, key tcell.Key) {
 
 	newHex := rgb.ToHex()
 	ce.colorValues[colorKey] = newHex
+	delete(ce.unsetColors, colorKey)
 	ce.isDirty = true
 
 	// Update just the current item in place instead of rebuilding the whole panel
@@ -760,7 +796,7 @@ func (ce *ColorEditor) adjustColorWithArrows(colorKey string, key tcell.Key) {
 	// Convert to RGB for display
 	rgbDisplay := fmt.Sprintf("R:%d G:%d B:%d", rgb.R, rgb.G, rgb.B)
 	displayName := strings.Replace(colorKey, ".", " ", -1)
-	text := fmt.Sprintf("  [%s]██[-] %-20s %s", colorValue, displayName, rgbDisplay)
+	text := fmt.Sprintf("  [%s]██[-] %-22s %s", colorValue, displayName, rgbDisplay)
 
 	// Update the current item
 	ce.colorPanel.SetItemText(currentIndex, text, "")
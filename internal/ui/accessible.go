@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// accessible replaces swatches, bars and spinners with text for screen
+// readers
+var accessible bool
+
+// SetAccessible turns accessibility mode on or off
+func SetAccessible(on bool) {
+	accessible = on
+}
+
+// Accessible reports whether accessibility mode is on
+func Accessible() bool {
+	return accessible
+}
+
+// cssColor is a named CSS color
+type cssColor struct {
+	name    string
+	r, g, b int
+}
+
+// cssColors are the CSS named colors, without the gray/grey, aqua/cyan and
+// fuchsia/magenta duplicates
+var cssColors = []cssColor{
+	{"alice blue", 240, 248, 255}, {"antique white", 250, 235, 215}, {"aquamarine", 127, 255, 212},
+	{"azure", 240, 255, 255}, {"beige", 245, 245, 220}, {"bisque", 255, 228, 196},
+	{"black", 0, 0, 0}, {"blanched almond", 255, 235, 205}, {"blue", 0, 0, 255},
+	{"blue violet", 138, 43, 226}, {"brown", 165, 42, 42}, {"burlywood", 222, 184, 135},
+	{"cadet blue", 95, 158, 160}, {"chartreuse", 127, 255, 0}, {"chocolate", 210, 105, 30},
+	{"coral", 255, 127, 80}, {"cornflower blue", 100, 149, 237}, {"cornsilk", 255, 248, 220},
+	{"crimson", 220, 20, 60}, {"cyan", 0, 255, 255}, {"dark blue", 0, 0, 139},
+	{"dark cyan", 0, 139, 139}, {"dark goldenrod", 184, 134, 11}, {"dark gray", 169, 169, 169},
+	{"dark green", 0, 100, 0}, {"dark khaki", 189, 183, 107}, {"dark magenta", 139, 0, 139},
+	{"dark olive green", 85, 107, 47}, {"dark orange", 255, 140, 0}, {"dark orchid", 153, 50, 204},
+	{"dark red", 139, 0, 0}, {"dark salmon", 233, 150, 122}, {"dark sea green", 143, 188, 143},
+	{"dark slate blue", 72, 61, 139}, {"dark slate gray", 47, 79, 79}, {"dark turquoise", 0, 206, 209},
+	{"dark violet", 148, 0, 211}, {"deep pink", 255, 20, 147}, {"deep sky blue", 0, 191, 255},
+	{"dim gray", 105, 105, 105}, {"dodger blue", 30, 144, 255}, {"firebrick", 178, 34, 34},
+	{"floral white", 255, 250, 240}, {"forest green", 34, 139, 34}, {"gainsboro", 220, 220, 220},
+	{"ghost white", 248, 248, 255}, {"gold", 255, 215, 0}, {"goldenrod", 218, 165, 32},
+	{"gray", 128, 128, 128}, {"green", 0, 128, 0}, {"green yellow", 173, 255, 47},
+	{"honeydew", 240, 255, 240}, {"hot pink", 255, 105, 180}, {"indian red", 205, 92, 92},
+	{"indigo", 75, 0, 130}, {"ivory", 255, 255, 240}, {"khaki", 240, 230, 140},
+	{"lavender", 230, 230, 250}, {"lavender blush", 255, 240, 245}, {"lawn green", 124, 252, 0},
+	{"lemon chiffon", 255, 250, 205}, {"light blue", 173, 216, 230}, {"light coral", 240, 128, 128},
+	{"light cyan", 224, 255, 255}, {"light goldenrod yellow", 250, 250, 210}, {"light gray", 211, 211, 211},
+	{"light green", 144, 238, 144}, {"light pink", 255, 182, 193}, {"light salmon", 255, 160, 122},
+	{"light sea green", 32, 178, 170}, {"light sky blue", 135, 206, 250}, {"light slate gray", 119, 136, 153},
+	{"light steel blue", 176, 196, 222}, {"light yellow", 255, 255, 224}, {"lime", 0, 255, 0},
+	{"lime green", 50, 205, 50}, {"linen", 250, 240, 230}, {"magenta", 255, 0, 255},
+	{"maroon", 128, 0, 0}, {"medium aquamarine", 102, 205, 170}, {"medium blue", 0, 0, 205},
+	{"medium orchid", 186, 85, 211}, {"medium purple", 147, 112, 219}, {"medium sea green", 60, 179, 113},
+	{"medium slate blue", 123, 104, 238}, {"medium spring green", 0, 250, 154}, {"medium turquoise", 72, 209, 204},
+	{"medium violet red", 199, 21, 133}, {"midnight blue", 25, 25, 112}, {"mint cream", 245, 255, 250},
+	{"misty rose", 255, 228, 225}, {"moccasin", 255, 228, 181}, {"navajo white", 255, 222, 173},
+	{"navy", 0, 0, 128}, {"old lace", 253, 245, 230}, {"olive", 128, 128, 0},
+	{"olive drab", 107, 142, 35}, {"orange", 255, 165, 0}, {"orange red", 255, 69, 0},
+	{"orchid", 218, 112, 214}, {"pale goldenrod", 238, 232, 170}, {"pale green", 152, 251, 152},
+	{"pale turquoise", 175, 238, 238}, {"pale violet red", 219, 112, 147}, {"papaya whip", 255, 239, 213},
+	{"peach puff", 255, 218, 185}, {"peru", 205, 133, 63}, {"pink", 255, 192, 203},
+	{"plum", 221, 160, 221}, {"powder blue", 176, 224, 230}, {"purple", 128, 0, 128},
+	{"rebecca purple", 102, 51, 153}, {"red", 255, 0, 0}, {"rosy brown", 188, 143, 143},
+	{"royal blue", 65, 105, 225}, {"saddle brown", 139, 69, 19}, {"salmon", 250, 128, 114},
+	{"sandy brown", 244, 164, 96}, {"sea green", 46, 139, 87}, {"seashell", 255, 245, 238},
+	{"sienna", 160, 82, 45}, {"silver", 192, 192, 192}, {"sky blue", 135, 206, 235},
+	{"slate blue", 106, 90, 205}, {"slate gray", 112, 128, 144}, {"snow", 255, 250, 250},
+	{"spring green", 0, 255, 127}, {"steel blue", 70, 130, 180}, {"tan", 210, 180, 140},
+	{"teal", 0, 128, 128}, {"thistle", 216, 191, 216}, {"tomato", 255, 99, 71},
+	{"turquoise", 64, 224, 208}, {"violet", 238, 130, 238}, {"wheat", 245, 222, 179},
+	{"white", 255, 255, 255}, {"white smoke", 245, 245, 245}, {"yellow", 255, 255, 0},
+	{"yellow green", 154, 205, 50},
+}
+
+// ColorName returns the nearest CSS color name for a hex color, or an empty
+// string when the value is not a hex color
+func ColorName(hexValue string) string {
+	var r, g, b int
+	if len(hexValue) != 7 {
+		return ""
+	}
+	if _, err := fmt.Sscanf(strings.ToLower(hexValue), "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return ""
+	}
+
+	best, bestDistance := "", math.MaxFloat64
+	for _, c := range cssColors {
+		if d := redmean(r, g, b, c.r, c.g, c.b); d < bestDistance {
+			best, bestDistance = c.name, d
+		}
+	}
+	return best
+}
+
+// redmean is a cheap perceptual RGB distance that weighs channels by how
+// red the pair is
+func redmean(r1, g1, b1, r2, g2, b2 int) float64 {
+	rm := float64(r1+r2) / 2
+	dr, dg, db := float64(r1-r2), float64(g1-g2), float64(b1-b2)
+	return (2+rm/256)*dr*dr + 4*dg*dg + (2+(255-rm)/256)*db*db
+}
+
+// announceStep is how often progress is announced in accessibility mode,
+// in percent
+const announceStep = 25
+
+// transferAnnounced is the last percentage PrintTransfer announced; -1
+// means the transfer has not been announced yet
+var transferAnnounced = -1
+
+// announceProgress prints a progress line when the percentage crosses a
+// step, instead of redrawing a bar that screen readers read char by char
+func announceProgress(last, percent int, operation, detail string) bool {
+	if percent == last || (last >= 0 && percent/announceStep == last/announceStep && percent < 100) {
+		return false
+	}
+	infoColor.Printf("%s: ", operation)
+	numberColor.Printf("%d%%", percent)
+	if detail != "" {
+		dimColor.Printf(" (%s)", detail)
+	}
+	fmt.Println()
+	return true
+}
+
+// announceTransfer is PrintTransfer for accessibility mode: the operation
+// once when the size is unknown, otherwise every announceStep percent
+func announceTransfer(current, total int64, operation string) {
+	if total <= 0 {
+		if transferAnnounced < 0 {
+			infoColor.Printf("%s...\n", operation)
+			transferAnnounced = 0
+		}
+		return
+	}
+
+	percent := int(current * 100 / total)
+	// A new transfer starts over
+	if percent < transferAnnounced {
+		transferAnnounced = -1
+	}
+	detail := fmt.Sprintf("%s of %s", formatSize(current), formatSize(total))
+	if announceProgress(transferAnnounced, percent, operation, detail) {
+		transferAnnounced = percent
+	}
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+)
+
+// Output modes for colors and unicode symbols
+const (
+	ModeAuto   = "auto"
+	ModeAlways = "always"
+	ModeNever  = "never"
+)
+
+// Modes lists the accepted output modes
+var Modes = []string{ModeAuto, ModeAlways, ModeNever}
+
+// stdoutIsTerminal is false when output is piped or redirected
+var stdoutIsTerminal = term.IsTerminal(int(os.Stdout.Fd()))
+
+// IsTerminal reports whether stdout is an interactive terminal
+func IsTerminal() bool {
+	return stdoutIsTerminal
+}
+
+// ColorEnabled reports whether output is colored
+func ColorEnabled() bool {
+	return supportsColor
+}
+
+// SetColorMode enables or disables colored output. In auto mode colors are
+// used only when stdout is a terminal that supports them and NO_COLOR is unset.
+func SetColorMode(mode string) error {
+	switch mode {
+	case ModeAuto, "":
+		supportsColor = stdoutIsTerminal && os.Getenv("NO_COLOR") == "" && checkColorSupport()
+	case ModeAlways:
+		supportsColor = true
+	case ModeNever:
+		supportsColor = false
+	default:
+		return fmt.Errorf("invalid color mode %q (use auto|always|never)", mode)
+	}
+
+	color.NoColor = !supportsColor
+	return nil
+}
+
+// SetUnicodeMode chooses between unicode symbols and ASCII fallbacks
+func SetUnicodeMode(mode string) error {
+	switch mode {
+	case ModeAuto, "":
+		supportsUnicode = checkUnicodeSupport()
+	case ModeAlways:
+		supportsUnicode = true
+	case ModeNever:
+		supportsUnicode = false
+	default:
+		return fmt.Errorf("invalid unicode mode %q (use auto|always|never)", mode)
+	}
+	return nil
+}
+
+// ColorizeCommand renders a command name for help output
+func ColorizeCommand(name string) string {
+	return themeColor.Sprint(name)
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/vitruves/alacritty-colors/internal/i18n"
+)
+
+// HintedError is an error carrying suggestions on how to fix it, such as
+// close theme names or the command that creates a missing directory
+type HintedError struct {
+	Err   error
+	Hints []string
+}
+
+func (e *HintedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *HintedError) Unwrap() error {
+	return e.Err
+}
+
+// WithHints attaches hints to err. Empty hints are dropped and a nil err
+// stays nil.
+func WithHints(err error, hints ...string) error {
+	if err == nil {
+		return nil
+	}
+
+	var kept []string
+	for _, hint := range hints {
+		if hint != "" {
+			kept = append(kept, hint)
+		}
+	}
+	if len(kept) == 0 {
+		return err
+	}
+	return &HintedError{Err: err, Hints: kept}
+}
+
+// Hints returns the hints attached anywhere in err's chain
+func Hints(err error) []string {
+	var hints []string
+	for err != nil {
+		var hinted *HintedError
+		if !errors.As(err, &hinted) {
+			break
+		}
+		hints = append(hints, hinted.Hints...)
+		err = hinted.Err
+	}
+	return hints
+}
+
+// PrintErrorWithHints prints err followed by its hints
+func PrintErrorWithHints(err error) {
+	PrintError("Error: %v", err)
+	for _, hint := range Hints(err) {
+		dimColor.Printf("  %s\n", i18n.T(hint))
+	}
+}
+
+// DidYouMean formats a suggestion for the closest candidates to name, or
+// returns "" when none is close enough
+func DidYouMean(name string, candidates []string) string {
+	matches := ClosestMatches(name, candidates, 3)
+	if len(matches) == 0 {
+		return ""
+	}
+	return i18n.Sprintf("Did you mean: %s?", strings.Join(matches, ", "))
+}
+
+// ClosestMatches returns up to limit candidates similar to name, closest
+// first. Candidates containing name, or contained in it, always match;
+// others must be within a third of name's length in edit distance.
+func ClosestMatches(name string, candidates []string, limit int) []string {
+	target := normalizeName(name)
+	if target == "" {
+		return nil
+	}
+
+	type match struct {
+		name     string
+		distance int
+	}
+	var matches []match
+	maxDistance := max(2, len(target)/3)
+
+	for _, candidate := range candidates {
+		normalized := normalizeName(candidate)
+		distance := editDistance(target, normalized)
+		// Compare against the start of longer names too, so a misspelt
+		// family name still finds its variants
+		if runes := []rune(normalized); len(runes) > len([]rune(target)) {
+			distance = min(distance, editDistance(target, string(runes[:len([]rune(target))]))+1)
+		}
+		if len(target) >= 3 && len(normalized) >= 3 &&
+			(strings.Contains(normalized, target) || strings.Contains(target, normalized)) {
+			// Substring matches rank just behind near-exact ones
+			distance = min(distance, 1)
+		} else if distance > maxDistance {
+			continue
+		}
+		matches = append(matches, match{candidate, distance})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	var names []string
+	for i := 0; i < len(matches) && len(names) < limit; i++ {
+		names = append(names, matches[i].name)
+	}
+	return names
+}
+
+// normalizeName ignores case and the separators theme names use
+// interchangeably
+func normalizeName(s string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "", ".toml", "").Replace(strings.ToLower(s))
+}
+
+// editDistance is the edit distance between a and b, counting a swap of
+// adjacent characters as one edit
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
@@ -0,0 +1,456 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/term"
+
+	"github.com/vitruves/alacritty-colors/internal/i18n"
+)
+
+// selectorRows is how many options the selector shows at once
+const selectorRows = 10
+
+// stdin is shared by all prompts so input buffered by one isn't lost to
+// the next
+var stdin = bufio.NewReader(os.Stdin)
+
+// stdinIsTerminal is false when input is piped, redirected or closed, as
+// in scripts, hooks and the daemon
+var stdinIsTerminal = term.IsTerminal(int(os.Stdin.Fd()))
+
+// assumeYes (--yes) answers every confirmation yes and noInput (--no-input)
+// asks nothing, leaving every confirmation at no; both take the default of
+// every selection
+var assumeYes, noInput bool
+
+// SetAssumeYes answers yes to every confirmation without asking
+func SetAssumeYes(on bool) {
+	assumeYes = on
+}
+
+// SetNoInput makes prompts take their defaults without asking
+func SetNoInput(on bool) {
+	noInput = on
+}
+
+// Interactive reports whether prompts wait for an answer
+func Interactive() bool {
+	return stdinIsTerminal && !assumeYes && !noInput
+}
+
+// skipReason tells why a prompt was answered without asking
+func skipReason() string {
+	switch {
+	case assumeYes:
+		return "--yes"
+	case noInput:
+		return "--no-input"
+	}
+	return "input is not a terminal"
+}
+
+// autoAnswer shows the answer a prompt took without asking, so logs of
+// unattended runs record it
+func autoAnswer(message, answer string) {
+	warningColor.Printf("%s %s ", promptSymbol(), i18n.T(message))
+	fmt.Print(answer)
+	dimColor.Printf(" (%s)\n", skipReason())
+}
+
+// keyPrompts reports whether prompts can read single key presses: stdin
+// and stdout are terminals and accessibility mode, whose screen readers
+// follow output line by line, is off
+func keyPrompts() bool {
+	return stdoutIsTerminal && stdinIsTerminal && !accessible
+}
+
+func promptSymbol() string {
+	if supportsUnicode {
+		return "❓"
+	}
+	return "?"
+}
+
+// readLine reads a line of input without its line ending. It fails at the
+// end of input, such as when stdin is closed or a pipe runs dry.
+func readLine() (string, error) {
+	line, err := stdin.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// key is a key press read from a terminal in raw mode
+type key int
+
+const (
+	keyOther key = iota
+	keyRune
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyEnter
+	keyBackspace
+	keyEscape
+	keyInterrupt
+)
+
+// readKey reads one key press, and for keyRune the character typed
+func readKey() (key, rune, error) {
+	c, _, err := stdin.ReadRune()
+	if err != nil {
+		return keyOther, 0, err
+	}
+	switch c {
+	case '\r', '\n':
+		return keyEnter, 0, nil
+	case 127, '\b':
+		return keyBackspace, 0, nil
+	case 3, 4: // Ctrl-C, Ctrl-D
+		return keyInterrupt, 0, nil
+	case 16: // Ctrl-P
+		return keyUp, 0, nil
+	case 14: // Ctrl-N
+		return keyDown, 0, nil
+	case 27:
+		// A lone Escape arrives on its own; arrow keys arrive as one
+		// sequence
+		if stdin.Buffered() == 0 {
+			return keyEscape, 0, nil
+		}
+		if next, _, _ := stdin.ReadRune(); next != '[' && next != 'O' {
+			return keyOther, 0, nil
+		}
+		code, _, _ := stdin.ReadRune()
+		switch code {
+		case 'A':
+			return keyUp, 0, nil
+		case 'B':
+			return keyDown, 0, nil
+		case 'C':
+			return keyRight, 0, nil
+		case 'D':
+			return keyLeft, 0, nil
+		}
+		// Skip the rest of longer sequences such as Page Up ("\x1b[5~")
+		for (code >= '0' && code <= '9' || code == ';') && stdin.Buffered() > 0 {
+			code, _, _ = stdin.ReadRune()
+		}
+		return keyOther, 0, nil
+	}
+	if unicode.IsPrint(c) {
+		return keyRune, c, nil
+	}
+	return keyOther, 0, nil
+}
+
+// PromptConfirm asks a yes/no question that defaults to no. On a terminal
+// y or n answers at once, the arrow keys move between the answers and
+// Escape cancels, which counts as no. Without input it answers no, or yes
+// with --yes.
+func PromptConfirm(message string) bool {
+	if !Interactive() {
+		answer := assumeYes
+		autoAnswer(message, yesNo(answer))
+		return answer
+	}
+	if keyPrompts() {
+		if answer, ok := confirmKeys(message); ok {
+			return answer
+		}
+	}
+
+	warningColor.Printf("%s %s ", promptSymbol(), i18n.T(message))
+	dimColor.Print(i18n.T("[y/N]: "))
+	response, err := readLine()
+	if err != nil {
+		fmt.Println()
+		return false
+	}
+	return i18n.IsYes(response)
+}
+
+// confirmKeys asks a yes/no question in raw mode. It reports false when the
+// terminal cannot be switched to raw mode.
+func confirmKeys(message string) (bool, bool) {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return false, false
+	}
+
+	yes := false
+	draw := func() {
+		answers := []string{i18n.T("Yes"), i18n.T("No")}
+		for i, answer := range answers {
+			if (i == 0) == yes {
+				answers[i] = accentColor.Sprint("[" + answer + "]")
+			} else {
+				answers[i] = dimColor.Sprint(" " + answer + " ")
+			}
+		}
+		fmt.Print("\r\x1b[K" + warningColor.Sprintf("%s %s ", promptSymbol(), i18n.T(message)) + strings.Join(answers, " "))
+	}
+
+	answered := false
+	for !answered {
+		draw()
+		k, r, err := readKey()
+		switch {
+		case err != nil || k == keyEscape || k == keyInterrupt:
+			yes, answered = false, true
+		case k == keyEnter:
+			answered = true
+		case k == keyLeft || k == keyRight || k == keyUp || k == keyDown:
+			yes = !yes
+		case k == keyRune && i18n.IsYes(string(r)):
+			yes, answered = true, true
+		case k == keyRune && unicode.ToLower(r) == 'n':
+			yes, answered = false, true
+		}
+	}
+	draw()
+	term.Restore(fd, state)
+	fmt.Println()
+	return yes, true
+}
+
+func yesNo(answer bool) string {
+	if answer {
+		return i18n.T("Yes")
+	}
+	return i18n.T("No")
+}
+
+// PromptInput asks for a line of text. Without input it returns "".
+func PromptInput(message string) string {
+	if !Interactive() {
+		autoAnswer(message, `""`)
+		return ""
+	}
+	infoColor.Printf("%s %s: ", promptSymbol(), i18n.T(message))
+	response, err := readLine()
+	if err != nil {
+		fmt.Println()
+	}
+	return response
+}
+
+// PromptSelect asks to pick one of options, the first one by default. It
+// returns the index picked, or -1 when cancelled.
+func PromptSelect(message string, options []string) int {
+	return PromptSelectDefault(message, options, 0)
+}
+
+// PromptSelectDefault asks to pick one of options with def highlighted. On
+// a terminal the arrow keys move, typing filters the options and Escape
+// cancels; elsewhere options are picked by number, an empty answer taking
+// the default. Without input it takes the default. It returns the index
+// picked, or -1 when cancelled.
+func PromptSelectDefault(message string, options []string, def int) int {
+	if len(options) == 0 {
+		return -1
+	}
+	if def < 0 || def >= len(options) {
+		def = 0
+	}
+	if !Interactive() {
+		autoAnswer(message, options[def])
+		return def
+	}
+	if keyPrompts() {
+		if choice, ok := selectKeys(message, options, def); ok {
+			return choice
+		}
+	}
+	return selectLine(message, options, def)
+}
+
+// selectLine asks to pick an option by number
+func selectLine(message string, options []string, def int) int {
+	fmt.Println()
+	accentColor.Println(i18n.T(message))
+
+	for i, option := range options {
+		numberColor.Printf("  %d. ", i+1)
+		if i == def {
+			highlightColor.Print(option)
+			dimColor.Println(" " + i18n.T("(default)"))
+		} else {
+			secondaryColor.Println(option)
+		}
+	}
+
+	for {
+		fmt.Print("\n" + i18n.T("Select option (number): "))
+		input, err := readLine()
+		if err != nil {
+			fmt.Println()
+			return -1
+		}
+		if input == "" {
+			return def
+		}
+		if choice, err := strconv.Atoi(input); err == nil && choice >= 1 && choice <= len(options) {
+			return choice - 1
+		}
+
+		errorColor.Printf(i18n.T("Invalid choice. Please enter a number between 1 and %d.")+"\n", len(options))
+	}
+}
+
+// selector is the state of an option list driven by the keyboard
+type selector struct {
+	message string
+	options []string
+	filter  string
+	// matches are the indexes of the options containing filter, cursor the
+	// highlighted one among them and top the first one shown
+	matches []int
+	cursor  int
+	top     int
+	// drawn is how many lines the last draw took
+	drawn int
+}
+
+// match keeps the options containing the filter, keeping the highlighted
+// option when it still matches
+func (s *selector) match() {
+	highlighted := -1
+	if s.cursor < len(s.matches) {
+		highlighted = s.matches[s.cursor]
+	}
+	filter := strings.ToLower(s.filter)
+	s.matches = s.matches[:0]
+	s.cursor = 0
+	for i, option := range s.options {
+		if strings.Contains(strings.ToLower(option), filter) {
+			if i == highlighted {
+				s.cursor = len(s.matches)
+			}
+			s.matches = append(s.matches, i)
+		}
+	}
+	s.scroll()
+}
+
+// scroll keeps the highlighted option in view
+func (s *selector) scroll() {
+	if s.cursor < s.top {
+		s.top = s.cursor
+	}
+	if s.cursor >= s.top+selectorRows {
+		s.top = s.cursor - selectorRows + 1
+	}
+	s.top = max(0, min(s.top, len(s.matches)-selectorRows))
+}
+
+func (s *selector) lines() []string {
+	width := TerminalWidth() - 4
+	head := warningColor.Sprintf("%s %s ", promptSymbol(), i18n.T(s.message))
+	if s.filter == "" {
+		head += dimColor.Sprint(i18n.T("(arrows move, type to filter, Esc cancels)"))
+	} else {
+		head += s.filter
+	}
+	lines := []string{head}
+
+	if len(s.matches) == 0 {
+		return append(lines, "  "+dimColor.Sprint(i18n.T("No matches")))
+	}
+	end := min(len(s.matches), s.top+selectorRows)
+	for i := s.top; i < end; i++ {
+		option := Truncate(s.options[s.matches[i]], width)
+		if i == s.cursor {
+			pointer := ">"
+			if supportsUnicode {
+				pointer = "❯"
+			}
+			lines = append(lines, accentColor.Sprint(pointer+" ")+highlightColor.Sprint(option))
+		} else {
+			lines = append(lines, "  "+option)
+		}
+	}
+	if len(s.matches) > selectorRows {
+		lines = append(lines, dimColor.Sprintf("  (%d/%d)", s.cursor+1, len(s.matches)))
+	}
+	return lines
+}
+
+// draw replaces the previous drawing. In raw mode lines end in \r\n.
+func (s *selector) draw(lines []string) {
+	if s.drawn > 1 {
+		fmt.Printf("\x1b[%dA", s.drawn-1)
+	}
+	fmt.Print("\r\x1b[J" + strings.Join(lines, "\r\n"))
+	s.drawn = len(lines)
+}
+
+// selectKeys runs the selector in raw mode. It reports false when the
+// terminal cannot be switched to raw mode.
+func selectKeys(message string, options []string, def int) (int, bool) {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return 0, false
+	}
+
+	s := &selector{message: message, options: options, matches: make([]int, 0, len(options))}
+	for i := range options {
+		s.matches = append(s.matches, i)
+	}
+	s.cursor = def
+	s.scroll()
+
+	choice := -2
+	for choice == -2 {
+		s.draw(s.lines())
+		k, r, err := readKey()
+		switch {
+		case err != nil || k == keyInterrupt:
+			choice = -1
+		case k == keyEscape && s.filter == "":
+			choice = -1
+		case k == keyEscape:
+			// The first Escape clears the filter
+			s.filter = ""
+			s.match()
+		case k == keyEnter:
+			if len(s.matches) > 0 {
+				choice = s.matches[s.cursor]
+			}
+		case k == keyUp && len(s.matches) > 0:
+			s.cursor = (s.cursor - 1 + len(s.matches)) % len(s.matches)
+			s.scroll()
+		case k == keyDown && len(s.matches) > 0:
+			s.cursor = (s.cursor + 1) % len(s.matches)
+			s.scroll()
+		case k == keyBackspace && s.filter != "":
+			runes := []rune(s.filter)
+			s.filter = string(runes[:len(runes)-1])
+			s.match()
+		case k == keyRune:
+			s.filter += string(r)
+			s.match()
+		}
+	}
+
+	// Leave only the question and the answer on screen
+	answer := dimColor.Sprint(i18n.T("cancelled"))
+	if choice >= 0 {
+		answer = highlightColor.Sprint(options[choice])
+	}
+	s.draw([]string{warningColor.Sprintf("%s %s ", promptSymbol(), i18n.T(message)) + answer})
+	term.Restore(fd, state)
+	fmt.Println()
+	return choice, true
+}
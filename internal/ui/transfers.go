@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// transferRedraw limits how often byte counts redraw a TransferGroup
+const transferRedraw = 100 * time.Millisecond
+
+// The most of a transfer's name and status a line shows, so lines never
+// wrap and the redraw stays in place
+const (
+	transferNameWidth   = 20
+	transferStatusWidth = 50
+)
+
+// transferRow is one transfer of a TransferGroup
+type transferRow struct {
+	name           string
+	current, total int64
+	start          time.Time
+	// status replaces the byte counts while the transfer isn't moving data
+	status string
+	done   bool
+	failed bool
+}
+
+// TransferGroup shows several transfers running at once, one line each,
+// redrawn in place on a terminal. Elsewhere, and in accessible mode, each
+// change of status is printed as a line of its own.
+type TransferGroup struct {
+	mu       sync.Mutex
+	rows     []*transferRow
+	width    int
+	live     bool
+	drawn    int
+	paused   int
+	lastDraw time.Time
+}
+
+// NewTransferGroup starts a display for the named transfers, all waiting
+func NewTransferGroup(names []string) *TransferGroup {
+	g := &TransferGroup{live: stdoutIsTerminal && !accessible}
+	for _, name := range names {
+		if len(name) > transferNameWidth {
+			name = name[:transferNameWidth-1] + "~"
+		}
+		g.width = max(g.width, len(name))
+		g.rows = append(g.rows, &transferRow{name: name, status: "waiting"})
+	}
+	g.draw()
+	return g
+}
+
+// Update records the bytes a transfer has moved out of total, which is 0
+// when unknown
+func (g *TransferGroup) Update(index int, current, total int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	row := g.rows[index]
+	if row.start.IsZero() || current < row.current {
+		row.start = time.Now()
+	}
+	row.current, row.total, row.status = current, total, ""
+	if now := time.Now(); now.Sub(g.lastDraw) >= transferRedraw {
+		g.draw()
+	}
+}
+
+// Status shows what a transfer is doing instead of its byte counts
+func (g *TransferGroup) Status(index int, format string, args ...interface{}) {
+	g.setStatus(index, fmt.Sprintf(format, args...), false, false)
+}
+
+// Progress is a Status that is only drawn, never printed as a line, for
+// steps frequent enough to flood a log
+func (g *TransferGroup) Progress(index int, format string, args ...interface{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rows[index].status = fmt.Sprintf(format, args...)
+	if now := time.Now(); now.Sub(g.lastDraw) >= transferRedraw {
+		g.draw()
+	}
+}
+
+// Done marks a transfer as finished with a summary
+func (g *TransferGroup) Done(index int, format string, args ...interface{}) {
+	g.setStatus(index, fmt.Sprintf(format, args...), true, false)
+}
+
+// Failed marks a transfer as failed with a short reason
+func (g *TransferGroup) Failed(index int, format string, args ...interface{}) {
+	g.setStatus(index, fmt.Sprintf(format, args...), true, true)
+}
+
+func (g *TransferGroup) setStatus(index int, status string, done, failed bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	row := g.rows[index]
+	row.status, row.done, row.failed = status, done, failed
+	if !g.live {
+		g.printStatus(row)
+		return
+	}
+	g.draw()
+}
+
+// Pause clears the lines so other output can be printed, until Resume
+// draws them again below it
+func (g *TransferGroup) Pause() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.paused == 0 {
+		g.clear()
+	}
+	g.paused++
+}
+
+// Resume ends a Pause
+func (g *TransferGroup) Resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.paused--
+	if g.paused == 0 {
+		g.draw()
+	}
+}
+
+// Finish draws the final state of every transfer and leaves it on screen
+func (g *TransferGroup) Finish() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.draw()
+	g.live = false
+}
+
+// clear moves back over the drawn lines and erases them
+func (g *TransferGroup) clear() {
+	if g.drawn > 0 {
+		fmt.Printf("\033[%dF\033[J", g.drawn)
+		g.drawn = 0
+	}
+}
+
+func (g *TransferGroup) draw() {
+	if !g.live || g.paused > 0 {
+		return
+	}
+	g.lastDraw = time.Now()
+	g.clear()
+	for _, row := range g.rows {
+		g.drawRow(row)
+	}
+	g.drawn = len(g.rows)
+}
+
+func (g *TransferGroup) drawRow(row *transferRow) {
+	infoColor.Printf("  %-*s  ", g.width, row.name)
+	switch {
+	case row.failed:
+		errorColor.Println(row.status)
+	case row.done:
+		successColor.Println(row.status)
+	case row.status != "":
+		status := row.status
+		if len(status) > transferStatusWidth {
+			status = status[:transferStatusWidth-1] + "~"
+		}
+		dimColor.Println(status)
+	case row.total > 0:
+		barWidth := 20
+		filled := int(float64(barWidth) * float64(row.current) / float64(row.total))
+		fillChar, emptyChar := "#", "-"
+		if supportsUnicode {
+			fillChar, emptyChar = "█", "░"
+		}
+		fmt.Printf("[%s%s] ", successColor.Sprint(strings.Repeat(fillChar, filled)), dimColor.Sprint(strings.Repeat(emptyChar, barWidth-filled)))
+		numberColor.Printf("%s/%s\n", formatSize(row.current), formatSize(row.total))
+	default:
+		numberColor.Printf("%s ", formatSize(row.current))
+		if elapsed := time.Since(row.start); elapsed > 0 {
+			dimColor.Printf("(%s/s)", formatSize(int64(float64(row.current)/elapsed.Seconds())))
+		}
+		fmt.Println()
+	}
+}
+
+func (g *TransferGroup) printStatus(row *transferRow) {
+	switch {
+	case row.failed:
+		PrintWarning("%s: %s", row.name, row.status)
+	case row.done:
+		PrintSuccess("%s: %s", row.name, row.status)
+	default:
+		PrintInfo("%s: %s", row.name, row.status)
+	}
+}
@@ -1,13 +1,16 @@
 package ui
 
 import (
+	"bufio"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/vitruves/alacritty-colors/internal/i18n"
 )
 
 var (
@@ -42,24 +45,28 @@ var (
 	verboseColor = color.New(color.FgHiBlack)
 )
 
-// Terminal capability detection
+// Terminal capabilities, adjusted by SetColorMode and SetUnicodeMode
 var (
-	supportsUnicode = checkUnicodeSupport()
-	supportsColor   = checkColorSupport()
+	supportsUnicode bool
+	supportsColor   bool
 )
 
 func init() {
-	// Disable colors if not supported or requested
-	if os.Getenv("NO_COLOR") != "" || !supportsColor {
-		color.NoColor = true
-	}
+	SetColorMode(ModeAuto)
+	SetUnicodeMode(ModeAuto)
 }
 
 // Header and section functions - made more sober
+// SupportsUnicode reports whether the terminal can render unicode symbols
+func SupportsUnicode() bool {
+	return supportsUnicode
+}
+
 func PrintHeader(text string) {
+	text = i18n.T(text)
 	if !supportsUnicode {
 		// Fallback for terminals without Unicode support
-		border := strings.Repeat("=", len(text)+4)
+		border := strings.Repeat("=", DisplayWidth(text)+4)
 		headerColor.Println(border)
 		headerColor.Printf("  %s  \n", text)
 		headerColor.Println(border)
@@ -67,10 +74,11 @@ func PrintHeader(text string) {
 	}
 
 	headerColor.Printf("▌%s\n", text)
-	dimColor.Println("  " + strings.Repeat("─", len(text)))
+	dimColor.Println("  " + strings.Repeat("─", DisplayWidth(text)))
 }
 
 func PrintSubHeader(text string) {
+	text = i18n.T(text)
 	if !supportsUnicode {
 		fmt.Printf("\n> %s\n", text)
 		return
@@ -80,6 +88,7 @@ func PrintSubHeader(text string) {
 }
 
 func PrintSection(title string) {
+	title = i18n.T(title)
 	if !supportsUnicode {
 		highlightColor.Printf("# %s\n", title)
 		return
@@ -103,7 +112,7 @@ func PrintSuccess(format string, args ...interface{}) {
 		symbol = "OK"
 	}
 	successColor.Print(symbol + " ")
-	primaryColor.Printf(format+"\n", args...)
+	primaryColor.Printf(i18n.T(format)+"\n", args...)
 }
 
 func PrintError(format string, args ...interface{}) {
@@ -112,7 +121,7 @@ func PrintError(format string, args ...interface{}) {
 		symbol = "ERROR"
 	}
 	errorColor.Print(symbol + " ")
-	primaryColor.Printf(format+"\n", args...)
+	primaryColor.Printf(i18n.T(format)+"\n", args...)
 }
 
 func PrintWarning(format string, args ...interface{}) {
@@ -121,11 +130,11 @@ func PrintWarning(format string, args ...interface{}) {
 		symbol = "WARN"
 	}
 	warningColor.Print(symbol + " ")
-	primaryColor.Printf(format+"\n", args...)
+	primaryColor.Printf(i18n.T(format)+"\n", args...)
 }
 
 func PrintInfo(format string, args ...interface{}) {
-	infoColor.Printf(format+"\n", args...)
+	infoColor.Printf(i18n.T(format)+"\n", args...)
 }
 
 func PrintVerbose(format string, args ...interface{}) {
@@ -139,7 +148,7 @@ func PrintVerbose(format string, args ...interface{}) {
 
 func PrintStep(step int, total int, text string) {
 	numberColor.Printf("[%d/%d] ", step, total)
-	primaryColor.Println(text)
+	primaryColor.Println(i18n.T(text))
 }
 
 func PrintStatus(status, message string) {
@@ -166,39 +175,84 @@ func PrintStatus(status, message string) {
 	}
 
 	statusColor.Printf("%s ", symbol)
-	secondaryColor.Println(message)
+	secondaryColor.Println(i18n.T(message))
 }
 
 // Theme and content display functions
+// PrintTheme prints a theme name and its description on one line, cutting
+// the description to the terminal width
 func PrintTheme(name string, description string) {
-	themeColor.Printf("  %-25s", name)
-	if description != "" {
-		separator := "│"
-		if !supportsUnicode {
-			separator = "|"
-		}
-		dimColor.Printf(" %s %s", separator, description)
+	width := TerminalWidth()
+	nameWidth := min(25, width-2)
+
+	if description == "" {
+		themeColor.Printf("  %s\n", Truncate(name, width-2))
+		return
+	}
+
+	themeColor.Printf("  %s", pad(name, nameWidth))
+	separator := "│"
+	if !supportsUnicode {
+		separator = "|"
+	}
+	// Two spaces of indent plus " │ " before the description
+	if room := width - nameWidth - 5; room > 0 {
+		dimColor.Printf(" %s %s", separator, Truncate(description, room))
 	}
 	fmt.Println()
 }
 
+// PrintThemeGrid prints names in columns. With columns <= 0 the column count
+// follows the terminal width and the longest name.
 func PrintThemeGrid(themes []string, columns int) {
+	if len(themes) == 0 {
+		return
+	}
+
+	longest := 0
+	for _, theme := range themes {
+		longest = max(longest, DisplayWidth(theme))
+	}
+	cellWidth := min(longest, maxGridCell) + 2
+
 	if columns <= 0 {
-		columns = 3
+		columns = gridColumns(cellWidth)
+	} else {
+		// Never overflow the terminal, even when asked for more columns
+		columns = min(columns, gridColumns(cellWidth))
+	}
+	// A single column may use the full width
+	if columns == 1 {
+		cellWidth = TerminalWidth() - 2
 	}
 
 	for i, theme := range themes {
-		if i%columns == 0 && i > 0 {
-			fmt.Println()
+		if i%columns == 0 {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Print("  ")
+		}
+		if i%columns == columns-1 || i == len(themes)-1 {
+			themeColor.Print(Truncate(theme, cellWidth-2))
+		} else {
+			themeColor.Print(pad(theme, cellWidth-2) + "  ")
 		}
-		themeColor.Printf("  %-25s", theme)
-	}
-	if len(themes) > 0 {
-		fmt.Println()
 	}
+	fmt.Println()
 }
 
 func PrintColorPreview(colorName, hexValue string) {
+	if accessible {
+		primaryColor.Printf("  %-14s", colorName)
+		dimColor.Printf(" %s", hexValue)
+		if name := ColorName(hexValue); name != "" {
+			secondaryColor.Printf(", %s", name)
+		}
+		fmt.Println()
+		return
+	}
+
 	// Enhanced color preview with better formatting
 	var colorFunc *color.Color
 	var swatch string
@@ -245,8 +299,38 @@ func PrintColorPreview(colorName, hexValue string) {
 	fmt.Println()
 }
 
+// Swatch renders a hex color as a block in that exact color, or returns an
+// empty string when colors are off or the value is not a hex color. In
+// accessibility mode it returns the nearest color name instead.
+func Swatch(hexValue string) string {
+	if accessible {
+		return ColorName(hexValue)
+	}
+	block := "██"
+	if !supportsUnicode {
+		block = "##"
+	}
+	if tinted := Tint(hexValue, block); tinted != block {
+		return tinted
+	}
+	return ""
+}
+
+// Tint renders text in the exact color of a hex value, or returns it
+// unchanged when colors are off or the value is not a hex color
+func Tint(hexValue, text string) string {
+	var r, g, b int
+	if !supportsColor || len(hexValue) != 7 {
+		return text
+	}
+	if _, err := fmt.Sscanf(hexValue, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return text
+	}
+	return fmt.Sprintf("\033[38;2;%d;%d;%dm%s\033[0m", r, g, b, text)
+}
+
 func PrintKeyValue(key, value string) {
-	accentColor.Printf("%-15s ", key+":")
+	accentColor.Print(runewidth.FillRight(i18n.T(key)+":", 15) + " ")
 	primaryColor.Println(value)
 }
 
@@ -310,6 +394,20 @@ func PrintTree(items map[string][]string) {
 
 // Progress and interaction functions
 func PrintProgress(current, total int, operation string) {
+	// Redrawing the bar only makes sense on a terminal
+	if !stdoutIsTerminal {
+		if current == total {
+			infoColor.Printf("%s ", operation)
+			numberColor.Printf("%d/%d\n", current, total)
+		}
+		return
+	}
+
+	if accessible && total > 0 {
+		announceProgress((current-1)*100/total, current*100/total, operation, fmt.Sprintf("%d/%d", current, total))
+		return
+	}
+
 	percentage := float64(current) / float64(total) * 100
 	barWidth := 25
 	filled := int(float64(barWidth) * float64(current) / float64(total))
@@ -350,6 +448,56 @@ func PrintProgress(current, total int, operation string) {
 	}
 }
 
+// PrintTransfer shows download progress with size, speed and ETA. When the
+// total size is unknown a spinner frame is shown in place of the bar.
+func PrintTransfer(current, total int64, elapsed time.Duration, operation string) {
+	if !stdoutIsTerminal {
+		return
+	}
+	if accessible {
+		announceTransfer(current, total, operation)
+		return
+	}
+
+	var speed float64
+	if elapsed > 0 {
+		speed = float64(current) / elapsed.Seconds()
+	}
+
+	if total <= 0 {
+		frames := []string{"|", "/", "-", "\\"}
+		if supportsUnicode {
+			frames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+		}
+		frame := frames[int(elapsed/(100*time.Millisecond))%len(frames)]
+
+		fmt.Printf("\r%s ", accentColor.Sprint(frame))
+		infoColor.Printf("%s ", operation)
+		numberColor.Printf("%s ", formatSize(current))
+		dimColor.Printf("(%s/s)   ", formatSize(int64(speed)))
+		return
+	}
+
+	percentage := float64(current) / float64(total) * 100
+	barWidth := 25
+	filled := int(float64(barWidth) * float64(current) / float64(total))
+
+	fillChar, emptyChar := "#", "-"
+	if supportsUnicode {
+		fillChar, emptyChar = "█", "░"
+	}
+
+	eta := "--"
+	if speed > 0 {
+		eta = time.Duration(float64(total-current) / speed * float64(time.Second)).Round(time.Second).String()
+	}
+
+	infoColor.Printf("\r%s ", operation)
+	fmt.Printf("[%s%s] ", successColor.Sprint(strings.Repeat(fillChar, filled)), dimColor.Sprint(strings.Repeat(emptyChar, barWidth-filled)))
+	numberColor.Printf("%s/%s ", formatSize(current), formatSize(total))
+	dimColor.Printf("(%.1f%%, %s/s, ETA %s)   ", percentage, formatSize(int64(speed)), eta)
+}
+
 func PrintSpinner(message string, delay time.Duration) func() {
 	var frames []string
 	if supportsUnicode {
@@ -358,6 +506,15 @@ func PrintSpinner(message string, delay time.Duration) func() {
 		frames = []string{"|", "/", "-", "\\"}
 	}
 
+	if !stdoutIsTerminal {
+		return func() {}
+	}
+	// Screen readers would read every frame, so say it once instead
+	if accessible {
+		infoColor.Printf("%s...\n", strings.TrimRight(message, ". "))
+		return func() {}
+	}
+
 	done := make(chan bool)
 	go func() {
 		i := 0
@@ -379,50 +536,34 @@ func PrintSpinner(message string, delay time.Duration) func() {
 	}
 }
 
-func PromptConfirm(message string) bool {
-	symbol := "?"
-	if supportsUnicode {
-		symbol = "❓"
-	}
-
-	warningColor.Printf("%s %s ", symbol, message)
-	dimColor.Print("[y/N]: ")
-	var response string
-	fmt.Scanln(&response)
-	return strings.ToLower(response) == "y" || strings.ToLower(response) == "yes"
-}
-
-func PromptInput(message string) string {
-	symbol := "?"
-	if supportsUnicode {
-		symbol = "❓"
+// PromptConfirmTimeout asks a yes/no question that goes unanswered after
+// timeout. It returns the answer and whether one came in time; a closed
+// input counts as no answer. Without input it returns no answer at once,
+// or yes with --yes.
+func PromptConfirmTimeout(message string, timeout time.Duration) (bool, bool) {
+	if !Interactive() {
+		autoAnswer(message, yesNo(assumeYes))
+		return assumeYes, assumeYes
 	}
 
-	infoColor.Printf("%s %s: ", symbol, message)
-	var response string
-	fmt.Scanln(&response)
-	return response
-}
-
-func PromptSelect(message string, options []string) int {
-	fmt.Println()
-	accentColor.Println(message)
-
-	for i, option := range options {
-		numberColor.Printf("  %d. ", i+1)
-		secondaryColor.Println(option)
-	}
+	warningColor.Printf("%s %s ", promptSymbol(), i18n.T(message))
+	dimColor.Print(i18n.T("[y/N]: "))
 
-	for {
-		fmt.Print("\nSelect option (number): ")
-		var input string
-		fmt.Scanln(&input)
-
-		if choice, err := strconv.Atoi(input); err == nil && choice >= 1 && choice <= len(options) {
-			return choice - 1
+	answer := make(chan string, 1)
+	go func() {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return
 		}
+		answer <- strings.TrimSpace(line)
+	}()
 
-		errorColor.Printf("Invalid choice. Please enter a number between 1 and %d.\n", len(options))
+	select {
+	case response := <-answer:
+		return i18n.IsYes(response), true
+	case <-time.After(timeout):
+		fmt.Println()
+		return false, false
 	}
 }
 
@@ -488,16 +629,17 @@ func PrintTable(headers []string, rows [][]string) {
 
 	for _, row := range rows {
 		for i, cell := range row {
-			if i < len(colWidths) && len(cell) > colWidths[i] {
-				colWidths[i] = len(cell)
+			if i < len(colWidths) && DisplayWidth(cell) > colWidths[i] {
+				colWidths[i] = DisplayWidth(cell)
 			}
 		}
 	}
+	fitColumns(colWidths, TerminalWidth()-2)
 
 	// Print header
 	fmt.Print("  ")
 	for i, header := range headers {
-		headerColor.Printf("%-*s", colWidths[i]+2, header)
+		headerColor.Print(pad(header, colWidths[i]+2))
 	}
 	fmt.Println()
 
@@ -513,13 +655,37 @@ func PrintTable(headers []string, rows [][]string) {
 		fmt.Print("  ")
 		for i, cell := range row {
 			if i < len(colWidths) {
-				secondaryColor.Printf("%-*s", colWidths[i]+2, cell)
+				secondaryColor.Print(pad(Truncate(cell, colWidths[i]), colWidths[i]+2))
 			}
 		}
 		fmt.Println()
 	}
 }
 
+// fitColumns shrinks the widest columns until the table, with two spaces
+// between columns, fits in width
+func fitColumns(colWidths []int, width int) {
+	total := 0
+	for _, w := range colWidths {
+		total += w + 2
+	}
+
+	for total > width {
+		widest := 0
+		for i, w := range colWidths {
+			if w > colWidths[widest] {
+				widest = i
+			}
+		}
+		// Keep room for at least a few characters and an ellipsis
+		if colWidths[widest] <= 4 {
+			return
+		}
+		colWidths[widest]--
+		total--
+	}
+}
+
 // Banner and branding
 func PrintBanner() {
 	banner := `
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+const (
+	// defaultWidth is used when output is not a terminal and $COLUMNS is unset
+	defaultWidth = 80
+	// minWidth keeps layouts usable on very narrow terminals
+	minWidth = 20
+	// maxGridCell caps grid cells so one long name does not force a single column
+	maxGridCell = 32
+)
+
+// TerminalWidth returns the width of the terminal on stdout, falling back to
+// $COLUMNS and then to 80 columns
+func TerminalWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return max(width, minWidth)
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return max(columns, minWidth)
+	}
+	return defaultWidth
+}
+
+// DisplayWidth returns the number of terminal cells s occupies
+func DisplayWidth(s string) int {
+	return runewidth.StringWidth(s)
+}
+
+// Truncate shortens s to at most width cells, ending it with an ellipsis
+// when anything was cut
+func Truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if runewidth.StringWidth(s) <= width {
+		return s
+	}
+
+	ellipsis := "…"
+	if !supportsUnicode {
+		ellipsis = "..."
+	}
+	if width <= runewidth.StringWidth(ellipsis) {
+		return runewidth.Truncate(s, width, "")
+	}
+	return runewidth.Truncate(s, width, ellipsis)
+}
+
+// pad truncates s to width cells and pads it with spaces to exactly width
+func pad(s string, width int) string {
+	return runewidth.FillRight(Truncate(s, width), width)
+}
+
+// gridColumns returns how many cells of cellWidth fit in the terminal after
+// the two-space indent
+func gridColumns(cellWidth int) int {
+	return max(1, (TerminalWidth()-2)/cellWidth)
+}
@@ -16,13 +16,40 @@ type Config struct {
 }
 
 type ColorScheme struct {
-	Primary   PrimaryColors     `toml:"primary"`
-	Cursor    CursorColors      `toml:"cursor"`
-	Selection SelectionColors   `toml:"selection"`
-	Normal    map[string]string `toml:"normal"`
-	Bright    map[string]string `toml:"bright"`
-	Dim       map[string]string `toml:"dim,omitempty"`
-	Indexed   map[string]string `toml:"indexed_colors,omitempty"`
+	Primary      PrimaryColors     `toml:"primary"`
+	Cursor       CursorColors      `toml:"cursor"`
+	ViModeCursor CursorColors      `toml:"vi_mode_cursor"`
+	Selection    SelectionColors   `toml:"selection"`
+	Normal       map[string]string `toml:"normal"`
+	Bright       map[string]string `toml:"bright"`
+	Dim          map[string]string `toml:"dim,omitempty"`
+	Indexed      map[string]string `toml:"indexed_colors,omitempty"`
+	// DrawBoldTextWithBrightColors is nil when the file leaves the setting
+	// to Alacritty's default, false
+	DrawBoldTextWithBrightColors *bool `toml:"draw_bold_text_with_bright_colors,omitempty"`
+}
+
+// DrawBoldBrightKey is the [colors] setting that renders bold text in the
+// bright variant of its color
+const DrawBoldBrightKey = "draw_bold_text_with_bright_colors"
+
+// ANSIColors are the names of the normal and bright colors, in order
+var ANSIColors = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// IdenticalBrights returns the colors whose bright variant is the same as
+// the normal one, which many upstream themes ship. With
+// draw_bold_text_with_bright_colors, bold text in those colors looks the
+// same as regular text.
+func (cs ColorScheme) IdenticalBrights() []string {
+	p := NewParser()
+	var same []string
+	for _, name := range ANSIColors {
+		normal, bright := cs.Normal[name], cs.Bright[name]
+		if normal != "" && bright != "" && p.NormalizeColor(normal) == p.NormalizeColor(bright) {
+			same = append(same, name)
+		}
+	}
+	return same
 }
 
 type PrimaryColors struct {
@@ -131,11 +158,22 @@ func (p *Parser) parseKeyValue(config *Config, section, line string) error {
 	key := strings.TrimSpace(parts[0])
 	value := strings.Trim(strings.TrimSpace(parts[1]), `"'`)
 
+	if section == "colors" && key == DrawBoldBrightKey {
+		on := value == "true"
+		if !on && value != "false" {
+			return fmt.Errorf("invalid %s: %s", key, value)
+		}
+		config.Colors.DrawBoldTextWithBrightColors = &on
+		return nil
+	}
+
 	switch section {
 	case "colors.primary":
 		p.setPrimaryColor(config, key, value)
 	case "colors.cursor":
-		p.setCursorColor(config, key, value)
+		p.setCursorColor(&config.Colors.Cursor, key, value)
+	case "colors.vi_mode_cursor":
+		p.setCursorColor(&config.Colors.ViModeCursor, key, value)
 	case "colors.selection":
 		p.setSelectionColor(config, key, value)
 	case "colors.normal":
@@ -170,12 +208,12 @@ func (p *Parser) setPrimaryColor(config *Config, key, value string) {
 	}
 }
 
-func (p *Parser) setCursorColor(config *Config, key, value string) {
+func (p *Parser) setCursorColor(cursor *CursorColors, key, value string) {
 	switch key {
 	case "text":
-		config.Colors.Cursor.Text = value
+		cursor.Text = value
 	case "cursor":
-		config.Colors.Cursor.Cursor = value
+		cursor.Cursor = value
 	}
 }
 
@@ -232,6 +270,12 @@ func (p *Parser) ExtractColors(config *Config) map[string]string {
 	if config.Colors.Cursor.Cursor != "" {
 		colors["cursor"] = config.Colors.Cursor.Cursor
 	}
+	if config.Colors.ViModeCursor.Text != "" {
+		colors["vi_mode_cursor_text"] = config.Colors.ViModeCursor.Text
+	}
+	if config.Colors.ViModeCursor.Cursor != "" {
+		colors["vi_mode_cursor"] = config.Colors.ViModeCursor.Cursor
+	}
 
 	// Selection colors
 	if config.Colors.Selection.Text != "" {
@@ -321,6 +365,10 @@ func (p *Parser) GenerateConfig(colors map[string]string, template *Config) *Con
 			config.Colors.Cursor.Text = normalizedColor
 		case "cursor":
 			config.Colors.Cursor.Cursor = normalizedColor
+		case "vi_mode_cursor_text":
+			config.Colors.ViModeCursor.Text = normalizedColor
+		case "vi_mode_cursor":
+			config.Colors.ViModeCursor.Cursor = normalizedColor
 		case "selection_text":
 			config.Colors.Selection.Text = normalizedColor
 		case "selection_background":